@@ -1,65 +1,186 @@
 package app
 
 import (
-	"database/sql"
-	"log"
+	"context"
 	"net/http"
+	"sms/config"
+	"sms/constants"
+	"sms/events"
 	"sms/handlers"
 	"sms/middleware"
-	gradeRepository "sms/repository/gradesRepository"
-	studentsRepository "sms/repository/studentRepository"
-	userrepository "sms/repository/userRepository"
+	"sms/openapi"
+	"sms/repository/storage"
 	"sms/services"
-
-	_ "modernc.org/sqlite"
 )
 
-func SetupServer(db *sql.DB) *http.ServeMux {
-	//repos
-	gradeRepo := gradeRepository.NewGradeRepo(db)
-	studentRepo := studentsRepository.NewStudentRepo(db)
-	userRepo := userrepository.NewUserRepo(db)
+// SetupServer builds the routes with the settings in cfg. Tokens are signed and
+// checked with cfg.JWT, so its secret has to be set.
+func SetupServer(db *storage.DB, cfg config.Config) (*http.ServeMux, error) {
+	mux, _, _, err := setup(db, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return mux.ServeMux, nil
+}
+
+// routes is a ServeMux that remembers the patterns registered on it, so they can
+// be checked against the API document.
+type routes struct {
+	*http.ServeMux
+	patterns []string
+}
 
-	//services
-	gradeService := services.NewGradeService(gradeRepo)
-	studentService := services.NewStudentService(studentRepo)
-	authSevice := services.NewAuthService(userRepo)
+func (mux *routes) Handle(pattern string, handler http.Handler) {
+	mux.patterns = append(mux.patterns, pattern)
+	mux.ServeMux.Handle(pattern, handler)
+}
+
+func (mux *routes) HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	mux.Handle(pattern, http.HandlerFunc(handler))
+}
+
+// worker is a background loop that runs until its context is done.
+type worker func(ctx context.Context)
+
+// setup builds the routes along with the workers Run runs next to the server and
+// the event bus whose asynchronous subscribers it waits for on shutdown.
+func setup(db *storage.DB, cfg config.Config) (*routes, []worker, *events.Bus, error) {
+	svc, err := NewServices(db, cfg)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	auth := middleware.JWTAuth(svc.Tokens)
 
 	//handlers
-	gradeHandler := handlers.NewGradeHandler(gradeService)
-	studentHandler := handlers.NewStudentHandler(&studentService)
-	authHandler := handlers.NewAuthHandler(authSevice)
+	gradeHandler := handlers.NewGradeHandler(svc.Grades)
+	studentHandler := handlers.NewStudentHandler(svc.Students)
+	authHandler := handlers.NewAuthHandler(svc.Auth)
+	reportHandler := handlers.NewReportHandler(svc.Reports, svc.Guardians)
+	alertHandler := handlers.NewAlertHandler(svc.Alerts)
+	attendanceHandler := handlers.NewAttendanceHandler(svc.Attendance, svc.Guardians)
+	enrollmentHandler := handlers.NewEnrollmentHandler(svc.Enrollments)
+	curriculumHandler := handlers.NewCurriculumHandler(svc.Curriculum)
+	rolloverHandler := handlers.NewRolloverHandler(svc.Rollovers)
+	termHandler := handlers.NewTermHandler(svc.Terms)
+	timetableHandler := handlers.NewTimetableHandler(svc.Timetable)
+	guardianHandler := handlers.NewGuardianHandler(svc.Auth, svc.Guardians)
+	notificationHandler := handlers.NewNotificationHandler(svc.Notifications)
+	webhookHandler := handlers.NewWebhookHandler(svc.Webhooks)
 
-	mux := http.NewServeMux()
+	mux := &routes{ServeMux: http.NewServeMux()}
 
 	//auth
 	mux.HandleFunc("POST /api/v1/login", authHandler.Login)
 	mux.HandleFunc("POST /api/v1/signup", authHandler.Signup)
 
+	// docs
+	mux.Handle("GET /api/v1/openapi.json", openapi.Handler(apiDocument()))
+	mux.Handle("GET /api/v1/docs", openapi.UIHandler())
+	mux.Handle("GET /api/v1/docs/{file}", openapi.UIAssetHandler())
+
 	//student
-	mux.Handle("POST /api/v1/students", middleware.JWTAuth(studentHandler.AddStudent))
-	mux.Handle("PATCH /api/v1/students/{studentID}", middleware.JWTAuth(studentHandler.UpdateStudent))
+	mux.Handle("POST /api/v1/students", auth(studentHandler.AddStudent))
+	mux.Handle("PATCH /api/v1/students/{studentID}", auth(studentHandler.UpdateStudent))
+	mux.Handle("GET /api/v1/students/{studentID}/progress", auth(reportHandler.GetProgressReport))
+	mux.Handle("GET /api/v1/students/{studentID}/grades", auth(reportHandler.GetStudentGrades))
+
+	// guardians
+	mux.Handle("POST /api/v1/guardians", auth(guardianHandler.CreateGuardian))
+	mux.Handle("POST /api/v1/guardians/{guardianID}/students", auth(guardianHandler.LinkStudent))
+	mux.Handle("DELETE /api/v1/guardians/{guardianID}/students/{studentID}", auth(guardianHandler.UnlinkStudent))
+	mux.Handle("GET /api/v1/me/students", auth(guardianHandler.GetMyStudents))
+
+	// notifications
+	mux.Handle("GET /api/v1/me/notifications", auth(notificationHandler.GetMyNotifications))
+	mux.Handle("POST /api/v1/me/notifications/{notificationID}/read", auth(notificationHandler.MarkRead))
+	mux.Handle("GET /api/v1/me/notification-preferences", auth(notificationHandler.GetPreferences))
+	mux.Handle("PUT /api/v1/me/notification-preferences/{channel}", auth(notificationHandler.SetPreference))
 
 	// grades
-	mux.Handle("POST /api/v1/grades", middleware.JWTAuth(gradeHandler.AddGrade))
+	mux.Handle("POST /api/v1/grades", auth(gradeHandler.AddGrade))
+	mux.Handle("POST /api/v1/grades/import", auth(gradeHandler.ImportGrades))
 
-	// mux.Handle("GET /api/v1/grades", middleware.JWTAuth(gradeHandler.GetAverageOfClass))
-	mux.Handle("GET /api/v1/classes/{classID}/semesters/{semester}/average", middleware.JWTAuth(gradeHandler.GetAverageOfClass))
+	// mux.Handle("GET /api/v1/grades", auth(gradeHandler.GetAverageOfClass))
+	mux.Handle("GET /api/v1/classes/{classID}/semesters/{semester}/average", auth(gradeHandler.GetAverageOfClass))
+	mux.Handle("GET /api/v1/classes/{classID}/semesters/{semester}/statistics", auth(gradeHandler.GetStatisticsOfClass))
 
-	// mux.Handle("GET /api/v1/grades/toppers", middleware.JWTAuth(gradeHandler.GetTopThree))
-	mux.Handle("GET /api/v1/classes/{classID}/semesters/{semester}/toppers", middleware.JWTAuth(gradeHandler.GetToppers))
+	// mux.Handle("GET /api/v1/grades/toppers", auth(gradeHandler.GetTopThree))
+	mux.Handle("GET /api/v1/classes/{classID}/semesters/{semester}/toppers", auth(gradeHandler.GetToppers))
+	mux.Handle("GET /api/v1/classes/{classID}/semesters/{semester}/ranks", auth(gradeHandler.GetRankList))
+	mux.Handle("GET /api/v1/classes/{classID}/semesters/{semester}/ranks/{studentID}", auth(gradeHandler.GetStudentRank))
 
-	mux.Handle("PATCH /api/v1/grades", middleware.JWTAuth(gradeHandler.UpdateGrade))
-	return mux
-}
+	mux.Handle("PATCH /api/v1/grades", auth(gradeHandler.UpdateGrade))
+
+	// assessments
+	mux.Handle("POST /api/v1/subjects/{subjectID}/semesters/{semester}/assessments", auth(gradeHandler.CreateAssessment))
+	mux.Handle("GET /api/v1/subjects/{subjectID}/semesters/{semester}/assessments", auth(gradeHandler.GetAssessments))
+	mux.Handle("PATCH /api/v1/assessments/{assessmentID}", auth(gradeHandler.UpdateAssessment))
+	mux.Handle("PUT /api/v1/assessments/{assessmentID}/scores", auth(gradeHandler.RecordScores))
+
+	// academic terms
+	mux.Handle("POST /api/v1/terms", auth(termHandler.CreateTerm))
+	mux.Handle("GET /api/v1/terms", auth(termHandler.GetTerms))
+	mux.Handle("GET /api/v1/terms/{termID}", auth(termHandler.GetTerm))
+	mux.Handle("PATCH /api/v1/terms/{termID}/status", auth(termHandler.SetTermStatus))
+	mux.Handle("PUT /api/v1/terms/{termID}/grade-window", auth(termHandler.SetGradeEntryWindow))
+
+	// enrollments
+	mux.Handle("POST /api/v1/enrollments", auth(enrollmentHandler.Enroll))
+	mux.Handle("POST /api/v1/enrollments/bulk", auth(enrollmentHandler.BulkEnroll))
+	mux.Handle("GET /api/v1/students/{studentID}/enrollments", auth(enrollmentHandler.GetStudentEnrollments))
+	mux.Handle("DELETE /api/v1/students/{studentID}/enrollments/{subjectID}", auth(enrollmentHandler.Drop))
+
+	// curriculum
+	mux.Handle("POST /api/v1/programs", auth(curriculumHandler.CreateProgram))
+	mux.Handle("POST /api/v1/programs/{programID}/subjects", auth(curriculumHandler.AddProgramSubject))
+	mux.Handle("GET /api/v1/programs/{programID}/semesters/{semester}/subjects", auth(curriculumHandler.GetCurriculum))
+	mux.Handle("PUT /api/v1/classes/{classID}/program", auth(curriculumHandler.AssignClassProgram))
+	mux.Handle("POST /api/v1/classes/{classID}/semesters/{semester}/auto-enroll", auth(curriculumHandler.AutoEnrollClass))
+	mux.Handle("POST /api/v1/students/{studentID}/electives", auth(curriculumHandler.EnrollElective))
+
+	// timetable
+	mux.Handle("POST /api/v1/rooms", auth(timetableHandler.CreateRoom))
+	mux.Handle("PUT /api/v1/classes/{classID}/room", auth(timetableHandler.SetClassHomeRoom))
+	mux.Handle("POST /api/v1/faculty-assignments", auth(timetableHandler.AssignFaculty))
+	mux.Handle("POST /api/v1/timetable/slots", auth(timetableHandler.AddSlot))
+	mux.Handle("DELETE /api/v1/timetable/slots/{slotID}", auth(timetableHandler.RemoveSlot))
+	mux.Handle("GET /api/v1/classes/{classID}/timetable", auth(timetableHandler.GetClassTimetable))
+	mux.Handle("GET /api/v1/classes/{classID}/timetable.ics", auth(timetableHandler.ExportClassCalendar))
+	mux.Handle("GET /api/v1/faculty/{facultyID}/timetable", auth(timetableHandler.GetFacultyTimetable))
+	mux.Handle("GET /api/v1/faculty/{facultyID}/timetable.ics", auth(timetableHandler.ExportFacultyCalendar))
+
+	// rollover
+	mux.Handle("POST /api/v1/classes/{classID}/semesters/{semester}/rollover", auth(rolloverHandler.Rollover))
+	mux.Handle("GET /api/v1/rollovers/{rolloverID}", auth(rolloverHandler.GetRollover))
+	mux.Handle("POST /api/v1/rollovers/{rolloverID}/undo", auth(rolloverHandler.UndoRollover))
+
+	// attendance
+	mux.Handle("POST /api/v1/attendance/sessions", auth(attendanceHandler.CreateSession))
+	mux.Handle("POST /api/v1/attendance/sessions/{sessionID}/marks", auth(attendanceHandler.MarkAttendance))
+	mux.Handle("GET /api/v1/students/{studentID}/attendance", auth(attendanceHandler.GetStudentAttendance))
+	mux.Handle("GET /api/v1/classes/{classID}/semesters/{semester}/attendance", auth(attendanceHandler.GetClassAttendance))
 
-func Start(DB *sql.DB) {
+	// alerts
+	mux.Handle("GET /api/v1/alerts/at-risk", auth(alertHandler.GetAtRisk))
+	mux.Handle("POST /api/v1/alerts/at-risk/scan", auth(alertHandler.ScanAtRisk))
 
-	mux := SetupServer(DB)
+	// webhooks
+	mux.Handle("POST /api/v1/webhooks", auth(webhookHandler.CreateSubscription))
+	mux.Handle("GET /api/v1/webhooks", auth(webhookHandler.GetSubscriptions))
+	mux.Handle("GET /api/v1/webhooks/{subscriptionID}", auth(webhookHandler.GetSubscription))
+	mux.Handle("PATCH /api/v1/webhooks/{subscriptionID}", auth(webhookHandler.UpdateSubscription))
+	mux.Handle("GET /api/v1/webhooks/{subscriptionID}/deliveries", auth(webhookHandler.GetDeliveries))
+	mux.Handle("GET /api/v1/webhook-deliveries/{deliveryID}", auth(webhookHandler.GetDelivery))
+	mux.Handle("POST /api/v1/webhook-deliveries/{deliveryID}/redeliver", auth(webhookHandler.Redeliver))
 
-	// Start server
-	log.Println("Starting server on :8080")
-	if err := http.ListenAndServe(":8080", mux); err != nil {
-		log.Fatal("failed to start server:", err)
+	workers := []worker{
+		func(ctx context.Context) {
+			svc.Notifications.RunWorker(ctx, constants.DefaultNotificationPollInterval)
+		},
+		func(ctx context.Context) { svc.Webhooks.RunWorker(ctx, constants.DefaultWebhookPollInterval) },
+		func(ctx context.Context) {
+			svc.Alerts.RunWorker(ctx, constants.DefaultAtRiskScanInterval, services.DefaultAtRiskCriteria())
+		},
 	}
+	return mux, workers, svc.Bus, nil
 }
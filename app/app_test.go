@@ -1,18 +1,24 @@
 package app_test
 
 import (
-	"database/sql"
 	"net/http"
 	"net/http/httptest"
 	"sms/app"
+	"sms/config"
+	"sms/repository/storage"
 	"testing"
 )
 
 func TestSetupServerRoutes(t *testing.T) {
 
-	db, _ := sql.Open("sqlite", ":memory:")
+	db, _ := storage.Open(storage.SQLite, ":memory:")
 
-	mux := app.SetupServer(db)
+	cfg := config.Default()
+	cfg.JWT.Secret = "0123456789abcdef0123456789abcdef"
+	mux, err := app.SetupServer(db, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	tests := []struct {
 		method string
@@ -22,10 +28,68 @@ func TestSetupServerRoutes(t *testing.T) {
 		{"POST", "/api/v1/signup"},
 		{"POST", "/api/v1/students"},
 		{"PATCH", "/api/v1/students/{studentID}"},
+		{"GET", "/api/v1/students/{studentID}/progress"},
+		{"GET", "/api/v1/students/{studentID}/grades"},
+		{"POST", "/api/v1/guardians"},
+		{"POST", "/api/v1/guardians/{guardianID}/students"},
+		{"DELETE", "/api/v1/guardians/{guardianID}/students/{studentID}"},
+		{"GET", "/api/v1/me/students"},
+		{"GET", "/api/v1/me/notifications"},
+		{"POST", "/api/v1/me/notifications/{notificationID}/read"},
+		{"GET", "/api/v1/me/notification-preferences"},
+		{"PUT", "/api/v1/me/notification-preferences/{channel}"},
+		{"POST", "/api/v1/webhooks"},
+		{"GET", "/api/v1/webhooks"},
+		{"GET", "/api/v1/webhooks/{subscriptionID}"},
+		{"PATCH", "/api/v1/webhooks/{subscriptionID}"},
+		{"GET", "/api/v1/webhooks/{subscriptionID}/deliveries"},
+		{"GET", "/api/v1/webhook-deliveries/{deliveryID}"},
+		{"POST", "/api/v1/webhook-deliveries/{deliveryID}/redeliver"},
 		{"POST", "/api/v1/grades"},
+		{"POST", "/api/v1/grades/import"},
 		{"GET", "/api/v1/classes/{classID}/semesters/{semester}/average"},
+		{"GET", "/api/v1/classes/{classID}/semesters/{semester}/statistics"},
 		{"GET", "/api/v1/classes/{classID}/semesters/{semester}/toppers"},
+		{"GET", "/api/v1/classes/{classID}/semesters/{semester}/ranks"},
+		{"GET", "/api/v1/classes/{classID}/semesters/{semester}/ranks/{studentID}"},
 		{"PATCH", "/api/v1/grades"},
+		{"POST", "/api/v1/subjects/{subjectID}/semesters/{semester}/assessments"},
+		{"GET", "/api/v1/subjects/{subjectID}/semesters/{semester}/assessments"},
+		{"PATCH", "/api/v1/assessments/{assessmentID}"},
+		{"PUT", "/api/v1/assessments/{assessmentID}/scores"},
+		{"POST", "/api/v1/terms"},
+		{"GET", "/api/v1/terms"},
+		{"GET", "/api/v1/terms/{termID}"},
+		{"PATCH", "/api/v1/terms/{termID}/status"},
+		{"PUT", "/api/v1/terms/{termID}/grade-window"},
+		{"POST", "/api/v1/enrollments"},
+		{"POST", "/api/v1/enrollments/bulk"},
+		{"GET", "/api/v1/students/{studentID}/enrollments"},
+		{"DELETE", "/api/v1/students/{studentID}/enrollments/{subjectID}"},
+		{"POST", "/api/v1/programs"},
+		{"POST", "/api/v1/programs/{programID}/subjects"},
+		{"GET", "/api/v1/programs/{programID}/semesters/{semester}/subjects"},
+		{"PUT", "/api/v1/classes/{classID}/program"},
+		{"POST", "/api/v1/classes/{classID}/semesters/{semester}/auto-enroll"},
+		{"POST", "/api/v1/students/{studentID}/electives"},
+		{"POST", "/api/v1/rooms"},
+		{"PUT", "/api/v1/classes/{classID}/room"},
+		{"POST", "/api/v1/faculty-assignments"},
+		{"POST", "/api/v1/timetable/slots"},
+		{"DELETE", "/api/v1/timetable/slots/{slotID}"},
+		{"GET", "/api/v1/classes/{classID}/timetable"},
+		{"GET", "/api/v1/classes/{classID}/timetable.ics"},
+		{"GET", "/api/v1/faculty/{facultyID}/timetable"},
+		{"GET", "/api/v1/faculty/{facultyID}/timetable.ics"},
+		{"POST", "/api/v1/classes/{classID}/semesters/{semester}/rollover"},
+		{"GET", "/api/v1/rollovers/{rolloverID}"},
+		{"POST", "/api/v1/rollovers/{rolloverID}/undo"},
+		{"POST", "/api/v1/attendance/sessions"},
+		{"POST", "/api/v1/attendance/sessions/{sessionID}/marks"},
+		{"GET", "/api/v1/students/{studentID}/attendance"},
+		{"GET", "/api/v1/classes/{classID}/semesters/{semester}/attendance"},
+		{"GET", "/api/v1/alerts/at-risk"},
+		{"POST", "/api/v1/alerts/at-risk/scan"},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,210 @@
+package app
+
+import (
+	"net/http"
+	"sms/handlers"
+	"sms/models"
+	"sms/openapi"
+	gradeRepository "sms/repository/gradesRepository"
+	"sms/utils"
+)
+
+// apiRoutes documents every route setup registers. TestOpenAPI fails when the
+// two lists differ or when app/openapi.json is out of date with the types here.
+// The request and response types are written here by hand, next to but apart
+// from the handlers that decode and encode them, so changing one means
+// changing the other; TestAPIRoutes sends every route its Body and decodes
+// what comes back into its Data to catch the one that was missed.
+var apiRoutes = []openapi.Route{
+	// auth
+	{Method: "POST", Path: "/api/v1/login", Tag: "auth", Summary: "Log in and get a bearer token", Public: true,
+		Body: handlers.LoginRequest{}, Status: http.StatusOK, Data: ""},
+	{Method: "POST", Path: "/api/v1/signup", Tag: "auth", Summary: "Sign up as faculty and get a bearer token", Public: true,
+		Body: handlers.SignupRequest{}, Status: http.StatusOK, Data: ""},
+
+	// docs
+	{Method: "GET", Path: "/api/v1/openapi.json", Tag: "docs", Summary: "This document", Public: true,
+		Status: http.StatusOK, ContentType: "application/json"},
+	{Method: "GET", Path: "/api/v1/docs", Tag: "docs", Summary: "Swagger UI for this document", Public: true,
+		Status: http.StatusOK, ContentType: "text/html"},
+	{Method: "GET", Path: "/api/v1/docs/{file}", Tag: "docs", Summary: "Scripts and styles of the Swagger UI page", Public: true,
+		Status: http.StatusOK, ContentType: "*/*"},
+
+	// students
+	{Method: "POST", Path: "/api/v1/students", Tag: "students", Summary: "Add a student, optionally enrolling them in subjects",
+		Body: handlers.CreateStudentRequest{}, Status: http.StatusCreated, Data: handlers.CreateStudentResponse{}},
+	{Method: "PATCH", Path: "/api/v1/students/{studentID}", Tag: "students", Summary: "Update a student",
+		Body: handlers.UpdateStudentRequest{}, Status: http.StatusOK},
+	{Method: "GET", Path: "/api/v1/students/{studentID}/progress", Tag: "students", Summary: "Progress report of a student",
+		Status: http.StatusOK, Data: models.ProgressReport{}},
+	{Method: "GET", Path: "/api/v1/students/{studentID}/grades", Tag: "students", Summary: "Grades of a student",
+		Status: http.StatusOK, Data: []models.Grade{}},
+
+	// guardians
+	{Method: "POST", Path: "/api/v1/guardians", Tag: "guardians", Summary: "Create a guardian account",
+		Body: handlers.CreateGuardianRequest{}, Status: http.StatusCreated, Data: map[string]string{}},
+	{Method: "POST", Path: "/api/v1/guardians/{guardianID}/students", Tag: "guardians", Summary: "Link a student to a guardian",
+		Body: handlers.LinkStudentRequest{}, Status: http.StatusCreated, Data: models.GuardianLink{}},
+	{Method: "DELETE", Path: "/api/v1/guardians/{guardianID}/students/{studentID}", Tag: "guardians", Summary: "Unlink a student from a guardian",
+		Status: http.StatusOK},
+	{Method: "GET", Path: "/api/v1/me/students", Tag: "guardians", Summary: "Students linked to the signed in guardian",
+		Status: http.StatusOK, Data: []models.Students{}},
+
+	// notifications
+	{Method: "GET", Path: "/api/v1/me/notifications", Tag: "notifications", Summary: "In-app inbox of the signed in user",
+		Status: http.StatusOK, Data: []models.Notification{}},
+	{Method: "POST", Path: "/api/v1/me/notifications/{notificationID}/read", Tag: "notifications", Summary: "Mark a notification as read",
+		Status: http.StatusOK},
+	{Method: "GET", Path: "/api/v1/me/notification-preferences", Tag: "notifications", Summary: "Notification channels of the signed in user",
+		Status: http.StatusOK, Data: []models.NotificationPreference{}},
+	{Method: "PUT", Path: "/api/v1/me/notification-preferences/{channel}", Tag: "notifications", Summary: "Turn a notification channel on or off",
+		Body: handlers.NotificationPreferenceRequest{}, Status: http.StatusOK, Data: models.NotificationPreference{}},
+
+	// grades
+	{Method: "POST", Path: "/api/v1/grades", Tag: "grades", Summary: "Add a grade",
+		Body: handlers.AddGradeRequest{}, Status: http.StatusCreated},
+	{Method: "POST", Path: "/api/v1/grades/import", Tag: "grades", Summary: "Import a batch of grades, all or none",
+		Body: handlers.ImportGradesRequest{}, Status: http.StatusCreated, Data: 0},
+	{Method: "PATCH", Path: "/api/v1/grades", Tag: "grades", Summary: "Change a grade",
+		Body: handlers.UpdateGrade{}, Status: http.StatusOK},
+	{Method: "GET", Path: "/api/v1/classes/{classID}/semesters/{semester}/average", Tag: "grades", Summary: "Average grade of a class",
+		Params: []openapi.Param{semesterParam}, Status: http.StatusOK, Data: 0.0},
+	{Method: "GET", Path: "/api/v1/classes/{classID}/semesters/{semester}/statistics", Tag: "grades", Summary: "Grade statistics of a class",
+		Params: []openapi.Param{
+			semesterParam,
+			openapi.Query("subjectID", "", "only this subject"),
+			openapi.Query("bucket_width", 0, "width of the histogram buckets"),
+			openapi.Query("pass_mark", 0, "lowest passing grade"),
+		}, Status: http.StatusOK, Data: models.GradeStatistics{}},
+	{Method: "GET", Path: "/api/v1/classes/{classID}/semesters/{semester}/toppers", Tag: "grades", Summary: "Students with the best averages",
+		Params: []openapi.Param{semesterParam, openapi.RequiredQuery("top", 0, "how many students")},
+		Status: http.StatusOK, Data: []gradeRepository.StudentAverage{}},
+	{Method: "GET", Path: "/api/v1/classes/{classID}/semesters/{semester}/ranks", Tag: "grades", Summary: "Rank list of a class",
+		Params: []openapi.Param{semesterParam, subjectQuery, rankingQuery}, Status: http.StatusOK, Data: []models.RankEntry{}},
+	{Method: "GET", Path: "/api/v1/classes/{classID}/semesters/{semester}/ranks/{studentID}", Tag: "grades", Summary: "Rank of one student",
+		Params: []openapi.Param{semesterParam, subjectQuery, rankingQuery}, Status: http.StatusOK, Data: models.RankEntry{}},
+
+	// assessments
+	{Method: "POST", Path: "/api/v1/subjects/{subjectID}/semesters/{semester}/assessments", Tag: "assessments", Summary: "Create an assessment",
+		Params: []openapi.Param{semesterParam}, Body: handlers.CreateAssessmentRequest{}, Status: http.StatusCreated, Data: models.Assessment{}},
+	{Method: "GET", Path: "/api/v1/subjects/{subjectID}/semesters/{semester}/assessments", Tag: "assessments", Summary: "Assessments of a subject",
+		Params: []openapi.Param{semesterParam}, Status: http.StatusOK, Data: []models.Assessment{}},
+	{Method: "PATCH", Path: "/api/v1/assessments/{assessmentID}", Tag: "assessments", Summary: "Change the weight or maximum marks of an assessment",
+		Body: handlers.UpdateAssessmentRequest{}, Status: http.StatusOK, Data: models.Assessment{}},
+	{Method: "PUT", Path: "/api/v1/assessments/{assessmentID}/scores", Tag: "assessments", Summary: "Record scores of an assessment",
+		Body: handlers.RecordScoresRequest{}, Status: http.StatusOK},
+
+	// terms
+	{Method: "POST", Path: "/api/v1/terms", Tag: "terms", Summary: "Create an academic term",
+		Body: handlers.CreateTermRequest{}, Status: http.StatusCreated, Data: models.AcademicTerm{}},
+	{Method: "GET", Path: "/api/v1/terms", Tag: "terms", Summary: "Academic terms",
+		Params: []openapi.Param{openapi.Query("year", 0, "only terms of this year")}, Status: http.StatusOK, Data: []models.AcademicTerm{}},
+	{Method: "GET", Path: "/api/v1/terms/{termID}", Tag: "terms", Summary: "An academic term",
+		Status: http.StatusOK, Data: models.AcademicTerm{}},
+	{Method: "PATCH", Path: "/api/v1/terms/{termID}/status", Tag: "terms", Summary: "Move a term to another status",
+		Body: handlers.TermStatusRequest{}, Status: http.StatusOK, Data: models.AcademicTerm{}},
+	{Method: "PUT", Path: "/api/v1/terms/{termID}/grade-window", Tag: "terms", Summary: "Set when grades can be entered for a term",
+		Body: handlers.GradeEntryWindowRequest{}, Status: http.StatusOK, Data: models.AcademicTerm{}},
+
+	// enrollments
+	{Method: "POST", Path: "/api/v1/enrollments", Tag: "enrollments", Summary: "Enroll a student in a subject",
+		Body: handlers.EnrollRequest{}, Status: http.StatusCreated, Data: models.Enrollment{}},
+	{Method: "POST", Path: "/api/v1/enrollments/bulk", Tag: "enrollments", Summary: "Enroll a class or a list of students in subjects",
+		Body: handlers.BulkEnrollRequest{}, Status: http.StatusCreated, Data: []models.Enrollment{}},
+	{Method: "GET", Path: "/api/v1/students/{studentID}/enrollments", Tag: "enrollments", Summary: "Enrollments of a student",
+		Params: []openapi.Param{openapi.Query("semester", 0, "only this semester")}, Status: http.StatusOK, Data: []models.Enrollment{}},
+	{Method: "DELETE", Path: "/api/v1/students/{studentID}/enrollments/{subjectID}", Tag: "enrollments", Summary: "Drop a subject",
+		Params: []openapi.Param{openapi.RequiredQuery("semester", 0, "semester of the enrollment")}, Status: http.StatusOK},
+
+	// curriculum
+	{Method: "POST", Path: "/api/v1/programs", Tag: "curriculum", Summary: "Create a program",
+		Body: handlers.CreateProgramRequest{}, Status: http.StatusCreated, Data: models.Program{}},
+	{Method: "POST", Path: "/api/v1/programs/{programID}/subjects", Tag: "curriculum", Summary: "Add a subject to a program",
+		Body: handlers.AddProgramSubjectRequest{}, Status: http.StatusCreated},
+	{Method: "GET", Path: "/api/v1/programs/{programID}/semesters/{semester}/subjects", Tag: "curriculum", Summary: "Subjects of a program in a semester",
+		Params: []openapi.Param{semesterParam}, Status: http.StatusOK, Data: []models.ProgramSubject{}},
+	{Method: "PUT", Path: "/api/v1/classes/{classID}/program", Tag: "curriculum", Summary: "Assign a program to a class",
+		Body: handlers.AssignProgramRequest{}, Status: http.StatusOK},
+	{Method: "POST", Path: "/api/v1/classes/{classID}/semesters/{semester}/auto-enroll", Tag: "curriculum", Summary: "Enroll a class in the core subjects of its program",
+		Params: []openapi.Param{semesterParam}, Status: http.StatusCreated, Data: []models.Enrollment{}},
+	{Method: "POST", Path: "/api/v1/students/{studentID}/electives", Tag: "curriculum", Summary: "Enroll a student in an elective",
+		Body: handlers.ElectiveRequest{}, Status: http.StatusCreated, Data: models.Enrollment{}},
+
+	// timetable
+	{Method: "POST", Path: "/api/v1/rooms", Tag: "timetable", Summary: "Create a room",
+		Body: handlers.CreateRoomRequest{}, Status: http.StatusCreated, Data: models.Room{}},
+	{Method: "PUT", Path: "/api/v1/classes/{classID}/room", Tag: "timetable", Summary: "Set the home room of a class",
+		Body: handlers.HomeRoomRequest{}, Status: http.StatusOK},
+	{Method: "POST", Path: "/api/v1/faculty-assignments", Tag: "timetable", Summary: "Assign a faculty to teach a subject to a class",
+		Body: handlers.FacultyAssignmentRequest{}, Status: http.StatusCreated, Data: models.FacultyAssignment{}},
+	{Method: "POST", Path: "/api/v1/timetable/slots", Tag: "timetable", Summary: "Add a weekly slot",
+		Body: handlers.AddSlotRequest{}, Status: http.StatusCreated, Data: models.TimetableSlot{}},
+	{Method: "DELETE", Path: "/api/v1/timetable/slots/{slotID}", Tag: "timetable", Summary: "Remove a slot",
+		Status: http.StatusOK},
+	{Method: "GET", Path: "/api/v1/classes/{classID}/timetable", Tag: "timetable", Summary: "Timetable of a class",
+		Status: http.StatusOK, Data: []models.TimetableSlot{}},
+	{Method: "GET", Path: "/api/v1/classes/{classID}/timetable.ics", Tag: "timetable", Summary: "Timetable of a class as an iCalendar file",
+		Params: calendarParams, Status: http.StatusOK, ContentType: "text/calendar"},
+	{Method: "GET", Path: "/api/v1/faculty/{facultyID}/timetable", Tag: "timetable", Summary: "Timetable of a faculty",
+		Status: http.StatusOK, Data: []models.TimetableSlot{}},
+	{Method: "GET", Path: "/api/v1/faculty/{facultyID}/timetable.ics", Tag: "timetable", Summary: "Timetable of a faculty as an iCalendar file",
+		Params: calendarParams, Status: http.StatusOK, ContentType: "text/calendar"},
+
+	// rollover
+	{Method: "POST", Path: "/api/v1/classes/{classID}/semesters/{semester}/rollover", Tag: "rollover", Summary: "Promote or hold back the students of a class; 200 with a preview on dry runs",
+		Params: []openapi.Param{semesterParam}, Body: handlers.RolloverRequest{}, Status: http.StatusCreated, Data: models.Rollover{}},
+	{Method: "GET", Path: "/api/v1/rollovers/{rolloverID}", Tag: "rollover", Summary: "A rollover",
+		Status: http.StatusOK, Data: models.Rollover{}},
+	{Method: "POST", Path: "/api/v1/rollovers/{rolloverID}/undo", Tag: "rollover", Summary: "Undo a rollover",
+		Status: http.StatusOK, Data: models.Rollover{}},
+
+	// attendance
+	{Method: "POST", Path: "/api/v1/attendance/sessions", Tag: "attendance", Summary: "Open an attendance session",
+		Body: handlers.CreateSessionRequest{}, Status: http.StatusCreated, Data: models.AttendanceSession{}},
+	{Method: "POST", Path: "/api/v1/attendance/sessions/{sessionID}/marks", Tag: "attendance", Summary: "Mark attendance for a session",
+		Body: handlers.MarkAttendanceRequest{}, Status: http.StatusOK},
+	{Method: "GET", Path: "/api/v1/students/{studentID}/attendance", Tag: "attendance", Summary: "Attendance of a student",
+		Params: []openapi.Param{openapi.RequiredQuery("semester", 0, ""), subjectQuery}, Status: http.StatusOK, Data: models.AttendanceSummary{}},
+	{Method: "GET", Path: "/api/v1/classes/{classID}/semesters/{semester}/attendance", Tag: "attendance", Summary: "Attendance of a class",
+		Params: []openapi.Param{semesterParam, subjectQuery}, Status: http.StatusOK, Data: []models.AttendanceSummary{}},
+
+	// alerts
+	{Method: "GET", Path: "/api/v1/alerts/at-risk", Tag: "alerts", Summary: "Students flagged as at risk",
+		Params: []openapi.Param{openapi.Query("semester", 0, "only this semester")}, Status: http.StatusOK, Data: []models.AtRiskFlag{}},
+	{Method: "POST", Path: "/api/v1/alerts/at-risk/scan", Tag: "alerts", Summary: "Look for students at risk",
+		Body: handlers.ScanAtRiskRequest{}, Status: http.StatusOK, Data: []models.AtRiskFlag{}},
+
+	// webhooks
+	{Method: "POST", Path: "/api/v1/webhooks", Tag: "webhooks", Summary: "Subscribe to events",
+		Body: handlers.CreateWebhookRequest{}, Status: http.StatusCreated, Data: models.WebhookSubscription{}},
+	{Method: "GET", Path: "/api/v1/webhooks", Tag: "webhooks", Summary: "Webhook subscriptions",
+		Status: http.StatusOK, Data: []models.WebhookSubscription{}},
+	{Method: "GET", Path: "/api/v1/webhooks/{subscriptionID}", Tag: "webhooks", Summary: "A webhook subscription",
+		Status: http.StatusOK, Data: models.WebhookSubscription{}},
+	{Method: "PATCH", Path: "/api/v1/webhooks/{subscriptionID}", Tag: "webhooks", Summary: "Pause or resume a subscription",
+		Body: handlers.UpdateWebhookRequest{}, Status: http.StatusOK},
+	{Method: "GET", Path: "/api/v1/webhooks/{subscriptionID}/deliveries", Tag: "webhooks", Summary: "Deliveries of a subscription",
+		Status: http.StatusOK, Data: []models.WebhookDelivery{}},
+	{Method: "GET", Path: "/api/v1/webhook-deliveries/{deliveryID}", Tag: "webhooks", Summary: "A delivery with its attempts",
+		Status: http.StatusOK, Data: models.WebhookDelivery{}},
+	{Method: "POST", Path: "/api/v1/webhook-deliveries/{deliveryID}/redeliver", Tag: "webhooks", Summary: "Queue a delivery again",
+		Status: http.StatusAccepted},
+}
+
+var (
+	semesterParam  = openapi.PathParam("semester", 0)
+	subjectQuery   = openapi.Query("subjectID", "", "only this subject")
+	rankingQuery   = openapi.Query("method", "", "competition (default) or dense")
+	calendarParams = []openapi.Param{
+		openapi.RequiredQuery("from", "", "first day, YYYY-MM-DD"),
+		openapi.RequiredQuery("until", "", "last day, YYYY-MM-DD"),
+	}
+)
+
+func apiDocument() *openapi.Document {
+	return openapi.Build(openapi.Info{
+		Title:       "Student Management System API",
+		Version:     "1.0.0",
+		Description: "Every JSON response is wrapped in the Response envelope; its data property holds the result.",
+	}, utils.CustomResponse{}, apiRoutes)
+}
@@ -0,0 +1,100 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"sms/config"
+	"sms/openapi"
+	"sms/repository/storage"
+	"sort"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite app/openapi.json from the route table")
+
+// TestOpenAPI keeps the API document honest: every registered route has to be
+// documented and the other way round, and the committed app/openapi.json has to
+// match what the request and response types generate. After changing either,
+// run go test ./app -run TestOpenAPI -update and review the diff. It compares
+// methods and paths only; TestAPIRoutes checks each route's Body and Data
+// against its handler.
+func TestOpenAPI(t *testing.T) {
+	db, _ := storage.Open(storage.SQLite, ":memory:")
+	defer db.Close()
+	cfg := config.Default()
+	cfg.JWT.Secret = "0123456789abcdef0123456789abcdef"
+	mux, _, _, err := setup(db, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	registered := append([]string(nil), mux.patterns...)
+	sort.Strings(registered)
+	if documented := openapi.Patterns(apiRoutes); !reflect.DeepEqual(registered, documented) {
+		t.Errorf("routes and apiRoutes differ:\nundocumented: %v\nnot registered: %v",
+			missing(registered, documented), missing(documented, registered))
+	}
+
+	generated, err := apiDocument().JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *update {
+		if err := os.WriteFile("openapi.json", generated, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	committed, err := os.ReadFile("openapi.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(generated, committed) {
+		t.Error("app/openapi.json is out of date; run go test ./app -run TestOpenAPI -update")
+	}
+
+	rr := httptest.NewRecorder()
+	mux.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/openapi.json", nil))
+	if rr.Code != http.StatusOK || !bytes.Equal(rr.Body.Bytes(), generated) {
+		t.Errorf("GET /api/v1/openapi.json returned %d and a different document", rr.Code)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(rr.Body.Bytes(), &doc); err != nil || doc["openapi"] != "3.1.0" {
+		t.Errorf("served document isn't OpenAPI 3.1: %v", err)
+	}
+
+	rr = httptest.NewRecorder()
+	mux.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/docs", nil))
+	if rr.Code != http.StatusOK || !bytes.Contains(rr.Body.Bytes(), []byte("SwaggerUIBundle")) {
+		t.Errorf("GET /api/v1/docs returned %d without Swagger UI", rr.Code)
+	}
+	if bytes.Contains(rr.Body.Bytes(), []byte("https://")) {
+		t.Error("expected the Swagger UI page to load nothing from other hosts")
+	}
+	for _, asset := range []string{"swagger-ui-bundle.js", "swagger-ui.css"} {
+		rr = httptest.NewRecorder()
+		mux.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/docs/"+asset, nil))
+		if rr.Code != http.StatusOK || !bytes.Contains(rr.Body.Bytes(), []byte("swagger")) {
+			t.Errorf("GET /api/v1/docs/%s returned %d", asset, rr.Code)
+		}
+	}
+
+}
+
+func missing(have, want []string) []string {
+	set := map[string]bool{}
+	for _, p := range want {
+		set[p] = true
+	}
+	var out []string
+	for _, p := range have {
+		if !set[p] {
+			out = append(out, p)
+		}
+	}
+	return out
+}
@@ -0,0 +1,309 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"sms/config"
+	"sms/constants"
+	"sms/handlers"
+	"sms/models"
+	"sms/openapi"
+	"sms/repository/storage"
+	userrepository "sms/repository/userRepository"
+	"sms/services"
+	"sms/utils"
+	"strings"
+	"testing"
+	"time"
+)
+
+// routeTester sends requests through the real mux, one documented route at a
+// time, and holds each exchange to what apiRoutes says about the route.
+type routeTester struct {
+	t      *testing.T
+	mux    *routes
+	routes map[string]openapi.Route
+	called map[string]bool
+}
+
+// call sends body to path as the holder of token, checks that path reaches the
+// route pattern names, that body is the route's documented Body and that the
+// route answers with its documented Status, and returns the response data
+// decoded into the route's documented Data. Unknown fields fail the decoding,
+// here as in the handlers, so a Body or Data type that no longer is the one the
+// handler decodes or encodes fails the test.
+func (rt *routeTester) call(pattern, path, token string, body any) any {
+	rt.t.Helper()
+	route, ok := rt.routes[pattern]
+	if !ok {
+		rt.t.Fatalf("%s isn't documented", pattern)
+	}
+	rt.called[pattern] = true
+	if reflect.TypeOf(body) != reflect.TypeOf(route.Body) {
+		rt.t.Fatalf("%s: sent a %T, documented Body is %T", pattern, body, route.Body)
+	}
+
+	var reqBody []byte
+	if body != nil {
+		var err error
+		if reqBody, err = json.Marshal(body); err != nil {
+			rt.t.Fatal(err)
+		}
+	}
+	req := httptest.NewRequest(route.Method, path, bytes.NewReader(reqBody))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if _, matched := rt.mux.Handler(req); matched != pattern {
+		rt.t.Fatalf("%s %s reaches %q, not %s", route.Method, path, matched, pattern)
+	}
+	rr := httptest.NewRecorder()
+	rt.mux.ServeHTTP(rr, req)
+	if rr.Code != route.Status {
+		rt.t.Fatalf("%s: expected status %d, got %d: %s", pattern, route.Status, rr.Code, rr.Body)
+	}
+
+	if route.ContentType != "" {
+		if got := rr.Header().Get("Content-Type"); route.ContentType != "*/*" && !strings.HasPrefix(got, route.ContentType) {
+			rt.t.Errorf("%s: expected %s, got %s", pattern, route.ContentType, got)
+		}
+		return nil
+	}
+	var envelope struct {
+		utils.CustomResponse
+		Data json.RawMessage `json:"data"`
+	}
+	if err := decodeStrict(rr.Body.Bytes(), &envelope); err != nil {
+		rt.t.Fatalf("%s: response isn't the envelope: %v", pattern, err)
+	}
+	if route.Data == nil {
+		if len(envelope.Data) != 0 {
+			rt.t.Errorf("%s: documented without data, got %s", pattern, envelope.Data)
+		}
+		return nil
+	}
+	data := reflect.New(reflect.TypeOf(route.Data))
+	if err := decodeStrict(envelope.Data, data.Interface()); err != nil {
+		rt.t.Fatalf("%s: data isn't a %T: %v\n%s", pattern, route.Data, err, envelope.Data)
+	}
+	return data.Elem().Interface()
+}
+
+func decodeStrict(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
+
+// TestAPIRoutes walks a school through every documented route: it sets up a
+// term, students, grades, a curriculum, a timetable and the rest, each call
+// building on what the ones before it created.
+func TestAPIRoutes(t *testing.T) {
+	ctx := context.Background()
+	db, err := storage.Open(storage.SQLite, filepath.Join(t.TempDir(), "sms.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err := storage.Migrate(ctx, db); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	for _, q := range []string{
+		`insert into class (ClassID, Capacity) values('C1', 60)`,
+		`insert into class (ClassID, Capacity) values('C2', 60)`,
+		`insert into subject values('MATH', 'Engineering Mathematics')`,
+		`insert into subject values('PHY', 'Physics')`,
+		`insert into subject values('CHEM', 'Chemistry')`,
+	} {
+		if _, err := db.ExecContext(ctx, q); err != nil {
+			t.Fatalf("failed to seed: %v", err)
+		}
+	}
+	const password = "Str0ng&Secret"
+	auth := services.NewAuthService(userrepository.NewUserRepo(db))
+	if _, err := auth.CreateAccount(ctx, "Admin", "admin@example.com", password, constants.Admin); err != nil {
+		t.Fatal(err)
+	}
+	faculty, err := auth.CreateAccount(ctx, "Faculty", "faculty@example.com", password, constants.Faculty)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.Default()
+	cfg.JWT.Secret = "0123456789abcdef0123456789abcdef"
+	mux, _, _, err := setup(db, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rt := &routeTester{t: t, mux: mux, routes: map[string]openapi.Route{}, called: map[string]bool{}}
+	for _, r := range apiRoutes {
+		rt.routes[r.Pattern()] = r
+	}
+
+	// auth
+	login := func(email string) string {
+		return rt.call("POST /api/v1/login", "/api/v1/login", "", handlers.LoginRequest{Email: email, Password: password}).(string)
+	}
+	admin, teacher := login("admin@example.com"), login("faculty@example.com")
+	newcomer := rt.call("POST /api/v1/signup", "/api/v1/signup", "",
+		handlers.SignupRequest{Name: "New", Email: "new@example.com", Password: password}).(string)
+
+	// docs
+	rt.call("GET /api/v1/openapi.json", "/api/v1/openapi.json", "", nil)
+	rt.call("GET /api/v1/docs", "/api/v1/docs", "", nil)
+	rt.call("GET /api/v1/docs/{file}", "/api/v1/docs/swagger-ui.css", "", nil)
+
+	// terms
+	today := time.Now().UTC()
+	day := func(days int) string { return today.AddDate(0, 0, days).Format(time.DateOnly) }
+	term := rt.call("POST /api/v1/terms", "/api/v1/terms", admin, handlers.CreateTermRequest{
+		Year: today.Year(), TermNumber: 1, Semesters: []int{1},
+		StartDate: day(-30), EndDate: day(90), GradeEntryStart: day(-1), GradeEntryEnd: day(1),
+	}).(models.AcademicTerm)
+	rt.call("GET /api/v1/terms", "/api/v1/terms?year="+day(0)[:4], admin, nil)
+	rt.call("GET /api/v1/terms/{termID}", "/api/v1/terms/"+term.TermID, teacher, nil)
+	rt.call("PUT /api/v1/terms/{termID}/grade-window", "/api/v1/terms/"+term.TermID+"/grade-window", admin,
+		handlers.GradeEntryWindowRequest{GradeEntryStart: day(-2), GradeEntryEnd: day(2)})
+
+	// students
+	var students []string
+	for _, s := range []handlers.CreateStudentRequest{
+		{RollNumber: "101", Name: "Anu", ClassID: "C1", Semester: 1, SubjectIDs: []string{"MATH"}},
+		{RollNumber: "102", Name: "Ravi", ClassID: "C1", Semester: 1, SubjectIDs: []string{"MATH"}},
+	} {
+		created := rt.call("POST /api/v1/students", "/api/v1/students", admin, s).(handlers.CreateStudentResponse)
+		students = append(students, created.StudentID)
+	}
+	anu, ravi := students[0], students[1]
+	rt.call("PATCH /api/v1/students/{studentID}", "/api/v1/students/"+ravi, admin, handlers.UpdateStudentRequest{Name: "Ravi K"})
+
+	// grades
+	rt.call("POST /api/v1/grades", "/api/v1/grades", teacher, handlers.AddGradeRequest{StudentID: anu, SubjectID: "MATH", Semester: 1, Grade: 35})
+	rt.call("POST /api/v1/grades/import", "/api/v1/grades/import", teacher, handlers.ImportGradesRequest{
+		Grades: []handlers.AddGradeRequest{{StudentID: ravi, SubjectID: "MATH", Semester: 1, Grade: 80}},
+	})
+	rt.call("PATCH /api/v1/grades", "/api/v1/grades", teacher, handlers.UpdateGrade{StudentID: anu, SubjectID: "MATH", NewGrade: 30})
+	semester := "/api/v1/classes/C1/semesters/1"
+	rt.call("GET /api/v1/classes/{classID}/semesters/{semester}/average", semester+"/average", teacher, nil)
+	rt.call("GET /api/v1/classes/{classID}/semesters/{semester}/statistics", semester+"/statistics", teacher, nil)
+	rt.call("GET /api/v1/classes/{classID}/semesters/{semester}/toppers", semester+"/toppers?top=1", teacher, nil)
+	rt.call("GET /api/v1/classes/{classID}/semesters/{semester}/ranks", semester+"/ranks", teacher, nil)
+	rt.call("GET /api/v1/classes/{classID}/semesters/{semester}/ranks/{studentID}", semester+"/ranks/"+anu, teacher, nil)
+	rt.call("GET /api/v1/students/{studentID}/progress", "/api/v1/students/"+anu+"/progress", admin, nil)
+	rt.call("GET /api/v1/students/{studentID}/grades", "/api/v1/students/"+anu+"/grades", admin, nil)
+
+	// assessments
+	assessment := rt.call("POST /api/v1/subjects/{subjectID}/semesters/{semester}/assessments", "/api/v1/subjects/MATH/semesters/1/assessments", teacher,
+		handlers.CreateAssessmentRequest{Name: "Midterm", Kind: constants.Midterm, Weight: 40, MaxMarks: 50}).(models.Assessment)
+	rt.call("GET /api/v1/subjects/{subjectID}/semesters/{semester}/assessments", "/api/v1/subjects/MATH/semesters/1/assessments", teacher, nil)
+	rt.call("PATCH /api/v1/assessments/{assessmentID}", "/api/v1/assessments/"+assessment.AssessmentID, teacher,
+		handlers.UpdateAssessmentRequest{Weight: 30, MaxMarks: 50})
+	rt.call("PUT /api/v1/assessments/{assessmentID}/scores", "/api/v1/assessments/"+assessment.AssessmentID+"/scores", teacher,
+		handlers.RecordScoresRequest{Scores: []handlers.AssessmentScoreRequest{{StudentID: anu, Marks: 20}}})
+
+	// enrollments
+	rt.call("POST /api/v1/enrollments", "/api/v1/enrollments", admin, handlers.EnrollRequest{StudentID: anu, SubjectID: "PHY", Semester: 1})
+	rt.call("POST /api/v1/enrollments/bulk", "/api/v1/enrollments/bulk", admin,
+		handlers.BulkEnrollRequest{StudentIDs: []string{ravi}, SubjectIDs: []string{"PHY"}, Semester: 1})
+	rt.call("GET /api/v1/students/{studentID}/enrollments", "/api/v1/students/"+anu+"/enrollments?semester=1", teacher, nil)
+	rt.call("DELETE /api/v1/students/{studentID}/enrollments/{subjectID}", "/api/v1/students/"+anu+"/enrollments/PHY?semester=1", admin, nil)
+
+	// curriculum
+	program := rt.call("POST /api/v1/programs", "/api/v1/programs", admin, handlers.CreateProgramRequest{Name: "B.Tech", Semesters: 8}).(models.Program)
+	programPath := "/api/v1/programs/" + program.ProgramID
+	rt.call("POST /api/v1/programs/{programID}/subjects", programPath+"/subjects", admin,
+		handlers.AddProgramSubjectRequest{SubjectID: "MATH", Semester: 1, Kind: constants.CoreSubject, Credits: 4})
+	rt.call("POST /api/v1/programs/{programID}/subjects", programPath+"/subjects", admin,
+		handlers.AddProgramSubjectRequest{SubjectID: "CHEM", Semester: 1, Kind: constants.ElectiveSubject, Credits: 2})
+	rt.call("GET /api/v1/programs/{programID}/semesters/{semester}/subjects", programPath+"/semesters/1/subjects", teacher, nil)
+	rt.call("PUT /api/v1/classes/{classID}/program", "/api/v1/classes/C1/program", admin, handlers.AssignProgramRequest{ProgramID: program.ProgramID})
+	rt.call("POST /api/v1/classes/{classID}/semesters/{semester}/auto-enroll", semester+"/auto-enroll", admin, nil)
+	rt.call("POST /api/v1/students/{studentID}/electives", "/api/v1/students/"+anu+"/electives", admin, handlers.ElectiveRequest{SubjectID: "CHEM", Semester: 1})
+
+	// timetable
+	room := rt.call("POST /api/v1/rooms", "/api/v1/rooms", admin, handlers.CreateRoomRequest{Name: "Room 101", Capacity: 60}).(models.Room)
+	rt.call("PUT /api/v1/classes/{classID}/room", "/api/v1/classes/C1/room", admin, handlers.HomeRoomRequest{RoomID: room.RoomID})
+	rt.call("POST /api/v1/faculty-assignments", "/api/v1/faculty-assignments", admin,
+		handlers.FacultyAssignmentRequest{FacultyID: faculty.UserID, ClassID: "C1", SubjectID: "MATH"})
+	slot := rt.call("POST /api/v1/timetable/slots", "/api/v1/timetable/slots", admin, handlers.AddSlotRequest{
+		ClassID: "C1", SubjectID: "MATH", FacultyID: faculty.UserID, Weekday: "monday", StartTime: "09:00", EndTime: "10:00",
+	}).(models.TimetableSlot)
+	calendar := "?from=" + day(0) + "&until=" + day(7)
+	rt.call("GET /api/v1/classes/{classID}/timetable", "/api/v1/classes/C1/timetable", teacher, nil)
+	rt.call("GET /api/v1/classes/{classID}/timetable.ics", "/api/v1/classes/C1/timetable.ics"+calendar, teacher, nil)
+	rt.call("GET /api/v1/faculty/{facultyID}/timetable", "/api/v1/faculty/"+faculty.UserID+"/timetable", teacher, nil)
+	rt.call("GET /api/v1/faculty/{facultyID}/timetable.ics", "/api/v1/faculty/"+faculty.UserID+"/timetable.ics"+calendar, teacher, nil)
+	rt.call("DELETE /api/v1/timetable/slots/{slotID}", "/api/v1/timetable/slots/"+slot.SlotID, admin, nil)
+
+	// attendance
+	session := rt.call("POST /api/v1/attendance/sessions", "/api/v1/attendance/sessions", teacher,
+		handlers.CreateSessionRequest{ClassID: "C1", SubjectID: "MATH", Semester: 1, Date: day(0)}).(models.AttendanceSession)
+	rt.call("POST /api/v1/attendance/sessions/{sessionID}/marks", "/api/v1/attendance/sessions/"+session.SessionID+"/marks", teacher,
+		handlers.MarkAttendanceRequest{Marks: []handlers.AttendanceMarkRequest{{StudentID: anu, Status: constants.Absent}, {StudentID: ravi, Status: constants.Present}}})
+	rt.call("GET /api/v1/students/{studentID}/attendance", "/api/v1/students/"+anu+"/attendance?semester=1", teacher, nil)
+	rt.call("GET /api/v1/classes/{classID}/semesters/{semester}/attendance", semester+"/attendance", teacher, nil)
+
+	// alerts
+	rt.call("POST /api/v1/alerts/at-risk/scan", "/api/v1/alerts/at-risk/scan", teacher, handlers.ScanAtRiskRequest{Semester: 1})
+	rt.call("GET /api/v1/alerts/at-risk", "/api/v1/alerts/at-risk?semester=1", teacher, nil)
+
+	// guardians
+	created := rt.call("POST /api/v1/guardians", "/api/v1/guardians", admin,
+		handlers.CreateGuardianRequest{Name: "Parent", Email: "parent@example.com", Password: password}).(map[string]string)
+	guardianPath := "/api/v1/guardians/" + created["guardianID"]
+	rt.call("POST /api/v1/guardians/{guardianID}/students", guardianPath+"/students", admin, handlers.LinkStudentRequest{StudentID: anu, Relationship: "mother"})
+	rt.call("GET /api/v1/me/students", "/api/v1/me/students", login("parent@example.com"), nil)
+	rt.call("DELETE /api/v1/guardians/{guardianID}/students/{studentID}", guardianPath+"/students/"+anu, admin, nil)
+
+	// notifications, starting with the one signing up queued
+	svc, err := NewServices(db, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.Notifications.ProcessOutbox(ctx, time.Now()); err != nil {
+		t.Fatalf("failed to send notifications: %v", err)
+	}
+	inbox := rt.call("GET /api/v1/me/notifications", "/api/v1/me/notifications", newcomer, nil).([]models.Notification)
+	if len(inbox) == 0 {
+		t.Fatal("expected a notification about the new account")
+	}
+	rt.call("POST /api/v1/me/notifications/{notificationID}/read", "/api/v1/me/notifications/"+inbox[0].NotificationID+"/read", newcomer, nil)
+	rt.call("GET /api/v1/me/notification-preferences", "/api/v1/me/notification-preferences", newcomer, nil)
+	rt.call("PUT /api/v1/me/notification-preferences/{channel}", "/api/v1/me/notification-preferences/email", newcomer,
+		handlers.NotificationPreferenceRequest{Enabled: true, Target: "new@example.com"})
+
+	// webhooks, with a delivery queued by the student added after subscribing
+	sub := rt.call("POST /api/v1/webhooks", "/api/v1/webhooks", admin, handlers.CreateWebhookRequest{
+		URL: "https://example.com/hook", Events: []string{string(constants.WebhookStudentCreated)},
+	}).(models.WebhookSubscription)
+	subPath := "/api/v1/webhooks/" + sub.SubscriptionID
+	rt.call("POST /api/v1/students", "/api/v1/students", admin, handlers.CreateStudentRequest{RollNumber: "103", Name: "Meera", ClassID: "C2", Semester: 1})
+	rt.call("GET /api/v1/webhooks", "/api/v1/webhooks", admin, nil)
+	rt.call("GET /api/v1/webhooks/{subscriptionID}", subPath, admin, nil)
+	deliveries := rt.call("GET /api/v1/webhooks/{subscriptionID}/deliveries", subPath+"/deliveries", admin, nil).([]models.WebhookDelivery)
+	if len(deliveries) == 0 {
+		t.Fatal("expected a delivery for the new student")
+	}
+	rt.call("GET /api/v1/webhook-deliveries/{deliveryID}", "/api/v1/webhook-deliveries/"+deliveries[0].DeliveryID, admin, nil)
+	rt.call("POST /api/v1/webhook-deliveries/{deliveryID}/redeliver", "/api/v1/webhook-deliveries/"+deliveries[0].DeliveryID+"/redeliver", admin, nil)
+	active := false
+	rt.call("PATCH /api/v1/webhooks/{subscriptionID}", subPath, admin, handlers.UpdateWebhookRequest{Active: &active})
+
+	// rollover, once the semester's grades are in
+	rollover := rt.call("POST /api/v1/classes/{classID}/semesters/{semester}/rollover", semester+"/rollover", admin,
+		handlers.RolloverRequest{TargetClassID: "C2"}).(models.Rollover)
+	rt.call("GET /api/v1/rollovers/{rolloverID}", "/api/v1/rollovers/"+rollover.RolloverID, admin, nil)
+	rt.call("POST /api/v1/rollovers/{rolloverID}/undo", "/api/v1/rollovers/"+rollover.RolloverID+"/undo", admin, nil)
+
+	rt.call("PATCH /api/v1/terms/{termID}/status", "/api/v1/terms/"+term.TermID+"/status", admin, handlers.TermStatusRequest{Status: constants.TermClosed})
+
+	for _, r := range apiRoutes {
+		if !rt.called[r.Pattern()] {
+			t.Errorf("%s isn't exercised", r.Pattern())
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package app
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sms/config"
+	"sms/events"
+	"sms/middleware"
+	"sms/repository/storage"
+	"sync"
+	"syscall"
+)
+
+// Run serves the API on cfg.Addr, over HTTPS when cfg.TLS names a certificate,
+// until ctx is done. It then shuts down in order: it stops accepting
+// connections and lets the requests in flight finish, stops the background
+// workers and waits for them, and waits for the event subscribers still
+// running. Each wait is bounded by cfg.HTTP.ShutdownTimeout. The database stays
+// open for the caller to close.
+func Run(ctx context.Context, db *storage.DB, cfg config.Config) error {
+	mux, workers, bus, err := setup(db, cfg)
+	if err != nil {
+		return err
+	}
+	l, err := net.Listen("tcp", cfg.Addr)
+	if err != nil {
+		return err
+	}
+	return serve(ctx, l, middleware.Timeout(cfg.HTTP.RequestTimeout, mux), workers, bus, cfg)
+}
+
+func serve(ctx context.Context, l net.Listener, h http.Handler, workers []worker, bus *events.Bus, cfg config.Config) error {
+	srv := &http.Server{
+		Handler:           h,
+		ReadHeaderTimeout: cfg.HTTP.ReadHeaderTimeout,
+		ReadTimeout:       cfg.HTTP.ReadTimeout,
+		WriteTimeout:      cfg.HTTP.WriteTimeout,
+		IdleTimeout:       cfg.HTTP.IdleTimeout,
+	}
+	scheme := "http"
+	if cfg.TLS.Enabled() {
+		certs, err := newCertReloader(cfg.TLS.CertFile, cfg.TLS.KeyFile)
+		if err != nil {
+			l.Close()
+			return err
+		}
+		stopReloading := reloadOnHangup(certs)
+		defer stopReloading()
+		srv.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12, GetCertificate: certs.GetCertificate}
+		l = tls.NewListener(l, srv.TLSConfig)
+		scheme = "https"
+	}
+
+	// the workers outlive ctx until the server has drained
+	workerCtx, stopWorkers := context.WithCancel(context.WithoutCancel(ctx))
+	defer stopWorkers()
+	var running sync.WaitGroup
+	for _, w := range workers {
+		running.Add(1)
+		go func() {
+			defer running.Done()
+			w(workerCtx)
+		}()
+	}
+
+	served := make(chan error, 1)
+	go func() { served <- srv.Serve(l) }()
+	log.Printf("serving on %s://%s", scheme, l.Addr())
+
+	var errs []error
+	select {
+	case <-ctx.Done():
+		log.Println("shutting down")
+	case err := <-served:
+		errs = append(errs, err)
+	}
+
+	drainCtx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(drainCtx); err != nil {
+		srv.Close()
+		errs = append(errs, fmt.Errorf("requests still running at shutdown were cut off: %w", err))
+	}
+
+	stopWorkers()
+	waitCtx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
+	defer cancel()
+	if err := wait(waitCtx, running.Wait); err != nil {
+		errs = append(errs, fmt.Errorf("background workers did not stop: %w", err))
+	} else if err := wait(waitCtx, bus.Wait); err != nil {
+		errs = append(errs, fmt.Errorf("event subscribers did not finish: %w", err))
+	}
+	return errors.Join(errs...)
+}
+
+// wait runs f, giving up when ctx is done first.
+func wait(ctx context.Context, f func()) error {
+	done := make(chan struct{})
+	go func() {
+		f()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// reloadOnHangup reloads the certificate whenever the process gets SIGHUP, for
+// renewals that keep the file times. The returned func stops listening.
+func reloadOnHangup(certs *certReloader) func() {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-hup:
+				if err := certs.Reload(); err != nil {
+					log.Printf("keeping the current certificate: %v", err)
+				} else {
+					log.Println("reloaded the certificate")
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(hup)
+		close(done)
+	}
+}
@@ -0,0 +1,243 @@
+package app
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sms/config"
+	"sms/events"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func listen(t *testing.T) net.Listener {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l
+}
+
+// TestServeShutsDownInOrder checks that a stopping server lets the request in
+// flight finish, then stops the workers, then waits for the event subscribers.
+func TestServeShutsDownInOrder(t *testing.T) {
+	var mu sync.Mutex
+	var order []string
+	record := func(step string) {
+		mu.Lock()
+		order = append(order, step)
+		mu.Unlock()
+	}
+
+	bus := events.NewBus()
+	events.SubscribeAsync(bus, func(ctx context.Context, e events.GradeAdded) error {
+		time.Sleep(50 * time.Millisecond)
+		record("subscriber")
+		return nil
+	})
+	started, release := make(chan struct{}), make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		bus.Publish(r.Context(), events.GradeAdded{StudentID: "s1"})
+		record("request")
+		io.WriteString(w, "done")
+	})
+	recordStop := func(ctx context.Context) {
+		<-ctx.Done()
+		record("worker")
+	}
+
+	l := listen(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- serve(ctx, l, handler, []worker{recordStop}, bus, config.Default()) }()
+
+	type result struct {
+		body string
+		err  error
+	}
+	responded := make(chan result, 1)
+	go func() {
+		resp, err := http.Get("http://" + l.Addr().String())
+		if err != nil {
+			responded <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		b, err := io.ReadAll(resp.Body)
+		responded <- result{string(b), err}
+	}()
+	<-started
+	cancel()
+
+	// the listener closes while the request is still running
+	deadline := time.Now().Add(time.Second)
+	for {
+		conn, err := net.Dial("tcp", l.Addr().String())
+		if err != nil {
+			break
+		}
+		conn.Close()
+		if time.Now().After(deadline) {
+			t.Fatal("expected the server to stop accepting connections")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	select {
+	case err := <-served:
+		t.Fatalf("serve returned before the request finished: %v", err)
+	default:
+	}
+
+	close(release)
+	if r := <-responded; r.err != nil || r.body != "done" {
+		t.Errorf("expected the request in flight to finish, got %q, %v", r.body, r.err)
+	}
+	if err := <-served; err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if got := strings.Join(order, ","); got != "request,worker,subscriber" {
+		t.Errorf("expected request,worker,subscriber, got %s", got)
+	}
+}
+
+func TestServeGivesUpOnStuckRequests(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	defer close(release)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+	})
+	cfg := config.Default()
+	cfg.HTTP.ShutdownTimeout = 50 * time.Millisecond
+
+	l := listen(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- serve(ctx, l, handler, nil, events.NewBus(), cfg) }()
+	requested := make(chan error, 1)
+	go func() {
+		resp, err := http.Get("http://" + l.Addr().String())
+		if err == nil {
+			resp.Body.Close()
+		}
+		requested <- err
+	}()
+	<-started
+	cancel()
+
+	select {
+	case err := <-served:
+		if err == nil || !strings.Contains(err.Error(), "cut off") {
+			t.Errorf("expected the stuck request to be reported, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("serve did not give up on the stuck request")
+	}
+	if err := <-requested; err == nil {
+		t.Error("expected the stuck request to be cut off")
+	}
+}
+
+func TestServeReloadsCertificate(t *testing.T) {
+	dir := t.TempDir()
+	cfg := config.Default()
+	cfg.TLS = config.TLS{CertFile: filepath.Join(dir, "tls.crt"), KeyFile: filepath.Join(dir, "tls.key")}
+	writeCert(t, cfg.TLS, 1, time.Now().Add(-time.Minute))
+
+	l := listen(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() {
+		served <- serve(ctx, l, http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}), nil, events.NewBus(), cfg)
+	}()
+	defer func() {
+		cancel()
+		if err := <-served; err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}()
+
+	serial := func() int64 {
+		t.Helper()
+		conn, err := tls.Dial("tcp", l.Addr().String(), &tls.Config{InsecureSkipVerify: true})
+		if err != nil {
+			t.Fatalf("handshake failed: %v", err)
+		}
+		defer conn.Close()
+		return conn.ConnectionState().PeerCertificates[0].SerialNumber.Int64()
+	}
+	if got := serial(); got != 1 {
+		t.Fatalf("expected certificate 1, got %d", got)
+	}
+
+	writeCert(t, cfg.TLS, 2, time.Now())
+	if got := serial(); got != 2 {
+		t.Errorf("expected the renewed certificate 2, got %d", got)
+	}
+
+	// a broken renewal keeps the certificate that works
+	if err := os.WriteFile(cfg.TLS.CertFile, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	touch(t, cfg.TLS.CertFile, time.Now().Add(time.Minute))
+	if got := serial(); got != 2 {
+		t.Errorf("expected certificate 2 to stay, got %d", got)
+	}
+}
+
+// writeCert writes a self-signed certificate for 127.0.0.1 with the serial
+// number and gives both files the modification time.
+func writeCert(t *testing.T, files config.TLS, serial int64, modTime time.Time) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for path, block := range map[string]*pem.Block{
+		files.CertFile: {Type: "CERTIFICATE", Bytes: der},
+		files.KeyFile:  {Type: "EC PRIVATE KEY", Bytes: keyDER},
+	} {
+		if err := os.WriteFile(path, pem.EncodeToMemory(block), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		touch(t, path, modTime)
+	}
+}
+
+func touch(t *testing.T, path string, modTime time.Time) {
+	t.Helper()
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
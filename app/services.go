@@ -0,0 +1,141 @@
+package app
+
+import (
+	"net"
+	"net/smtp"
+	"sms/config"
+	"sms/constants"
+	"sms/events"
+	alertRepository "sms/repository/alertRepository"
+	assessmentRepository "sms/repository/assessmentRepository"
+	attendanceRepository "sms/repository/attendanceRepository"
+	curriculumRepository "sms/repository/curriculumRepository"
+	enrollmentRepository "sms/repository/enrollmentRepository"
+	gradeRepository "sms/repository/gradesRepository"
+	guardianRepository "sms/repository/guardianRepository"
+	notificationRepository "sms/repository/notificationRepository"
+	rolloverRepository "sms/repository/rolloverRepository"
+	"sms/repository/storage"
+	studentsRepository "sms/repository/studentRepository"
+	subjectRepository "sms/repository/subjectRepository"
+	termRepository "sms/repository/termRepository"
+	timetableRepository "sms/repository/timetableRepository"
+	"sms/repository/transaction"
+	userrepository "sms/repository/userRepository"
+	webhookRepository "sms/repository/webhookRepository"
+	"sms/services"
+	"time"
+)
+
+// Services are the services of the server, wired to each other and to the
+// event bus the way the server runs them. smsctl builds its services here too,
+// so changes made at the console follow the same rules and send the same
+// notifications and webhooks.
+type Services struct {
+	Bus           *events.Bus
+	Tokens        *services.JWT
+	Auth          *services.AuthService
+	Students      *services.StudentService
+	Grades        *services.GradeService
+	Reports       *services.ReportService
+	Alerts        *services.AlertService
+	Attendance    *services.AttendanceService
+	Enrollments   *services.EnrollmentService
+	Curriculum    *services.CurriculumService
+	Rollovers     *services.RolloverService
+	Terms         *services.TermService
+	Timetable     *services.TimetableService
+	Guardians     *services.GuardianService
+	Notifications *services.NotificationService
+	Webhooks      *services.WebhookService
+}
+
+// NewServices builds the services on db with the settings in cfg.
+func NewServices(db *storage.DB, cfg config.Config) (*Services, error) {
+	tokens, err := services.NewJWT([]byte(cfg.JWT.Secret), cfg.JWT.Expiry)
+	if err != nil {
+		return nil, err
+	}
+
+	//repos
+	gradeRepo := gradeRepository.NewGradeRepo(db)
+	studentRepo := studentsRepository.NewStudentRepo(db)
+	userRepo := userrepository.NewUserRepo(db)
+	alertRepo := alertRepository.NewAlertRepo(db)
+	attendanceRepo := attendanceRepository.NewAttendanceRepo(db)
+	enrollmentRepo := enrollmentRepository.NewEnrollmentRepo(db)
+	curriculumRepo := curriculumRepository.NewCurriculumRepo(db)
+	rolloverRepo := rolloverRepository.NewRolloverRepo(db)
+	termRepo := termRepository.NewTermRepo(db)
+	assessmentRepo := assessmentRepository.NewAssessmentRepo(db)
+	timetableRepo := timetableRepository.NewTimetableRepo(db)
+	guardianRepo := guardianRepository.NewGuardianRepo(db)
+	notificationRepo := notificationRepository.NewNotificationRepo(db)
+	webhookRepo := webhookRepository.NewWebhookRepo(db)
+	subjectRepo := subjectRepository.NewSubjectRepo(db)
+
+	txManager := transaction.NewTxManager(db)
+
+	//services
+	notificationService := services.NewNotificationService(notificationRepo, userRepo, guardianRepo, timetableRepo, studentRepo, subjectRepo, notificationSenders(cfg.SMTP),
+		constants.DefaultNotificationMaxAttempts, constants.DefaultNotificationBackoff)
+	webhookService := services.NewWebhookService(webhookRepo, services.NewPublicHTTPClient(10*time.Second),
+		constants.DefaultWebhookMaxAttempts, constants.DefaultWebhookBackoff)
+
+	//events
+	bus := events.NewBus()
+	notificationService.Subscribe(bus)
+	webhookService.Subscribe(bus)
+	termService := services.NewTermService(termRepo)
+	attendanceService := services.NewAttendanceService(attendanceRepo, constants.DefaultMinAttendance)
+	enrollmentService := services.NewEnrollmentService(enrollmentRepo, studentRepo)
+	gradeService := services.NewGradeService(gradeRepo,
+		services.WithGradeEntryWindow(termService),
+		services.WithEligibilityChecker(enrollmentService),
+		services.WithEligibilityChecker(attendanceService),
+		services.WithAssessments(txManager, assessmentRepo),
+		services.WithGradeEvents(bus),
+		services.WithGradeTransactions(txManager),
+	)
+	studentService := services.NewStudentService(studentRepo,
+		services.WithStudentEvents(bus),
+		services.WithStudentEnrollments(txManager, enrollmentRepo),
+	)
+
+	return &Services{
+		Bus:           bus,
+		Tokens:        tokens,
+		Auth:          services.NewAuthService(userRepo, services.WithAuthEvents(bus), services.WithBcryptCost(cfg.BcryptCost), services.WithTokens(tokens)),
+		Students:      &studentService,
+		Grades:        gradeService,
+		Reports:       services.NewReportService(gradeRepo, studentRepo),
+		Alerts:        services.NewAlertService(alertRepo, notificationService),
+		Attendance:    attendanceService,
+		Enrollments:   enrollmentService,
+		Curriculum:    services.NewCurriculumService(curriculumRepo, studentRepo, gradeRepo, enrollmentService),
+		Rollovers:     services.NewRolloverService(rolloverRepo, studentRepo, gradeRepo, constants.DefaultRolloverUndoWindow),
+		Terms:         termService,
+		Timetable:     services.NewTimetableService(timetableRepo),
+		Guardians:     services.NewGuardianService(guardianRepo, userRepo, studentRepo),
+		Notifications: notificationService,
+		Webhooks:      webhookService,
+	}, nil
+}
+
+// notificationSenders registers the in-app and webhook channels, and email when
+// the SMTP settings name a server.
+func notificationSenders(cfg config.SMTP) map[constants.NotificationChannel]services.NotificationSenderI {
+	senders := map[constants.NotificationChannel]services.NotificationSenderI{
+		constants.ChannelInApp:   services.NewInAppSender(),
+		constants.ChannelWebhook: services.NewWebhookSender(services.NewPublicHTTPClient(10 * time.Second)),
+	}
+	if cfg.Addr != "" {
+		var auth smtp.Auth
+		if cfg.Username != "" {
+			host, _, _ := net.SplitHostPort(cfg.Addr)
+			auth = smtp.PlainAuth("", cfg.Username, string(cfg.Password), host)
+		}
+		senders[constants.ChannelEmail] = services.NewSMTPSender(cfg.Addr, cfg.From, auth)
+	}
+	return senders
+}
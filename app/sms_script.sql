@@ -1,12 +1,17 @@
 -- SQLite
+-- Migrate in repository/storage brings a database up to date on startup from the
+-- numbered files in repository/storage/migrations, one directory per dialect.
+-- Schema changes go in a new file there; keep this script in step with them.
 -- .tables
 -- create table user(
 -- UserID Text PRIMARY Key,
 -- Name Text Not NUll,
 -- Email Text Not Null,
 -- Password Text Not Null,
--- Role Text Not Null Check(Role In ('faculty','student','admin'))DEFAULT 'faculty'
+-- Role Text Not Null Check(Role In ('faculty','student','admin','guardian'))DEFAULT 'faculty'
 -- );
+-- existing databases get the 'guardian' role from migration 002, which rebuilds
+-- the table since sqlite can't alter a check constraint in place.
 
 
 -- create table class(
@@ -58,3 +63,273 @@
 -- '4f4c7579-7aa3-47cd-8e2f-c2580aa3d8f6',
 -- 'Physics'
 -- )
+
+
+-- create table at_risk_flag(
+-- FlagID Text PRIMARY KEY,
+-- StudentID Text not null,
+-- ClassID Text not null,
+-- semester integer not null,
+-- Reasons Text not null,
+-- CreatedAt DATETIME not null,
+-- FOREIGN Key(StudentID) REFERENCES students(StudentID)
+-- );
+
+
+-- create table attendance_session(
+-- SessionID Text PRIMARY KEY,
+-- ClassID Text not null,
+-- SubjectID Text not null,
+-- semester integer not null,
+-- Date DATETIME not null,
+-- CreatedBy Text not null,
+-- FOREIGN Key(ClassID) REFERENCES class(ClassID),
+-- FOREIGN Key(SubjectID) REFERENCES subject(SubjectID),
+-- FOREIGN Key(CreatedBy) REFERENCES user(UserID)
+-- );
+
+
+-- create table attendance(
+-- SessionID Text not null,
+-- StudentID Text not null,
+-- Status Text not null Check(Status In ('present','absent','late','excused')),
+-- PRIMARY KEY(SessionID,StudentID),
+-- FOREIGN Key(SessionID) REFERENCES attendance_session(SessionID),
+-- FOREIGN Key(StudentID) REFERENCES students(StudentID)
+-- );
+
+
+-- create table enrollment(
+-- StudentID Text not null,
+-- SubjectID Text not null,
+-- semester integer not null,
+-- Status Text not null Check(Status In ('enrolled','dropped','completed')) DEFAULT 'enrolled',
+-- AddedOn DATETIME not null,
+-- DroppedOn DATETIME,
+-- PRIMARY KEY(StudentID,SubjectID,semester),
+-- FOREIGN Key(StudentID) REFERENCES students(StudentID),
+-- FOREIGN Key(SubjectID) REFERENCES subject(SubjectID)
+-- );
+
+
+-- create table program(
+-- ProgramID Text PRIMARY KEY,
+-- Name Text not null UNIQUE,
+-- Semesters integer not null
+-- );
+
+
+-- create table program_subject(
+-- ProgramID Text not null,
+-- SubjectID Text not null,
+-- semester integer not null,
+-- Kind Text not null Check(Kind In ('core','elective')),
+-- Credits integer not null,
+-- PRIMARY KEY(ProgramID,SubjectID),
+-- FOREIGN Key(ProgramID) REFERENCES program(ProgramID),
+-- FOREIGN Key(SubjectID) REFERENCES subject(SubjectID)
+-- );
+
+
+-- create table subject_prerequisite(
+-- ProgramID Text not null,
+-- SubjectID Text not null,
+-- PrerequisiteID Text not null,
+-- PRIMARY KEY(ProgramID,SubjectID,PrerequisiteID),
+-- FOREIGN Key(ProgramID,SubjectID) REFERENCES program_subject(ProgramID,SubjectID),
+-- FOREIGN Key(PrerequisiteID) REFERENCES subject(SubjectID)
+-- );
+
+
+-- alter table class add column ProgramID Text REFERENCES program(ProgramID);
+
+
+-- create table rollover(
+-- RolloverID Text PRIMARY KEY,
+-- ClassID Text not null,
+-- semester integer not null,
+-- TargetClassID Text not null,
+-- CreatedBy Text not null,
+-- CreatedAt DATETIME not null,
+-- UndoUntil DATETIME not null,
+-- UndoneAt DATETIME,
+-- FOREIGN Key(ClassID) REFERENCES class(ClassID),
+-- FOREIGN Key(TargetClassID) REFERENCES class(ClassID),
+-- FOREIGN Key(CreatedBy) REFERENCES user(UserID)
+-- );
+
+
+-- create table rollover_student(
+-- RolloverID Text not null,
+-- StudentID Text not null,
+-- FromClassID Text not null,
+-- ToClassID Text not null,
+-- FromSemester integer not null,
+-- ToSemester integer not null,
+-- Outcome Text not null Check(Outcome In ('promoted','flagged','detained')),
+-- Reasons Text not null,
+-- PRIMARY KEY(RolloverID,StudentID),
+-- FOREIGN Key(RolloverID) REFERENCES rollover(RolloverID),
+-- FOREIGN Key(StudentID) REFERENCES students(StudentID)
+-- );
+
+
+-- create table academic_term(
+-- TermID Text PRIMARY KEY,
+-- Year integer not null,
+-- TermNumber integer not null,
+-- StartDate DATETIME not null,
+-- EndDate DATETIME not null,
+-- GradeEntryStart DATETIME not null,
+-- GradeEntryEnd DATETIME not null,
+-- Status Text not null Check(Status In ('open','closed')) DEFAULT 'open',
+-- UNIQUE(Year,TermNumber)
+-- );
+
+
+-- create table academic_term_semester(
+-- TermID Text not null,
+-- semester integer not null,
+-- PRIMARY KEY(TermID,semester),
+-- FOREIGN Key(TermID) REFERENCES academic_term(TermID)
+-- );
+
+
+-- create table assessment(
+-- AssessmentID Text PRIMARY KEY,
+-- SubjectID Text not null,
+-- semester integer not null,
+-- Name Text not null,
+-- Kind Text not null Check(Kind In ('midterm','assignment','lab','final')),
+-- Weight REAL not null,
+-- MaxMarks integer not null,
+-- UNIQUE(SubjectID,semester,Name),
+-- FOREIGN Key(SubjectID) REFERENCES subject(SubjectID)
+-- );
+
+
+-- create table assessment_score(
+-- AssessmentID Text not null,
+-- StudentID Text not null,
+-- Marks REAL not null,
+-- PRIMARY KEY(AssessmentID,StudentID),
+-- FOREIGN Key(AssessmentID) REFERENCES assessment(AssessmentID),
+-- FOREIGN Key(StudentID) REFERENCES students(StudentID)
+-- );
+
+
+-- create table room(
+-- RoomID Text PRIMARY KEY,
+-- Name Text not null UNIQUE,
+-- Capacity integer not null
+-- );
+
+
+-- alter table class add column HomeRoomID Text REFERENCES room(RoomID);
+
+
+-- create table faculty_assignment(
+-- FacultyID Text not null,
+-- ClassID Text not null,
+-- SubjectID Text not null,
+-- PRIMARY KEY(FacultyID,ClassID,SubjectID),
+-- FOREIGN Key(FacultyID) REFERENCES user(UserID),
+-- FOREIGN Key(ClassID) REFERENCES class(ClassID),
+-- FOREIGN Key(SubjectID) REFERENCES subject(SubjectID)
+-- );
+
+
+-- create table timetable_slot(
+-- SlotID Text PRIMARY KEY,
+-- ClassID Text not null,
+-- SubjectID Text not null,
+-- FacultyID Text not null,
+-- RoomID Text not null,
+-- Weekday integer not null Check(Weekday between 0 and 6),
+-- StartTime Text not null,
+-- EndTime Text not null,
+-- FOREIGN Key(FacultyID,ClassID,SubjectID) REFERENCES faculty_assignment(FacultyID,ClassID,SubjectID),
+-- FOREIGN Key(RoomID) REFERENCES room(RoomID)
+-- );
+
+
+-- create table guardian_student(
+-- GuardianID Text not null,
+-- StudentID Text not null,
+-- Relationship Text,
+-- PRIMARY KEY(GuardianID,StudentID),
+-- FOREIGN Key(GuardianID) REFERENCES user(UserID),
+-- FOREIGN Key(StudentID) REFERENCES students(StudentID)
+-- );
+
+
+-- create table notification(
+-- NotificationID Text PRIMARY KEY,
+-- UserID Text not null,
+-- Event Text not null,
+-- Channel Text not null Check(Channel In ('in_app','email','webhook')),
+-- Target Text not null DEFAULT '',
+-- Subject Text not null,
+-- Body Text not null,
+-- Status Text not null Check(Status In ('pending','sent','failed')) DEFAULT 'pending',
+-- Attempts integer not null DEFAULT 0,
+-- NextAttemptAt DATETIME not null,
+-- LastError Text not null DEFAULT '',
+-- CreatedAt DATETIME not null,
+-- SentAt DATETIME,
+-- ReadAt DATETIME,
+-- FOREIGN Key(UserID) REFERENCES user(UserID)
+-- );
+-- create index notification_due on notification(Status, NextAttemptAt);
+
+
+-- create table notification_preference(
+-- UserID Text not null,
+-- Channel Text not null Check(Channel In ('in_app','email','webhook')),
+-- Enabled Boolean not null,
+-- Target Text not null DEFAULT '',
+-- PRIMARY KEY(UserID,Channel),
+-- FOREIGN Key(UserID) REFERENCES user(UserID)
+-- );
+
+
+-- create table webhook_subscription(
+-- SubscriptionID Text PRIMARY KEY,
+-- URL Text not null,
+-- Secret Text not null,
+-- Active Boolean not null DEFAULT 1,
+-- CreatedAt DATETIME not null
+-- );
+
+
+-- create table webhook_subscription_event(
+-- SubscriptionID Text not null,
+-- Event Text not null Check(Event In ('grade.posted','grade.changed','student.created','student.updated')),
+-- PRIMARY KEY(SubscriptionID,Event),
+-- FOREIGN Key(SubscriptionID) REFERENCES webhook_subscription(SubscriptionID)
+-- );
+
+
+-- create table webhook_delivery(
+-- DeliveryID Text PRIMARY KEY,
+-- SubscriptionID Text not null,
+-- Event Text not null,
+-- Payload Text not null,
+-- Status Text not null Check(Status In ('pending','delivered','failed')) DEFAULT 'pending',
+-- Attempts integer not null DEFAULT 0,
+-- NextAttemptAt DATETIME not null,
+-- CreatedAt DATETIME not null,
+-- DeliveredAt DATETIME,
+-- FOREIGN Key(SubscriptionID) REFERENCES webhook_subscription(SubscriptionID)
+-- );
+-- create index webhook_delivery_due on webhook_delivery(Status, NextAttemptAt);
+
+
+-- create table webhook_attempt(
+-- DeliveryID Text not null,
+-- AttemptedAt DATETIME not null,
+-- StatusCode integer not null,
+-- Error Text not null DEFAULT '',
+-- DurationMS integer not null,
+-- FOREIGN Key(DeliveryID) REFERENCES webhook_delivery(DeliveryID)
+-- );
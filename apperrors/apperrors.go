@@ -0,0 +1,102 @@
+// Package apperrors holds the typed errors services and repositories return so
+// that callers can tell what went wrong without matching on messages.
+package apperrors
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The kinds an Error can be. Match them with errors.Is.
+var (
+	ErrValidation   = errors.New("validation failed")
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrInternal     = errors.New("internal error")
+)
+
+// FieldError is the problem with one field of a request.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error is a domain error. Message is safe to show to clients, Err is the cause
+// kept for logs and errors.Is.
+type Error struct {
+	Kind    error
+	Message string
+	Fields  []FieldError
+	Err     error
+}
+
+func (e *Error) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return e.Kind.Error()
+}
+
+func (e *Error) Unwrap() []error {
+	if e.Err == nil {
+		return []error{e.Kind}
+	}
+	return []error{e.Kind, e.Err}
+}
+
+func newError(kind error, format string, args []any) *Error {
+	return &Error{Kind: kind, Message: fmt.Sprintf(format, args...)}
+}
+
+func Validation(format string, args ...any) *Error {
+	return newError(ErrValidation, format, args)
+}
+
+// Invalid reports the fields of a request that failed validation, all at once.
+func Invalid(fields ...FieldError) *Error {
+	return &Error{Kind: ErrValidation, Message: "request validation failed", Fields: fields}
+}
+
+func NotFound(format string, args ...any) *Error {
+	return newError(ErrNotFound, format, args)
+}
+
+func Conflict(format string, args ...any) *Error {
+	return newError(ErrConflict, format, args)
+}
+
+func Unauthorized(format string, args ...any) *Error {
+	return newError(ErrUnauthorized, format, args)
+}
+
+func Forbidden(format string, args ...any) *Error {
+	return newError(ErrForbidden, format, args)
+}
+
+// Internal marks err as a failure the client can't do anything about. Its
+// message is never shown to them.
+func Internal(err error) *Error {
+	return &Error{Kind: ErrInternal, Err: err}
+}
+
+// Because records the error it was caused by, so errors.Is matches it too.
+func (e *Error) Because(err error) *Error {
+	e.Err = err
+	return e
+}
+
+// Wrap prefixes the message of err. A typed err keeps its kind, so a wrapped
+// conflict is still reported as a conflict; any other err stays internal.
+func Wrap(err error, format string, args ...any) error {
+	prefix := fmt.Sprintf(format, args...)
+	var e *Error
+	if !errors.As(err, &e) || errors.Is(e.Kind, ErrInternal) {
+		return fmt.Errorf("%s: %w", prefix, err)
+	}
+	return &Error{Kind: e.Kind, Message: prefix + ": " + e.Error(), Fields: e.Fields, Err: err}
+}
@@ -0,0 +1,39 @@
+package apperrors_test
+
+import (
+	"errors"
+	"sms/apperrors"
+	"testing"
+)
+
+func TestErrorIs(t *testing.T) {
+	cause := errors.New("UNIQUE constraint failed: grades.SubjectID, grades.StudentID")
+	err := apperrors.Conflict("record already exists").Because(cause)
+
+	if !errors.Is(err, apperrors.ErrConflict) || !errors.Is(err, cause) {
+		t.Errorf("expected %v to match its kind and its cause", err)
+	}
+	if errors.Is(err, apperrors.ErrNotFound) {
+		t.Errorf("expected %v not to be a not-found error", err)
+	}
+	if err.Error() != "record already exists" {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+}
+
+func TestWrap(t *testing.T) {
+	err := apperrors.Wrap(apperrors.Conflict("record already exists"), "grade of student %s in %s", "s1", "MATH")
+	if !errors.Is(err, apperrors.ErrConflict) {
+		t.Errorf("expected a wrapped conflict to stay a conflict, got %v", err)
+	}
+	if err.Error() != "grade of student s1 in MATH: record already exists" {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+
+	cause := errors.New("database is locked")
+	err = apperrors.Wrap(cause, "grade of student %s in %s", "s1", "MATH")
+	var typed *apperrors.Error
+	if errors.As(err, &typed) || !errors.Is(err, cause) {
+		t.Errorf("expected an untyped error to stay untyped, got %v", err)
+	}
+}
@@ -0,0 +1,109 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sms/handlers"
+	"sms/models"
+	gradeRepository "sms/repository/gradesRepository"
+)
+
+func loginRequest(email, password string) handlers.LoginRequest {
+	return handlers.LoginRequest{Email: email, Password: password}
+}
+
+func signupRequest(name, email, password string) handlers.SignupRequest {
+	return handlers.SignupRequest{Name: name, Email: email, Password: password}
+}
+
+// CreateGuardian creates a guardian account and returns its ID.
+func (c *Client) CreateGuardian(ctx context.Context, req handlers.CreateGuardianRequest) (string, error) {
+	var created struct {
+		GuardianID string `json:"guardianID"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/api/v1/guardians", req, &created, true); err != nil {
+		return "", err
+	}
+	return created.GuardianID, nil
+}
+
+// AddStudent creates a student, and enrolls them in req.SubjectIDs when given.
+func (c *Client) AddStudent(ctx context.Context, req handlers.CreateStudentRequest) (*handlers.CreateStudentResponse, error) {
+	var student handlers.CreateStudentResponse
+	if err := c.do(ctx, http.MethodPost, "/api/v1/students", req, &student, true); err != nil {
+		return nil, err
+	}
+	return &student, nil
+}
+
+// UpdateStudent changes the fields of a student that are set in req.
+func (c *Client) UpdateStudent(ctx context.Context, studentID string, req handlers.UpdateStudentRequest) error {
+	return c.do(ctx, http.MethodPatch, "/api/v1/students/"+url.PathEscape(studentID), req, nil, true)
+}
+
+func (c *Client) StudentGrades(ctx context.Context, studentID string) ([]models.Grade, error) {
+	var grades []models.Grade
+	if err := c.do(ctx, http.MethodGet, "/api/v1/students/"+url.PathEscape(studentID)+"/grades", nil, &grades, true); err != nil {
+		return nil, err
+	}
+	return grades, nil
+}
+
+func (c *Client) ProgressReport(ctx context.Context, studentID string) (*models.ProgressReport, error) {
+	var report models.ProgressReport
+	if err := c.do(ctx, http.MethodGet, "/api/v1/students/"+url.PathEscape(studentID)+"/progress", nil, &report, true); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+func (c *Client) AddGrade(ctx context.Context, req handlers.AddGradeRequest) error {
+	return c.do(ctx, http.MethodPost, "/api/v1/grades", req, nil, true)
+}
+
+// ImportGrades saves all the grades or none of them and returns how many were
+// saved.
+func (c *Client) ImportGrades(ctx context.Context, grades []handlers.AddGradeRequest) (int, error) {
+	var imported int
+	if err := c.do(ctx, http.MethodPost, "/api/v1/grades/import", handlers.ImportGradesRequest{Grades: grades}, &imported, true); err != nil {
+		return 0, err
+	}
+	return imported, nil
+}
+
+func (c *Client) UpdateGrade(ctx context.Context, req handlers.UpdateGrade) error {
+	return c.do(ctx, http.MethodPatch, "/api/v1/grades", req, nil, true)
+}
+
+func (c *Client) ClassAverage(ctx context.Context, classID string, semester int) (float64, error) {
+	var average float64
+	if err := c.do(ctx, http.MethodGet, classPath(classID, semester, "average"), nil, &average, true); err != nil {
+		return 0, err
+	}
+	return average, nil
+}
+
+// Toppers returns the top students of a class by average, best first.
+func (c *Client) Toppers(ctx context.Context, classID string, semester, top int) ([]gradeRepository.StudentAverage, error) {
+	var toppers []gradeRepository.StudentAverage
+	path := classPath(classID, semester, "toppers") + fmt.Sprintf("?top=%d", top)
+	if err := c.do(ctx, http.MethodGet, path, nil, &toppers, true); err != nil {
+		return nil, err
+	}
+	return toppers, nil
+}
+
+// RankList ranks the students of a class who have grades in the semester.
+func (c *Client) RankList(ctx context.Context, classID string, semester int) ([]models.RankEntry, error) {
+	var ranks []models.RankEntry
+	if err := c.do(ctx, http.MethodGet, classPath(classID, semester, "ranks"), nil, &ranks, true); err != nil {
+		return nil, err
+	}
+	return ranks, nil
+}
+
+func classPath(classID string, semester int, resource string) string {
+	return fmt.Sprintf("/api/v1/classes/%s/semesters/%d/%s", url.PathEscape(classID), semester, resource)
+}
@@ -0,0 +1,260 @@
+// Package client is a Go client for the SMS API. It takes and returns the same
+// request and response types the handlers use, keeps the bearer token, logs in
+// again when the token runs out and retries idempotent requests that failed on
+// the way.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sms/constants"
+	"sms/utils"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type Client struct {
+	baseURL    string
+	http       *http.Client
+	maxRetries int
+	backoff    time.Duration
+
+	mu       sync.Mutex
+	token    string
+	email    string
+	password string
+}
+
+type Option func(*Client)
+
+// WithHTTPClient sends requests through hc instead of http.DefaultClient.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.http = hc
+	}
+}
+
+// WithToken starts the client with a token from an earlier login.
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithCredentials lets the client log in by itself whenever it has no token or
+// its token has expired.
+func WithCredentials(email, password string) Option {
+	return func(c *Client) {
+		c.email, c.password = email, password
+	}
+}
+
+// WithRetries sets how many times a failed idempotent request is tried again
+// and the wait before the first retry. Zero retries turns retrying off.
+func WithRetries(maxRetries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries, c.backoff = maxRetries, backoff
+	}
+}
+
+// New returns a client for the API at baseURL, such as http://localhost:8080.
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		http:       http.DefaultClient,
+		maxRetries: constants.DefaultClientMaxRetries,
+		backoff:    constants.DefaultClientBackoff,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Token is the bearer token the client currently sends.
+func (c *Client) Token() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.token
+}
+
+// Login logs in and keeps both the token and the credentials, so the client can
+// log in again when the token expires.
+func (c *Client) Login(ctx context.Context, email, password string) error {
+	var token string
+	if err := c.do(ctx, http.MethodPost, "/api/v1/login", loginRequest(email, password), &token, false); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.token, c.email, c.password = token, email, password
+	c.mu.Unlock()
+	return nil
+}
+
+// Signup creates a faculty account and logs in as it.
+func (c *Client) Signup(ctx context.Context, name, email, password string) error {
+	var token string
+	if err := c.do(ctx, http.MethodPost, "/api/v1/signup", signupRequest(name, email, password), &token, false); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.token, c.email, c.password = token, email, password
+	c.mu.Unlock()
+	return nil
+}
+
+// bearer returns a token that is good for a while yet, logging in first when
+// there is none or it is about to expire and the credentials are known.
+func (c *Client) bearer(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	token, email, password := c.token, c.email, c.password
+	c.mu.Unlock()
+	if email == "" || (token != "" && !expiring(token)) {
+		return token, nil
+	}
+	if err := c.Login(ctx, email, password); err != nil {
+		return "", err
+	}
+	return c.Token(), nil
+}
+
+func (c *Client) canLogin() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.email != ""
+}
+
+// relogin replaces a token the server turned down.
+func (c *Client) relogin(ctx context.Context, rejected string) error {
+	c.mu.Lock()
+	token, email, password := c.token, c.email, c.password
+	c.mu.Unlock()
+	if token != rejected {
+		// another request logged in already
+		return nil
+	}
+	return c.Login(ctx, email, password)
+}
+
+// expiring reports whether a token expires within the refresh margin. The
+// signature isn't checked; that is up to the server.
+func expiring(token string) bool {
+	var claims jwt.RegisteredClaims
+	if _, _, err := jwt.NewParser().ParseUnverified(token, &claims); err != nil || claims.ExpiresAt == nil {
+		return false
+	}
+	return time.Until(claims.ExpiresAt.Time) < constants.ClientTokenRefreshMargin
+}
+
+// do sends a request and decodes the data of the response into out. A 401 on an
+// authenticated request logs in again once; idempotent requests are retried with
+// backoff when they fail on the way or the server is unavailable.
+func (c *Client) do(ctx context.Context, method, path string, body, out any, auth bool) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+	relogged := false
+	for attempt := 0; ; attempt++ {
+		token := ""
+		if auth {
+			var err error
+			if token, err = c.bearer(ctx); err != nil {
+				return err
+			}
+		}
+		resp, err := c.send(ctx, method, path, payload, token)
+		if err == nil && resp.StatusCode == http.StatusUnauthorized && auth && !relogged && c.canLogin() {
+			resp.Body.Close()
+			relogged = true
+			if err := c.relogin(ctx, token); err != nil {
+				return err
+			}
+			attempt--
+			continue
+		}
+		if retryable(method, resp, err) && attempt < c.maxRetries {
+			if resp != nil {
+				resp.Body.Close()
+			}
+			if err := sleep(ctx, utils.Backoff(c.backoff, constants.MaxClientBackoff, attempt)); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		return decode(resp, out)
+	}
+}
+
+func (c *Client) send(ctx context.Context, method, path string, payload []byte, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return c.http.Do(req)
+}
+
+// retryable reports whether a request may be sent again. Only methods that are
+// safe to repeat are retried, so a grade is never posted twice.
+func retryable(method string, resp *http.Response, err error) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+	default:
+		return false
+	}
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// decode reads the response envelope, putting its data into out. Error statuses
+// come back as *Error.
+func decode(resp *http.Response, out any) error {
+	defer resp.Body.Close()
+	body := utils.CustomResponse{Data: out}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		if resp.StatusCode >= http.StatusBadRequest {
+			return &Error{StatusCode: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+		}
+		return fmt.Errorf("decoding %s response: %w", resp.Request.URL.Path, err)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return &Error{StatusCode: resp.StatusCode, Code: body.Code, Message: body.Message, Fields: body.Errors}
+	}
+	return nil
+}
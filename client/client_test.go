@@ -0,0 +1,261 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sms/app"
+	"sms/apperrors"
+	"sms/client"
+	"sms/config"
+	"sms/constants"
+	"sms/handlers"
+	"sms/models"
+	"sms/repository/storage"
+	termRepository "sms/repository/termRepository"
+	userrepository "sms/repository/userRepository"
+	"sms/services"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const password = "Str0ng&Secret"
+
+// newServer runs the whole API on a fresh database with a class, a subject, an
+// open grade entry window and an admin and a faculty account.
+func newServer(t *testing.T, wrap func(http.Handler) http.Handler) *httptest.Server {
+	t.Helper()
+	ctx := context.Background()
+	db, err := storage.Open(storage.SQLite, filepath.Join(t.TempDir(), "sms.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := storage.Migrate(ctx, db); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	for _, q := range []string{
+		`insert into class (ClassID, Capacity) values('C1', 60)`,
+		`insert into subject values('MATH', 'Engineering Mathematics')`,
+	} {
+		if _, err := db.ExecContext(ctx, q); err != nil {
+			t.Fatalf("failed to seed: %v", err)
+		}
+	}
+	auth := services.NewAuthService(userrepository.NewUserRepo(db))
+	for _, u := range []struct {
+		email string
+		role  constants.Role
+	}{{"admin@example.com", constants.Admin}, {"faculty@example.com", constants.Faculty}} {
+		if _, err := auth.CreateAccount(ctx, "Test", u.email, password, u.role); err != nil {
+			t.Fatalf("failed to create %s: %v", u.role, err)
+		}
+	}
+	today := time.Now().Truncate(24 * time.Hour)
+	_, err = services.NewTermService(termRepository.NewTermRepo(db)).CreateTerm(ctx, models.AcademicTerm{
+		Year: today.Year(), TermNumber: 1, Semesters: []int{1},
+		StartDate: today.AddDate(0, -1, 0), EndDate: today.AddDate(0, 3, 0),
+		GradeEntryStart: today.AddDate(0, 0, -1), GradeEntryEnd: today.AddDate(0, 0, 1),
+		Status: constants.TermOpen,
+	})
+	if err != nil {
+		t.Fatalf("failed to create term: %v", err)
+	}
+
+	cfg := config.Default()
+	cfg.JWT.Secret = "0123456789abcdef0123456789abcdef"
+	mux, err := app.SetupServer(db, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var h http.Handler = mux
+	if wrap != nil {
+		h = wrap(h)
+	}
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+	srv := newServer(t, nil)
+
+	admin := client.New(srv.URL)
+	if err := admin.Login(ctx, "admin@example.com", password); err != nil {
+		t.Fatalf("admin login: %v", err)
+	}
+	students := map[string]string{}
+	for _, s := range []handlers.CreateStudentRequest{
+		{RollNumber: "101", Name: "Anu", ClassID: "C1", Semester: 1, SubjectIDs: []string{"MATH"}},
+		{RollNumber: "102", Name: "Ravi", ClassID: "C1", Semester: 1, SubjectIDs: []string{"MATH"}},
+	} {
+		created, err := admin.AddStudent(ctx, s)
+		if err != nil {
+			t.Fatalf("AddStudent: %v", err)
+		}
+		if created.StudentID == "" || len(created.Enrollments) != 1 {
+			t.Fatalf("unexpected student: %+v", created)
+		}
+		students[s.Name] = created.StudentID
+	}
+	if err := admin.UpdateStudent(ctx, students["Ravi"], handlers.UpdateStudentRequest{Name: "Ravi K"}); err != nil {
+		t.Fatalf("UpdateStudent: %v", err)
+	}
+	if err := admin.UpdateStudent(ctx, "missing", handlers.UpdateStudentRequest{Name: "Nobody"}); !errors.Is(err, apperrors.ErrNotFound) {
+		t.Errorf("expected not found for an unknown student, got %v", err)
+	}
+
+	_, err := admin.AddStudent(ctx, handlers.CreateStudentRequest{RollNumber: "101", Name: "Dup", ClassID: "C1", Semester: 1})
+	if !errors.Is(err, apperrors.ErrConflict) {
+		t.Errorf("expected a conflict for a duplicate roll number, got %v", err)
+	}
+
+	faculty := client.New(srv.URL, client.WithCredentials("faculty@example.com", password))
+	if err := faculty.AddGrade(ctx, handlers.AddGradeRequest{StudentID: students["Anu"], SubjectID: "MATH", Semester: 1, Grade: 90}); err != nil {
+		t.Fatalf("AddGrade: %v", err)
+	}
+	if faculty.Token() == "" {
+		t.Error("expected the client to log in with its credentials")
+	}
+	imported, err := faculty.ImportGrades(ctx, []handlers.AddGradeRequest{
+		{StudentID: students["Ravi"], SubjectID: "MATH", Semester: 1, Grade: 60},
+	})
+	if err != nil || imported != 1 {
+		t.Fatalf("ImportGrades: %d, %v", imported, err)
+	}
+	if err := faculty.UpdateGrade(ctx, handlers.UpdateGrade{StudentID: students["Ravi"], SubjectID: "MATH", NewGrade: 70}); err != nil {
+		t.Fatalf("UpdateGrade: %v", err)
+	}
+
+	ranks, err := faculty.RankList(ctx, "C1", 1)
+	if err != nil || len(ranks) != 2 || ranks[0].StudentID != students["Anu"] || ranks[0].Rank != 1 {
+		t.Errorf("RankList: unexpected %+v, %v", ranks, err)
+	}
+	average, err := faculty.ClassAverage(ctx, "C1", 1)
+	if err != nil || average != 80 {
+		t.Errorf("ClassAverage: expected 80, got %v, %v", average, err)
+	}
+	toppers, err := faculty.Toppers(ctx, "C1", 1, 1)
+	if err != nil || len(toppers) != 1 || toppers[0].StudentID != students["Anu"] {
+		t.Errorf("Toppers: unexpected %+v, %v", toppers, err)
+	}
+	grades, err := faculty.StudentGrades(ctx, students["Ravi"])
+	if err != nil || len(grades) != 1 || grades[0].Grade != 70 {
+		t.Errorf("StudentGrades: unexpected %+v, %v", grades, err)
+	}
+	report, err := faculty.ProgressReport(ctx, students["Anu"])
+	if err != nil || report.Name != "Anu" {
+		t.Errorf("ProgressReport: unexpected %+v, %v", report, err)
+	}
+
+	err = faculty.AddGrade(ctx, handlers.AddGradeRequest{SubjectID: "MATH", Semester: 1, Grade: 10000})
+	var apiErr *client.Error
+	if !errors.As(err, &apiErr) || !errors.Is(err, apperrors.ErrValidation) {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+	if apiErr.StatusCode != http.StatusUnprocessableEntity || apiErr.Code != "validation_failed" || len(apiErr.Fields) != 2 {
+		t.Errorf("unexpected error: %+v", apiErr)
+	}
+
+	guardianID, err := admin.CreateGuardian(ctx, handlers.CreateGuardianRequest{Name: "Lakshmi", Email: "lakshmi@example.com", Password: password})
+	if err != nil || guardianID == "" {
+		t.Errorf("CreateGuardian: %q, %v", guardianID, err)
+	}
+
+	newcomer := client.New(srv.URL)
+	if err := newcomer.Signup(ctx, "Meera", "meera@example.com", password); err != nil {
+		t.Fatalf("Signup: %v", err)
+	}
+	if _, err := newcomer.AddStudent(ctx, handlers.CreateStudentRequest{RollNumber: "103", Name: "Kiran", ClassID: "C1", Semester: 1}); !errors.Is(err, apperrors.ErrForbidden) {
+		t.Errorf("expected faculty to be forbidden from adding students, got %v", err)
+	}
+	if err := client.New(srv.URL).Login(ctx, "faculty@example.com", "wrong"); !errors.Is(err, apperrors.ErrUnauthorized) {
+		t.Errorf("expected a wrong password to be unauthorized, got %v", err)
+	}
+}
+
+func TestClientLogsInAgainWhenTheTokenIsRejected(t *testing.T) {
+	ctx := context.Background()
+	srv := newServer(t, nil)
+
+	c := client.New(srv.URL, client.WithToken("expired"), client.WithCredentials("faculty@example.com", password))
+	if _, err := c.Toppers(ctx, "C1", 1, 3); err != nil {
+		t.Fatalf("expected the request to succeed after logging in again, got %v", err)
+	}
+	if c.Token() == "expired" {
+		t.Error("expected the rejected token to be replaced")
+	}
+
+	// without credentials there is nothing to refresh with
+	_, err := client.New(srv.URL, client.WithToken("expired")).Toppers(ctx, "C1", 1, 3)
+	if !errors.Is(err, apperrors.ErrUnauthorized) {
+		t.Errorf("expected unauthorized, got %v", err)
+	}
+}
+
+func TestClientRetries(t *testing.T) {
+	ctx := context.Background()
+	var failures, calls atomic.Int32
+	srv := newServer(t, func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			if failures.Add(-1) >= 0 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	})
+	c := client.New(srv.URL, client.WithRetries(2, time.Millisecond))
+	if err := c.Login(ctx, "faculty@example.com", password); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		failures  int32
+		call      func() error
+		wantCalls int32
+		wantErr   bool
+	}{
+		{
+			name:      "GET succeeds after retries",
+			failures:  2,
+			call:      func() error { _, err := c.Toppers(ctx, "C1", 1, 3); return err },
+			wantCalls: 3,
+		},
+		{
+			name:      "GET gives up",
+			failures:  3,
+			call:      func() error { _, err := c.Toppers(ctx, "C1", 1, 3); return err },
+			wantCalls: 3,
+			wantErr:   true,
+		},
+		{
+			name:     "POST is not retried",
+			failures: 1,
+			call: func() error {
+				return c.AddGrade(ctx, handlers.AddGradeRequest{StudentID: "s1", SubjectID: "MATH", Semester: 1})
+			},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failures.Store(tt.failures)
+			calls.Store(0)
+			err := tt.call()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("expected %d calls, got %d", tt.wantCalls, got)
+			}
+		})
+	}
+}
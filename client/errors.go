@@ -0,0 +1,44 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"sms/apperrors"
+)
+
+// Error is an error response from the API. It matches the apperrors kind of its
+// status with errors.Is, so callers can check for apperrors.ErrNotFound and the
+// like without looking at status codes.
+type Error struct {
+	StatusCode int
+	Code       string
+	Message    string
+	Fields     []apperrors.FieldError
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("sms api: %d %s", e.StatusCode, e.Message)
+	for _, f := range e.Fields {
+		msg += fmt.Sprintf("; %s %s", f.Field, f.Message)
+	}
+	return msg
+}
+
+func (e *Error) Unwrap() error {
+	switch e.StatusCode {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity, http.StatusRequestEntityTooLarge:
+		return apperrors.ErrValidation
+	case http.StatusUnauthorized:
+		return apperrors.ErrUnauthorized
+	case http.StatusForbidden:
+		return apperrors.ErrForbidden
+	case http.StatusNotFound:
+		return apperrors.ErrNotFound
+	case http.StatusConflict:
+		return apperrors.ErrConflict
+	}
+	if e.StatusCode >= http.StatusInternalServerError {
+		return apperrors.ErrInternal
+	}
+	return nil
+}
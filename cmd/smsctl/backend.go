@@ -0,0 +1,250 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sms/app"
+	"sms/client"
+	"sms/constants"
+	"sms/handlers"
+	"sms/models"
+	"sms/repository/classRepository"
+	gradeRepository "sms/repository/gradesRepository"
+	"sms/repository/storage"
+	studentsRepository "sms/repository/studentRepository"
+	"sms/repository/subjectRepository"
+	"sms/services"
+	"sort"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// backend carries out the commands, either on the database or through the API
+// of a running server.
+type backend interface {
+	CreateUser(ctx context.Context, name, email, password string, role constants.Role) (string, error)
+	ResetPassword(ctx context.Context, email, password string) error
+	AddStudent(ctx context.Context, req handlers.CreateStudentRequest) (string, error)
+	UpdateStudent(ctx context.Context, studentID string, req handlers.UpdateStudentRequest) error
+	ListStudents(ctx context.Context, classID string) ([]models.Students, error)
+	AddSubject(ctx context.Context, subject models.Subject) error
+	ListSubjects(ctx context.Context) ([]models.Subject, error)
+	AddClass(ctx context.Context, class models.Class) error
+	ListClasses(ctx context.Context) ([]models.Class, error)
+	ImportGrades(ctx context.Context, grades []handlers.AddGradeRequest) (int, error)
+	// ClassGrades returns the grades of a class in a semester, ordered by
+	// student and subject.
+	ClassGrades(ctx context.Context, classID string, semester int) ([]models.Grade, error)
+}
+
+// dbBackend works on the database through the services of the server, so grade
+// imports keep to the grade entry window and the attendance rule, and changes
+// queue notifications and webhooks. Subjects, classes and the listings have no
+// rules of their own and go to the repositories.
+type dbBackend struct {
+	students studentsRepository.StudentRepositoryI
+	subjects subjectRepository.SubjectRepositoryI
+	classes  classRepository.ClassRepositoryI
+	grades   gradeRepository.GradeRepositoryI
+	svc      *app.Services
+}
+
+func newDBBackend(db *storage.DB, svc *app.Services) *dbBackend {
+	return &dbBackend{
+		students: studentsRepository.NewStudentRepo(db),
+		subjects: subjectRepository.NewSubjectRepo(db),
+		classes:  classRepository.NewClassRepo(db),
+		grades:   gradeRepository.NewGradeRepo(db),
+		svc:      svc,
+	}
+}
+
+func (b *dbBackend) CreateUser(ctx context.Context, name, email, password string, role constants.Role) (string, error) {
+	user, err := b.svc.Auth.CreateAccount(ctx, name, email, password, role)
+	return user.UserID, err
+}
+
+func (b *dbBackend) ResetPassword(ctx context.Context, email, password string) error {
+	return b.svc.Auth.ResetPassword(ctx, email, password)
+}
+
+func (b *dbBackend) AddStudent(ctx context.Context, req handlers.CreateStudentRequest) (string, error) {
+	var student *models.Students
+	var err error
+	if len(req.SubjectIDs) == 0 {
+		student, err = b.svc.Students.CreateStudent(ctx, req.RollNumber, req.Name, req.ClassID, req.Semester)
+	} else {
+		student, _, err = b.svc.Students.CreateStudentWithEnrollments(ctx, req.RollNumber, req.Name, req.ClassID, req.Semester, req.SubjectIDs)
+	}
+	if err != nil {
+		return "", err
+	}
+	return student.StudentID, nil
+}
+
+func (b *dbBackend) UpdateStudent(ctx context.Context, studentID string, req handlers.UpdateStudentRequest) error {
+	return b.svc.Students.UpdateStudent(ctx, studentID, req.Name, req.RollNumber, req.ClassID, req.Semester)
+}
+
+func (b *dbBackend) ListStudents(ctx context.Context, classID string) ([]models.Students, error) {
+	return b.students.GetStudentsByClass(ctx, classID)
+}
+
+func (b *dbBackend) AddSubject(ctx context.Context, subject models.Subject) error {
+	return b.subjects.AddSubject(ctx, subject)
+}
+
+func (b *dbBackend) ListSubjects(ctx context.Context) ([]models.Subject, error) {
+	return b.subjects.GetSubjects(ctx)
+}
+
+func (b *dbBackend) AddClass(ctx context.Context, class models.Class) error {
+	return b.classes.AddClass(ctx, class)
+}
+
+func (b *dbBackend) ListClasses(ctx context.Context) ([]models.Class, error) {
+	return b.classes.GetClasses(ctx)
+}
+
+func (b *dbBackend) ImportGrades(ctx context.Context, grades []handlers.AddGradeRequest) (int, error) {
+	rows := make([]models.Grade, len(grades))
+	for i, g := range grades {
+		rows[i] = models.Grade{StudentID: g.StudentID, SubjectID: g.SubjectID, Semester: g.Semester, Grade: g.Grade}
+	}
+	if err := b.svc.Grades.ImportGrades(ctx, rows); err != nil {
+		return 0, err
+	}
+	return len(rows), nil
+}
+
+func (b *dbBackend) ClassGrades(ctx context.Context, classID string, semester int) ([]models.Grade, error) {
+	students, err := b.students.GetStudentsByClass(ctx, classID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(students))
+	for i, s := range students {
+		ids[i] = s.StudentID
+	}
+	return collectGrades(ctx, ids, semester, b.grades.GetStudentGrades)
+}
+
+// apiBackend sends the commands to a running server, which applies its own
+// rules: who may do what, the grade entry window, events and so on.
+type apiBackend struct {
+	baseURL string
+	api     *client.Client
+}
+
+func newAPIBackend(baseURL, email, password string) *apiBackend {
+	return &apiBackend{baseURL, client.New(baseURL, client.WithCredentials(email, password))}
+}
+
+// CreateUser signs faculty up and has the logged in admin create guardians.
+// The API has no way to create admins.
+func (b *apiBackend) CreateUser(ctx context.Context, name, email, password string, role constants.Role) (string, error) {
+	switch role {
+	case constants.Faculty:
+		// a client of its own, so the command keeps its login
+		signup := client.New(b.baseURL)
+		if err := signup.Signup(ctx, name, email, password); err != nil {
+			return "", err
+		}
+		return userID(signup.Token())
+	case constants.Guardian:
+		return b.api.CreateGuardian(ctx, handlers.CreateGuardianRequest{Name: name, Email: email, Password: password})
+	}
+	return "", databaseOnly("creating " + string(role) + " accounts")
+}
+
+func (b *apiBackend) ResetPassword(ctx context.Context, email, password string) error {
+	return databaseOnly("resetting passwords")
+}
+
+func (b *apiBackend) AddStudent(ctx context.Context, req handlers.CreateStudentRequest) (string, error) {
+	student, err := b.api.AddStudent(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	return student.StudentID, nil
+}
+
+func (b *apiBackend) UpdateStudent(ctx context.Context, studentID string, req handlers.UpdateStudentRequest) error {
+	return b.api.UpdateStudent(ctx, studentID, req)
+}
+
+func (b *apiBackend) ListStudents(ctx context.Context, classID string) ([]models.Students, error) {
+	return nil, databaseOnly("listing students")
+}
+
+func (b *apiBackend) AddSubject(ctx context.Context, subject models.Subject) error {
+	return databaseOnly("adding subjects")
+}
+
+func (b *apiBackend) ListSubjects(ctx context.Context) ([]models.Subject, error) {
+	return nil, databaseOnly("listing subjects")
+}
+
+func (b *apiBackend) AddClass(ctx context.Context, class models.Class) error {
+	return databaseOnly("adding classes")
+}
+
+func (b *apiBackend) ListClasses(ctx context.Context) ([]models.Class, error) {
+	return nil, databaseOnly("listing classes")
+}
+
+func (b *apiBackend) ImportGrades(ctx context.Context, grades []handlers.AddGradeRequest) (int, error) {
+	return b.api.ImportGrades(ctx, grades)
+}
+
+// ClassGrades goes through the rank list, which names every student of the
+// class with grades in the semester.
+func (b *apiBackend) ClassGrades(ctx context.Context, classID string, semester int) ([]models.Grade, error) {
+	ranks, err := b.api.RankList(ctx, classID, semester)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(ranks))
+	for i, r := range ranks {
+		ids[i] = r.StudentID
+	}
+	return collectGrades(ctx, ids, semester, b.api.StudentGrades)
+}
+
+// collectGrades gathers the grades the students have in the semester.
+func collectGrades(ctx context.Context, studentIDs []string, semester int,
+	studentGrades func(context.Context, string) ([]models.Grade, error)) ([]models.Grade, error) {
+	var grades []models.Grade
+	for _, id := range studentIDs {
+		all, err := studentGrades(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		for _, g := range all {
+			if g.Semester == semester {
+				grades = append(grades, g)
+			}
+		}
+	}
+	sort.Slice(grades, func(i, j int) bool {
+		if grades[i].StudentID != grades[j].StudentID {
+			return grades[i].StudentID < grades[j].StudentID
+		}
+		return grades[i].SubjectID < grades[j].SubjectID
+	})
+	return grades, nil
+}
+
+// userID reads the ID of the account a token was issued to. Checking the
+// signature is up to the server.
+func userID(token string) (string, error) {
+	var claims services.Claims
+	if _, _, err := jwt.NewParser().ParseUnverified(token, &claims); err != nil {
+		return "", err
+	}
+	return claims.UserID, nil
+}
+
+func databaseOnly(what string) error {
+	return fmt.Errorf("%s works on the database only; run the command without -api", what)
+}
@@ -0,0 +1,380 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sms/constants"
+	"sms/handlers"
+	"sms/models"
+	"sms/repository/storage"
+	"sms/validation"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// gradeColumns is the header of grade CSV files, on import and export alike.
+var gradeColumns = []string{"studentID", "subjectID", "semester", "grade"}
+
+func migrate(c *cli, args []string) error {
+	if err := c.flags().Parse(args); err != nil {
+		return err
+	}
+	db, _, err := c.database()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	if err := storage.Migrate(c.ctx, db); err != nil {
+		return err
+	}
+	fmt.Fprintln(c.stdout, "database is up to date")
+	return nil
+}
+
+func backup(c *cli, args []string) error {
+	fs := c.flags()
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+	db, _, err := c.database()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	if err := storage.Backup(c.ctx, db, fs.Arg(0)); err != nil {
+		return err
+	}
+	fmt.Fprintln(c.stdout, "backed up to", fs.Arg(0))
+	return nil
+}
+
+func createUser(c *cli, args []string) error {
+	fs := c.flags()
+	name := fs.String("name", "", "name of the account holder")
+	email := fs.String("email", "", "email to log in with")
+	role := fs.String("role", string(constants.Faculty), "admin, faculty or guardian")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *name == "" {
+		return errors.New("-name is required")
+	}
+	return c.backend(func(b backend) error {
+		password, err := c.password("Password of " + *email)
+		if err != nil {
+			return err
+		}
+		id, err := b.CreateUser(c.ctx, *name, *email, password, constants.Role(*role))
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(c.stdout, id)
+		return nil
+	})
+}
+
+func resetPassword(c *cli, args []string) error {
+	fs := c.flags()
+	email := fs.String("email", "", "email of the account")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	return c.backend(func(b backend) error {
+		password, err := c.password("New password of " + *email)
+		if err != nil {
+			return err
+		}
+		if err := b.ResetPassword(c.ctx, *email, password); err != nil {
+			return err
+		}
+		fmt.Fprintln(c.stdout, "password updated")
+		return nil
+	})
+}
+
+func addStudent(c *cli, args []string) error {
+	fs := c.flags()
+	var req handlers.CreateStudentRequest
+	fs.StringVar(&req.RollNumber, "roll", "", "roll number")
+	fs.StringVar(&req.Name, "name", "", "name of the student")
+	fs.StringVar(&req.ClassID, "class", "", "class of the student")
+	fs.IntVar(&req.Semester, "semester", 0, "current semester")
+	subjects := fs.String("subjects", "", "comma separated subjects to enroll the student in")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	req.SubjectIDs = splitList(*subjects)
+	if err := validation.Validate(req); err != nil {
+		return err
+	}
+	return c.backend(func(b backend) error {
+		id, err := b.AddStudent(c.ctx, req)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(c.stdout, id)
+		return nil
+	})
+}
+
+func updateStudent(c *cli, args []string) error {
+	fs := c.flags()
+	var req handlers.UpdateStudentRequest
+	fs.StringVar(&req.RollNumber, "roll", "", "new roll number")
+	fs.StringVar(&req.Name, "name", "", "new name")
+	fs.StringVar(&req.ClassID, "class", "", "new class")
+	fs.IntVar(&req.Semester, "semester", 0, "new semester")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+	return c.backend(func(b backend) error {
+		if err := b.UpdateStudent(c.ctx, fs.Arg(0), req); err != nil {
+			return err
+		}
+		fmt.Fprintln(c.stdout, "student updated")
+		return nil
+	})
+}
+
+func listStudents(c *cli, args []string) error {
+	fs := c.flags()
+	classID := fs.String("class", "", "class to list")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *classID == "" {
+		return errors.New("-class is required")
+	}
+	return c.backend(func(b backend) error {
+		students, err := b.ListStudents(c.ctx, *classID)
+		if err != nil {
+			return err
+		}
+		tw := tabwriter.NewWriter(c.stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tROLL\tNAME\tSEMESTER")
+		for _, s := range students {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%d\n", s.StudentID, s.RollNumber, s.Name, s.Semester)
+		}
+		return tw.Flush()
+	})
+}
+
+func addSubject(c *cli, args []string) error {
+	fs := c.flags()
+	var subject models.Subject
+	fs.StringVar(&subject.SubjectID, "id", "", "subject code, such as MATH")
+	fs.StringVar(&subject.SubjectName, "name", "", "name of the subject")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if subject.SubjectID == "" || subject.SubjectName == "" {
+		return errors.New("-id and -name are required")
+	}
+	return c.backend(func(b backend) error {
+		return b.AddSubject(c.ctx, subject)
+	})
+}
+
+func listSubjects(c *cli, args []string) error {
+	if err := c.flags().Parse(args); err != nil {
+		return err
+	}
+	return c.backend(func(b backend) error {
+		subjects, err := b.ListSubjects(c.ctx)
+		if err != nil {
+			return err
+		}
+		tw := tabwriter.NewWriter(c.stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tNAME")
+		for _, s := range subjects {
+			fmt.Fprintf(tw, "%s\t%s\n", s.SubjectID, s.SubjectName)
+		}
+		return tw.Flush()
+	})
+}
+
+func addClass(c *cli, args []string) error {
+	fs := c.flags()
+	var class models.Class
+	fs.StringVar(&class.ClassID, "id", "", "class code, such as C1")
+	fs.IntVar(&class.Capacity, "capacity", constants.DefaultClassCapacity, "number of seats")
+	fs.StringVar(&class.ProgramID, "program", "", "program the class follows")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if class.ClassID == "" {
+		return errors.New("-id is required")
+	}
+	if class.Capacity <= 0 {
+		return errors.New("-capacity must be positive")
+	}
+	return c.backend(func(b backend) error {
+		return b.AddClass(c.ctx, class)
+	})
+}
+
+func listClasses(c *cli, args []string) error {
+	if err := c.flags().Parse(args); err != nil {
+		return err
+	}
+	return c.backend(func(b backend) error {
+		classes, err := b.ListClasses(c.ctx)
+		if err != nil {
+			return err
+		}
+		tw := tabwriter.NewWriter(c.stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tCAPACITY\tPROGRAM")
+		for _, cl := range classes {
+			fmt.Fprintf(tw, "%s\t%d\t%s\n", cl.ClassID, cl.Capacity, cl.ProgramID)
+		}
+		return tw.Flush()
+	})
+}
+
+func importGrades(c *cli, args []string) error {
+	fs := c.flags()
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+	return c.backend(func(b backend) error {
+		// read after the login, whose password may come first on standard input
+		var in io.Reader = c.stdin
+		if fs.Arg(0) != "-" {
+			f, err := os.Open(fs.Arg(0))
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			in = f
+		}
+		grades, err := readGrades(in)
+		if err != nil {
+			return err
+		}
+		n, err := b.ImportGrades(c.ctx, grades)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(c.stdout, "imported %d grades\n", n)
+		return nil
+	})
+}
+
+func exportGrades(c *cli, args []string) error {
+	fs := c.flags()
+	classID := fs.String("class", "", "class to export")
+	semester := fs.Int("semester", 0, "semester to export")
+	out := fs.String("o", "-", "file to write, - for standard output")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *classID == "" || *semester <= 0 {
+		return errors.New("-class and a positive -semester are required")
+	}
+	return c.backend(func(b backend) error {
+		grades, err := b.ClassGrades(c.ctx, *classID, *semester)
+		if err != nil {
+			return err
+		}
+		if *out == "-" {
+			return writeGrades(c.stdout, grades)
+		}
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		if err := writeGrades(f, grades); err != nil {
+			f.Close()
+			return err
+		}
+		return f.Close()
+	})
+}
+
+// readGrades reads a grade CSV file. The columns may come in any order, and
+// every row is checked like the body of POST /api/v1/grades/import.
+func readGrades(r io.Reader) ([]handlers.AddGradeRequest, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, errors.New("grade file is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+	index := map[string]int{}
+	for i, name := range header {
+		index[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range gradeColumns {
+		if _, ok := index[strings.ToLower(name)]; !ok {
+			return nil, fmt.Errorf("grade file has no %s column; the header is %s", name, strings.Join(gradeColumns, ","))
+		}
+	}
+
+	var grades []handlers.AddGradeRequest
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := cr.FieldPos(0)
+		field := func(name string) string { return strings.TrimSpace(record[index[strings.ToLower(name)]]) }
+		g := handlers.AddGradeRequest{StudentID: field("studentID"), SubjectID: field("subjectID")}
+		if g.Semester, err = strconv.Atoi(field("semester")); err != nil {
+			return nil, fmt.Errorf("line %d: semester must be a number", line)
+		}
+		if g.Grade, err = strconv.Atoi(field("grade")); err != nil {
+			return nil, fmt.Errorf("line %d: grade must be a number", line)
+		}
+		if err := validation.Validate(g); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		grades = append(grades, g)
+	}
+	if len(grades) == 0 {
+		return nil, errors.New("grade file has no grades")
+	}
+	return grades, nil
+}
+
+func writeGrades(w io.Writer, grades []models.Grade) error {
+	cw := csv.NewWriter(w)
+	cw.Write(gradeColumns)
+	for _, g := range grades {
+		cw.Write([]string{g.StudentID, g.SubjectID, strconv.Itoa(g.Semester), strconv.Itoa(g.Grade)})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,224 @@
+// Command smsctl administers the school management system from the command
+// line: accounts, students, subjects, classes and grades, and the database
+// itself.
+//
+// By default it works directly on the database of the server's configuration,
+// read like the server reads it (see package config), through the same services
+// with the same rules. The events it causes queue the same notifications and
+// webhooks, which the server delivers. With -api it sends the commands to a
+// running server instead, logged in as -api-email, so the server's permissions
+// apply as well. Managing subjects and classes, listing students, resetting
+// passwords, migrating and backing up only work on the database.
+//
+// Passwords are read from standard input, one per line, so they stay out of the
+// process list and the shell history. With -api the password of -api-email comes
+// from SMS_API_PASSWORD or else the first line; a command that needs a password
+// of its own reads it next.
+//
+// Usage:
+//
+//	smsctl [global flags] <command> [flags] [args]
+//
+// Run smsctl -h for the commands and smsctl <command> -h for their flags.
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sms/app"
+	"sms/apperrors"
+	"sms/config"
+	"sms/repository/storage"
+	"strings"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if err := run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(2)
+		}
+		fmt.Fprintln(os.Stderr, "smsctl:", describe(err))
+		os.Exit(1)
+	}
+}
+
+// cli is what a command runs with: the global flags and the standard streams.
+type cli struct {
+	ctx      context.Context
+	stdin    *bufio.Reader
+	terminal bool
+	stdout   io.Writer
+	stderr   io.Writer
+	cmd      command
+
+	config   func() (config.Config, error)
+	api      string
+	apiEmail string
+}
+
+type command struct {
+	name  string
+	args  string
+	about string
+	run   func(c *cli, args []string) error
+}
+
+var commands = []command{
+	{"migrate", "", "bring the database schema up to the latest version", migrate},
+	{"backup", "<file>", "copy the SQLite database to a new file while it is in use", backup},
+	{"user create", "-name -email [-role]", "create an admin, faculty or guardian account", createUser},
+	{"user reset-password", "-email", "set a new password for an account", resetPassword},
+	{"student add", "-roll -name -class -semester [-subjects]", "add a student, enrolled in the given subjects", addStudent},
+	{"student update", "[-roll] [-name] [-class] [-semester] <studentID>", "change the given fields of a student", updateStudent},
+	{"student list", "-class", "list the students of a class", listStudents},
+	{"subject add", "-id -name", "add a subject", addSubject},
+	{"subject list", "", "list the subjects", listSubjects},
+	{"class add", "-id [-capacity] [-program]", "add a class", addClass},
+	{"class list", "", "list the classes", listClasses},
+	{"grades import", "<file.csv | ->", "import grades from CSV, all of them or none", importGrades},
+	{"grades export", "-class -semester [-o file.csv]", "export the grades of a class as CSV", exportGrades},
+}
+
+// run parses the global flags and runs the command the remaining arguments
+// name.
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	c := &cli{ctx: ctx, stdin: bufio.NewReader(stdin), terminal: isTerminal(stdin), stdout: stdout, stderr: stderr}
+	fs := flag.NewFlagSet("smsctl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	c.config = config.Flags(fs)
+	fs.StringVar(&c.api, "api", os.Getenv("SMS_API_URL"), "URL of a running server to send the commands to instead (SMS_API_URL)")
+	fs.StringVar(&c.apiEmail, "api-email", os.Getenv("SMS_API_EMAIL"), "account to log in to the server as (SMS_API_EMAIL)")
+	fs.Usage = func() {
+		fmt.Fprint(stderr, "Usage: smsctl [global flags] <command> [flags] [args]\n\nCommands:\n")
+		for _, cmd := range commands {
+			fmt.Fprintf(stderr, "  %-20s %s\n", cmd.name, cmd.about)
+		}
+		fmt.Fprint(stderr, "\nGlobal flags:\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	args = fs.Args()
+	for _, cmd := range commands {
+		words := strings.Fields(cmd.name)
+		if len(args) >= len(words) && strings.Join(args[:len(words)], " ") == cmd.name {
+			c.cmd = cmd
+			return cmd.run(c, args[len(words):])
+		}
+	}
+	if len(args) > 0 {
+		fmt.Fprintf(stderr, "smsctl: unknown command %q\n", strings.Join(args, " "))
+	}
+	fs.Usage()
+	return flag.ErrHelp
+}
+
+// flags returns the flag set of the command being run, printing its usage line
+// on -h.
+func (c *cli) flags() *flag.FlagSet {
+	fs := flag.NewFlagSet("smsctl "+c.cmd.name, flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(c.stderr, "Usage: smsctl %s %s\n\n%s.\n\n", c.cmd.name, c.cmd.args, c.cmd.about)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// database loads the configuration and opens its database, for the commands
+// that work on the database.
+func (c *cli) database() (*storage.DB, config.Config, error) {
+	if c.api != "" {
+		return nil, config.Config{}, databaseOnly(c.cmd.name)
+	}
+	cfg, err := c.config()
+	if err != nil {
+		return nil, config.Config{}, fmt.Errorf("invalid configuration:\n%w", err)
+	}
+	dialect, err := storage.ParseDialect(cfg.Database.Driver)
+	if err != nil {
+		return nil, config.Config{}, err
+	}
+	db, err := storage.Open(dialect, cfg.Database.DSN)
+	if err != nil {
+		return nil, config.Config{}, err
+	}
+	if err := db.PingContext(c.ctx); err != nil {
+		db.Close()
+		return nil, config.Config{}, err
+	}
+	return db, cfg, nil
+}
+
+// backend runs f on the API when -api is set and on the database otherwise.
+func (c *cli) backend(f func(b backend) error) error {
+	if c.api != "" {
+		if c.apiEmail == "" {
+			return errors.New("-api-email is required with -api")
+		}
+		password := os.Getenv("SMS_API_PASSWORD")
+		if password == "" {
+			var err error
+			if password, err = c.password("Password of " + c.apiEmail); err != nil {
+				return err
+			}
+		}
+		return f(newAPIBackend(c.api, c.apiEmail, password))
+	}
+	db, cfg, err := c.database()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	svc, err := app.NewServices(db, cfg)
+	if err != nil {
+		return err
+	}
+	// let the subscribers that run in the background finish before closing
+	defer svc.Bus.Wait()
+	return f(newDBBackend(db, svc))
+}
+
+// password reads the next line of standard input, asking for it first when
+// that is a terminal. What is typed there is not hidden.
+func (c *cli) password(prompt string) (string, error) {
+	if c.terminal {
+		fmt.Fprintf(c.stderr, "%s: ", prompt)
+	}
+	line, err := c.stdin.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", errors.New("expected a password on standard input")
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// describe adds the fields of a validation error to its message.
+func describe(err error) string {
+	msg := err.Error()
+	var appErr *apperrors.Error
+	if errors.As(err, &appErr) {
+		for _, f := range appErr.Fields {
+			msg += fmt.Sprintf("\n  %s %s", f.Field, f.Message)
+		}
+	}
+	return msg
+}
+
+func isTerminal(r io.Reader) bool {
+	f, ok := r.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
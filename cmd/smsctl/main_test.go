@@ -0,0 +1,228 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sms/app"
+	"sms/apperrors"
+	"sms/config"
+	"sms/constants"
+	"sms/models"
+	"sms/repository/storage"
+	termRepository "sms/repository/termRepository"
+	"sms/services"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const password = "Str0ng&Secret"
+
+// jwtSecret is set for every test, as smsctl reads the server's configuration.
+const jwtSecret = "0123456789abcdef0123456789abcdef"
+
+// smsctl runs the command and returns what it printed.
+func smsctl(t *testing.T, stdin string, args ...string) (string, error) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	err := run(context.Background(), args, strings.NewReader(stdin), &stdout, &stderr)
+	return stdout.String(), err
+}
+
+func mustRun(t *testing.T, stdin string, args ...string) string {
+	t.Helper()
+	out, err := smsctl(t, stdin, args...)
+	if err != nil {
+		t.Fatalf("smsctl %s: %v", strings.Join(args, " "), describe(err))
+	}
+	return out
+}
+
+// setupDatabase migrates a new SQLite database and adds class C1 with subject
+// MATH through smsctl itself. It then opens grade entry for semester 1 and
+// closes it for semester 2.
+func setupDatabase(t *testing.T) (dsn string) {
+	t.Setenv("SMS_JWT_SECRET", jwtSecret)
+	dsn = filepath.Join(t.TempDir(), "sms.db")
+	mustRun(t, "", "-db-dsn", dsn, "migrate")
+	mustRun(t, "", "-db-dsn", dsn, "class", "add", "-id", "C1", "-capacity", "40")
+	mustRun(t, "", "-db-dsn", dsn, "subject", "add", "-id", "MATH", "-name", "Engineering Mathematics")
+
+	db, err := storage.Open(storage.SQLite, dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	today := time.Now().Truncate(24 * time.Hour)
+	terms := services.NewTermService(termRepository.NewTermRepo(db))
+	for semester, status := range map[int]constants.TermStatus{1: constants.TermOpen, 2: constants.TermClosed} {
+		_, err = terms.CreateTerm(context.Background(), models.AcademicTerm{
+			Year: today.Year(), TermNumber: semester, Semesters: []int{semester},
+			StartDate: today.AddDate(0, -1, 0), EndDate: today.AddDate(0, 3, 0),
+			GradeEntryStart: today.AddDate(0, 0, -1), GradeEntryEnd: today.AddDate(0, 0, 1),
+			Status: status,
+		})
+		if err != nil {
+			t.Fatalf("failed to create term: %v", err)
+		}
+	}
+	return dsn
+}
+
+func TestDatabaseCommands(t *testing.T) {
+	dsn := setupDatabase(t)
+	db := func(args ...string) []string { return append([]string{"-db-dsn", dsn}, args...) }
+
+	if out := mustRun(t, "", db("class", "list")...); !strings.Contains(out, "C1") || !strings.Contains(out, "40") {
+		t.Errorf("unexpected class list:\n%s", out)
+	}
+	if out := mustRun(t, "", db("subject", "list")...); !strings.Contains(out, "Engineering Mathematics") {
+		t.Errorf("unexpected subject list:\n%s", out)
+	}
+	if _, err := smsctl(t, "", db("subject", "add", "-id", "MATH", "-name", "Again")...); !errors.Is(err, apperrors.ErrConflict) {
+		t.Errorf("expected a conflict for a duplicate subject, got %v", err)
+	}
+
+	if id := mustRun(t, password+"\n", db("user", "create", "-name", "Admin", "-email", "admin@example.com", "-role", "admin")...); strings.TrimSpace(id) == "" {
+		t.Error("expected the ID of the new user")
+	}
+	mustRun(t, "N3w&Password!\n", db("user", "reset-password", "-email", "admin@example.com")...)
+	if _, err := smsctl(t, "short\n", db("user", "reset-password", "-email", "admin@example.com")...); !errors.Is(err, apperrors.ErrValidation) {
+		t.Errorf("expected a weak password to be refused, got %v", err)
+	}
+	if _, err := smsctl(t, "", db("user", "reset-password", "-email", "admin@example.com")...); err == nil || !strings.Contains(err.Error(), "standard input") {
+		t.Errorf("expected the missing password to be reported, got %v", err)
+	}
+
+	anu := strings.TrimSpace(mustRun(t, "", db("student", "add", "-roll", "101", "-name", "Anu", "-class", "C1", "-semester", "1", "-subjects", "MATH")...))
+	ravi := strings.TrimSpace(mustRun(t, "", db("student", "add", "-roll", "102", "-name", "Ravi", "-class", "C1", "-semester", "1", "-subjects", "MATH")...))
+	mustRun(t, "", db("student", "update", "-name", "Ravi K", ravi)...)
+	if out := mustRun(t, "", db("student", "list", "-class", "C1")...); !strings.Contains(out, "Anu") || !strings.Contains(out, "Ravi K") {
+		t.Errorf("unexpected student list:\n%s", out)
+	}
+	if _, err := smsctl(t, "", db("student", "update", "-name", "Nobody", "missing")...); !errors.Is(err, apperrors.ErrNotFound) {
+		t.Errorf("expected not found for an unknown student, got %v", err)
+	}
+	_, err := smsctl(t, "", db("student", "add", "-name", "Kiran")...)
+	var appErr *apperrors.Error
+	if !errors.As(err, &appErr) || len(appErr.Fields) != 3 {
+		t.Errorf("expected the missing fields to be listed, got %v", err)
+	}
+
+	csv := "studentID,subjectID,semester,grade\n" + anu + ",MATH,1,90\n" + ravi + ",MATH,1,70\n"
+	if out := mustRun(t, csv, db("grades", "import", "-")...); out != "imported 2 grades\n" {
+		t.Errorf("unexpected import output %q", out)
+	}
+	if _, err := smsctl(t, "studentID,subjectID,semester,grade\n"+anu+",MATH,1,900\n", db("grades", "import", "-")...); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected the bad line to be named, got %v", err)
+	}
+	if _, err := smsctl(t, "grade,semester\n", db("grades", "import", "-")...); err == nil {
+		t.Error("expected a file without studentID column to be refused")
+	}
+	// the same rules as the server: the term of semester 2 is closed
+	if _, err := smsctl(t, "studentID,subjectID,semester,grade\n"+anu+",MATH,2,90\n", db("grades", "import", "-")...); !errors.Is(err, apperrors.ErrForbidden) {
+		t.Errorf("expected grades outside the entry window to be refused, got %v", err)
+	}
+
+	exported := filepath.Join(t.TempDir(), "grades.csv")
+	mustRun(t, "", db("grades", "export", "-class", "C1", "-semester", "1", "-o", exported)...)
+	if b, err := os.ReadFile(exported); err != nil || string(b) != sortedCSV(anu, ravi, 90, 70) {
+		t.Errorf("unexpected export %q, %v", b, err)
+	}
+
+	backup := filepath.Join(t.TempDir(), "backup.db")
+	mustRun(t, "", db("backup", backup)...)
+	if out := mustRun(t, "", "-db-dsn", backup, "student", "list", "-class", "C1"); !strings.Contains(out, "Anu") {
+		t.Errorf("expected the backup to hold the students:\n%s", out)
+	}
+
+	t.Setenv("SMS_JWT_SECRET", "")
+	if _, err := smsctl(t, "", db("class", "list")...); err == nil || !strings.Contains(err.Error(), "jwt.secret") {
+		t.Errorf("expected the configuration to be checked like the server does, got %v", err)
+	}
+}
+
+func TestAPICommands(t *testing.T) {
+	dsn := setupDatabase(t)
+	mustRun(t, password+"\n", "-db-dsn", dsn, "user", "create", "-name", "Admin", "-email", "admin@example.com", "-role", "admin")
+
+	db, err := storage.Open(storage.SQLite, dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	cfg := config.Default()
+	cfg.JWT.Secret = jwtSecret
+	mux, err := app.SetupServer(db, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	admin := func(args ...string) []string {
+		return append([]string{"-api", srv.URL, "-api-email", "admin@example.com"}, args...)
+	}
+	faculty := func(args ...string) []string {
+		return append([]string{"-api", srv.URL, "-api-email", "faculty@example.com"}, args...)
+	}
+
+	// the login password comes first, then the password of the new account
+	if id := mustRun(t, password+"\n"+password+"\n", admin("user", "create", "-name", "Meera", "-email", "faculty@example.com")...); strings.TrimSpace(id) == "" {
+		t.Error("expected the ID of the new faculty")
+	}
+	t.Setenv("SMS_API_PASSWORD", password)
+	mustRun(t, password+"\n", admin("user", "create", "-name", "Lakshmi", "-email", "guardian@example.com", "-role", "guardian")...)
+	anu := strings.TrimSpace(mustRun(t, "", admin("student", "add", "-roll", "101", "-name", "Anu", "-class", "C1", "-semester", "1", "-subjects", "MATH")...))
+	ravi := strings.TrimSpace(mustRun(t, "", admin("student", "add", "-roll", "102", "-name", "Ravi", "-class", "C1", "-semester", "1", "-subjects", "MATH")...))
+	mustRun(t, "", admin("student", "update", "-semester", "1", ravi)...)
+	t.Setenv("SMS_API_PASSWORD", "")
+
+	csv := "grade,studentID,subjectID,semester\n80," + anu + ",MATH,1\n85," + ravi + ",MATH,1\n"
+	if _, err := smsctl(t, password+"\n"+csv, admin("grades", "import", "-")...); !errors.Is(err, apperrors.ErrForbidden) {
+		t.Errorf("expected the server to keep admins from importing grades, got %v", err)
+	}
+	mustRun(t, password+"\n"+csv, faculty("grades", "import", "-")...)
+	if out := mustRun(t, password+"\n", faculty("grades", "export", "-class", "C1", "-semester", "1")...); out != sortedCSV(anu, ravi, 80, 85) {
+		t.Errorf("unexpected export %q", out)
+	}
+
+	for _, args := range [][]string{
+		{"subject", "list"},
+		{"class", "add", "-id", "C2"},
+		{"student", "list", "-class", "C1"},
+		{"user", "reset-password", "-email", "faculty@example.com"},
+		{"user", "create", "-name", "Root", "-email", "root@example.com", "-role", "admin"},
+		{"migrate"},
+	} {
+		if _, err := smsctl(t, password+"\n"+password+"\n", admin(args...)...); err == nil || !strings.Contains(err.Error(), "database only") {
+			t.Errorf("%s: expected a database only error, got %v", strings.Join(args, " "), err)
+		}
+	}
+	if _, err := smsctl(t, "", "-api", srv.URL, "subject", "list"); err == nil {
+		t.Error("expected -api without credentials to be refused")
+	}
+}
+
+func TestUsage(t *testing.T) {
+	for _, args := range [][]string{nil, {"nonsense"}, {"student"}, {"backup"}, {"student", "add", "-h"}} {
+		if _, err := smsctl(t, "", args...); !errors.Is(err, flag.ErrHelp) {
+			t.Errorf("%v: expected usage, got %v", args, err)
+		}
+	}
+}
+
+// sortedCSV is the export of two students with one MATH grade each.
+func sortedCSV(a, b string, gradeA, gradeB int) string {
+	rows := []string{a + ",MATH,1," + strconv.Itoa(gradeA), b + ",MATH,1," + strconv.Itoa(gradeB)}
+	if b < a {
+		rows[0], rows[1] = rows[1], rows[0]
+	}
+	return "studentID,subjectID,semester,grade\n" + strings.Join(rows, "\n") + "\n"
+}
@@ -0,0 +1,382 @@
+// Package config holds the settings of the server. They come from a YAML file,
+// environment variables and command line flags, each overriding the one before,
+// and are checked before the server starts:
+//
+//	addr: ":8080"
+//	database:
+//	  driver: sqlite
+//	  dsn: sms.db
+//	jwt:
+//	  secret: a long random string
+//	  expiry: 24h
+//	bcrypt_cost: 10
+//	smtp:
+//	  addr: mail.example.com:587
+//	http:
+//	  write_timeout: 30s
+//	  shutdown_timeout: 30s
+//	tls:
+//	  cert_file: /etc/sms/tls.crt
+//	  key_file: /etc/sms/tls.key
+//
+// Every setting has an SMS_ environment variable and a flag; -h lists them.
+// Secrets are redacted when a Config is printed.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"regexp"
+	"sms/constants"
+	"sms/repository/storage"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	"gopkg.in/yaml.v3"
+)
+
+type Config struct {
+	Addr       string   `yaml:"addr"`
+	Database   Database `yaml:"database"`
+	JWT        JWT      `yaml:"jwt"`
+	BcryptCost int      `yaml:"bcrypt_cost"`
+	SMTP       SMTP     `yaml:"smtp"`
+	HTTP       HTTP     `yaml:"http"`
+	TLS        TLS      `yaml:"tls"`
+}
+
+type Database struct {
+	Driver string `yaml:"driver"`
+	// DSN is a file for SQLite and a connection string for PostgreSQL. A
+	// password in it is redacted like a Secret.
+	DSN string `yaml:"dsn"`
+}
+
+type JWT struct {
+	Secret Secret        `yaml:"secret"`
+	Expiry time.Duration `yaml:"expiry"`
+}
+
+// SMTP is the server email notifications go out through. Email is off while
+// Addr is empty.
+type SMTP struct {
+	Addr     string `yaml:"addr"`
+	Username string `yaml:"username"`
+	Password Secret `yaml:"password"`
+	From     string `yaml:"from"`
+}
+
+// HTTP holds the timeouts of the server. RequestTimeout bounds the work of one
+// request; ShutdownTimeout is how long a stopping server waits for requests in
+// flight and then for its background work.
+type HTTP struct {
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout"`
+	ReadTimeout       time.Duration `yaml:"read_timeout"`
+	WriteTimeout      time.Duration `yaml:"write_timeout"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout"`
+	RequestTimeout    time.Duration `yaml:"request_timeout"`
+	ShutdownTimeout   time.Duration `yaml:"shutdown_timeout"`
+}
+
+// TLS turns on HTTPS when both files are given. The server picks up a renewed
+// certificate by itself.
+type TLS struct {
+	CertFile string `yaml:"cert_file"`
+	KeyFile  string `yaml:"key_file"`
+}
+
+func (t TLS) Enabled() bool {
+	return t.CertFile != "" || t.KeyFile != ""
+}
+
+// Secret is a setting that is never printed. Convert it to a string to use it.
+type Secret string
+
+const redacted = "[redacted]"
+
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return redacted
+}
+
+func (s Secret) GoString() string {
+	return strconv.Quote(s.String())
+}
+
+func (s Secret) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Default returns the settings the server runs with when nothing overrides
+// them. It has no JWT secret, so it doesn't pass Validate by itself.
+func Default() Config {
+	return Config{
+		Addr:       constants.DefaultAddr,
+		Database:   Database{Driver: string(storage.SQLite), DSN: constants.DefaultDBDSN},
+		JWT:        JWT{Expiry: constants.DefaultJWTExpiry},
+		BcryptCost: bcrypt.DefaultCost,
+		HTTP: HTTP{
+			ReadHeaderTimeout: constants.DefaultReadHeaderTimeout,
+			ReadTimeout:       constants.DefaultReadTimeout,
+			WriteTimeout:      constants.DefaultWriteTimeout,
+			IdleTimeout:       constants.DefaultIdleTimeout,
+			RequestTimeout:    constants.DefaultRequestTimeout,
+			ShutdownTimeout:   constants.DefaultShutdownTimeout,
+		},
+	}
+}
+
+// setting ties a field of Config to its environment variable and flag.
+type setting struct {
+	key   string
+	env   string
+	flag  string
+	usage string
+	field func(c *Config) any
+}
+
+var settings = []setting{
+	{"addr", "SMS_ADDR", "addr", "address to listen on",
+		func(c *Config) any { return &c.Addr }},
+	{"database.driver", "SMS_DB_DRIVER", "db-driver", "database driver, sqlite or postgres",
+		func(c *Config) any { return &c.Database.Driver }},
+	{"database.dsn", "SMS_DB_DSN", "db-dsn", "database file or connection string",
+		func(c *Config) any { return &c.Database.DSN }},
+	{"jwt.secret", "SMS_JWT_SECRET", "jwt-secret", "secret tokens are signed with, at least 32 bytes",
+		func(c *Config) any { return &c.JWT.Secret }},
+	{"jwt.expiry", "SMS_JWT_EXPIRY", "jwt-expiry", "how long a token is valid",
+		func(c *Config) any { return &c.JWT.Expiry }},
+	{"bcrypt_cost", "SMS_BCRYPT_COST", "bcrypt-cost", "cost of password hashes",
+		func(c *Config) any { return &c.BcryptCost }},
+	{"smtp.addr", "SMS_SMTP_ADDR", "smtp-addr", "host:port of the SMTP server, empty to send no email",
+		func(c *Config) any { return &c.SMTP.Addr }},
+	{"smtp.username", "SMS_SMTP_USERNAME", "smtp-username", "SMTP user, empty to send without logging in",
+		func(c *Config) any { return &c.SMTP.Username }},
+	{"smtp.password", "SMS_SMTP_PASSWORD", "smtp-password", "SMTP password",
+		func(c *Config) any { return &c.SMTP.Password }},
+	{"smtp.from", "SMS_SMTP_FROM", "smtp-from", "sender address of emails",
+		func(c *Config) any { return &c.SMTP.From }},
+	{"http.read_header_timeout", "SMS_HTTP_READ_HEADER_TIMEOUT", "http-read-header-timeout", "time allowed to read request headers",
+		func(c *Config) any { return &c.HTTP.ReadHeaderTimeout }},
+	{"http.read_timeout", "SMS_HTTP_READ_TIMEOUT", "http-read-timeout", "time allowed to read a whole request",
+		func(c *Config) any { return &c.HTTP.ReadTimeout }},
+	{"http.write_timeout", "SMS_HTTP_WRITE_TIMEOUT", "http-write-timeout", "time allowed to write a response",
+		func(c *Config) any { return &c.HTTP.WriteTimeout }},
+	{"http.idle_timeout", "SMS_HTTP_IDLE_TIMEOUT", "http-idle-timeout", "how long an idle keep-alive connection stays open",
+		func(c *Config) any { return &c.HTTP.IdleTimeout }},
+	{"http.request_timeout", "SMS_HTTP_REQUEST_TIMEOUT", "http-request-timeout", "time allowed for the work of one request",
+		func(c *Config) any { return &c.HTTP.RequestTimeout }},
+	{"http.shutdown_timeout", "SMS_HTTP_SHUTDOWN_TIMEOUT", "http-shutdown-timeout", "how long shutting down waits for requests and workers",
+		func(c *Config) any { return &c.HTTP.ShutdownTimeout }},
+	{"tls.cert_file", "SMS_TLS_CERT_FILE", "tls-cert-file", "PEM certificate chain, to serve HTTPS",
+		func(c *Config) any { return &c.TLS.CertFile }},
+	{"tls.key_file", "SMS_TLS_KEY_FILE", "tls-key-file", "PEM private key of the certificate",
+		func(c *Config) any { return &c.TLS.KeyFile }},
+}
+
+// set parses value into the field the setting points at.
+func (s setting) set(c *Config, value string) error {
+	switch p := s.field(c).(type) {
+	case *string:
+		*p = value
+	case *Secret:
+		*p = Secret(value)
+	case *int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%s must be a number", s.key)
+		}
+		*p = n
+	case *time.Duration:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("%s must be a duration such as 30s or 24h", s.key)
+		}
+		*p = d
+	}
+	return nil
+}
+
+// get is the field formatted for printing, with secrets redacted.
+func (s setting) get(c *Config) string {
+	switch p := s.field(c).(type) {
+	case *string:
+		if s.key == "database.dsn" {
+			return redactDSN(*p)
+		}
+		return *p
+	case *Secret:
+		return p.String()
+	case *int:
+		return strconv.Itoa(*p)
+	case *time.Duration:
+		return p.String()
+	}
+	return ""
+}
+
+// Load builds the configuration from the defaults, the YAML file named by
+// -config or SMS_CONFIG, the environment and then args, and validates it. On -h
+// it prints the flags to stderr and returns flag.ErrHelp.
+func Load(args []string) (Config, error) {
+	return load(args, os.Getenv, os.Stderr)
+}
+
+func load(args []string, getenv func(string) string, output io.Writer) (Config, error) {
+	fs := flag.NewFlagSet("sms", flag.ContinueOnError)
+	fs.SetOutput(output)
+	build := flags(fs, getenv)
+	if err := fs.Parse(args); err != nil {
+		return Config{}, err
+	}
+	if fs.NArg() > 0 {
+		return Config{}, fmt.Errorf("unexpected arguments %q", fs.Args())
+	}
+	return build()
+}
+
+// Flags registers -config and the flag of every setting on fs, for commands
+// that take the server's settings next to flags of their own. Once fs is
+// parsed, the returned func builds and validates the configuration like Load.
+func Flags(fs *flag.FlagSet) func() (Config, error) {
+	return flags(fs, os.Getenv)
+}
+
+func flags(fs *flag.FlagSet, getenv func(string) string) func() (Config, error) {
+	path := fs.String("config", getenv("SMS_CONFIG"), "YAML file to read the settings from (SMS_CONFIG)")
+	for _, s := range settings {
+		fs.String(s.flag, "", fmt.Sprintf("%s (%s)", s.usage, s.env))
+	}
+
+	return func() (Config, error) {
+		cfg := Default()
+		if *path != "" {
+			if err := cfg.readFile(*path); err != nil {
+				return Config{}, err
+			}
+		}
+		for _, s := range settings {
+			if v := getenv(s.env); v != "" {
+				if err := s.set(&cfg, v); err != nil {
+					return Config{}, fmt.Errorf("%s: %w", s.env, err)
+				}
+			}
+		}
+		var err error
+		fs.Visit(func(f *flag.Flag) {
+			for _, s := range settings {
+				if s.flag == f.Name && err == nil {
+					if e := s.set(&cfg, f.Value.String()); e != nil {
+						err = fmt.Errorf("-%s: %w", s.flag, e)
+					}
+				}
+			}
+		})
+		if err != nil {
+			return Config{}, err
+		}
+		return cfg, cfg.Validate()
+	}
+}
+
+// readFile overlays the settings in a YAML file. Unknown keys are an error, so
+// a typo doesn't go unnoticed.
+func (c *Config) readFile(path string) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	dec.KnownFields(true)
+	if err := dec.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// Validate reports every setting the server can't start with.
+func (c Config) Validate() error {
+	var errs []error
+	if _, _, err := net.SplitHostPort(c.Addr); err != nil {
+		errs = append(errs, fmt.Errorf("addr %q must be host:port", c.Addr))
+	}
+	if _, err := storage.ParseDialect(c.Database.Driver); err != nil {
+		errs = append(errs, fmt.Errorf("database.driver: %w", err))
+	}
+	if c.Database.DSN == "" {
+		errs = append(errs, errors.New("database.dsn is required"))
+	}
+	if len(c.JWT.Secret) < constants.MinJWTSecretLength {
+		errs = append(errs, fmt.Errorf("jwt.secret must be at least %d bytes", constants.MinJWTSecretLength))
+	}
+	if c.JWT.Expiry <= 0 {
+		errs = append(errs, errors.New("jwt.expiry must be positive"))
+	}
+	if c.BcryptCost < bcrypt.MinCost || c.BcryptCost > bcrypt.MaxCost {
+		errs = append(errs, fmt.Errorf("bcrypt_cost must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost))
+	}
+	if c.SMTP.Addr != "" {
+		if _, _, err := net.SplitHostPort(c.SMTP.Addr); err != nil {
+			errs = append(errs, fmt.Errorf("smtp.addr %q must be host:port", c.SMTP.Addr))
+		}
+		if c.SMTP.From == "" {
+			errs = append(errs, errors.New("smtp.from is required to send email"))
+		}
+	}
+	for _, t := range []struct {
+		key string
+		d   time.Duration
+	}{
+		{"http.read_header_timeout", c.HTTP.ReadHeaderTimeout},
+		{"http.read_timeout", c.HTTP.ReadTimeout},
+		{"http.write_timeout", c.HTTP.WriteTimeout},
+		{"http.idle_timeout", c.HTTP.IdleTimeout},
+		{"http.request_timeout", c.HTTP.RequestTimeout},
+		{"http.shutdown_timeout", c.HTTP.ShutdownTimeout},
+	} {
+		if t.d <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be positive", t.key))
+		}
+	}
+	if c.HTTP.WriteTimeout <= c.HTTP.RequestTimeout {
+		errs = append(errs, errors.New("http.write_timeout must be longer than http.request_timeout"))
+	}
+	if c.TLS.Enabled() && (c.TLS.CertFile == "" || c.TLS.KeyFile == "") {
+		errs = append(errs, errors.New("tls.cert_file and tls.key_file go together"))
+	}
+	return errors.Join(errs...)
+}
+
+// String lists the settings one per line with the secrets redacted, for the
+// startup log.
+func (c Config) String() string {
+	var b strings.Builder
+	for _, s := range settings {
+		fmt.Fprintf(&b, "%s=%s\n", s.key, s.get(&c))
+	}
+	return b.String()
+}
+
+var dsnPassword = regexp.MustCompile(`(password=)('[^']*'|\S+)`)
+
+// redactDSN hides the password of a PostgreSQL URL or keyword/value connection
+// string.
+func redactDSN(dsn string) string {
+	if u, err := url.Parse(dsn); err == nil && u.User != nil {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), redacted)
+			return strings.Replace(u.String(), url.QueryEscape(redacted), redacted, 1)
+		}
+	}
+	return dsnPassword.ReplaceAllString(dsn, "${1}"+redacted)
+}
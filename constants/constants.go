@@ -1,10 +1,13 @@
 package constants
 
+import "time"
+
 type Role string
 
 const (
-	Admin   Role = "admin"
-	Faculty Role = "faculty"
+	Admin    Role = "admin"
+	Faculty  Role = "faculty"
+	Guardian Role = "guardian"
 )
 
 type contextKey string
@@ -14,3 +17,197 @@ const (
 	ContextUserEmailKey contextKey = "userEmail"
 	ContextUserRoleKey  contextKey = "userRole"
 )
+
+const (
+	DefaultPassMark             = 40
+	DefaultHistogramBucketWidth = 10
+)
+
+const (
+	DefaultAtRiskMinAverage        = 50
+	DefaultAtRiskMaxDrop           = 15
+	DefaultAtRiskMaxFailedSubjects = 1
+	DefaultAtRiskScanInterval      = 24 * time.Hour
+)
+
+type RankingMethod string
+
+const (
+	DenseRanking       RankingMethod = "dense"
+	CompetitionRanking RankingMethod = "competition"
+)
+
+type Trend string
+
+const (
+	TrendImproving Trend = "improving"
+	TrendDeclining Trend = "declining"
+	TrendSteady    Trend = "steady"
+)
+
+type AttendanceStatus string
+
+const (
+	Present AttendanceStatus = "present"
+	Absent  AttendanceStatus = "absent"
+	Late    AttendanceStatus = "late"
+	Excused AttendanceStatus = "excused"
+)
+
+const DefaultMinAttendance = 75
+
+type EnrollmentStatus string
+
+const (
+	Enrolled  EnrollmentStatus = "enrolled"
+	Dropped   EnrollmentStatus = "dropped"
+	Completed EnrollmentStatus = "completed"
+)
+
+type SubjectKind string
+
+const (
+	CoreSubject     SubjectKind = "core"
+	ElectiveSubject SubjectKind = "elective"
+)
+
+type RolloverOutcome string
+
+const (
+	Promoted RolloverOutcome = "promoted"
+	Flagged  RolloverOutcome = "flagged"
+	Detained RolloverOutcome = "detained"
+)
+
+const (
+	DefaultRolloverMinAverage        = 40
+	DefaultRolloverMaxFailedSubjects = 2
+	DefaultRolloverUndoWindow        = 24 * time.Hour
+)
+
+type TermStatus string
+
+const (
+	TermOpen   TermStatus = "open"
+	TermClosed TermStatus = "closed"
+)
+
+type AssessmentKind string
+
+const (
+	Midterm    AssessmentKind = "midterm"
+	Assignment AssessmentKind = "assignment"
+	Lab        AssessmentKind = "lab"
+	Final      AssessmentKind = "final"
+)
+
+type NotificationEvent string
+
+const (
+	EventGradePosted    NotificationEvent = "grade_posted"
+	EventGradeChanged   NotificationEvent = "grade_changed"
+	EventAccountCreated NotificationEvent = "account_created"
+	EventAtRisk         NotificationEvent = "at_risk"
+)
+
+type NotificationChannel string
+
+const (
+	ChannelInApp   NotificationChannel = "in_app"
+	ChannelEmail   NotificationChannel = "email"
+	ChannelWebhook NotificationChannel = "webhook"
+)
+
+type NotificationStatus string
+
+const (
+	NotificationPending NotificationStatus = "pending"
+	NotificationSent    NotificationStatus = "sent"
+	NotificationFailed  NotificationStatus = "failed"
+)
+
+const (
+	DefaultNotificationMaxAttempts  = 5
+	DefaultNotificationBackoff      = time.Minute
+	MaxNotificationBackoff          = 6 * time.Hour
+	DefaultNotificationPollInterval = 15 * time.Second
+	NotificationBatchSize           = 50
+)
+
+type WebhookEvent string
+
+const (
+	WebhookGradePosted    WebhookEvent = "grade.posted"
+	WebhookGradeChanged   WebhookEvent = "grade.changed"
+	WebhookStudentCreated WebhookEvent = "student.created"
+	WebhookStudentUpdated WebhookEvent = "student.updated"
+)
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	DeliveryFailed    DeliveryStatus = "failed"
+)
+
+const (
+	DefaultWebhookMaxAttempts  = 8
+	DefaultWebhookBackoff      = 30 * time.Second
+	MaxWebhookBackoff          = 6 * time.Hour
+	DefaultWebhookPollInterval = 10 * time.Second
+	WebhookBatchSize           = 50
+)
+
+// Headers sent with every webhook delivery. The signature is the hex HMAC-SHA256
+// of "<timestamp>.<body>" keyed with the subscription secret.
+const (
+	WebhookEventHeader     = "X-SMS-Event"
+	WebhookDeliveryHeader  = "X-SMS-Delivery"
+	WebhookTimestampHeader = "X-SMS-Timestamp"
+	WebhookSignatureHeader = "X-SMS-Signature"
+)
+
+// DefaultRequestTimeout bounds the database work of one request. A client that
+// disconnects cancels it sooner.
+const DefaultRequestTimeout = 10 * time.Second
+
+// Timeouts of the HTTP server. The write timeout is longer than the request
+// timeout so a request that runs out of time can still send its error.
+const (
+	DefaultReadHeaderTimeout = 5 * time.Second
+	DefaultReadTimeout       = 30 * time.Second
+	DefaultWriteTimeout      = 30 * time.Second
+	DefaultIdleTimeout       = 2 * time.Minute
+	// DefaultShutdownTimeout is how long a stopping server waits for requests
+	// in flight, and then for its background workers, before giving up on them.
+	DefaultShutdownTimeout = 30 * time.Second
+)
+
+// MaxRequestBodyBytes caps the JSON body of a request. A grade import of a few
+// thousand rows fits comfortably.
+const MaxRequestBodyBytes = 1 << 20
+
+// The API client retries idempotent requests that failed on the way or with a
+// 502, 503 or 504, waiting twice as long after each try.
+const (
+	DefaultClientMaxRetries = 3
+	DefaultClientBackoff    = 200 * time.Millisecond
+	MaxClientBackoff        = 5 * time.Second
+	// ClientTokenRefreshMargin is how long before its expiry a token is replaced.
+	ClientTokenRefreshMargin = time.Minute
+)
+
+// DefaultClassCapacity is the number of seats smsctl gives a class added
+// without one.
+const DefaultClassCapacity = 60
+
+// Server settings used when the configuration doesn't give them.
+const (
+	DefaultAddr      = ":8080"
+	DefaultDBDSN     = "sms.db"
+	DefaultJWTExpiry = 24 * time.Hour
+	// MinJWTSecretLength is the shortest signing secret the server starts with;
+	// HS256 wants at least 256 bits.
+	MinJWTSecretLength = 32
+)
@@ -0,0 +1,105 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"sync"
+)
+
+type subscriber struct {
+	handle func(context.Context, Event) error
+	async  bool
+}
+
+// Bus delivers published events to the subscribers of their type. Synchronous
+// subscribers run in Publish, in the order they subscribed, and their errors are
+// returned from it. Asynchronous subscribers run on their own goroutine and only
+// log their errors. They are given the publisher's context without its
+// cancellation, since they usually outlive the request that published.
+type Bus struct {
+	mu     sync.RWMutex
+	byType map[reflect.Type][]subscriber
+	all    []subscriber
+	wg     sync.WaitGroup
+}
+
+func NewBus() *Bus {
+	return &Bus{byType: map[reflect.Type][]subscriber{}}
+}
+
+// Subscribe runs handle inside Publish for every event of type E.
+func Subscribe[E Event](b *Bus, handle func(context.Context, E) error) {
+	b.add(typeOf[E](), subscriber{handle: adapt(handle)})
+}
+
+// SubscribeAsync runs handle on a new goroutine for every event of type E.
+func SubscribeAsync[E Event](b *Bus, handle func(context.Context, E) error) {
+	b.add(typeOf[E](), subscriber{handle: adapt(handle), async: true})
+}
+
+// SubscribeAll runs handle inside Publish for every event, before the
+// subscribers of the event's type.
+func (b *Bus) SubscribeAll(handle func(context.Context, Event) error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.all = append(b.all, subscriber{handle: handle})
+}
+
+// Publish hands the event to its subscribers. Every synchronous subscriber runs
+// even if an earlier one fails; the errors are joined.
+func (b *Bus) Publish(ctx context.Context, e Event) error {
+	b.mu.RLock()
+	subs := make([]subscriber, 0, len(b.all)+len(b.byType[reflect.TypeOf(e)]))
+	subs = append(subs, b.all...)
+	subs = append(subs, b.byType[reflect.TypeOf(e)]...)
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, s := range subs {
+		if s.async {
+			b.wg.Add(1)
+			go b.runAsync(context.WithoutCancel(ctx), s, e)
+			continue
+		}
+		if err := s.handle(ctx, e); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", e.EventName(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Wait blocks until every asynchronous subscriber started so far has returned.
+func (b *Bus) Wait() {
+	b.wg.Wait()
+}
+
+func (b *Bus) runAsync(ctx context.Context, s subscriber, e Event) {
+	defer b.wg.Done()
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("subscriber of %s panicked: %v", e.EventName(), r)
+		}
+	}()
+	if err := s.handle(ctx, e); err != nil {
+		log.Printf("subscriber of %s failed: %v", e.EventName(), err)
+	}
+}
+
+func (b *Bus) add(t reflect.Type, s subscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.byType[t] = append(b.byType[t], s)
+}
+
+func typeOf[E Event]() reflect.Type {
+	return reflect.TypeOf((*E)(nil)).Elem()
+}
+
+func adapt[E Event](handle func(context.Context, E) error) func(context.Context, Event) error {
+	return func(ctx context.Context, e Event) error {
+		return handle(ctx, e.(E))
+	}
+}
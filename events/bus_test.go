@@ -0,0 +1,140 @@
+package events_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"sms/events"
+	"sms/events/eventstest"
+)
+
+func TestBusRoutesByType(t *testing.T) {
+	bus := events.NewBus()
+
+	var added []events.GradeAdded
+	var updated int
+	events.Subscribe(bus, func(_ context.Context, e events.GradeAdded) error {
+		added = append(added, e)
+		return nil
+	})
+	events.Subscribe(bus, func(context.Context, events.GradeUpdated) error {
+		updated++
+		return nil
+	})
+
+	want := events.GradeAdded{StudentID: "s1", SubjectID: "sub1", Grade: 90, Semester: 1}
+	if err := bus.Publish(context.Background(), want); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := bus.Publish(context.Background(), events.UserSignedUp{UserID: "u1"}); err != nil {
+		t.Fatalf("expected no error for an event without subscribers, got %v", err)
+	}
+
+	if len(added) != 1 || added[0] != want {
+		t.Errorf("expected %+v, got %+v", want, added)
+	}
+	if updated != 0 {
+		t.Errorf("expected GradeUpdated subscriber not to run, ran %d times", updated)
+	}
+}
+
+func TestBusSyncOrderAndErrors(t *testing.T) {
+	bus := events.NewBus()
+
+	var order []string
+	events.Subscribe(bus, func(context.Context, events.StudentCreated) error {
+		order = append(order, "first")
+		return errors.New("boom")
+	})
+	events.Subscribe(bus, func(context.Context, events.StudentCreated) error {
+		order = append(order, "second")
+		return nil
+	})
+	bus.SubscribeAll(func(context.Context, events.Event) error {
+		order = append(order, "all")
+		return nil
+	})
+
+	err := bus.Publish(context.Background(), events.StudentCreated{})
+	if err == nil || !strings.Contains(err.Error(), "student.created: boom") {
+		t.Errorf("expected joined subscriber error, got %v", err)
+	}
+	if strings.Join(order, ",") != "all,first,second" {
+		t.Errorf("unexpected subscriber order %v", order)
+	}
+}
+
+func TestBusAsyncSubscribers(t *testing.T) {
+	bus := events.NewBus()
+
+	var mu sync.Mutex
+	var seen []string
+	events.SubscribeAsync(bus, func(_ context.Context, e events.UserSignedUp) error {
+		mu.Lock()
+		defer mu.Unlock()
+		seen = append(seen, e.UserID)
+		return errors.New("logged, not returned")
+	})
+	events.SubscribeAsync(bus, func(context.Context, events.UserSignedUp) error {
+		panic("recovered")
+	})
+
+	if err := bus.Publish(context.Background(), events.UserSignedUp{UserID: "u1"}); err != nil {
+		t.Fatalf("async errors should not be returned, got %v", err)
+	}
+	bus.Wait()
+
+	if len(seen) != 1 || seen[0] != "u1" {
+		t.Errorf("expected async subscriber to see u1, got %v", seen)
+	}
+}
+
+func TestRecorderOnBus(t *testing.T) {
+	bus := events.NewBus()
+	recorder := eventstest.NewRecorder()
+	bus.SubscribeAll(recorder.Publish)
+
+	bus.Publish(context.Background(), events.GradeAdded{StudentID: "s1"})
+	bus.Publish(context.Background(), events.GradeUpdated{StudentID: "s1", OldGrade: 80, Grade: 85})
+
+	eventstest.AssertPublished(t, recorder,
+		events.GradeAdded{StudentID: "s1"},
+		events.GradeUpdated{StudentID: "s1", OldGrade: 80, Grade: 85},
+	)
+	eventstest.AssertNotPublished[events.StudentCreated](t, recorder)
+	if got := eventstest.Of[events.GradeUpdated](recorder); len(got) != 1 || got[0].Grade != 85 {
+		t.Errorf("expected one GradeUpdated, got %+v", got)
+	}
+
+	recorder.Reset()
+	eventstest.AssertPublished(t, recorder)
+}
+
+func TestBusAsyncSubscribersOutliveThePublisher(t *testing.T) {
+	bus := events.NewBus()
+
+	var syncErr, asyncErr error
+	events.Subscribe(bus, func(ctx context.Context, e events.StudentUpdated) error {
+		syncErr = ctx.Err()
+		return nil
+	})
+	events.SubscribeAsync(bus, func(ctx context.Context, e events.StudentUpdated) error {
+		asyncErr = ctx.Err()
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	bus.Publish(ctx, events.StudentUpdated{})
+	bus.Wait()
+
+	if syncErr == nil {
+		t.Errorf("expected the synchronous subscriber to see the publisher's cancellation")
+	}
+	if asyncErr != nil {
+		t.Errorf("expected the asynchronous subscriber to run uncancelled, got %v", asyncErr)
+	}
+}
@@ -0,0 +1,66 @@
+// Package events is an in-process bus for domain events. Services publish an
+// event after the change it describes has been saved, and subscribers react to
+// it without the service knowing about them.
+package events
+
+import (
+	"context"
+	"sms/constants"
+	"sms/models"
+)
+
+// Event is a domain event. Subscribers are registered per concrete event type;
+// EventName identifies the event in logs.
+type Event interface {
+	EventName() string
+}
+
+// Publisher is what services publish through. *Bus implements it, and so does
+// eventstest.Recorder for tests.
+type Publisher interface {
+	Publish(ctx context.Context, e Event) error
+}
+
+// GradeAdded is published when a student first receives a grade for a subject.
+type GradeAdded struct {
+	StudentID string
+	SubjectID string
+	Grade     int
+	Semester  int
+}
+
+func (GradeAdded) EventName() string { return "grade.added" }
+
+// GradeUpdated is published when an existing grade changes.
+type GradeUpdated struct {
+	StudentID string
+	SubjectID string
+	OldGrade  int
+	Grade     int
+	Semester  int
+}
+
+func (GradeUpdated) EventName() string { return "grade.updated" }
+
+type StudentCreated struct {
+	Student models.Students
+}
+
+func (StudentCreated) EventName() string { return "student.created" }
+
+// StudentUpdated carries the student as saved after the update.
+type StudentUpdated struct {
+	Student models.Students
+}
+
+func (StudentUpdated) EventName() string { return "student.updated" }
+
+// UserSignedUp is published when an account is created, through signup or by an admin.
+type UserSignedUp struct {
+	UserID string
+	Name   string
+	Email  string
+	Role   constants.Role
+}
+
+func (UserSignedUp) EventName() string { return "user.signed_up" }
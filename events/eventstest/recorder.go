@@ -0,0 +1,73 @@
+// Package eventstest helps tests assert which domain events were published.
+package eventstest
+
+import (
+	"context"
+	"reflect"
+	"sms/events"
+	"sync"
+	"testing"
+)
+
+// Recorder is an events.Publisher that keeps every event it is given. Pass it to
+// a service in place of the bus, or attach it to a bus with
+// bus.SubscribeAll(recorder.Publish).
+type Recorder struct {
+	mu     sync.Mutex
+	events []events.Event
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+func (r *Recorder) Publish(ctx context.Context, e events.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+	return nil
+}
+
+// Events returns the recorded events in publish order.
+func (r *Recorder) Events() []events.Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]events.Event(nil), r.events...)
+}
+
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = nil
+}
+
+// Of returns the recorded events of type E in publish order.
+func Of[E events.Event](r *Recorder) []E {
+	var matched []E
+	for _, e := range r.Events() {
+		if typed, ok := e.(E); ok {
+			matched = append(matched, typed)
+		}
+	}
+	return matched
+}
+
+// AssertPublished fails the test unless exactly the wanted events were recorded, in order.
+func AssertPublished(t testing.TB, r *Recorder, want ...events.Event) {
+	t.Helper()
+	got := r.Events()
+	if len(got) == 0 && len(want) == 0 {
+		return
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("published events:\n got  %+v\n want %+v", got, want)
+	}
+}
+
+// AssertNotPublished fails the test if any event of type E was recorded.
+func AssertNotPublished[E events.Event](t testing.TB, r *Recorder) {
+	t.Helper()
+	if matched := Of[E](r); len(matched) > 0 {
+		t.Errorf("expected no %T events, got %+v", *new(E), matched)
+	}
+}
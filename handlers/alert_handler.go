@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"net/http"
+	"sms/constants"
+	"sms/middleware"
+	"sms/services"
+	"sms/utils"
+	"strconv"
+)
+
+type ScanAtRiskRequest struct {
+	Semester          int      `json:"semester"`
+	MinAverage        *float64 `json:"min_average,omitempty"`
+	MaxDrop           *float64 `json:"max_drop,omitempty"`
+	PassMark          *int     `json:"pass_mark,omitempty"`
+	MaxFailedSubjects *int     `json:"max_failed_subjects,omitempty"`
+}
+
+type AlertHandler struct {
+	as services.AlertServiceI
+}
+
+func NewAlertHandler(as services.AlertServiceI) *AlertHandler {
+	return &AlertHandler{as: as}
+}
+
+func (ah *AlertHandler) GetAtRisk(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || (role != constants.Faculty && role != constants.Admin) {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only faculty and admin can access")
+		return
+	}
+
+	semester := 0
+	if v := r.URL.Query().Get("semester"); v != "" {
+		semester, err = strconv.Atoi(v)
+		if err != nil || semester <= 0 {
+			utils.CustomResponseSender(w, http.StatusBadRequest, "semester must be a positive number")
+			return
+		}
+	}
+
+	flags, err := ah.as.GetAtRiskFlags(r.Context(), semester)
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusOK, "ok", flags)
+}
+
+func (ah *AlertHandler) ScanAtRisk(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || (role != constants.Faculty && role != constants.Admin) {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only faculty and admin can access")
+		return
+	}
+
+	var req ScanAtRiskRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+
+	criteria := services.DefaultAtRiskCriteria()
+	if req.MinAverage != nil {
+		if *req.MinAverage < 0 {
+			utils.CustomResponseSender(w, http.StatusBadRequest, "min_average can't be negative")
+			return
+		}
+		criteria.MinAverage = *req.MinAverage
+	}
+	if req.MaxDrop != nil {
+		if *req.MaxDrop < 0 {
+			utils.CustomResponseSender(w, http.StatusBadRequest, "max_drop can't be negative")
+			return
+		}
+		criteria.MaxDrop = *req.MaxDrop
+	}
+	if req.PassMark != nil {
+		if *req.PassMark < 0 {
+			utils.CustomResponseSender(w, http.StatusBadRequest, "pass_mark can't be negative")
+			return
+		}
+		criteria.PassMark = *req.PassMark
+	}
+	if req.MaxFailedSubjects != nil {
+		if *req.MaxFailedSubjects < 0 {
+			utils.CustomResponseSender(w, http.StatusBadRequest, "max_failed_subjects can't be negative")
+			return
+		}
+		criteria.MaxFailedSubjects = *req.MaxFailedSubjects
+	}
+
+	flags, err := ah.as.DetectAtRisk(r.Context(), req.Semester, criteria)
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusOK, "scan completed", flags)
+}
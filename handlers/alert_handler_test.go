@@ -0,0 +1,196 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sms/apperrors"
+	"sms/constants"
+	"sms/handlers"
+	"sms/mocks"
+	"sms/models"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestAlertHandler_GetAtRisk(t *testing.T) {
+	tests := []struct {
+		name           string
+		role           constants.Role
+		query          string
+		mockService    func(mockAlertService *mocks.MockAlertServiceI)
+		expectedStatus int
+	}{
+		{
+			name: "faculty lists all flags",
+			role: "faculty",
+			mockService: func(mockAlertService *mocks.MockAlertServiceI) {
+				mockAlertService.EXPECT().GetAtRiskFlags(gomock.Any(), 0).Return([]models.AtRiskFlag{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "admin lists flags of a semester",
+			role:  "admin",
+			query: "?semester=3",
+			mockService: func(mockAlertService *mocks.MockAlertServiceI) {
+				mockAlertService.EXPECT().GetAtRiskFlags(gomock.Any(), 3).Return([]models.AtRiskFlag{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid semester",
+			role:           "faculty",
+			query:          "?semester=x",
+			mockService:    func(mockAlertService *mocks.MockAlertServiceI) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "missing role",
+			role:           "",
+			mockService:    func(mockAlertService *mocks.MockAlertServiceI) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "service error",
+			role: "faculty",
+			mockService: func(mockAlertService *mocks.MockAlertServiceI) {
+				mockAlertService.EXPECT().GetAtRiskFlags(gomock.Any(), 0).Return(nil, errors.New("db error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockAlertService := mocks.NewMockAlertServiceI(ctrl)
+			handler := handlers.NewAlertHandler(mockAlertService)
+
+			req := httptest.NewRequest(http.MethodGet, "/alerts/at-risk"+tt.query, nil)
+			req = req.WithContext(AddUserToContext(req.Context(), tt.role))
+
+			tt.mockService(mockAlertService)
+			rr := httptest.NewRecorder()
+
+			handler.GetAtRisk(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
+
+func TestAlertHandler_ScanAtRisk(t *testing.T) {
+	defaults := models.AtRiskCriteria{
+		MinAverage:        constants.DefaultAtRiskMinAverage,
+		MaxDrop:           constants.DefaultAtRiskMaxDrop,
+		PassMark:          constants.DefaultPassMark,
+		MaxFailedSubjects: constants.DefaultAtRiskMaxFailedSubjects,
+	}
+	tests := []struct {
+		name           string
+		role           constants.Role
+		body           any
+		mockService    func(mockAlertService *mocks.MockAlertServiceI)
+		expectedStatus int
+	}{
+		{
+			name: "scan with default criteria",
+			role: "faculty",
+			body: map[string]any{"semester": 2},
+			mockService: func(mockAlertService *mocks.MockAlertServiceI) {
+				mockAlertService.EXPECT().DetectAtRisk(gomock.Any(), 2, defaults).Return([]models.AtRiskFlag{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "scan with custom criteria",
+			role: "admin",
+			body: map[string]any{"semester": 2, "min_average": 60, "max_drop": 10, "pass_mark": 50, "max_failed_subjects": 1},
+			mockService: func(mockAlertService *mocks.MockAlertServiceI) {
+				mockAlertService.EXPECT().DetectAtRisk(gomock.Any(), 2, models.AtRiskCriteria{MinAverage: 60, MaxDrop: 10, PassMark: 50, MaxFailedSubjects: 1}).Return(nil, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "explicit zeros aren't replaced by the defaults",
+			role: "faculty",
+			body: map[string]any{"semester": 2, "min_average": 0, "max_drop": 0, "pass_mark": 0, "max_failed_subjects": 0},
+			mockService: func(mockAlertService *mocks.MockAlertServiceI) {
+				criteria := models.AtRiskCriteria{}
+				mockAlertService.EXPECT().DetectAtRisk(gomock.Any(), 2, criteria).Return(nil, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "negative min average",
+			role:           "faculty",
+			body:           map[string]any{"semester": 2, "min_average": -1},
+			mockService:    func(mockAlertService *mocks.MockAlertServiceI) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "negative max drop",
+			role:           "faculty",
+			body:           map[string]any{"semester": 2, "max_drop": -5.5},
+			mockService:    func(mockAlertService *mocks.MockAlertServiceI) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "negative max failed subjects",
+			role:           "faculty",
+			body:           map[string]any{"semester": 2, "max_failed_subjects": -1},
+			mockService:    func(mockAlertService *mocks.MockAlertServiceI) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid request body",
+			role:           "faculty",
+			body:           "not json",
+			mockService:    func(mockAlertService *mocks.MockAlertServiceI) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "service error",
+			role: "faculty",
+			body: map[string]any{"semester": 0},
+			mockService: func(mockAlertService *mocks.MockAlertServiceI) {
+				mockAlertService.EXPECT().DetectAtRisk(gomock.Any(), 0, defaults).Return(nil, apperrors.Validation("semester must be positive"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockAlertService := mocks.NewMockAlertServiceI(ctrl)
+			handler := handlers.NewAlertHandler(mockAlertService)
+
+			var reqBody []byte
+			if s, ok := tt.body.(string); ok {
+				reqBody = []byte(s)
+			} else {
+				reqBody, _ = json.Marshal(tt.body)
+			}
+			req := httptest.NewRequest(http.MethodPost, "/alerts/at-risk/scan", bytes.NewReader(reqBody))
+			req = req.WithContext(AddUserToContext(req.Context(), tt.role))
+
+			tt.mockService(mockAlertService)
+			rr := httptest.NewRecorder()
+
+			handler.ScanAtRisk(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"net/http"
+	"sms/constants"
+	"sms/middleware"
+	"sms/models"
+	"sms/services"
+	"sms/utils"
+	"strconv"
+	"time"
+)
+
+type CreateSessionRequest struct {
+	ClassID   string `json:"classID"`
+	SubjectID string `json:"subjectID"`
+	Semester  int    `json:"semester"`
+	Date      string `json:"date"`
+}
+
+type AttendanceMarkRequest struct {
+	StudentID string                     `json:"studentID"`
+	Status    constants.AttendanceStatus `json:"status"`
+}
+
+type MarkAttendanceRequest struct {
+	Marks []AttendanceMarkRequest `json:"marks"`
+}
+
+type AttendanceHandler struct {
+	as        services.AttendanceServiceI
+	guardians services.GuardianServiceI
+}
+
+func NewAttendanceHandler(as services.AttendanceServiceI, guardians services.GuardianServiceI) *AttendanceHandler {
+	return &AttendanceHandler{as: as, guardians: guardians}
+}
+
+func (ah *AttendanceHandler) CreateSession(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || role != constants.Faculty {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only faculty can access")
+		return
+	}
+	userID, _ := middleware.GetUserID(r.Context())
+
+	var req CreateSessionRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	date, err := time.Parse(time.DateOnly, req.Date)
+	if err != nil {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "date must be in YYYY-MM-DD format")
+		return
+	}
+
+	session, err := ah.as.CreateSession(r.Context(), req.ClassID, req.SubjectID, req.Semester, date, userID)
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusCreated, "session created", session)
+}
+
+func (ah *AttendanceHandler) MarkAttendance(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || role != constants.Faculty {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only faculty can access")
+		return
+	}
+	sessionID := r.PathValue("sessionID")
+	if sessionID == "" {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid sessionID")
+		return
+	}
+
+	var req MarkAttendanceRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	marks := make([]models.AttendanceMark, 0, len(req.Marks))
+	for _, m := range req.Marks {
+		marks = append(marks, models.AttendanceMark{SessionID: sessionID, StudentID: m.StudentID, Status: m.Status})
+	}
+
+	if err := ah.as.MarkAttendance(r.Context(), sessionID, marks); err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusOK, "attendance marked")
+}
+
+func (ah *AttendanceHandler) GetStudentAttendance(w http.ResponseWriter, r *http.Request) {
+	studentID := r.PathValue("studentID")
+	if studentID == "" {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid studentID")
+		return
+	}
+	if !authorizeStudentView(w, r, ah.guardians, studentID) {
+		return
+	}
+	query := r.URL.Query()
+	semester, err := strconv.Atoi(query.Get("semester"))
+	if err != nil || semester <= 0 {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "semester must be a positive number")
+		return
+	}
+
+	summary, err := ah.as.GetStudentAttendance(r.Context(), studentID, query.Get("subjectID"), semester)
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusOK, "ok", summary)
+}
+
+func (ah *AttendanceHandler) GetClassAttendance(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || (role != constants.Faculty && role != constants.Admin) {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only faculty and admin can access")
+		return
+	}
+	classID := r.PathValue("classID")
+	if classID == "" {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid classID")
+		return
+	}
+	semester, err := strconv.Atoi(r.PathValue("semester"))
+	if err != nil || semester <= 0 {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "semester must be a positive number")
+		return
+	}
+
+	summaries, err := ah.as.GetClassAttendance(r.Context(), classID, r.URL.Query().Get("subjectID"), semester)
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusOK, "ok", summaries)
+}
@@ -0,0 +1,302 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sms/apperrors"
+	"sms/constants"
+	"sms/handlers"
+	"sms/mocks"
+	"sms/models"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestAttendanceHandler_CreateSession(t *testing.T) {
+	date := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name           string
+		role           constants.Role
+		body           any
+		mockService    func(mockAttendanceService *mocks.MockAttendanceServiceI)
+		expectedStatus int
+	}{
+		{
+			name: "faculty creates session",
+			role: "faculty",
+			body: map[string]any{"classID": "C1", "subjectID": "sub1", "semester": 1, "date": "2025-08-01"},
+			mockService: func(mockAttendanceService *mocks.MockAttendanceServiceI) {
+				mockAttendanceService.EXPECT().CreateSession(gomock.Any(), "C1", "sub1", 1, date, "fac1").Return(&models.AttendanceSession{SessionID: "sess1"}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "admin can't create session",
+			role:           "admin",
+			body:           map[string]any{"classID": "C1", "subjectID": "sub1", "semester": 1, "date": "2025-08-01"},
+			mockService:    func(mockAttendanceService *mocks.MockAttendanceServiceI) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "invalid date",
+			role:           "faculty",
+			body:           map[string]any{"classID": "C1", "subjectID": "sub1", "semester": 1, "date": "01/08/2025"},
+			mockService:    func(mockAttendanceService *mocks.MockAttendanceServiceI) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "service error",
+			role: "faculty",
+			body: map[string]any{"classID": "", "subjectID": "sub1", "semester": 1, "date": "2025-08-01"},
+			mockService: func(mockAttendanceService *mocks.MockAttendanceServiceI) {
+				mockAttendanceService.EXPECT().CreateSession(gomock.Any(), "", "sub1", 1, date, "fac1").Return(nil, apperrors.Validation("classID and subjectID can't be empty"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockAttendanceService := mocks.NewMockAttendanceServiceI(ctrl)
+			handler := handlers.NewAttendanceHandler(mockAttendanceService, mocks.NewMockGuardianServiceI(ctrl))
+
+			reqBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPost, "/attendance/sessions", bytes.NewReader(reqBody))
+			ctx := context.WithValue(AddUserToContext(req.Context(), tt.role), constants.ContextUserIDKey, "fac1")
+			req = req.WithContext(ctx)
+
+			tt.mockService(mockAttendanceService)
+			rr := httptest.NewRecorder()
+
+			handler.CreateSession(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
+
+func TestAttendanceHandler_MarkAttendance(t *testing.T) {
+	tests := []struct {
+		name           string
+		sessionID      string
+		body           any
+		mockService    func(mockAttendanceService *mocks.MockAttendanceServiceI)
+		expectedStatus int
+	}{
+		{
+			name:      "faculty marks attendance",
+			sessionID: "sess1",
+			body:      map[string]any{"marks": []map[string]any{{"studentID": "s1", "status": "present"}}},
+			mockService: func(mockAttendanceService *mocks.MockAttendanceServiceI) {
+				mockAttendanceService.EXPECT().MarkAttendance(gomock.Any(), "sess1", []models.AttendanceMark{{SessionID: "sess1", StudentID: "s1", Status: constants.Present}}).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing sessionID",
+			sessionID:      "",
+			body:           map[string]any{"marks": []map[string]any{}},
+			mockService:    func(mockAttendanceService *mocks.MockAttendanceServiceI) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid request body",
+			sessionID:      "sess1",
+			body:           "marks",
+			mockService:    func(mockAttendanceService *mocks.MockAttendanceServiceI) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "service error",
+			sessionID: "sess1",
+			body:      map[string]any{"marks": []map[string]any{{"studentID": "s1", "status": "asleep"}}},
+			mockService: func(mockAttendanceService *mocks.MockAttendanceServiceI) {
+				mockAttendanceService.EXPECT().MarkAttendance(gomock.Any(), "sess1", gomock.Any()).Return(apperrors.Validation("invalid attendance status"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockAttendanceService := mocks.NewMockAttendanceServiceI(ctrl)
+			handler := handlers.NewAttendanceHandler(mockAttendanceService, mocks.NewMockGuardianServiceI(ctrl))
+
+			var reqBody []byte
+			if s, ok := tt.body.(string); ok {
+				reqBody = []byte(s)
+			} else {
+				reqBody, _ = json.Marshal(tt.body)
+			}
+			req := httptest.NewRequest(http.MethodPost, "/attendance/sessions/"+tt.sessionID+"/marks", bytes.NewReader(reqBody))
+			req = req.WithContext(AddUserToContext(req.Context(), "faculty"))
+			req.SetPathValue("sessionID", tt.sessionID)
+
+			tt.mockService(mockAttendanceService)
+			rr := httptest.NewRecorder()
+
+			handler.MarkAttendance(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
+
+func TestAttendanceHandler_GetStudentAttendance(t *testing.T) {
+	tests := []struct {
+		name           string
+		role           constants.Role
+		query          string
+		mockService    func(mockAttendanceService *mocks.MockAttendanceServiceI)
+		mockGuardians  func(mockGuardianService *mocks.MockGuardianServiceI)
+		expectedStatus int
+	}{
+		{
+			name:  "admin gets attendance",
+			role:  "admin",
+			query: "?semester=1&subjectID=sub1",
+			mockService: func(mockAttendanceService *mocks.MockAttendanceServiceI) {
+				mockAttendanceService.EXPECT().GetStudentAttendance(gomock.Any(), "s1", "sub1", 1).Return(&models.AttendanceSummary{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing semester",
+			role:           "faculty",
+			mockService:    func(mockAttendanceService *mocks.MockAttendanceServiceI) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "missing role",
+			role:           "",
+			query:          "?semester=1",
+			mockService:    func(mockAttendanceService *mocks.MockAttendanceServiceI) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:  "guardian gets linked student's attendance",
+			role:  "guardian",
+			query: "?semester=1",
+			mockService: func(mockAttendanceService *mocks.MockAttendanceServiceI) {
+				mockAttendanceService.EXPECT().GetStudentAttendance(gomock.Any(), "s1", "", 1).Return(&models.AttendanceSummary{}, nil)
+			},
+			mockGuardians: func(mockGuardianService *mocks.MockGuardianServiceI) {
+				mockGuardianService.EXPECT().CanViewStudent(gomock.Any(), "g1", "s1").Return(true, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "guardian of another student",
+			role:        "guardian",
+			query:       "?semester=1",
+			mockService: func(mockAttendanceService *mocks.MockAttendanceServiceI) {},
+			mockGuardians: func(mockGuardianService *mocks.MockGuardianServiceI) {
+				mockGuardianService.EXPECT().CanViewStudent(gomock.Any(), "g1", "s1").Return(false, nil)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "student cannot access",
+			role:           "student",
+			query:          "?semester=1",
+			mockService:    func(mockAttendanceService *mocks.MockAttendanceServiceI) {},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockAttendanceService := mocks.NewMockAttendanceServiceI(ctrl)
+			mockGuardianService := mocks.NewMockGuardianServiceI(ctrl)
+			handler := handlers.NewAttendanceHandler(mockAttendanceService, mockGuardianService)
+
+			req := httptest.NewRequest(http.MethodGet, "/students/s1/attendance"+tt.query, nil)
+			ctx := context.WithValue(AddUserToContext(req.Context(), tt.role), constants.ContextUserIDKey, "g1")
+			req = req.WithContext(ctx)
+			req.SetPathValue("studentID", "s1")
+
+			tt.mockService(mockAttendanceService)
+			if tt.mockGuardians != nil {
+				tt.mockGuardians(mockGuardianService)
+			}
+			rr := httptest.NewRecorder()
+
+			handler.GetStudentAttendance(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
+
+func TestAttendanceHandler_GetClassAttendance(t *testing.T) {
+	tests := []struct {
+		name           string
+		semester       string
+		mockService    func(mockAttendanceService *mocks.MockAttendanceServiceI)
+		expectedStatus int
+	}{
+		{
+			name:     "faculty gets class attendance",
+			semester: "1",
+			mockService: func(mockAttendanceService *mocks.MockAttendanceServiceI) {
+				mockAttendanceService.EXPECT().GetClassAttendance(gomock.Any(), "C1", "", 1).Return([]models.AttendanceSummary{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid semester",
+			semester:       "first",
+			mockService:    func(mockAttendanceService *mocks.MockAttendanceServiceI) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:     "service error",
+			semester: "1",
+			mockService: func(mockAttendanceService *mocks.MockAttendanceServiceI) {
+				mockAttendanceService.EXPECT().GetClassAttendance(gomock.Any(), "C1", "", 1).Return(nil, errors.New("db error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockAttendanceService := mocks.NewMockAttendanceServiceI(ctrl)
+			handler := handlers.NewAttendanceHandler(mockAttendanceService, mocks.NewMockGuardianServiceI(ctrl))
+
+			req := httptest.NewRequest(http.MethodGet, "/classes/C1/semesters/"+tt.semester+"/attendance", nil)
+			req = req.WithContext(AddUserToContext(req.Context(), "faculty"))
+			req.SetPathValue("classID", "C1")
+			req.SetPathValue("semester", tt.semester)
+
+			tt.mockService(mockAttendanceService)
+			rr := httptest.NewRecorder()
+
+			handler.GetClassAttendance(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
@@ -1,8 +1,8 @@
 package handlers
 
 import (
-	"encoding/json"
 	"net/http"
+	"sms/apperrors"
 	"sms/services"
 	"sms/utils"
 )
@@ -37,20 +37,20 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req LoginRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid request body")
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.ErrorResponseSender(w, err)
 		return
 	}
 
 	user, err := h.as.ValidateLogin(r.Context(), req.Email, req.Password)
 	if err != nil {
-		utils.CustomResponseSender(w, http.StatusUnauthorized, err.Error())
+		utils.ErrorResponseSender(w, err)
 		return
 	}
 
-	token, err := services.GenerateJWT(user.UserID, user.Email, user.Role)
+	token, err := h.as.IssueToken(user)
 	if err != nil {
-		utils.CustomResponseSender(w, 409, "Failed to generate token")
+		utils.ErrorResponseSender(w, apperrors.Internal(err))
 		return
 	}
 	utils.CustomResponseSender(w, http.StatusOK, "login successful", token)
@@ -62,8 +62,8 @@ func (h *AuthHandler) Signup(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	var req SignupRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid request body")
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.ErrorResponseSender(w, err)
 		return
 	}
 
@@ -74,13 +74,13 @@ func (h *AuthHandler) Signup(w http.ResponseWriter, r *http.Request) {
 
 	user, err := h.as.Signup(r.Context(), req.Name, req.Email, req.Password)
 	if err != nil {
-		utils.CustomResponseSender(w, http.StatusBadRequest, err.Error())
+		utils.ErrorResponseSender(w, err)
 		return
 	}
 
-	token, err := services.GenerateJWT(user.UserID, user.Email, user.Role)
+	token, err := h.as.IssueToken(user)
 	if err != nil {
-		utils.CustomResponseSender(w, 409, "Failed to generate token")
+		utils.ErrorResponseSender(w, apperrors.Internal(err))
 		return
 	}
 
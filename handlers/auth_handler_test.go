@@ -3,9 +3,9 @@ package handlers_test
 import (
 	"bytes"
 	"encoding/json"
-	"errors"
 	"net/http"
 	"net/http/httptest"
+	"sms/apperrors"
 	"sms/handlers"
 	"sms/mocks"
 	"sms/models"
@@ -36,6 +36,7 @@ func TestAuthHandler_Login(t *testing.T) {
 			mockSetup: func(mock *mocks.MockAuthServiceI) {
 				mock.EXPECT().ValidateLogin(gomock.Any(), "test@example.com", "Password123!").
 					Return(models.User{UserID: "123", Email: "test@example.com", Role: "faculty"}, nil)
+				mock.EXPECT().IssueToken(models.User{UserID: "123", Email: "test@example.com", Role: "faculty"}).Return("token", nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectToken:    true,
@@ -63,7 +64,7 @@ func TestAuthHandler_Login(t *testing.T) {
 			},
 			mockSetup: func(mock *mocks.MockAuthServiceI) {
 				mock.EXPECT().ValidateLogin(gomock.Any(), "test@example.com", "wrongpassword").
-					Return(models.User{}, errors.New("invalid email or password"))
+					Return(models.User{}, apperrors.Unauthorized("invalid email or password"))
 			},
 			expectedStatus: http.StatusUnauthorized,
 		},
@@ -136,6 +137,7 @@ func TestAuthHandler_Signup(t *testing.T) {
 			mockSetup: func(mock *mocks.MockAuthServiceI) {
 				mock.EXPECT().Signup(gomock.Any(), "John Doe", "john@example.com", "Password123!").
 					Return(models.User{UserID: "123", Email: "john@example.com", Role: "faculty"}, nil)
+				mock.EXPECT().IssueToken(models.User{UserID: "123", Email: "john@example.com", Role: "faculty"}).Return("token", nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectToken:    true,
@@ -175,9 +177,9 @@ func TestAuthHandler_Signup(t *testing.T) {
 			},
 			mockSetup: func(mock *mocks.MockAuthServiceI) {
 				mock.EXPECT().Signup(gomock.Any(), "John Doe", "john@example.com", "Password123!").
-					Return(models.User{}, errors.New("email already in use"))
+					Return(models.User{}, apperrors.Conflict("email already in use"))
 			},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusConflict,
 		},
 	}
 
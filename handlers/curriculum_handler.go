@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"net/http"
+	"sms/constants"
+	"sms/middleware"
+	"sms/models"
+	"sms/services"
+	"sms/utils"
+	"strconv"
+)
+
+type CreateProgramRequest struct {
+	Name      string `json:"name"`
+	Semesters int    `json:"semesters"`
+}
+
+type AddProgramSubjectRequest struct {
+	SubjectID     string                `json:"subjectID"`
+	Semester      int                   `json:"semester"`
+	Kind          constants.SubjectKind `json:"kind"`
+	Credits       int                   `json:"credits"`
+	Prerequisites []string              `json:"prerequisites,omitempty"`
+}
+
+type AssignProgramRequest struct {
+	ProgramID string `json:"programID"`
+}
+
+type ElectiveRequest struct {
+	SubjectID string `json:"subjectID"`
+	Semester  int    `json:"semester"`
+}
+
+type CurriculumHandler struct {
+	cs services.CurriculumServiceI
+}
+
+func NewCurriculumHandler(cs services.CurriculumServiceI) *CurriculumHandler {
+	return &CurriculumHandler{cs: cs}
+}
+
+func (ch *CurriculumHandler) CreateProgram(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || role != constants.Admin {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only admin can access")
+		return
+	}
+	var req CreateProgramRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+
+	program, err := ch.cs.CreateProgram(r.Context(), req.Name, req.Semesters)
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusCreated, "program created", program)
+}
+
+func (ch *CurriculumHandler) AddProgramSubject(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || role != constants.Admin {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only admin can access")
+		return
+	}
+	programID := r.PathValue("programID")
+	if programID == "" {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid programID")
+		return
+	}
+	var req AddProgramSubjectRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+
+	err = ch.cs.AddProgramSubject(r.Context(), models.ProgramSubject{
+		ProgramID:     programID,
+		SubjectID:     req.SubjectID,
+		Semester:      req.Semester,
+		Kind:          req.Kind,
+		Credits:       req.Credits,
+		Prerequisites: req.Prerequisites,
+	})
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusCreated, "subject added to program")
+}
+
+func (ch *CurriculumHandler) GetCurriculum(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || (role != constants.Faculty && role != constants.Admin) {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only faculty and admin can access")
+		return
+	}
+	programID := r.PathValue("programID")
+	if programID == "" {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid programID")
+		return
+	}
+	semester, err := strconv.Atoi(r.PathValue("semester"))
+	if err != nil {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "semester must be a number")
+		return
+	}
+
+	subjects, err := ch.cs.GetCurriculum(r.Context(), programID, semester)
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusOK, "ok", subjects)
+}
+
+func (ch *CurriculumHandler) AssignClassProgram(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || role != constants.Admin {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only admin can access")
+		return
+	}
+	classID := r.PathValue("classID")
+	if classID == "" {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid classID")
+		return
+	}
+	var req AssignProgramRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+
+	if err := ch.cs.AssignClassProgram(r.Context(), classID, req.ProgramID); err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusOK, "program assigned")
+}
+
+func (ch *CurriculumHandler) AutoEnrollClass(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || role != constants.Admin {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only admin can access")
+		return
+	}
+	classID := r.PathValue("classID")
+	if classID == "" {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid classID")
+		return
+	}
+	semester, err := strconv.Atoi(r.PathValue("semester"))
+	if err != nil || semester <= 0 {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "semester must be a positive number")
+		return
+	}
+
+	enrollments, err := ch.cs.AutoEnrollClass(r.Context(), classID, semester)
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusCreated, "class enrolled in core subjects", enrollments)
+}
+
+func (ch *CurriculumHandler) EnrollElective(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || role != constants.Admin {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only admin can access")
+		return
+	}
+	studentID := r.PathValue("studentID")
+	if studentID == "" {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid studentID")
+		return
+	}
+	var req ElectiveRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+
+	enrollment, err := ch.cs.EnrollElective(r.Context(), studentID, req.SubjectID, req.Semester)
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusCreated, "elective enrolled", enrollment)
+}
@@ -0,0 +1,142 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sms/apperrors"
+	"sms/constants"
+	"sms/handlers"
+	"sms/mocks"
+	"sms/models"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestCurriculumHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		role           constants.Role
+		method         string
+		pathValues     map[string]string
+		body           any
+		handle         func(h *handlers.CurriculumHandler) http.HandlerFunc
+		mockService    func(mockCurriculumService *mocks.MockCurriculumServiceI)
+		expectedStatus int
+	}{
+		{
+			name:   "admin creates program",
+			role:   "admin",
+			method: http.MethodPost,
+			body:   map[string]any{"name": "B.Tech CSE", "semesters": 8},
+			handle: func(h *handlers.CurriculumHandler) http.HandlerFunc { return h.CreateProgram },
+			mockService: func(mockCurriculumService *mocks.MockCurriculumServiceI) {
+				mockCurriculumService.EXPECT().CreateProgram(gomock.Any(), "B.Tech CSE", 8).Return(&models.Program{}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "faculty can't create program",
+			role:           "faculty",
+			method:         http.MethodPost,
+			body:           map[string]any{"name": "B.Tech CSE", "semesters": 8},
+			handle:         func(h *handlers.CurriculumHandler) http.HandlerFunc { return h.CreateProgram },
+			mockService:    func(mockCurriculumService *mocks.MockCurriculumServiceI) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:       "admin adds program subject",
+			role:       "admin",
+			method:     http.MethodPost,
+			pathValues: map[string]string{"programID": "p1"},
+			body:       map[string]any{"subjectID": "ml", "semester": 5, "kind": "elective", "credits": 3, "prerequisites": []string{"maths"}},
+			handle:     func(h *handlers.CurriculumHandler) http.HandlerFunc { return h.AddProgramSubject },
+			mockService: func(mockCurriculumService *mocks.MockCurriculumServiceI) {
+				mockCurriculumService.EXPECT().AddProgramSubject(gomock.Any(), models.ProgramSubject{ProgramID: "p1", SubjectID: "ml", Semester: 5, Kind: constants.ElectiveSubject, Credits: 3, Prerequisites: []string{"maths"}}).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:       "faculty reads curriculum",
+			role:       "faculty",
+			method:     http.MethodGet,
+			pathValues: map[string]string{"programID": "p1", "semester": "5"},
+			handle:     func(h *handlers.CurriculumHandler) http.HandlerFunc { return h.GetCurriculum },
+			mockService: func(mockCurriculumService *mocks.MockCurriculumServiceI) {
+				mockCurriculumService.EXPECT().GetCurriculum(gomock.Any(), "p1", 5).Return([]models.ProgramSubject{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:       "assign program service error",
+			role:       "admin",
+			method:     http.MethodPut,
+			pathValues: map[string]string{"classID": "C1"},
+			body:       map[string]any{"programID": "missing"},
+			handle:     func(h *handlers.CurriculumHandler) http.HandlerFunc { return h.AssignClassProgram },
+			mockService: func(mockCurriculumService *mocks.MockCurriculumServiceI) {
+				mockCurriculumService.EXPECT().AssignClassProgram(gomock.Any(), "C1", "missing").Return(apperrors.NotFound("program not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:       "auto enroll class",
+			role:       "admin",
+			method:     http.MethodPost,
+			pathValues: map[string]string{"classID": "C1", "semester": "1"},
+			handle:     func(h *handlers.CurriculumHandler) http.HandlerFunc { return h.AutoEnrollClass },
+			mockService: func(mockCurriculumService *mocks.MockCurriculumServiceI) {
+				mockCurriculumService.EXPECT().AutoEnrollClass(gomock.Any(), "C1", 1).Return([]models.Enrollment{}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "auto enroll invalid semester",
+			role:           "admin",
+			method:         http.MethodPost,
+			pathValues:     map[string]string{"classID": "C1", "semester": "zero"},
+			handle:         func(h *handlers.CurriculumHandler) http.HandlerFunc { return h.AutoEnrollClass },
+			mockService:    func(mockCurriculumService *mocks.MockCurriculumServiceI) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "elective with failed prerequisite",
+			role:       "admin",
+			method:     http.MethodPost,
+			pathValues: map[string]string{"studentID": "s1"},
+			body:       map[string]any{"subjectID": "ml", "semester": 5},
+			handle:     func(h *handlers.CurriculumHandler) http.HandlerFunc { return h.EnrollElective },
+			mockService: func(mockCurriculumService *mocks.MockCurriculumServiceI) {
+				mockCurriculumService.EXPECT().EnrollElective(gomock.Any(), "s1", "ml", 5).Return(nil, apperrors.Forbidden("prerequisite maths has not been passed"))
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockCurriculumService := mocks.NewMockCurriculumServiceI(ctrl)
+			handler := handlers.NewCurriculumHandler(mockCurriculumService)
+
+			reqBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(tt.method, "/", bytes.NewReader(reqBody))
+			req = req.WithContext(AddUserToContext(req.Context(), tt.role))
+			for k, v := range tt.pathValues {
+				req.SetPathValue(k, v)
+			}
+
+			tt.mockService(mockCurriculumService)
+			rr := httptest.NewRecorder()
+
+			tt.handle(handler)(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
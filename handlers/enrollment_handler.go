@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"net/http"
+	"sms/constants"
+	"sms/middleware"
+	"sms/services"
+	"sms/utils"
+	"strconv"
+)
+
+type EnrollRequest struct {
+	StudentID string `json:"studentID"`
+	SubjectID string `json:"subjectID"`
+	Semester  int    `json:"semester"`
+}
+
+type BulkEnrollRequest struct {
+	ClassID    string   `json:"classID,omitempty"`
+	StudentIDs []string `json:"studentIDs,omitempty"`
+	SubjectIDs []string `json:"subjectIDs"`
+	Semester   int      `json:"semester"`
+}
+
+type EnrollmentHandler struct {
+	es services.EnrollmentServiceI
+}
+
+func NewEnrollmentHandler(es services.EnrollmentServiceI) *EnrollmentHandler {
+	return &EnrollmentHandler{es: es}
+}
+
+func (eh *EnrollmentHandler) Enroll(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || role != constants.Admin {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only admin can access")
+		return
+	}
+
+	var req EnrollRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+
+	enrollment, err := eh.es.Enroll(r.Context(), req.StudentID, req.SubjectID, req.Semester)
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusCreated, "successfully enrolled", enrollment)
+}
+
+func (eh *EnrollmentHandler) BulkEnroll(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || role != constants.Admin {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only admin can access")
+		return
+	}
+
+	var req BulkEnrollRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	if (req.ClassID == "") == (len(req.StudentIDs) == 0) {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "either classID or studentIDs must be given")
+		return
+	}
+
+	if req.ClassID != "" {
+		enrollments, err := eh.es.EnrollClass(r.Context(), req.ClassID, req.SubjectIDs, req.Semester)
+		if err != nil {
+			utils.ErrorResponseSender(w, err)
+			return
+		}
+		utils.CustomResponseSender(w, http.StatusCreated, "successfully enrolled", enrollments)
+		return
+	}
+
+	enrollments, err := eh.es.BulkEnroll(r.Context(), req.StudentIDs, req.SubjectIDs, req.Semester)
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusCreated, "successfully enrolled", enrollments)
+}
+
+func (eh *EnrollmentHandler) Drop(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || role != constants.Admin {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only admin can access")
+		return
+	}
+	studentID := r.PathValue("studentID")
+	subjectID := r.PathValue("subjectID")
+	if studentID == "" || subjectID == "" {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid studentID or subjectID")
+		return
+	}
+	semester, err := strconv.Atoi(r.URL.Query().Get("semester"))
+	if err != nil || semester <= 0 {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "semester must be a positive number")
+		return
+	}
+
+	if err := eh.es.Drop(r.Context(), studentID, subjectID, semester); err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusOK, "successfully dropped")
+}
+
+func (eh *EnrollmentHandler) GetStudentEnrollments(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || (role != constants.Faculty && role != constants.Admin) {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only faculty and admin can access")
+		return
+	}
+	studentID := r.PathValue("studentID")
+	if studentID == "" {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid studentID")
+		return
+	}
+	semester := 0
+	if v := r.URL.Query().Get("semester"); v != "" {
+		semester, err = strconv.Atoi(v)
+		if err != nil || semester <= 0 {
+			utils.CustomResponseSender(w, http.StatusBadRequest, "semester must be a positive number")
+			return
+		}
+	}
+
+	enrollments, err := eh.es.GetStudentEnrollments(r.Context(), studentID, semester)
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusOK, "ok", enrollments)
+}
@@ -0,0 +1,217 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sms/apperrors"
+	"sms/constants"
+	"sms/handlers"
+	"sms/mocks"
+	"sms/models"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestEnrollmentHandler_Enroll(t *testing.T) {
+	tests := []struct {
+		name           string
+		role           constants.Role
+		body           any
+		mockService    func(mockEnrollmentService *mocks.MockEnrollmentServiceI)
+		expectedStatus int
+	}{
+		{
+			name: "admin enrolls student",
+			role: "admin",
+			body: map[string]any{"studentID": "s1", "subjectID": "sub1", "semester": 1},
+			mockService: func(mockEnrollmentService *mocks.MockEnrollmentServiceI) {
+				mockEnrollmentService.EXPECT().Enroll(gomock.Any(), "s1", "sub1", 1).Return(&models.Enrollment{}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "faculty can't enroll",
+			role:           "faculty",
+			body:           map[string]any{"studentID": "s1", "subjectID": "sub1", "semester": 1},
+			mockService:    func(mockEnrollmentService *mocks.MockEnrollmentServiceI) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "wrongly typed field",
+			role:           "admin",
+			body:           map[string]any{"studentID": 1},
+			mockService:    func(mockEnrollmentService *mocks.MockEnrollmentServiceI) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "service error",
+			role: "admin",
+			body: map[string]any{"studentID": "s1", "subjectID": "sub1", "semester": 1},
+			mockService: func(mockEnrollmentService *mocks.MockEnrollmentServiceI) {
+				mockEnrollmentService.EXPECT().Enroll(gomock.Any(), "s1", "sub1", 1).Return(nil, apperrors.Conflict("already enrolled"))
+			},
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockEnrollmentService := mocks.NewMockEnrollmentServiceI(ctrl)
+			handler := handlers.NewEnrollmentHandler(mockEnrollmentService)
+
+			reqBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPost, "/enrollments", bytes.NewReader(reqBody))
+			req = req.WithContext(AddUserToContext(req.Context(), tt.role))
+
+			tt.mockService(mockEnrollmentService)
+			rr := httptest.NewRecorder()
+
+			handler.Enroll(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
+
+func TestEnrollmentHandler_BulkEnroll(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           any
+		mockService    func(mockEnrollmentService *mocks.MockEnrollmentServiceI)
+		expectedStatus int
+	}{
+		{
+			name: "enroll listed students",
+			body: map[string]any{"studentIDs": []string{"s1", "s2"}, "subjectIDs": []string{"sub1"}, "semester": 1},
+			mockService: func(mockEnrollmentService *mocks.MockEnrollmentServiceI) {
+				mockEnrollmentService.EXPECT().BulkEnroll(gomock.Any(), []string{"s1", "s2"}, []string{"sub1"}, 1).Return([]models.Enrollment{}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "enroll a whole class",
+			body: map[string]any{"classID": "C1", "subjectIDs": []string{"sub1"}, "semester": 1},
+			mockService: func(mockEnrollmentService *mocks.MockEnrollmentServiceI) {
+				mockEnrollmentService.EXPECT().EnrollClass(gomock.Any(), "C1", []string{"sub1"}, 1).Return([]models.Enrollment{}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "both classID and studentIDs",
+			body:           map[string]any{"classID": "C1", "studentIDs": []string{"s1"}, "subjectIDs": []string{"sub1"}, "semester": 1},
+			mockService:    func(mockEnrollmentService *mocks.MockEnrollmentServiceI) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "service error",
+			body: map[string]any{"classID": "C1", "subjectIDs": []string{"sub1"}, "semester": 1},
+			mockService: func(mockEnrollmentService *mocks.MockEnrollmentServiceI) {
+				mockEnrollmentService.EXPECT().EnrollClass(gomock.Any(), "C1", []string{"sub1"}, 1).Return(nil, apperrors.Validation("class has no students"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockEnrollmentService := mocks.NewMockEnrollmentServiceI(ctrl)
+			handler := handlers.NewEnrollmentHandler(mockEnrollmentService)
+
+			reqBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPost, "/enrollments/bulk", bytes.NewReader(reqBody))
+			req = req.WithContext(AddUserToContext(req.Context(), "admin"))
+
+			tt.mockService(mockEnrollmentService)
+			rr := httptest.NewRecorder()
+
+			handler.BulkEnroll(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
+
+func TestEnrollmentHandler_Drop(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		mockService    func(mockEnrollmentService *mocks.MockEnrollmentServiceI)
+		expectedStatus int
+	}{
+		{
+			name:  "admin drops subject",
+			query: "?semester=1",
+			mockService: func(mockEnrollmentService *mocks.MockEnrollmentServiceI) {
+				mockEnrollmentService.EXPECT().Drop(gomock.Any(), "s1", "sub1", 1).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing semester",
+			mockService:    func(mockEnrollmentService *mocks.MockEnrollmentServiceI) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "service error",
+			query: "?semester=1",
+			mockService: func(mockEnrollmentService *mocks.MockEnrollmentServiceI) {
+				mockEnrollmentService.EXPECT().Drop(gomock.Any(), "s1", "sub1", 1).Return(apperrors.NotFound("not enrolled"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockEnrollmentService := mocks.NewMockEnrollmentServiceI(ctrl)
+			handler := handlers.NewEnrollmentHandler(mockEnrollmentService)
+
+			req := httptest.NewRequest(http.MethodDelete, "/students/s1/enrollments/sub1"+tt.query, nil)
+			req = req.WithContext(AddUserToContext(req.Context(), "admin"))
+			req.SetPathValue("studentID", "s1")
+			req.SetPathValue("subjectID", "sub1")
+
+			tt.mockService(mockEnrollmentService)
+			rr := httptest.NewRecorder()
+
+			handler.Drop(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
+
+func TestEnrollmentHandler_GetStudentEnrollments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockEnrollmentService := mocks.NewMockEnrollmentServiceI(ctrl)
+	handler := handlers.NewEnrollmentHandler(mockEnrollmentService)
+
+	mockEnrollmentService.EXPECT().GetStudentEnrollments(gomock.Any(), "s1", 2).Return([]models.Enrollment{}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/students/s1/enrollments?semester=2", nil)
+	req = req.WithContext(AddUserToContext(req.Context(), "faculty"))
+	req.SetPathValue("studentID", "s1")
+	rr := httptest.NewRecorder()
+
+	handler.GetStudentEnrollments(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+}
@@ -1,26 +1,51 @@
 package handlers
 
 import (
-	"encoding/json"
 	"net/http"
 	"sms/constants"
 	"sms/middleware"
+	"sms/models"
 	"sms/services"
 	"sms/utils"
 	"strconv"
 )
 
 type AddGradeRequest struct {
-	StudentID string `json:"studentID"`
-	SubjectID string `json:"subjectID"`
-	Semester  int    `json:"semester"`
-	Grade     int    `json:"grade"`
+	StudentID string `json:"studentID" validate:"required"`
+	SubjectID string `json:"subjectID" validate:"required"`
+	Semester  int    `json:"semester" validate:"required,min=1"`
+	Grade     int    `json:"grade" validate:"min=0,max=100"`
+}
+
+type ImportGradesRequest struct {
+	Grades []AddGradeRequest `json:"grades" validate:"required"`
 }
 
 type UpdateGrade struct {
-	StudentID string `json:"studentID"`
-	SubjectID string `json:"subjectID"`
-	NewGrade  int    `json:"new_grade"`
+	StudentID string `json:"studentID" validate:"required"`
+	SubjectID string `json:"subjectID" validate:"required"`
+	NewGrade  int    `json:"new_grade" validate:"min=0,max=100"`
+}
+
+type CreateAssessmentRequest struct {
+	Name     string                   `json:"name"`
+	Kind     constants.AssessmentKind `json:"kind"`
+	Weight   float64                  `json:"weight"`
+	MaxMarks int                      `json:"maxMarks"`
+}
+
+type UpdateAssessmentRequest struct {
+	Weight   float64 `json:"weight"`
+	MaxMarks int     `json:"maxMarks"`
+}
+
+type AssessmentScoreRequest struct {
+	StudentID string  `json:"studentID"`
+	Marks     float64 `json:"marks"`
+}
+
+type RecordScoresRequest struct {
+	Scores []AssessmentScoreRequest `json:"scores"`
 }
 
 type GradeHandler struct {
@@ -57,9 +82,9 @@ func (gh *GradeHandler) GetAverageOfClass(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	data, err := gh.gs.GetAverageOfClass(classID, semester)
+	data, err := gh.gs.GetAverageOfClass(r.Context(), classID, semester)
 	if err != nil {
-		utils.CustomResponseSender(w, http.StatusBadRequest, err.Error())
+		utils.ErrorResponseSender(w, err)
 		return
 	}
 	utils.CustomResponseSender(w, http.StatusOK, "ok", data)
@@ -102,14 +127,125 @@ func (gh *GradeHandler) GetToppers(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	data, err := gh.gs.GetToppers(classID, semester, limit)
+	data, err := gh.gs.GetToppers(r.Context(), classID, semester, limit)
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusOK, "ok", data)
+}
+
+func (gh *GradeHandler) GetStatisticsOfClass(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || role != constants.Faculty {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only faculty can access")
+		return
+	}
+	classID := r.PathValue("classID")
+	if classID == "" {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid classID")
+		return
+	}
+	semester, err := strconv.Atoi(r.PathValue("semester"))
+	if err != nil {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "semester must be a number")
+		return
+	}
+	if semester <= 0 {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "semester must be positive")
+		return
+	}
+
+	query := r.URL.Query()
+	bucketWidth := constants.DefaultHistogramBucketWidth
+	if v := query.Get("bucket_width"); v != "" {
+		bucketWidth, err = strconv.Atoi(v)
+		if err != nil || bucketWidth <= 0 {
+			utils.CustomResponseSender(w, http.StatusBadRequest, "bucket_width must be a positive number")
+			return
+		}
+	}
+	passMark := constants.DefaultPassMark
+	if v := query.Get("pass_mark"); v != "" {
+		passMark, err = strconv.Atoi(v)
+		if err != nil || passMark < 0 {
+			utils.CustomResponseSender(w, http.StatusBadRequest, "pass_mark must be a non-negative number")
+			return
+		}
+	}
+
+	data, err := gh.gs.GetClassStatistics(r.Context(), classID, semester, query.Get("subjectID"), bucketWidth, passMark)
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusOK, "ok", data)
+}
+
+func (gh *GradeHandler) GetRankList(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || role != constants.Faculty {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only faculty can access")
+		return
+	}
+	classID := r.PathValue("classID")
+	if classID == "" {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid classID")
+		return
+	}
+	semester, err := strconv.Atoi(r.PathValue("semester"))
+	if err != nil || semester <= 0 {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "semester must be a positive number")
+		return
+	}
+
+	query := r.URL.Query()
+	method := rankingMethod(query.Get("method"))
+
+	data, err := gh.gs.GetRankList(r.Context(), classID, semester, query.Get("subjectID"), method)
 	if err != nil {
-		utils.CustomResponseSender(w, http.StatusBadRequest, err.Error())
+		utils.ErrorResponseSender(w, err)
 		return
 	}
 	utils.CustomResponseSender(w, http.StatusOK, "ok", data)
 }
 
+func (gh *GradeHandler) GetStudentRank(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || role != constants.Faculty {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only faculty can access")
+		return
+	}
+	classID := r.PathValue("classID")
+	studentID := r.PathValue("studentID")
+	if classID == "" || studentID == "" {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid classID or studentID")
+		return
+	}
+	semester, err := strconv.Atoi(r.PathValue("semester"))
+	if err != nil || semester <= 0 {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "semester must be a positive number")
+		return
+	}
+
+	query := r.URL.Query()
+	method := rankingMethod(query.Get("method"))
+
+	data, err := gh.gs.GetStudentRank(r.Context(), classID, semester, query.Get("subjectID"), studentID, method)
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusOK, "ok", data)
+}
+
+func rankingMethod(method string) constants.RankingMethod {
+	if method == "" {
+		return constants.CompetitionRanking
+	}
+	return constants.RankingMethod(method)
+}
+
 func (gh *GradeHandler) AddGrade(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		utils.CustomResponseSender(w, http.StatusMethodNotAllowed, "Method not allowed")
@@ -121,18 +257,41 @@ func (gh *GradeHandler) AddGrade(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	var req AddGradeRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid request body")
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.ErrorResponseSender(w, err)
 		return
 	}
-	err = gh.gs.AddGrades(req.StudentID, req.SubjectID, req.Grade, req.Semester)
+	err = gh.gs.AddGrades(r.Context(), req.StudentID, req.SubjectID, req.Grade, req.Semester)
 	if err != nil {
-		utils.CustomResponseSender(w, http.StatusBadRequest, err.Error())
+		utils.ErrorResponseSender(w, err)
 		return
 	}
 	utils.CustomResponseSender(w, http.StatusCreated, "grade successfully added")
 }
 
+// ImportGrades adds a batch of grades; either all of them are saved or none is.
+func (gh *GradeHandler) ImportGrades(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || role != constants.Faculty {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only faculty can access")
+		return
+	}
+	var req ImportGradesRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	grades := make([]models.Grade, len(req.Grades))
+	for i, g := range req.Grades {
+		grades[i] = models.Grade{StudentID: g.StudentID, SubjectID: g.SubjectID, Grade: g.Grade, Semester: g.Semester}
+	}
+	if err := gh.gs.ImportGrades(r.Context(), grades); err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusCreated, "grades successfully imported", len(grades))
+}
+
 func (gh *GradeHandler) UpdateGrade(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPatch {
 		utils.CustomResponseSender(w, http.StatusMethodNotAllowed, "Method not allowed")
@@ -144,14 +303,121 @@ func (gh *GradeHandler) UpdateGrade(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	var req UpdateGrade
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid request body")
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.ErrorResponseSender(w, err)
 		return
 	}
-	err = gh.gs.UpdateGrade(req.StudentID, req.SubjectID, req.NewGrade)
+	err = gh.gs.UpdateGrade(r.Context(), req.StudentID, req.SubjectID, req.NewGrade)
 	if err != nil {
-		utils.CustomResponseSender(w, http.StatusBadRequest, err.Error())
+		utils.ErrorResponseSender(w, err)
 		return
 	}
 	utils.CustomResponseSender(w, http.StatusOK, "grade updated added")
 }
+
+func (gh *GradeHandler) CreateAssessment(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || role != constants.Faculty {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only faculty can access")
+		return
+	}
+	subjectID := r.PathValue("subjectID")
+	semester, err := strconv.Atoi(r.PathValue("semester"))
+	if subjectID == "" || err != nil || semester <= 0 {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid subjectID or semester")
+		return
+	}
+	var req CreateAssessmentRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+
+	assessment, err := gh.gs.CreateAssessment(r.Context(), models.Assessment{
+		SubjectID: subjectID,
+		Semester:  semester,
+		Name:      req.Name,
+		Kind:      req.Kind,
+		Weight:    req.Weight,
+		MaxMarks:  req.MaxMarks,
+	})
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusCreated, "assessment created", assessment)
+}
+
+func (gh *GradeHandler) GetAssessments(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || (role != constants.Faculty && role != constants.Admin) {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only faculty and admin can access")
+		return
+	}
+	subjectID := r.PathValue("subjectID")
+	semester, err := strconv.Atoi(r.PathValue("semester"))
+	if subjectID == "" || err != nil || semester <= 0 {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid subjectID or semester")
+		return
+	}
+
+	assessments, err := gh.gs.GetAssessments(r.Context(), subjectID, semester)
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusOK, "ok", assessments)
+}
+
+func (gh *GradeHandler) UpdateAssessment(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || role != constants.Faculty {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only faculty can access")
+		return
+	}
+	assessmentID := r.PathValue("assessmentID")
+	if assessmentID == "" {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid assessmentID")
+		return
+	}
+	var req UpdateAssessmentRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+
+	assessment, err := gh.gs.UpdateAssessment(r.Context(), assessmentID, req.Weight, req.MaxMarks)
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusOK, "assessment updated", assessment)
+}
+
+func (gh *GradeHandler) RecordScores(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || role != constants.Faculty {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only faculty can access")
+		return
+	}
+	assessmentID := r.PathValue("assessmentID")
+	if assessmentID == "" {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid assessmentID")
+		return
+	}
+	var req RecordScoresRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+
+	scores := make([]models.AssessmentScore, len(req.Scores))
+	for i, s := range req.Scores {
+		scores[i] = models.AssessmentScore{StudentID: s.StudentID, Marks: s.Marks}
+	}
+	if err := gh.gs.RecordScores(r.Context(), assessmentID, scores); err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusOK, "scores recorded")
+}
@@ -8,9 +8,11 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"sms/apperrors"
 	"sms/constants"
 	"sms/handlers"
 	"sms/mocks"
+	"sms/models"
 	gradeRepository "sms/repository/gradesRepository"
 	"testing"
 
@@ -43,7 +45,7 @@ func TestGradeHandler_AddGrade(t *testing.T) {
 			},
 			role: "faculty",
 			mockService: func() {
-				mockGradeService.EXPECT().AddGrades("1", "sub1", 95, 1).Return(nil)
+				mockGradeService.EXPECT().AddGrades(gomock.Any(), "1", "sub1", 95, 1).Return(nil)
 			},
 			expectedStatus: http.StatusCreated,
 		},
@@ -71,9 +73,9 @@ func TestGradeHandler_AddGrade(t *testing.T) {
 			},
 			role: "faculty",
 			mockService: func() {
-				mockGradeService.EXPECT().AddGrades("1", "sub1", 95, 1).Return(errors.New("grade already exists"))
+				mockGradeService.EXPECT().AddGrades(gomock.Any(), "1", "sub1", 95, 1).Return(apperrors.Conflict("grade already exists"))
 			},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusConflict,
 		}, {
 			name:   "wrong method",
 			method: http.MethodGet,
@@ -88,7 +90,7 @@ func TestGradeHandler_AddGrade(t *testing.T) {
 			expectedStatus: http.StatusMethodNotAllowed,
 		},
 		{
-			name:   "invalid request body",
+			name:   "wrongly typed field",
 			method: http.MethodPost,
 			body: map[any]any{
 				"studentID": 1789,
@@ -96,7 +98,34 @@ func TestGradeHandler_AddGrade(t *testing.T) {
 			},
 			role:           "faculty",
 			mockService:    func() {},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "grade out of range",
+			method: http.MethodPost,
+			body: map[string]any{
+				"studentID": "",
+				"subjectID": "sub1",
+				"semester":  0,
+				"grade":     10000,
+			},
+			role:           "faculty",
+			mockService:    func() {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "unknown field",
+			method: http.MethodPost,
+			body: map[string]any{
+				"studentID": "1",
+				"subjectID": "sub1",
+				"semester":  1,
+				"grade":     95,
+				"remarks":   "good",
+			},
+			role:           "faculty",
+			mockService:    func() {},
+			expectedStatus: http.StatusUnprocessableEntity,
 		},
 	}
 
@@ -124,6 +153,82 @@ func TestGradeHandler_AddGrade(t *testing.T) {
 	}
 
 }
+func TestGradeHandler_ImportGrades(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGradeService := mocks.NewMockGradeServiceI(ctrl)
+	handler := handlers.NewGradeHandler(mockGradeService)
+
+	grades := []map[string]any{
+		{"studentID": "1", "subjectID": "sub1", "semester": 1, "grade": 95},
+		{"studentID": "2", "subjectID": "sub1", "semester": 1, "grade": 80},
+	}
+	tests := []struct {
+		name           string
+		body           any
+		role           constants.Role
+		mockService    func()
+		expectedStatus int
+	}{
+		{
+			name: "faculty imports grades",
+			body: map[string]any{"grades": grades},
+			role: "faculty",
+			mockService: func() {
+				mockGradeService.EXPECT().ImportGrades(gomock.Any(), []models.Grade{
+					{StudentID: "1", SubjectID: "sub1", Grade: 95, Semester: 1},
+					{StudentID: "2", SubjectID: "sub1", Grade: 80, Semester: 1},
+				}).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "admin can't import grades",
+			body:           map[string]any{"grades": grades},
+			role:           "admin",
+			mockService:    func() {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "service error",
+			body: map[string]any{"grades": grades},
+			role: "faculty",
+			mockService: func() {
+				mockGradeService.EXPECT().ImportGrades(gomock.Any(), gomock.Len(2)).Return(apperrors.Conflict("grade of student 2 in sub1: UNIQUE constraint failed"))
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "wrongly typed field",
+			body:           `{"grades": {}}`,
+			role:           "faculty",
+			mockService:    func() {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reqBody []byte
+			if s, ok := tt.body.(string); ok {
+				reqBody = []byte(s)
+			} else {
+				reqBody, _ = json.Marshal(tt.body)
+			}
+			req := httptest.NewRequest(http.MethodPost, "/grades/import", bytes.NewReader(reqBody))
+			req = req.WithContext(AddUserToContext(req.Context(), tt.role))
+
+			rr := httptest.NewRecorder()
+			tt.mockService()
+			handler.ImportGrades(rr, req)
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
+
 func AddUserToContext(ctx context.Context, role constants.Role) context.Context {
 	ctx = context.WithValue(ctx, constants.ContextUserRoleKey, role)
 	return ctx
@@ -154,7 +259,7 @@ func TestGradeHandler_UpdateGrade(t *testing.T) {
 				"new_grade": 95,
 			},
 			mockSetup: func() {
-				mockGradeService.EXPECT().UpdateGrade("1", "sub1", 95).Return(nil)
+				mockGradeService.EXPECT().UpdateGrade(gomock.Any(), "1", "sub1", 95).Return(nil)
 			},
 			expectedStatus: http.StatusOK,
 			role:           "faculty",
@@ -184,7 +289,7 @@ func TestGradeHandler_UpdateGrade(t *testing.T) {
 			role:           "admin",
 		},
 		{
-			name:   "invalid request body",
+			name:   "wrongly typed field",
 			method: http.MethodPatch,
 			body: map[string]any{
 				"studentID": 1,
@@ -192,7 +297,7 @@ func TestGradeHandler_UpdateGrade(t *testing.T) {
 				"new_grade": "invalid",
 			},
 			mockSetup:      func() {},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusUnprocessableEntity,
 			role:           "faculty",
 		},
 		{
@@ -204,9 +309,9 @@ func TestGradeHandler_UpdateGrade(t *testing.T) {
 				"new_grade": 70,
 			},
 			mockSetup: func() {
-				mockGradeService.EXPECT().UpdateGrade("invalid", "sub1", 70).Return(errors.New("invalid studentID"))
+				mockGradeService.EXPECT().UpdateGrade(gomock.Any(), "invalid", "sub1", 70).Return(apperrors.NotFound("grade not found"))
 			},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusNotFound,
 			role:           "faculty",
 		},
 	}
@@ -257,7 +362,7 @@ func TestHandler_GetAverageOfClass(t *testing.T) {
 			classID:  "1",
 			semester: "1",
 			mockSetup: func() {
-				mockGradeService.EXPECT().GetAverageOfClass("1", 1).Return(70.0, nil).Times(1)
+				mockGradeService.EXPECT().GetAverageOfClass(gomock.Any(), "1", 1).Return(70.0, nil).Times(1)
 			},
 			expectedStatus: http.StatusOK,
 			role:           "faculty",
@@ -305,9 +410,9 @@ func TestHandler_GetAverageOfClass(t *testing.T) {
 			classID:  "1",
 			semester: "1",
 			mockSetup: func() {
-				mockGradeService.EXPECT().GetAverageOfClass("1", 1).Return(0.0, errors.New("service error")).Times(1)
+				mockGradeService.EXPECT().GetAverageOfClass(gomock.Any(), "1", 1).Return(0.0, errors.New("service error")).Times(1)
 			},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusInternalServerError,
 			role:           "faculty",
 		},
 		{
@@ -368,7 +473,7 @@ func TestHandler_GetToppers(t *testing.T) {
 			semester: "1",
 			topLimit: "3",
 			mockSetup: func() {
-				mockGradeService.EXPECT().GetToppers("1", 1, 3).Return([]gradeRepository.StudentAverage{}, nil).Times(1)
+				mockGradeService.EXPECT().GetToppers(gomock.Any(), "1", 1, 3).Return([]gradeRepository.StudentAverage{}, nil).Times(1)
 			},
 			expectedStatus: http.StatusOK,
 			role:           "faculty",
@@ -420,9 +525,9 @@ func TestHandler_GetToppers(t *testing.T) {
 			semester: "1",
 			topLimit: "3",
 			mockSetup: func() {
-				mockGradeService.EXPECT().GetToppers("1", 1, 3).Return(nil, errors.New("service error")).Times(1)
+				mockGradeService.EXPECT().GetToppers(gomock.Any(), "1", 1, 3).Return(nil, errors.New("service error")).Times(1)
 			},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusInternalServerError,
 			role:           "faculty",
 		},
 		{
@@ -488,3 +593,340 @@ func TestHandler_GetToppers(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_GetStatisticsOfClass(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGradeService := mocks.NewMockGradeServiceI(ctrl)
+
+	handler := handlers.NewGradeHandler(mockGradeService)
+	tests := []struct {
+		name           string
+		classID        string
+		semester       string
+		query          string
+		mockSetup      func()
+		expectedStatus int
+		role           constants.Role
+	}{
+		{
+			name:     "successful retrieval with defaults",
+			classID:  "1",
+			semester: "1",
+			mockSetup: func() {
+				mockGradeService.EXPECT().GetClassStatistics(gomock.Any(), "1", 1, "", constants.DefaultHistogramBucketWidth, constants.DefaultPassMark).Return(&models.GradeStatistics{}, nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			role:           "faculty",
+		},
+		{
+			name:     "successful retrieval for a subject",
+			classID:  "1",
+			semester: "2",
+			query:    "subjectID=sub1&bucket_width=5&pass_mark=50",
+			mockSetup: func() {
+				mockGradeService.EXPECT().GetClassStatistics(gomock.Any(), "1", 2, "sub1", 5, 50).Return(&models.GradeStatistics{}, nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			role:           "faculty",
+		},
+		{
+			name:           "invalid role",
+			classID:        "1",
+			semester:       "1",
+			mockSetup:      func() {},
+			expectedStatus: http.StatusForbidden,
+			role:           "admin",
+		},
+		{
+			name:           "invalid semester",
+			classID:        "1",
+			semester:       "abc",
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+			role:           "faculty",
+		},
+		{
+			name:           "invalid bucket width",
+			classID:        "1",
+			semester:       "1",
+			query:          "bucket_width=0",
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+			role:           "faculty",
+		},
+		{
+			name:           "invalid pass mark",
+			classID:        "1",
+			semester:       "1",
+			query:          "pass_mark=-1",
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+			role:           "faculty",
+		},
+		{
+			name:     "service error",
+			classID:  "1",
+			semester: "1",
+			mockSetup: func() {
+				mockGradeService.EXPECT().GetClassStatistics(gomock.Any(), "1", 1, "", constants.DefaultHistogramBucketWidth, constants.DefaultPassMark).Return(nil, errors.New("service error")).Times(1)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			role:           "faculty",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/classes/%s/semesters/%s/statistics?%s", tt.classID, tt.semester, tt.query), nil)
+			req.SetPathValue("classID", tt.classID)
+			req.SetPathValue("semester", tt.semester)
+
+			req = req.WithContext(AddUserToContext(req.Context(), tt.role))
+			tt.mockSetup()
+
+			rr := httptest.NewRecorder()
+
+			handler.GetStatisticsOfClass(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("Test failed: %s. Expected status %d, got %d. Response: %s", tt.name, tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_GetRankList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGradeService := mocks.NewMockGradeServiceI(ctrl)
+
+	handler := handlers.NewGradeHandler(mockGradeService)
+	tests := []struct {
+		name           string
+		semester       string
+		query          string
+		mockSetup      func()
+		expectedStatus int
+		role           constants.Role
+	}{
+		{
+			name:     "defaults to competition ranking",
+			semester: "1",
+			mockSetup: func() {
+				mockGradeService.EXPECT().GetRankList(gomock.Any(), "1", 1, "", constants.CompetitionRanking).Return([]models.RankEntry{}, nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			role:           "faculty",
+		},
+		{
+			name:     "dense ranking within a subject",
+			semester: "1",
+			query:    "method=dense&subjectID=sub1",
+			mockSetup: func() {
+				mockGradeService.EXPECT().GetRankList(gomock.Any(), "1", 1, "sub1", constants.DenseRanking).Return([]models.RankEntry{}, nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			role:           "faculty",
+		},
+		{
+			name:     "service error",
+			semester: "1",
+			query:    "method=unknown",
+			mockSetup: func() {
+				mockGradeService.EXPECT().GetRankList(gomock.Any(), "1", 1, "", constants.RankingMethod("unknown")).Return(nil, apperrors.Validation("unknown ranking method")).Times(1)
+			},
+			expectedStatus: http.StatusBadRequest,
+			role:           "faculty",
+		},
+		{
+			name:           "invalid semester",
+			semester:       "0",
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+			role:           "faculty",
+		},
+		{
+			name:           "invalid role",
+			semester:       "1",
+			mockSetup:      func() {},
+			expectedStatus: http.StatusForbidden,
+			role:           "admin",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/classes/1/semesters/%s/ranks?%s", tt.semester, tt.query), nil)
+			req.SetPathValue("classID", "1")
+			req.SetPathValue("semester", tt.semester)
+
+			req = req.WithContext(AddUserToContext(req.Context(), tt.role))
+			tt.mockSetup()
+
+			rr := httptest.NewRecorder()
+
+			handler.GetRankList(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("Test failed: %s. Expected status %d, got %d. Response: %s", tt.name, tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_GetStudentRank(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGradeService := mocks.NewMockGradeServiceI(ctrl)
+
+	handler := handlers.NewGradeHandler(mockGradeService)
+	tests := []struct {
+		name           string
+		studentID      string
+		mockSetup      func()
+		expectedStatus int
+	}{
+		{
+			name:      "successful retrieval",
+			studentID: "s1",
+			mockSetup: func() {
+				mockGradeService.EXPECT().GetStudentRank(gomock.Any(), "1", 1, "", "s1", constants.CompetitionRanking).Return(&models.RankEntry{StudentID: "s1", Rank: 1}, nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:      "student not ranked",
+			studentID: "s2",
+			mockSetup: func() {
+				mockGradeService.EXPECT().GetStudentRank(gomock.Any(), "1", 1, "", "s2", constants.CompetitionRanking).Return(nil, apperrors.NotFound("student has no grades")).Times(1)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "missing studentID",
+			studentID:      "",
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/classes/1/semesters/1/ranks/"+tt.studentID, nil)
+			req.SetPathValue("classID", "1")
+			req.SetPathValue("semester", "1")
+			req.SetPathValue("studentID", tt.studentID)
+
+			req = req.WithContext(AddUserToContext(req.Context(), "faculty"))
+			tt.mockSetup()
+
+			rr := httptest.NewRecorder()
+
+			handler.GetStudentRank(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("Test failed: %s. Expected status %d, got %d. Response: %s", tt.name, tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestGradeHandler_Assessments(t *testing.T) {
+	tests := []struct {
+		name           string
+		role           constants.Role
+		pathValues     map[string]string
+		body           any
+		handle         func(h *handlers.GradeHandler) http.HandlerFunc
+		mockService    func(mockGradeService *mocks.MockGradeServiceI)
+		expectedStatus int
+	}{
+		{
+			name:       "faculty creates assessment",
+			role:       "faculty",
+			pathValues: map[string]string{"subjectID": "sub1", "semester": "1"},
+			body:       map[string]any{"name": "Midterm", "kind": "midterm", "weight": 30, "maxMarks": 50},
+			handle:     func(h *handlers.GradeHandler) http.HandlerFunc { return h.CreateAssessment },
+			mockService: func(mockGradeService *mocks.MockGradeServiceI) {
+				mockGradeService.EXPECT().CreateAssessment(gomock.Any(), models.Assessment{SubjectID: "sub1", Semester: 1, Name: "Midterm", Kind: constants.Midterm, Weight: 30, MaxMarks: 50}).
+					Return(&models.Assessment{AssessmentID: "a1"}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "admin can't create assessment",
+			role:           "admin",
+			pathValues:     map[string]string{"subjectID": "sub1", "semester": "1"},
+			body:           map[string]any{},
+			handle:         func(h *handlers.GradeHandler) http.HandlerFunc { return h.CreateAssessment },
+			mockService:    func(mockGradeService *mocks.MockGradeServiceI) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "invalid semester",
+			role:           "faculty",
+			pathValues:     map[string]string{"subjectID": "sub1", "semester": "first"},
+			handle:         func(h *handlers.GradeHandler) http.HandlerFunc { return h.GetAssessments },
+			mockService:    func(mockGradeService *mocks.MockGradeServiceI) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "admin lists assessments",
+			role:       "admin",
+			pathValues: map[string]string{"subjectID": "sub1", "semester": "1"},
+			handle:     func(h *handlers.GradeHandler) http.HandlerFunc { return h.GetAssessments },
+			mockService: func(mockGradeService *mocks.MockGradeServiceI) {
+				mockGradeService.EXPECT().GetAssessments(gomock.Any(), "sub1", 1).Return([]models.Assessment{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:       "weights above 100",
+			role:       "faculty",
+			pathValues: map[string]string{"assessmentID": "a1"},
+			body:       map[string]any{"weight": 90, "maxMarks": 50},
+			handle:     func(h *handlers.GradeHandler) http.HandlerFunc { return h.UpdateAssessment },
+			mockService: func(mockGradeService *mocks.MockGradeServiceI) {
+				mockGradeService.EXPECT().UpdateAssessment(gomock.Any(), "a1", 90.0, 50).Return(nil, apperrors.Conflict("weights of the subject's assessments would add up to 120.00, more than 100"))
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:       "record scores",
+			role:       "faculty",
+			pathValues: map[string]string{"assessmentID": "a1"},
+			body:       map[string]any{"scores": []map[string]any{{"studentID": "s1", "marks": 42.5}}},
+			handle:     func(h *handlers.GradeHandler) http.HandlerFunc { return h.RecordScores },
+			mockService: func(mockGradeService *mocks.MockGradeServiceI) {
+				mockGradeService.EXPECT().RecordScores(gomock.Any(), "a1", []models.AssessmentScore{{StudentID: "s1", Marks: 42.5}}).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockGradeService := mocks.NewMockGradeServiceI(ctrl)
+			handler := handlers.NewGradeHandler(mockGradeService)
+
+			reqBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(reqBody))
+			req = req.WithContext(AddUserToContext(req.Context(), tt.role))
+			for k, v := range tt.pathValues {
+				req.SetPathValue(k, v)
+			}
+
+			tt.mockService(mockGradeService)
+			rr := httptest.NewRecorder()
+
+			tt.handle(handler)(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
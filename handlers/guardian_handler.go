@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"net/http"
+	"sms/constants"
+	"sms/middleware"
+	"sms/models"
+	"sms/services"
+	"sms/utils"
+)
+
+type CreateGuardianRequest struct {
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type LinkStudentRequest struct {
+	StudentID    string `json:"studentID"`
+	Relationship string `json:"relationship"`
+}
+
+type GuardianHandler struct {
+	as services.AuthServiceI
+	gs services.GuardianServiceI
+}
+
+func NewGuardianHandler(as services.AuthServiceI, gs services.GuardianServiceI) *GuardianHandler {
+	return &GuardianHandler{as: as, gs: gs}
+}
+
+func (gh *GuardianHandler) CreateGuardian(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || role != constants.Admin {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only admin can access")
+		return
+	}
+	var req CreateGuardianRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	if req.Name == "" || req.Email == "" || req.Password == "" {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "name, email and password can't be empty")
+		return
+	}
+
+	user, err := gh.as.CreateAccount(r.Context(), req.Name, req.Email, req.Password, constants.Guardian)
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusCreated, "guardian created", map[string]string{"guardianID": user.UserID})
+}
+
+func (gh *GuardianHandler) LinkStudent(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || role != constants.Admin {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only admin can access")
+		return
+	}
+	guardianID := r.PathValue("guardianID")
+	if guardianID == "" {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid guardianID")
+		return
+	}
+	var req LinkStudentRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+
+	link := models.GuardianLink{GuardianID: guardianID, StudentID: req.StudentID, Relationship: req.Relationship}
+	if err := gh.gs.LinkStudent(r.Context(), link); err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusCreated, "student linked", link)
+}
+
+func (gh *GuardianHandler) UnlinkStudent(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || role != constants.Admin {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only admin can access")
+		return
+	}
+	guardianID, studentID := r.PathValue("guardianID"), r.PathValue("studentID")
+	if guardianID == "" || studentID == "" {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid guardianID or studentID")
+		return
+	}
+
+	if err := gh.gs.UnlinkStudent(r.Context(), guardianID, studentID); err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusOK, "student unlinked")
+}
+
+func (gh *GuardianHandler) GetMyStudents(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || role != constants.Guardian {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only guardians can access")
+		return
+	}
+	guardianID, _ := middleware.GetUserID(r.Context())
+
+	students, err := gh.gs.GetLinkedStudents(r.Context(), guardianID)
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusOK, "ok", students)
+}
+
+// authorizeStudentView lets faculty and admin view any student and guardians
+// only the students linked to them. It writes the error response itself.
+func authorizeStudentView(w http.ResponseWriter, r *http.Request, guardians services.GuardianServiceI, studentID string) bool {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only faculty, admin and guardians can access")
+		return false
+	}
+	switch role {
+	case constants.Faculty, constants.Admin:
+		return true
+	case constants.Guardian:
+		guardianID, _ := middleware.GetUserID(r.Context())
+		linked, err := guardians.CanViewStudent(r.Context(), guardianID, studentID)
+		if err != nil {
+			utils.ErrorResponseSender(w, err)
+			return false
+		}
+		if !linked {
+			utils.CustomResponseSender(w, http.StatusForbidden, "guardians can only access their own students")
+			return false
+		}
+		return true
+	}
+	utils.CustomResponseSender(w, http.StatusForbidden, "only faculty, admin and guardians can access")
+	return false
+}
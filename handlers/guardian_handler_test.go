@@ -0,0 +1,157 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sms/apperrors"
+	"sms/constants"
+	"sms/handlers"
+	"sms/mocks"
+	"sms/models"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestGuardianHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		role           constants.Role
+		pathValues     map[string]string
+		body           any
+		handle         func(h *handlers.GuardianHandler) http.HandlerFunc
+		mockAuth       func(mockAuthService *mocks.MockAuthServiceI)
+		mockService    func(mockGuardianService *mocks.MockGuardianServiceI)
+		expectedStatus int
+	}{
+		{
+			name:   "admin creates guardian",
+			role:   "admin",
+			body:   map[string]string{"name": "Lakshmi", "email": "lakshmi@example.com", "password": "StrongPass123!"},
+			handle: func(h *handlers.GuardianHandler) http.HandlerFunc { return h.CreateGuardian },
+			mockAuth: func(mockAuthService *mocks.MockAuthServiceI) {
+				mockAuthService.EXPECT().CreateAccount(gomock.Any(), "Lakshmi", "lakshmi@example.com", "StrongPass123!", constants.Guardian).
+					Return(models.User{UserID: "g1", Role: constants.Guardian}, nil)
+			},
+			mockService:    func(mockGuardianService *mocks.MockGuardianServiceI) {},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "missing guardian fields",
+			role:           "admin",
+			body:           map[string]string{"name": "Lakshmi"},
+			handle:         func(h *handlers.GuardianHandler) http.HandlerFunc { return h.CreateGuardian },
+			mockService:    func(mockGuardianService *mocks.MockGuardianServiceI) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "duplicate guardian email",
+			role:   "admin",
+			body:   map[string]string{"name": "Lakshmi", "email": "lakshmi@example.com", "password": "StrongPass123!"},
+			handle: func(h *handlers.GuardianHandler) http.HandlerFunc { return h.CreateGuardian },
+			mockAuth: func(mockAuthService *mocks.MockAuthServiceI) {
+				mockAuthService.EXPECT().CreateAccount(gomock.Any(), "Lakshmi", "lakshmi@example.com", "StrongPass123!", constants.Guardian).
+					Return(models.User{}, apperrors.Conflict("email already in use"))
+			},
+			mockService:    func(mockGuardianService *mocks.MockGuardianServiceI) {},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "faculty can't create guardian",
+			role:           "faculty",
+			body:           map[string]string{},
+			handle:         func(h *handlers.GuardianHandler) http.HandlerFunc { return h.CreateGuardian },
+			mockService:    func(mockGuardianService *mocks.MockGuardianServiceI) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:       "admin links student",
+			role:       "admin",
+			pathValues: map[string]string{"guardianID": "g1"},
+			body:       map[string]string{"studentID": "s1", "relationship": "mother"},
+			handle:     func(h *handlers.GuardianHandler) http.HandlerFunc { return h.LinkStudent },
+			mockService: func(mockGuardianService *mocks.MockGuardianServiceI) {
+				mockGuardianService.EXPECT().LinkStudent(gomock.Any(), models.GuardianLink{GuardianID: "g1", StudentID: "s1", Relationship: "mother"}).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:       "link to missing student",
+			role:       "admin",
+			pathValues: map[string]string{"guardianID": "g1"},
+			body:       map[string]string{"studentID": "missing"},
+			handle:     func(h *handlers.GuardianHandler) http.HandlerFunc { return h.LinkStudent },
+			mockService: func(mockGuardianService *mocks.MockGuardianServiceI) {
+				mockGuardianService.EXPECT().LinkStudent(gomock.Any(), models.GuardianLink{GuardianID: "g1", StudentID: "missing"}).Return(apperrors.NotFound("student not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:       "admin unlinks student",
+			role:       "admin",
+			pathValues: map[string]string{"guardianID": "g1", "studentID": "s1"},
+			handle:     func(h *handlers.GuardianHandler) http.HandlerFunc { return h.UnlinkStudent },
+			mockService: func(mockGuardianService *mocks.MockGuardianServiceI) {
+				mockGuardianService.EXPECT().UnlinkStudent(gomock.Any(), "g1", "s1").Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "guardian can't unlink student",
+			role:           "guardian",
+			pathValues:     map[string]string{"guardianID": "g1", "studentID": "s1"},
+			handle:         func(h *handlers.GuardianHandler) http.HandlerFunc { return h.UnlinkStudent },
+			mockService:    func(mockGuardianService *mocks.MockGuardianServiceI) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:   "guardian lists own students",
+			role:   "guardian",
+			handle: func(h *handlers.GuardianHandler) http.HandlerFunc { return h.GetMyStudents },
+			mockService: func(mockGuardianService *mocks.MockGuardianServiceI) {
+				mockGuardianService.EXPECT().GetLinkedStudents(gomock.Any(), "g1").Return([]models.Students{{StudentID: "s1"}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "faculty has no linked students",
+			role:           "faculty",
+			handle:         func(h *handlers.GuardianHandler) http.HandlerFunc { return h.GetMyStudents },
+			mockService:    func(mockGuardianService *mocks.MockGuardianServiceI) {},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockAuthService := mocks.NewMockAuthServiceI(ctrl)
+			mockGuardianService := mocks.NewMockGuardianServiceI(ctrl)
+			handler := handlers.NewGuardianHandler(mockAuthService, mockGuardianService)
+
+			reqBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(reqBody))
+			ctx := context.WithValue(AddUserToContext(req.Context(), tt.role), constants.ContextUserIDKey, "g1")
+			req = req.WithContext(ctx)
+			for k, v := range tt.pathValues {
+				req.SetPathValue(k, v)
+			}
+
+			if tt.mockAuth != nil {
+				tt.mockAuth(mockAuthService)
+			}
+			tt.mockService(mockGuardianService)
+			rr := httptest.NewRecorder()
+
+			tt.handle(handler)(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
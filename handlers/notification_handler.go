@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"net/http"
+	"sms/constants"
+	"sms/middleware"
+	"sms/models"
+	"sms/services"
+	"sms/utils"
+)
+
+type NotificationPreferenceRequest struct {
+	Enabled bool   `json:"enabled"`
+	Target  string `json:"target"`
+}
+
+type NotificationHandler struct {
+	ns services.NotificationServiceI
+}
+
+func NewNotificationHandler(ns services.NotificationServiceI) *NotificationHandler {
+	return &NotificationHandler{ns: ns}
+}
+
+func (nh *NotificationHandler) GetMyNotifications(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserID(r.Context())
+	if err != nil || userID == "" {
+		utils.CustomResponseSender(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	notifications, err := nh.ns.GetInbox(r.Context(), userID)
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusOK, "ok", notifications)
+}
+
+func (nh *NotificationHandler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserID(r.Context())
+	if err != nil || userID == "" {
+		utils.CustomResponseSender(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	notificationID := r.PathValue("notificationID")
+	if notificationID == "" {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid notificationID")
+		return
+	}
+
+	if err := nh.ns.MarkRead(r.Context(), userID, notificationID); err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusOK, "notification marked as read")
+}
+
+func (nh *NotificationHandler) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserID(r.Context())
+	if err != nil || userID == "" {
+		utils.CustomResponseSender(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	prefs, err := nh.ns.GetPreferences(r.Context(), userID)
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusOK, "ok", prefs)
+}
+
+func (nh *NotificationHandler) SetPreference(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserID(r.Context())
+	if err != nil || userID == "" {
+		utils.CustomResponseSender(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	channel := r.PathValue("channel")
+	if channel == "" {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid channel")
+		return
+	}
+	var req NotificationPreferenceRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+
+	pref := models.NotificationPreference{UserID: userID, Channel: constants.NotificationChannel(channel), Enabled: req.Enabled, Target: req.Target}
+	if err := nh.ns.SetPreference(r.Context(), pref); err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusOK, "preference saved", pref)
+}
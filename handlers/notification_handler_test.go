@@ -0,0 +1,127 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sms/apperrors"
+	"sms/constants"
+	"sms/handlers"
+	"sms/mocks"
+	"sms/models"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestNotificationHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		userID         string
+		pathValues     map[string]string
+		body           any
+		handle         func(h *handlers.NotificationHandler) http.HandlerFunc
+		mockService    func(mockNotificationService *mocks.MockNotificationServiceI)
+		expectedStatus int
+	}{
+		{
+			name:   "user reads own inbox",
+			userID: "u1",
+			handle: func(h *handlers.NotificationHandler) http.HandlerFunc { return h.GetMyNotifications },
+			mockService: func(mockNotificationService *mocks.MockNotificationServiceI) {
+				mockNotificationService.EXPECT().GetInbox(gomock.Any(), "u1").Return([]models.Notification{{NotificationID: "n1"}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "inbox without user",
+			handle:         func(h *handlers.NotificationHandler) http.HandlerFunc { return h.GetMyNotifications },
+			mockService:    func(mockNotificationService *mocks.MockNotificationServiceI) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "mark notification read",
+			userID:     "u1",
+			pathValues: map[string]string{"notificationID": "n1"},
+			handle:     func(h *handlers.NotificationHandler) http.HandlerFunc { return h.MarkRead },
+			mockService: func(mockNotificationService *mocks.MockNotificationServiceI) {
+				mockNotificationService.EXPECT().MarkRead(gomock.Any(), "u1", "n1").Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:       "mark unknown notification read",
+			userID:     "u1",
+			pathValues: map[string]string{"notificationID": "n9"},
+			handle:     func(h *handlers.NotificationHandler) http.HandlerFunc { return h.MarkRead },
+			mockService: func(mockNotificationService *mocks.MockNotificationServiceI) {
+				mockNotificationService.EXPECT().MarkRead(gomock.Any(), "u1", "n9").Return(apperrors.NotFound("notification not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "get preferences",
+			userID: "u1",
+			handle: func(h *handlers.NotificationHandler) http.HandlerFunc { return h.GetPreferences },
+			mockService: func(mockNotificationService *mocks.MockNotificationServiceI) {
+				mockNotificationService.EXPECT().GetPreferences(gomock.Any(), "u1").Return([]models.NotificationPreference{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:       "enable webhook",
+			userID:     "u1",
+			pathValues: map[string]string{"channel": "webhook"},
+			body:       map[string]any{"enabled": true, "target": "https://example.com/hook"},
+			handle:     func(h *handlers.NotificationHandler) http.HandlerFunc { return h.SetPreference },
+			mockService: func(mockNotificationService *mocks.MockNotificationServiceI) {
+				mockNotificationService.EXPECT().SetPreference(gomock.Any(), models.NotificationPreference{
+					UserID: "u1", Channel: constants.ChannelWebhook, Enabled: true, Target: "https://example.com/hook",
+				}).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid preference",
+			userID:     "u1",
+			pathValues: map[string]string{"channel": "sms"},
+			body:       map[string]any{"enabled": true},
+			handle:     func(h *handlers.NotificationHandler) http.HandlerFunc { return h.SetPreference },
+			mockService: func(mockNotificationService *mocks.MockNotificationServiceI) {
+				mockNotificationService.EXPECT().SetPreference(gomock.Any(), gomock.Any()).Return(apperrors.Validation("unknown channel sms"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockNotificationService := mocks.NewMockNotificationServiceI(ctrl)
+			handler := handlers.NewNotificationHandler(mockNotificationService)
+
+			reqBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(reqBody))
+			ctx := AddUserToContext(req.Context(), "faculty")
+			if tt.userID != "" {
+				ctx = context.WithValue(ctx, constants.ContextUserIDKey, tt.userID)
+			}
+			req = req.WithContext(ctx)
+			for k, v := range tt.pathValues {
+				req.SetPathValue(k, v)
+			}
+
+			tt.mockService(mockNotificationService)
+			rr := httptest.NewRecorder()
+
+			tt.handle(handler)(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"net/http"
+	"sms/services"
+	"sms/utils"
+)
+
+type ReportHandler struct {
+	rs        services.ReportServiceI
+	guardians services.GuardianServiceI
+}
+
+func NewReportHandler(rs services.ReportServiceI, guardians services.GuardianServiceI) *ReportHandler {
+	return &ReportHandler{rs: rs, guardians: guardians}
+}
+
+func (rh *ReportHandler) GetProgressReport(w http.ResponseWriter, r *http.Request) {
+	studentID := r.PathValue("studentID")
+	if studentID == "" {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid studentID")
+		return
+	}
+	if !authorizeStudentView(w, r, rh.guardians, studentID) {
+		return
+	}
+
+	report, err := rh.rs.GetProgressReport(r.Context(), studentID)
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusOK, "ok", report)
+}
+
+func (rh *ReportHandler) GetStudentGrades(w http.ResponseWriter, r *http.Request) {
+	studentID := r.PathValue("studentID")
+	if studentID == "" {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid studentID")
+		return
+	}
+	if !authorizeStudentView(w, r, rh.guardians, studentID) {
+		return
+	}
+
+	grades, err := rh.rs.GetStudentGrades(r.Context(), studentID)
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusOK, "ok", grades)
+}
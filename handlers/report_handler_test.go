@@ -0,0 +1,207 @@
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sms/apperrors"
+	"sms/constants"
+	"sms/handlers"
+	"sms/mocks"
+	"sms/models"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestReportHandler_GetProgressReport(t *testing.T) {
+	tests := []struct {
+		name           string
+		role           constants.Role
+		studentID      string
+		mockService    func(mockReportService *mocks.MockReportServiceI)
+		mockGuardians  func(mockGuardianService *mocks.MockGuardianServiceI)
+		expectedStatus int
+	}{
+		{
+			name:      "faculty gets progress report",
+			role:      "faculty",
+			studentID: "1",
+			mockService: func(mockReportService *mocks.MockReportServiceI) {
+				mockReportService.EXPECT().GetProgressReport(gomock.Any(), "1").Return(&models.ProgressReport{StudentID: "1"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:      "admin gets progress report",
+			role:      "admin",
+			studentID: "1",
+			mockService: func(mockReportService *mocks.MockReportServiceI) {
+				mockReportService.EXPECT().GetProgressReport(gomock.Any(), "1").Return(&models.ProgressReport{StudentID: "1"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing role",
+			role:           "",
+			studentID:      "1",
+			mockService:    func(mockReportService *mocks.MockReportServiceI) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:      "guardian gets linked student's report",
+			role:      "guardian",
+			studentID: "1",
+			mockService: func(mockReportService *mocks.MockReportServiceI) {
+				mockReportService.EXPECT().GetProgressReport(gomock.Any(), "1").Return(&models.ProgressReport{StudentID: "1"}, nil)
+			},
+			mockGuardians: func(mockGuardianService *mocks.MockGuardianServiceI) {
+				mockGuardianService.EXPECT().CanViewStudent(gomock.Any(), "g1", "1").Return(true, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "guardian of another student",
+			role:        "guardian",
+			studentID:   "2",
+			mockService: func(mockReportService *mocks.MockReportServiceI) {},
+			mockGuardians: func(mockGuardianService *mocks.MockGuardianServiceI) {
+				mockGuardianService.EXPECT().CanViewStudent(gomock.Any(), "g1", "2").Return(false, nil)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "invalid studentID",
+			role:           "faculty",
+			studentID:      "",
+			mockService:    func(mockReportService *mocks.MockReportServiceI) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "service error",
+			role:      "faculty",
+			studentID: "1",
+			mockService: func(mockReportService *mocks.MockReportServiceI) {
+				mockReportService.EXPECT().GetProgressReport(gomock.Any(), "1").Return(nil, apperrors.NotFound("student not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockReportService := mocks.NewMockReportServiceI(ctrl)
+			mockGuardianService := mocks.NewMockGuardianServiceI(ctrl)
+			handler := handlers.NewReportHandler(mockReportService, mockGuardianService)
+
+			req := httptest.NewRequest(http.MethodGet, "/students/"+tt.studentID+"/progress", nil)
+			ctx := context.WithValue(AddUserToContext(req.Context(), tt.role), constants.ContextUserIDKey, "g1")
+			req = req.WithContext(ctx)
+			req.SetPathValue("studentID", tt.studentID)
+
+			tt.mockService(mockReportService)
+			if tt.mockGuardians != nil {
+				tt.mockGuardians(mockGuardianService)
+			}
+			rr := httptest.NewRecorder()
+
+			handler.GetProgressReport(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
+
+func TestReportHandler_GetStudentGrades(t *testing.T) {
+	tests := []struct {
+		name           string
+		role           constants.Role
+		mockService    func(mockReportService *mocks.MockReportServiceI)
+		mockGuardians  func(mockGuardianService *mocks.MockGuardianServiceI)
+		expectedStatus int
+	}{
+		{
+			name: "faculty gets grades",
+			role: "faculty",
+			mockService: func(mockReportService *mocks.MockReportServiceI) {
+				mockReportService.EXPECT().GetStudentGrades(gomock.Any(), "1").Return([]models.Grade{{StudentID: "1"}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "guardian gets linked student's grades",
+			role: "guardian",
+			mockService: func(mockReportService *mocks.MockReportServiceI) {
+				mockReportService.EXPECT().GetStudentGrades(gomock.Any(), "1").Return([]models.Grade{{StudentID: "1"}}, nil)
+			},
+			mockGuardians: func(mockGuardianService *mocks.MockGuardianServiceI) {
+				mockGuardianService.EXPECT().CanViewStudent(gomock.Any(), "g1", "1").Return(true, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "guardian of another student",
+			role:        "guardian",
+			mockService: func(mockReportService *mocks.MockReportServiceI) {},
+			mockGuardians: func(mockGuardianService *mocks.MockGuardianServiceI) {
+				mockGuardianService.EXPECT().CanViewStudent(gomock.Any(), "g1", "1").Return(false, nil)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:        "guardian lookup error",
+			role:        "guardian",
+			mockService: func(mockReportService *mocks.MockReportServiceI) {},
+			mockGuardians: func(mockGuardianService *mocks.MockGuardianServiceI) {
+				mockGuardianService.EXPECT().CanViewStudent(gomock.Any(), "g1", "1").Return(false, errors.New("db error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "student cannot access",
+			role:           "student",
+			mockService:    func(mockReportService *mocks.MockReportServiceI) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "service error",
+			role: "admin",
+			mockService: func(mockReportService *mocks.MockReportServiceI) {
+				mockReportService.EXPECT().GetStudentGrades(gomock.Any(), "1").Return(nil, apperrors.NotFound("student not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockReportService := mocks.NewMockReportServiceI(ctrl)
+			mockGuardianService := mocks.NewMockGuardianServiceI(ctrl)
+			handler := handlers.NewReportHandler(mockReportService, mockGuardianService)
+
+			req := httptest.NewRequest(http.MethodGet, "/students/1/grades", nil)
+			ctx := context.WithValue(AddUserToContext(req.Context(), tt.role), constants.ContextUserIDKey, "g1")
+			req = req.WithContext(ctx)
+			req.SetPathValue("studentID", "1")
+
+			tt.mockService(mockReportService)
+			if tt.mockGuardians != nil {
+				tt.mockGuardians(mockGuardianService)
+			}
+			rr := httptest.NewRecorder()
+
+			handler.GetStudentGrades(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"net/http"
+	"sms/constants"
+	"sms/middleware"
+	"sms/models"
+	"sms/services"
+	"sms/utils"
+	"strconv"
+)
+
+type RolloverRequest struct {
+	TargetClassID     string  `json:"targetClassID,omitempty"`
+	PassMark          int     `json:"pass_mark,omitempty"`
+	MinAverage        float64 `json:"min_average,omitempty"`
+	MaxFailedSubjects *int    `json:"max_failed_subjects,omitempty"`
+	DryRun            bool    `json:"dry_run"`
+}
+
+type RolloverHandler struct {
+	rs services.RolloverServiceI
+}
+
+func NewRolloverHandler(rs services.RolloverServiceI) *RolloverHandler {
+	return &RolloverHandler{rs: rs}
+}
+
+func (rh *RolloverHandler) Rollover(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || role != constants.Admin {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only admin can access")
+		return
+	}
+	classID := r.PathValue("classID")
+	semester, err := strconv.Atoi(r.PathValue("semester"))
+	if classID == "" || err != nil || semester <= 0 {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid classID or semester")
+		return
+	}
+	var req RolloverRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+
+	criteria := models.RolloverCriteria{
+		PassMark:          constants.DefaultPassMark,
+		MinAverage:        constants.DefaultRolloverMinAverage,
+		MaxFailedSubjects: constants.DefaultRolloverMaxFailedSubjects,
+	}
+	if req.PassMark > 0 {
+		criteria.PassMark = req.PassMark
+	}
+	if req.MinAverage > 0 {
+		criteria.MinAverage = req.MinAverage
+	}
+	if req.MaxFailedSubjects != nil {
+		if *req.MaxFailedSubjects < 0 {
+			utils.CustomResponseSender(w, http.StatusBadRequest, "max_failed_subjects can't be negative")
+			return
+		}
+		criteria.MaxFailedSubjects = *req.MaxFailedSubjects
+	}
+
+	if req.DryRun {
+		preview, err := rh.rs.PreviewRollover(r.Context(), classID, semester, req.TargetClassID, criteria)
+		if err != nil {
+			utils.ErrorResponseSender(w, err)
+			return
+		}
+		utils.CustomResponseSender(w, http.StatusOK, "rollover preview", preview)
+		return
+	}
+
+	userID, _ := middleware.GetUserID(r.Context())
+	rollover, err := rh.rs.Rollover(r.Context(), classID, semester, req.TargetClassID, criteria, userID)
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusCreated, "rollover completed", rollover)
+}
+
+func (rh *RolloverHandler) GetRollover(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || role != constants.Admin {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only admin can access")
+		return
+	}
+	rolloverID := r.PathValue("rolloverID")
+	if rolloverID == "" {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid rolloverID")
+		return
+	}
+
+	rollover, err := rh.rs.GetRollover(r.Context(), rolloverID)
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusOK, "ok", rollover)
+}
+
+func (rh *RolloverHandler) UndoRollover(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || role != constants.Admin {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only admin can access")
+		return
+	}
+	rolloverID := r.PathValue("rolloverID")
+	if rolloverID == "" {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid rolloverID")
+		return
+	}
+
+	rollover, err := rh.rs.UndoRollover(r.Context(), rolloverID)
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusOK, "rollover undone", rollover)
+}
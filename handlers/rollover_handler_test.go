@@ -0,0 +1,122 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sms/apperrors"
+	"sms/constants"
+	"sms/handlers"
+	"sms/mocks"
+	"sms/models"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestRolloverHandler(t *testing.T) {
+	defaults := models.RolloverCriteria{
+		PassMark:          constants.DefaultPassMark,
+		MinAverage:        constants.DefaultRolloverMinAverage,
+		MaxFailedSubjects: constants.DefaultRolloverMaxFailedSubjects,
+	}
+
+	tests := []struct {
+		name           string
+		role           constants.Role
+		pathValues     map[string]string
+		body           any
+		handle         func(h *handlers.RolloverHandler) http.HandlerFunc
+		mockService    func(mockRolloverService *mocks.MockRolloverServiceI)
+		expectedStatus int
+	}{
+		{
+			name:       "dry run returns preview",
+			role:       "admin",
+			pathValues: map[string]string{"classID": "C1", "semester": "1"},
+			body:       map[string]any{"targetClassID": "C2", "dry_run": true},
+			handle:     func(h *handlers.RolloverHandler) http.HandlerFunc { return h.Rollover },
+			mockService: func(mockRolloverService *mocks.MockRolloverServiceI) {
+				mockRolloverService.EXPECT().PreviewRollover(gomock.Any(), "C1", 1, "C2", defaults).Return(&models.Rollover{DryRun: true}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:       "rollover with zero failures allowed",
+			role:       "admin",
+			pathValues: map[string]string{"classID": "C1", "semester": "1"},
+			body:       map[string]any{"max_failed_subjects": 0},
+			handle:     func(h *handlers.RolloverHandler) http.HandlerFunc { return h.Rollover },
+			mockService: func(mockRolloverService *mocks.MockRolloverServiceI) {
+				criteria := defaults
+				criteria.MaxFailedSubjects = 0
+				mockRolloverService.EXPECT().Rollover(gomock.Any(), "C1", 1, "", criteria, gomock.Any()).Return(&models.Rollover{}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "faculty can't roll over",
+			role:           "faculty",
+			pathValues:     map[string]string{"classID": "C1", "semester": "1"},
+			body:           map[string]any{},
+			handle:         func(h *handlers.RolloverHandler) http.HandlerFunc { return h.Rollover },
+			mockService:    func(mockRolloverService *mocks.MockRolloverServiceI) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "invalid semester",
+			role:           "admin",
+			pathValues:     map[string]string{"classID": "C1", "semester": "0"},
+			body:           map[string]any{},
+			handle:         func(h *handlers.RolloverHandler) http.HandlerFunc { return h.Rollover },
+			mockService:    func(mockRolloverService *mocks.MockRolloverServiceI) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "get rollover",
+			role:       "admin",
+			pathValues: map[string]string{"rolloverID": "r1"},
+			handle:     func(h *handlers.RolloverHandler) http.HandlerFunc { return h.GetRollover },
+			mockService: func(mockRolloverService *mocks.MockRolloverServiceI) {
+				mockRolloverService.EXPECT().GetRollover(gomock.Any(), "r1").Return(&models.Rollover{RolloverID: "r1"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:       "undo after window",
+			role:       "admin",
+			pathValues: map[string]string{"rolloverID": "r1"},
+			handle:     func(h *handlers.RolloverHandler) http.HandlerFunc { return h.UndoRollover },
+			mockService: func(mockRolloverService *mocks.MockRolloverServiceI) {
+				mockRolloverService.EXPECT().UndoRollover(gomock.Any(), "r1").Return(nil, apperrors.Conflict("undo window has expired"))
+			},
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockRolloverService := mocks.NewMockRolloverServiceI(ctrl)
+			handler := handlers.NewRolloverHandler(mockRolloverService)
+
+			reqBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(reqBody))
+			req = req.WithContext(AddUserToContext(req.Context(), tt.role))
+			for k, v := range tt.pathValues {
+				req.SetPathValue(k, v)
+			}
+
+			tt.mockService(mockRolloverService)
+			rr := httptest.NewRecorder()
+
+			tt.handle(handler)(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
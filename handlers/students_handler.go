@@ -1,34 +1,38 @@
 package handlers
 
 import (
-	"encoding/json"
 	"net/http"
 	"sms/constants"
 	"sms/middleware"
+	"sms/models"
 	"sms/services"
 	"sms/utils"
 )
 
 type CreateStudentRequest struct {
-	RollNumber string `json:"roll_number"`
-	Name       string `json:"name"`
-	ClassID    string `json:"classID"`
-	Semester   int    `json:"semester"`
+	RollNumber string `json:"roll_number" validate:"required"`
+	Name       string `json:"name" validate:"required"`
+	ClassID    string `json:"classID" validate:"required"`
+	Semester   int    `json:"semester" validate:"required,min=1"`
+	// SubjectIDs, when given, enroll the new student in the subjects for their
+	// semester together with creating them.
+	SubjectIDs []string `json:"subjectIDs,omitempty"`
 }
 
 type CreateStudentResponse struct {
-	StudentID  string `json:"studentID"`
-	RollNumber string `json:"roll_number"`
-	Name       string `json:"name"`
-	ClassID    string `json:"classID"`
-	Semester   int    `json:"semester"`
+	StudentID   string              `json:"studentID"`
+	RollNumber  string              `json:"roll_number"`
+	Name        string              `json:"name"`
+	ClassID     string              `json:"classID"`
+	Semester    int                 `json:"semester"`
+	Enrollments []models.Enrollment `json:"enrollments,omitempty"`
 }
 
 type UpdateStudentRequest struct {
 	RollNumber string `json:"roll_number,omitempty"`
 	Name       string `json:"name,omitempty"`
 	ClassID    string `json:"classID,omitempty"`
-	Semester   int    `json:"semester,omitempty"`
+	Semester   int    `json:"semester,omitempty" validate:"min=1"`
 }
 
 type StudentHandler struct {
@@ -52,24 +56,32 @@ func (sh *StudentHandler) AddStudent(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req CreateStudentRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid request body")
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.ErrorResponseSender(w, err)
 		return
 	}
 	// log.Println("reaching till here")
 
-	student, err := sh.ss.CreateStudent(req.RollNumber, req.Name, req.ClassID, req.Semester)
+	var student *models.Students
+	var enrollments []models.Enrollment
+	var err error
+	if len(req.SubjectIDs) > 0 {
+		student, enrollments, err = sh.ss.CreateStudentWithEnrollments(r.Context(), req.RollNumber, req.Name, req.ClassID, req.Semester, req.SubjectIDs)
+	} else {
+		student, err = sh.ss.CreateStudent(r.Context(), req.RollNumber, req.Name, req.ClassID, req.Semester)
+	}
 	if err != nil {
-		utils.CustomResponseSender(w, http.StatusBadRequest, err.Error())
+		utils.ErrorResponseSender(w, err)
 		return
 	}
 	// log.Println("reaching after db")
 	res := CreateStudentResponse{
-		StudentID:  student.StudentID,
-		RollNumber: student.RollNumber,
-		Name:       student.Name,
-		ClassID:    student.ClassID,
-		Semester:   student.Semester,
+		StudentID:   student.StudentID,
+		RollNumber:  student.RollNumber,
+		Name:        student.Name,
+		ClassID:     student.ClassID,
+		Semester:    student.Semester,
+		Enrollments: enrollments,
 	}
 
 	// log.Println("reaching after create response")
@@ -93,13 +105,13 @@ func (sh *StudentHandler) UpdateStudent(w http.ResponseWriter, r *http.Request)
 		return
 	}
 	var updateStudent UpdateStudentRequest
-	if err := json.NewDecoder(r.Body).Decode(&updateStudent); err != nil {
-		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid request body")
+	if err := utils.DecodeJSON(w, r, &updateStudent); err != nil {
+		utils.ErrorResponseSender(w, err)
 		return
 	}
-	err = sh.ss.UpdateStudent(studentID, updateStudent.Name, updateStudent.RollNumber, updateStudent.ClassID, updateStudent.Semester)
+	err = sh.ss.UpdateStudent(r.Context(), studentID, updateStudent.Name, updateStudent.RollNumber, updateStudent.ClassID, updateStudent.Semester)
 	if err != nil {
-		utils.CustomResponseSender(w, http.StatusBadRequest, err.Error())
+		utils.ErrorResponseSender(w, err)
 		return
 	}
 	utils.CustomResponseSender(w, http.StatusOK, "updated successfully")
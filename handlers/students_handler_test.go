@@ -6,6 +6,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"sms/apperrors"
 	"sms/constants"
 	"sms/handlers"
 	"sms/mocks"
@@ -40,10 +41,41 @@ func TestStudentHandler_AddStudent(t *testing.T) {
 			},
 			role: "admin",
 			mockService: func() {
-				mockStudentService.EXPECT().CreateStudent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&models.Students{}, nil)
+				mockStudentService.EXPECT().CreateStudent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&models.Students{}, nil)
 			},
 			expectedStatus: http.StatusCreated,
 		},
+		{
+			name: "admin adds and enrolls student in one step",
+			body: map[string]any{
+				"roll_number": "1",
+				"name":        "rohith",
+				"classID":     "1",
+				"semester":    7,
+				"subjectIDs":  []string{"maths", "physics"},
+			},
+			role: "admin",
+			mockService: func() {
+				mockStudentService.EXPECT().CreateStudentWithEnrollments(gomock.Any(), "1", "rohith", "1", 7, []string{"maths", "physics"}).
+					Return(&models.Students{StudentID: "s1"}, []models.Enrollment{{StudentID: "s1", SubjectID: "maths"}, {StudentID: "s1", SubjectID: "physics"}}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "enrollment failure creates no student",
+			body: map[string]any{
+				"roll_number": "1",
+				"name":        "rohith",
+				"classID":     "1",
+				"semester":    7,
+				"subjectIDs":  []string{"history"},
+			},
+			role: "admin",
+			mockService: func() {
+				mockStudentService.EXPECT().CreateStudentWithEnrollments(gomock.Any(), "1", "rohith", "1", 7, []string{"history"}).Return(nil, nil, apperrors.Validation("no such subject"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
 		{
 			name: "faculty can't add student",
 			body: map[string]any{
@@ -67,12 +99,12 @@ func TestStudentHandler_AddStudent(t *testing.T) {
 			},
 			role: "admin",
 			mockService: func() {
-				mockStudentService.EXPECT().CreateStudent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("service error"))
+				mockStudentService.EXPECT().CreateStudent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("service error"))
 			},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusInternalServerError,
 		},
 		{
-			name: "invalid request body",
+			name: "wrongly typed field",
 			body: map[string]any{
 				"roll_number": 123,
 				"name":        nil,
@@ -83,6 +115,23 @@ func TestStudentHandler_AddStudent(t *testing.T) {
 			mockService: func() {
 				// mockStudentService.EXPECT().CreateStudent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(models.Students{}, nil)
 			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "missing fields",
+			body: map[string]any{
+				"roll_number": " ",
+				"semester":    0,
+			},
+			role:           "admin",
+			mockService:    func() {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "malformed json",
+			body:           `{"roll_number": "101",`,
+			role:           "admin",
+			mockService:    func() {},
 			expectedStatus: http.StatusBadRequest,
 		},
 	}
@@ -130,7 +179,7 @@ func TestStudentHandler_UpdateStudent(t *testing.T) {
 				"semester":    7,
 			},
 			mockService: func(mockStudentService *mocks.MockStudentServiceI) {
-				mockStudentService.EXPECT().UpdateStudent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockStudentService.EXPECT().UpdateStudent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 			},
 			studentID: "1",
 		},
@@ -163,9 +212,9 @@ func TestStudentHandler_UpdateStudent(t *testing.T) {
 			studentID: "",
 		},
 		{
-			name:           "invalid request body",
+			name:           "wrongly typed field",
 			role:           "admin",
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusUnprocessableEntity,
 			body: map[string]any{
 				"roll_number": 1,
 				"classID":     7,
@@ -175,10 +224,21 @@ func TestStudentHandler_UpdateStudent(t *testing.T) {
 			},
 			studentID: "1",
 		},
+		{
+			name:           "negative semester",
+			role:           "admin",
+			expectedStatus: http.StatusUnprocessableEntity,
+			body: map[string]any{
+				"semester": -1,
+			},
+			mockService: func(mockStudentService *mocks.MockStudentServiceI) {
+			},
+			studentID: "1",
+		},
 		{
 			name:           "service error",
 			role:           "admin",
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusInternalServerError,
 			body: map[string]any{
 				"roll_number": "1",
 				"name":        "rohith",
@@ -187,7 +247,7 @@ func TestStudentHandler_UpdateStudent(t *testing.T) {
 			},
 			mockService: func(mockStudentService *mocks.MockStudentServiceI) {
 				mockStudentService.EXPECT().
-					UpdateStudent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					UpdateStudent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(errors.New("service error"))
 			},
 			studentID: "1",
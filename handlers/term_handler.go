@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"net/http"
+	"sms/constants"
+	"sms/middleware"
+	"sms/models"
+	"sms/services"
+	"sms/utils"
+	"strconv"
+	"time"
+)
+
+type CreateTermRequest struct {
+	Year            int                  `json:"year"`
+	TermNumber      int                  `json:"termNumber"`
+	Semesters       []int                `json:"semesters"`
+	StartDate       string               `json:"startDate"`
+	EndDate         string               `json:"endDate"`
+	GradeEntryStart string               `json:"gradeEntryStart"`
+	GradeEntryEnd   string               `json:"gradeEntryEnd"`
+	Status          constants.TermStatus `json:"status,omitempty"`
+}
+
+type TermStatusRequest struct {
+	Status constants.TermStatus `json:"status"`
+}
+
+type GradeEntryWindowRequest struct {
+	GradeEntryStart string `json:"gradeEntryStart"`
+	GradeEntryEnd   string `json:"gradeEntryEnd"`
+}
+
+type TermHandler struct {
+	ts services.TermServiceI
+}
+
+func NewTermHandler(ts services.TermServiceI) *TermHandler {
+	return &TermHandler{ts: ts}
+}
+
+func (th *TermHandler) CreateTerm(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || role != constants.Admin {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only admin can access")
+		return
+	}
+	var req CreateTermRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	dates, err := parseDates(req.StartDate, req.EndDate, req.GradeEntryStart, req.GradeEntryEnd)
+	if err != nil {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "dates must be in YYYY-MM-DD format")
+		return
+	}
+
+	term, err := th.ts.CreateTerm(r.Context(), models.AcademicTerm{
+		Year:            req.Year,
+		TermNumber:      req.TermNumber,
+		Semesters:       req.Semesters,
+		StartDate:       dates[0],
+		EndDate:         dates[1],
+		GradeEntryStart: dates[2],
+		GradeEntryEnd:   dates[3],
+		Status:          req.Status,
+	})
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusCreated, "term created", term)
+}
+
+func (th *TermHandler) GetTerms(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || (role != constants.Faculty && role != constants.Admin) {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only faculty and admin can access")
+		return
+	}
+	year := 0
+	if v := r.URL.Query().Get("year"); v != "" {
+		year, err = strconv.Atoi(v)
+		if err != nil || year <= 0 {
+			utils.CustomResponseSender(w, http.StatusBadRequest, "year must be a positive number")
+			return
+		}
+	}
+
+	terms, err := th.ts.GetTerms(r.Context(), year)
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusOK, "ok", terms)
+}
+
+func (th *TermHandler) GetTerm(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || (role != constants.Faculty && role != constants.Admin) {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only faculty and admin can access")
+		return
+	}
+	termID := r.PathValue("termID")
+	if termID == "" {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid termID")
+		return
+	}
+
+	term, err := th.ts.GetTerm(r.Context(), termID)
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusOK, "ok", term)
+}
+
+func (th *TermHandler) SetTermStatus(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || role != constants.Admin {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only admin can access")
+		return
+	}
+	termID := r.PathValue("termID")
+	if termID == "" {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid termID")
+		return
+	}
+	var req TermStatusRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+
+	term, err := th.ts.SetTermStatus(r.Context(), termID, req.Status)
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusOK, "term updated", term)
+}
+
+func (th *TermHandler) SetGradeEntryWindow(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || role != constants.Admin {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only admin can access")
+		return
+	}
+	termID := r.PathValue("termID")
+	if termID == "" {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid termID")
+		return
+	}
+	var req GradeEntryWindowRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	dates, err := parseDates(req.GradeEntryStart, req.GradeEntryEnd)
+	if err != nil {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "dates must be in YYYY-MM-DD format")
+		return
+	}
+
+	term, err := th.ts.SetGradeEntryWindow(r.Context(), termID, dates[0], dates[1])
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusOK, "term updated", term)
+}
+
+func parseDates(values ...string) ([]time.Time, error) {
+	dates := make([]time.Time, len(values))
+	for i, v := range values {
+		date, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			return nil, err
+		}
+		dates[i] = date
+	}
+	return dates, nil
+}
@@ -0,0 +1,152 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sms/apperrors"
+	"sms/constants"
+	"sms/handlers"
+	"sms/mocks"
+	"sms/models"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestTermHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		role           constants.Role
+		target         string
+		pathValues     map[string]string
+		body           any
+		handle         func(h *handlers.TermHandler) http.HandlerFunc
+		mockService    func(mockTermService *mocks.MockTermServiceI)
+		expectedStatus int
+	}{
+		{
+			name:   "admin creates term",
+			role:   "admin",
+			target: "/",
+			body: map[string]any{
+				"year": 2026, "termNumber": 1, "semesters": []int{1, 3},
+				"startDate": "2026-07-01", "endDate": "2026-11-30",
+				"gradeEntryStart": "2026-11-15", "gradeEntryEnd": "2026-12-15",
+			},
+			handle: func(h *handlers.TermHandler) http.HandlerFunc { return h.CreateTerm },
+			mockService: func(mockTermService *mocks.MockTermServiceI) {
+				mockTermService.EXPECT().CreateTerm(gomock.Any(), models.AcademicTerm{
+					Year:            2026,
+					TermNumber:      1,
+					Semesters:       []int{1, 3},
+					StartDate:       time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC),
+					EndDate:         time.Date(2026, 11, 30, 0, 0, 0, 0, time.UTC),
+					GradeEntryStart: time.Date(2026, 11, 15, 0, 0, 0, 0, time.UTC),
+					GradeEntryEnd:   time.Date(2026, 12, 15, 0, 0, 0, 0, time.UTC),
+				}).Return(&models.AcademicTerm{TermID: "t1"}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "invalid date",
+			role:           "admin",
+			target:         "/",
+			body:           map[string]any{"year": 2026, "termNumber": 1, "startDate": "01/07/2026"},
+			handle:         func(h *handlers.TermHandler) http.HandlerFunc { return h.CreateTerm },
+			mockService:    func(mockTermService *mocks.MockTermServiceI) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "faculty can't create term",
+			role:           "faculty",
+			target:         "/",
+			body:           map[string]any{},
+			handle:         func(h *handlers.TermHandler) http.HandlerFunc { return h.CreateTerm },
+			mockService:    func(mockTermService *mocks.MockTermServiceI) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:   "faculty lists terms of a year",
+			role:   "faculty",
+			target: "/?year=2026",
+			handle: func(h *handlers.TermHandler) http.HandlerFunc { return h.GetTerms },
+			mockService: func(mockTermService *mocks.MockTermServiceI) {
+				mockTermService.EXPECT().GetTerms(gomock.Any(), 2026).Return([]models.AcademicTerm{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid year",
+			role:           "faculty",
+			target:         "/?year=last",
+			handle:         func(h *handlers.TermHandler) http.HandlerFunc { return h.GetTerms },
+			mockService:    func(mockTermService *mocks.MockTermServiceI) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "get missing term",
+			role:       "admin",
+			target:     "/",
+			pathValues: map[string]string{"termID": "missing"},
+			handle:     func(h *handlers.TermHandler) http.HandlerFunc { return h.GetTerm },
+			mockService: func(mockTermService *mocks.MockTermServiceI) {
+				mockTermService.EXPECT().GetTerm(gomock.Any(), "missing").Return(nil, apperrors.NotFound("term not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:       "close term",
+			role:       "admin",
+			target:     "/",
+			pathValues: map[string]string{"termID": "t1"},
+			body:       map[string]any{"status": "closed"},
+			handle:     func(h *handlers.TermHandler) http.HandlerFunc { return h.SetTermStatus },
+			mockService: func(mockTermService *mocks.MockTermServiceI) {
+				mockTermService.EXPECT().SetTermStatus(gomock.Any(), "t1", constants.TermClosed).Return(&models.AcademicTerm{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:       "extend grade entry window",
+			role:       "admin",
+			target:     "/",
+			pathValues: map[string]string{"termID": "t1"},
+			body:       map[string]any{"gradeEntryStart": "2026-11-15", "gradeEntryEnd": "2026-12-31"},
+			handle:     func(h *handlers.TermHandler) http.HandlerFunc { return h.SetGradeEntryWindow },
+			mockService: func(mockTermService *mocks.MockTermServiceI) {
+				mockTermService.EXPECT().SetGradeEntryWindow(gomock.Any(), "t1",
+					time.Date(2026, 11, 15, 0, 0, 0, 0, time.UTC),
+					time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)).Return(&models.AcademicTerm{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockTermService := mocks.NewMockTermServiceI(ctrl)
+			handler := handlers.NewTermHandler(mockTermService)
+
+			reqBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPost, tt.target, bytes.NewReader(reqBody))
+			req = req.WithContext(AddUserToContext(req.Context(), tt.role))
+			for k, v := range tt.pathValues {
+				req.SetPathValue(k, v)
+			}
+
+			tt.mockService(mockTermService)
+			rr := httptest.NewRecorder()
+
+			tt.handle(handler)(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
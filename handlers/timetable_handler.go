@@ -0,0 +1,290 @@
+package handlers
+
+import (
+	"mime"
+	"net/http"
+	"sms/constants"
+	"sms/middleware"
+	"sms/models"
+	"sms/services"
+	"sms/utils"
+	"strings"
+	"time"
+)
+
+type CreateRoomRequest struct {
+	Name     string `json:"name"`
+	Capacity int    `json:"capacity"`
+}
+
+type HomeRoomRequest struct {
+	RoomID string `json:"roomID"`
+}
+
+type FacultyAssignmentRequest struct {
+	FacultyID string `json:"facultyID"`
+	ClassID   string `json:"classID"`
+	SubjectID string `json:"subjectID"`
+}
+
+type AddSlotRequest struct {
+	ClassID   string `json:"classID"`
+	SubjectID string `json:"subjectID"`
+	FacultyID string `json:"facultyID"`
+	RoomID    string `json:"roomID,omitempty"`
+	Weekday   string `json:"weekday"`
+	StartTime string `json:"startTime"`
+	EndTime   string `json:"endTime"`
+}
+
+type TimetableHandler struct {
+	ts services.TimetableServiceI
+}
+
+func NewTimetableHandler(ts services.TimetableServiceI) *TimetableHandler {
+	return &TimetableHandler{ts: ts}
+}
+
+func (th *TimetableHandler) CreateRoom(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || role != constants.Admin {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only admin can access")
+		return
+	}
+	var req CreateRoomRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+
+	room, err := th.ts.CreateRoom(r.Context(), req.Name, req.Capacity)
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusCreated, "room created", room)
+}
+
+func (th *TimetableHandler) SetClassHomeRoom(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || role != constants.Admin {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only admin can access")
+		return
+	}
+	classID := r.PathValue("classID")
+	if classID == "" {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid classID")
+		return
+	}
+	var req HomeRoomRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+
+	if err := th.ts.SetClassHomeRoom(r.Context(), classID, req.RoomID); err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusOK, "home room assigned")
+}
+
+func (th *TimetableHandler) AssignFaculty(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || role != constants.Admin {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only admin can access")
+		return
+	}
+	var req FacultyAssignmentRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+
+	assignment := models.FacultyAssignment{FacultyID: req.FacultyID, ClassID: req.ClassID, SubjectID: req.SubjectID}
+	if err := th.ts.AssignFaculty(r.Context(), assignment); err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusCreated, "faculty assigned", assignment)
+}
+
+func (th *TimetableHandler) AddSlot(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || role != constants.Admin {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only admin can access")
+		return
+	}
+	var req AddSlotRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	weekday, ok := parseWeekday(req.Weekday)
+	if !ok {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "weekday must be a day name such as monday")
+		return
+	}
+
+	slot, err := th.ts.AddSlot(r.Context(), models.TimetableSlot{
+		ClassID:   req.ClassID,
+		SubjectID: req.SubjectID,
+		FacultyID: req.FacultyID,
+		RoomID:    req.RoomID,
+		Weekday:   weekday,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+	})
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusCreated, "slot added", slot)
+}
+
+func (th *TimetableHandler) RemoveSlot(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || role != constants.Admin {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only admin can access")
+		return
+	}
+	slotID := r.PathValue("slotID")
+	if slotID == "" {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid slotID")
+		return
+	}
+
+	if err := th.ts.RemoveSlot(r.Context(), slotID); err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusOK, "slot removed")
+}
+
+func (th *TimetableHandler) GetClassTimetable(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || (role != constants.Faculty && role != constants.Admin) {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only faculty and admin can access")
+		return
+	}
+	classID := r.PathValue("classID")
+	if classID == "" {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid classID")
+		return
+	}
+
+	slots, err := th.ts.GetClassTimetable(r.Context(), classID)
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusOK, "ok", slots)
+}
+
+func (th *TimetableHandler) GetFacultyTimetable(w http.ResponseWriter, r *http.Request) {
+	facultyID, ok := th.authorizeFaculty(w, r)
+	if !ok {
+		return
+	}
+
+	slots, err := th.ts.GetFacultyTimetable(r.Context(), facultyID)
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusOK, "ok", slots)
+}
+
+func (th *TimetableHandler) ExportClassCalendar(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || (role != constants.Faculty && role != constants.Admin) {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only faculty and admin can access")
+		return
+	}
+	classID := r.PathValue("classID")
+	if classID == "" {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid classID")
+		return
+	}
+	from, until, ok := calendarRange(w, r)
+	if !ok {
+		return
+	}
+
+	calendar, err := th.ts.ClassCalendar(r.Context(), classID, from, until)
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	writeCalendar(w, "class-"+classID+".ics", calendar)
+}
+
+func (th *TimetableHandler) ExportFacultyCalendar(w http.ResponseWriter, r *http.Request) {
+	facultyID, ok := th.authorizeFaculty(w, r)
+	if !ok {
+		return
+	}
+	from, until, ok := calendarRange(w, r)
+	if !ok {
+		return
+	}
+
+	calendar, err := th.ts.FacultyCalendar(r.Context(), facultyID, from, until)
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	writeCalendar(w, "faculty-"+facultyID+".ics", calendar)
+}
+
+// authorizeFaculty lets admins read any faculty timetable and faculty only their own.
+func (th *TimetableHandler) authorizeFaculty(w http.ResponseWriter, r *http.Request) (string, bool) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || (role != constants.Faculty && role != constants.Admin) {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only faculty and admin can access")
+		return "", false
+	}
+	facultyID := r.PathValue("facultyID")
+	if facultyID == "" {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid facultyID")
+		return "", false
+	}
+	if role == constants.Faculty {
+		if userID, _ := middleware.GetUserID(r.Context()); userID != facultyID {
+			utils.CustomResponseSender(w, http.StatusForbidden, "faculty can only access their own timetable")
+			return "", false
+		}
+	}
+	return facultyID, true
+}
+
+func calendarRange(w http.ResponseWriter, r *http.Request) (time.Time, time.Time, bool) {
+	query := r.URL.Query()
+	from, err := time.Parse(time.DateOnly, query.Get("from"))
+	if err != nil {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "from must be in YYYY-MM-DD format")
+		return time.Time{}, time.Time{}, false
+	}
+	until, err := time.Parse(time.DateOnly, query.Get("until"))
+	if err != nil {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "until must be in YYYY-MM-DD format")
+		return time.Time{}, time.Time{}, false
+	}
+	return from, until, true
+}
+
+func writeCalendar(w http.ResponseWriter, filename string, calendar []byte) {
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	w.WriteHeader(http.StatusOK)
+	w.Write(calendar)
+}
+
+func parseWeekday(day string) (time.Weekday, bool) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(d.String(), day) {
+			return d, true
+		}
+	}
+	return 0, false
+}
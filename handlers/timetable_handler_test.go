@@ -0,0 +1,166 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"sms/apperrors"
+	"sms/constants"
+	"sms/handlers"
+	"sms/mocks"
+	"sms/models"
+	"sms/services"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestTimetableHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		role           constants.Role
+		target         string
+		pathValues     map[string]string
+		body           any
+		handle         func(h *handlers.TimetableHandler) http.HandlerFunc
+		mockService    func(mockTimetableService *mocks.MockTimetableServiceI)
+		expectedStatus int
+	}{
+		{
+			name:   "admin adds slot",
+			role:   "admin",
+			target: "/",
+			body:   map[string]any{"classID": "C1", "subjectID": "sub1", "facultyID": "fac1", "weekday": "Monday", "startTime": "09:00", "endTime": "10:00"},
+			handle: func(h *handlers.TimetableHandler) http.HandlerFunc { return h.AddSlot },
+			mockService: func(mockTimetableService *mocks.MockTimetableServiceI) {
+				mockTimetableService.EXPECT().AddSlot(gomock.Any(), models.TimetableSlot{ClassID: "C1", SubjectID: "sub1", FacultyID: "fac1", Weekday: time.Monday, StartTime: "09:00", EndTime: "10:00"}).
+					Return(&models.TimetableSlot{SlotID: "sl1"}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:   "conflicting slot",
+			role:   "admin",
+			target: "/",
+			body:   map[string]any{"classID": "C1", "subjectID": "sub1", "facultyID": "fac1", "weekday": "monday", "startTime": "09:00", "endTime": "10:00"},
+			handle: func(h *handlers.TimetableHandler) http.HandlerFunc { return h.AddSlot },
+			mockService: func(mockTimetableService *mocks.MockTimetableServiceI) {
+				mockTimetableService.EXPECT().AddSlot(gomock.Any(), gomock.Any()).Return(nil, apperrors.Conflict("%s: room Room 101 is booked", services.ErrTimetableConflict).Because(services.ErrTimetableConflict))
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "invalid weekday",
+			role:           "admin",
+			target:         "/",
+			body:           map[string]any{"weekday": "someday"},
+			handle:         func(h *handlers.TimetableHandler) http.HandlerFunc { return h.AddSlot },
+			mockService:    func(mockTimetableService *mocks.MockTimetableServiceI) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "faculty can't create room",
+			role:           "faculty",
+			target:         "/",
+			body:           map[string]any{"name": "Room 101", "capacity": 60},
+			handle:         func(h *handlers.TimetableHandler) http.HandlerFunc { return h.CreateRoom },
+			mockService:    func(mockTimetableService *mocks.MockTimetableServiceI) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:       "faculty reads own timetable",
+			role:       "faculty",
+			target:     "/",
+			pathValues: map[string]string{"facultyID": "fac1"},
+			handle:     func(h *handlers.TimetableHandler) http.HandlerFunc { return h.GetFacultyTimetable },
+			mockService: func(mockTimetableService *mocks.MockTimetableServiceI) {
+				mockTimetableService.EXPECT().GetFacultyTimetable(gomock.Any(), "fac1").Return([]models.TimetableSlot{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "faculty can't read another faculty's timetable",
+			role:           "faculty",
+			target:         "/",
+			pathValues:     map[string]string{"facultyID": "fac2"},
+			handle:         func(h *handlers.TimetableHandler) http.HandlerFunc { return h.GetFacultyTimetable },
+			mockService:    func(mockTimetableService *mocks.MockTimetableServiceI) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:       "export class calendar",
+			role:       "faculty",
+			target:     "/?from=2026-07-06&until=2026-11-30",
+			pathValues: map[string]string{"classID": "C1"},
+			handle:     func(h *handlers.TimetableHandler) http.HandlerFunc { return h.ExportClassCalendar },
+			mockService: func(mockTimetableService *mocks.MockTimetableServiceI) {
+				mockTimetableService.EXPECT().ClassCalendar(gomock.Any(), "C1", time.Date(2026, 7, 6, 0, 0, 0, 0, time.UTC), time.Date(2026, 11, 30, 0, 0, 0, 0, time.UTC)).
+					Return([]byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"), nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:       "export class calendar with quotes in the class",
+			role:       "faculty",
+			target:     "/?from=2026-07-06&until=2026-11-30",
+			pathValues: map[string]string{"classID": `C1"; filename="evil.exe`},
+			handle:     func(h *handlers.TimetableHandler) http.HandlerFunc { return h.ExportClassCalendar },
+			mockService: func(mockTimetableService *mocks.MockTimetableServiceI) {
+				mockTimetableService.EXPECT().ClassCalendar(gomock.Any(), `C1"; filename="evil.exe`, gomock.Any(), gomock.Any()).
+					Return([]byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"), nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "export without range",
+			role:           "admin",
+			target:         "/",
+			pathValues:     map[string]string{"facultyID": "fac1"},
+			handle:         func(h *handlers.TimetableHandler) http.HandlerFunc { return h.ExportFacultyCalendar },
+			mockService:    func(mockTimetableService *mocks.MockTimetableServiceI) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockTimetableService := mocks.NewMockTimetableServiceI(ctrl)
+			handler := handlers.NewTimetableHandler(mockTimetableService)
+
+			reqBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPost, tt.target, bytes.NewReader(reqBody))
+			ctx := context.WithValue(AddUserToContext(req.Context(), tt.role), constants.ContextUserIDKey, "fac1")
+			req = req.WithContext(ctx)
+			for k, v := range tt.pathValues {
+				req.SetPathValue(k, v)
+			}
+
+			tt.mockService(mockTimetableService)
+			rr := httptest.NewRecorder()
+
+			tt.handle(handler)(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+			if tt.expectedStatus == http.StatusOK && tt.name == "export class calendar" {
+				if ct := rr.Header().Get("Content-Type"); ct != "text/calendar; charset=utf-8" {
+					t.Errorf("expected calendar content type, got %q", ct)
+				}
+			}
+			if tt.expectedStatus == http.StatusOK && strings.HasPrefix(tt.name, "export class calendar") {
+				_, params, err := mime.ParseMediaType(rr.Header().Get("Content-Disposition"))
+				if want := "class-" + tt.pathValues["classID"] + ".ics"; err != nil || params["filename"] != want {
+					t.Errorf("expected filename %q, got %v (%v)", want, params, err)
+				}
+			}
+		})
+	}
+}
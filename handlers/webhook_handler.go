@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"net/http"
+	"sms/constants"
+	"sms/middleware"
+	"sms/models"
+	"sms/services"
+	"sms/utils"
+)
+
+type CreateWebhookRequest struct {
+	URL    string   `json:"url"`
+	Secret string   `json:"secret"`
+	Events []string `json:"events"`
+}
+
+type UpdateWebhookRequest struct {
+	Active *bool `json:"active" validate:"required"`
+}
+
+type WebhookHandler struct {
+	ws services.WebhookServiceI
+}
+
+func NewWebhookHandler(ws services.WebhookServiceI) *WebhookHandler {
+	return &WebhookHandler{ws: ws}
+}
+
+func (wh *WebhookHandler) CreateSubscription(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || role != constants.Admin {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only admin can access")
+		return
+	}
+	var req CreateWebhookRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+
+	sub := models.WebhookSubscription{URL: req.URL, Secret: req.Secret}
+	for _, event := range req.Events {
+		sub.Events = append(sub.Events, constants.WebhookEvent(event))
+	}
+	created, err := wh.ws.CreateSubscription(r.Context(), sub)
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusCreated, "webhook subscription created", created)
+}
+
+func (wh *WebhookHandler) GetSubscriptions(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || role != constants.Admin {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only admin can access")
+		return
+	}
+
+	subs, err := wh.ws.GetSubscriptions(r.Context())
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusOK, "ok", subs)
+}
+
+func (wh *WebhookHandler) GetSubscription(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || role != constants.Admin {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only admin can access")
+		return
+	}
+	subscriptionID := r.PathValue("subscriptionID")
+	if subscriptionID == "" {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid subscriptionID")
+		return
+	}
+
+	sub, err := wh.ws.GetSubscription(r.Context(), subscriptionID)
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusOK, "ok", sub)
+}
+
+func (wh *WebhookHandler) UpdateSubscription(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || role != constants.Admin {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only admin can access")
+		return
+	}
+	subscriptionID := r.PathValue("subscriptionID")
+	if subscriptionID == "" {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid subscriptionID")
+		return
+	}
+	var req UpdateWebhookRequest
+	if err := utils.DecodeJSON(w, r, &req); err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+
+	if err := wh.ws.SetSubscriptionActive(r.Context(), subscriptionID, *req.Active); err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusOK, "webhook subscription updated")
+}
+
+func (wh *WebhookHandler) GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || role != constants.Admin {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only admin can access")
+		return
+	}
+	subscriptionID := r.PathValue("subscriptionID")
+	if subscriptionID == "" {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid subscriptionID")
+		return
+	}
+
+	deliveries, err := wh.ws.GetDeliveries(r.Context(), subscriptionID)
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusOK, "ok", deliveries)
+}
+
+func (wh *WebhookHandler) GetDelivery(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || role != constants.Admin {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only admin can access")
+		return
+	}
+	deliveryID := r.PathValue("deliveryID")
+	if deliveryID == "" {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid deliveryID")
+		return
+	}
+
+	delivery, err := wh.ws.GetDelivery(r.Context(), deliveryID)
+	if err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusOK, "ok", delivery)
+}
+
+func (wh *WebhookHandler) Redeliver(w http.ResponseWriter, r *http.Request) {
+	role, err := middleware.GetUserRole(r.Context())
+	if err != nil || role != constants.Admin {
+		utils.CustomResponseSender(w, http.StatusForbidden, "only admin can access")
+		return
+	}
+	deliveryID := r.PathValue("deliveryID")
+	if deliveryID == "" {
+		utils.CustomResponseSender(w, http.StatusBadRequest, "invalid deliveryID")
+		return
+	}
+
+	if err := wh.ws.Redeliver(r.Context(), deliveryID); err != nil {
+		utils.ErrorResponseSender(w, err)
+		return
+	}
+	utils.CustomResponseSender(w, http.StatusAccepted, "delivery queued")
+}
@@ -0,0 +1,154 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sms/apperrors"
+	"sms/constants"
+	"sms/handlers"
+	"sms/mocks"
+	"sms/models"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestWebhookHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		role           constants.Role
+		pathValues     map[string]string
+		body           any
+		handle         func(h *handlers.WebhookHandler) http.HandlerFunc
+		mockService    func(mockWebhookService *mocks.MockWebhookServiceI)
+		expectedStatus int
+	}{
+		{
+			name:   "admin creates subscription",
+			role:   "admin",
+			body:   map[string]any{"url": "https://lms.example.com/hook", "events": []string{"grade.posted", "grade.changed"}},
+			handle: func(h *handlers.WebhookHandler) http.HandlerFunc { return h.CreateSubscription },
+			mockService: func(mockWebhookService *mocks.MockWebhookServiceI) {
+				mockWebhookService.EXPECT().CreateSubscription(gomock.Any(), models.WebhookSubscription{
+					URL:    "https://lms.example.com/hook",
+					Events: []constants.WebhookEvent{constants.WebhookGradePosted, constants.WebhookGradeChanged},
+				}).Return(&models.WebhookSubscription{SubscriptionID: "w1"}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:   "invalid subscription",
+			role:   "admin",
+			body:   map[string]any{"url": "lms"},
+			handle: func(h *handlers.WebhookHandler) http.HandlerFunc { return h.CreateSubscription },
+			mockService: func(mockWebhookService *mocks.MockWebhookServiceI) {
+				mockWebhookService.EXPECT().CreateSubscription(gomock.Any(), gomock.Any()).Return(nil, apperrors.Validation("url must be an http or https URL"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "faculty can't create subscription",
+			role:           "faculty",
+			body:           map[string]any{},
+			handle:         func(h *handlers.WebhookHandler) http.HandlerFunc { return h.CreateSubscription },
+			mockService:    func(mockWebhookService *mocks.MockWebhookServiceI) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:   "admin lists subscriptions",
+			role:   "admin",
+			handle: func(h *handlers.WebhookHandler) http.HandlerFunc { return h.GetSubscriptions },
+			mockService: func(mockWebhookService *mocks.MockWebhookServiceI) {
+				mockWebhookService.EXPECT().GetSubscriptions(gomock.Any()).Return([]models.WebhookSubscription{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:       "admin deactivates subscription",
+			role:       "admin",
+			pathValues: map[string]string{"subscriptionID": "w1"},
+			body:       map[string]any{"active": false},
+			handle:     func(h *handlers.WebhookHandler) http.HandlerFunc { return h.UpdateSubscription },
+			mockService: func(mockWebhookService *mocks.MockWebhookServiceI) {
+				mockWebhookService.EXPECT().SetSubscriptionActive(gomock.Any(), "w1", false).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "update without active",
+			role:           "admin",
+			pathValues:     map[string]string{"subscriptionID": "w1"},
+			body:           map[string]any{},
+			handle:         func(h *handlers.WebhookHandler) http.HandlerFunc { return h.UpdateSubscription },
+			mockService:    func(mockWebhookService *mocks.MockWebhookServiceI) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "admin lists deliveries",
+			role:       "admin",
+			pathValues: map[string]string{"subscriptionID": "w1"},
+			handle:     func(h *handlers.WebhookHandler) http.HandlerFunc { return h.GetDeliveries },
+			mockService: func(mockWebhookService *mocks.MockWebhookServiceI) {
+				mockWebhookService.EXPECT().GetDeliveries(gomock.Any(), "w1").Return([]models.WebhookDelivery{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:       "admin reads delivery log",
+			role:       "admin",
+			pathValues: map[string]string{"deliveryID": "d1"},
+			handle:     func(h *handlers.WebhookHandler) http.HandlerFunc { return h.GetDelivery },
+			mockService: func(mockWebhookService *mocks.MockWebhookServiceI) {
+				mockWebhookService.EXPECT().GetDelivery(gomock.Any(), "d1").Return(&models.WebhookDelivery{DeliveryID: "d1"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:       "admin redelivers",
+			role:       "admin",
+			pathValues: map[string]string{"deliveryID": "d1"},
+			handle:     func(h *handlers.WebhookHandler) http.HandlerFunc { return h.Redeliver },
+			mockService: func(mockWebhookService *mocks.MockWebhookServiceI) {
+				mockWebhookService.EXPECT().Redeliver(gomock.Any(), "d1").Return(nil)
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:       "redeliver missing delivery",
+			role:       "admin",
+			pathValues: map[string]string{"deliveryID": "missing"},
+			handle:     func(h *handlers.WebhookHandler) http.HandlerFunc { return h.Redeliver },
+			mockService: func(mockWebhookService *mocks.MockWebhookServiceI) {
+				mockWebhookService.EXPECT().Redeliver(gomock.Any(), "missing").Return(apperrors.NotFound("delivery not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockWebhookService := mocks.NewMockWebhookServiceI(ctrl)
+			handler := handlers.NewWebhookHandler(mockWebhookService)
+
+			reqBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(reqBody))
+			req = req.WithContext(AddUserToContext(req.Context(), tt.role))
+			for k, v := range tt.pathValues {
+				req.SetPathValue(k, v)
+			}
+
+			tt.mockService(mockWebhookService)
+			rr := httptest.NewRecorder()
+
+			tt.handle(handler)(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
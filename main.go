@@ -1,20 +1,54 @@
 package main
 
 import (
-	"database/sql"
+	"context"
+	"errors"
+	"flag"
 	"log"
+	"os"
+	"os/signal"
 	"sms/app"
+	"sms/config"
+	"sms/repository/storage"
+	"syscall"
 )
 
+// main loads the settings from the file, environment and flags (see package
+// config; at least SMS_JWT_SECRET has to be set), opens the database, creates
+// any missing tables and serves until SIGINT or SIGTERM. It then drains the
+// server and closes the database.
 func main() {
-	DB, error := InitDBWithDSN("sms.db")
+	cfg, err := config.Load(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatalf("invalid configuration:\n%v", err)
+	}
+	log.Printf("configuration:\n%s", cfg)
+
+	dialect, _ := storage.ParseDialect(cfg.Database.Driver)
+	DB, error := InitDBWithDSN(dialect, cfg.Database.DSN)
 	if error != nil {
 		log.Fatal(error.Error())
 	}
-	app.Start(DB)
+	if err := storage.Migrate(context.Background(), DB); err != nil {
+		log.Fatal(err.Error())
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	err = app.Run(ctx, DB, cfg)
+	if closeErr := DB.Close(); closeErr != nil {
+		err = errors.Join(err, closeErr)
+	}
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	log.Println("stopped")
 }
-func InitDBWithDSN(dsn string) (*sql.DB, error) {
-	db, err := sql.Open("sqlite", dsn)
+func InitDBWithDSN(dialect storage.Dialect, dsn string) (*storage.DB, error) {
+	db, err := storage.Open(dialect, dsn)
 	if err != nil {
 		return nil, err
 	}
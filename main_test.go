@@ -3,6 +3,7 @@ package main_test
 import (
 	"os"
 	main "sms"
+	"sms/repository/storage"
 	"testing"
 )
 
@@ -31,7 +32,7 @@ func TestInitDBWithDSN(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			db, err := main.InitDBWithDSN(tt.dsn)
+			db, err := main.InitDBWithDSN(storage.SQLite, tt.dsn)
 
 			if err != nil {
 				if tt.shouldSucceed {
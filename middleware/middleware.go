@@ -4,37 +4,44 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"sms/apperrors"
 	"sms/constants"
 	"sms/services"
+	"sms/utils"
 	"strings"
+	"time"
 )
 
-func JWTAuth(next http.HandlerFunc) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		authHeader := r.Header.Get("Authorization")
-		if authHeader == "" {
-			http.Error(w, "Authorization header missing", http.StatusUnauthorized)
-			return
-		}
+// JWTAuth returns the middleware that lets a request through only with a bearer
+// token signed by tokens, and puts the user it names on the request context.
+func JWTAuth(tokens *services.JWT) func(next http.HandlerFunc) http.HandlerFunc {
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			authHeader := r.Header.Get("Authorization")
+			if authHeader == "" {
+				utils.ErrorResponseSender(w, apperrors.Unauthorized("authorization header missing"))
+				return
+			}
 
-		parts := strings.SplitN(authHeader, " ", 2)
-		if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
-			http.Error(w, "Invalid Authorization header format", http.StatusUnauthorized)
-			return
-		}
+			parts := strings.SplitN(authHeader, " ", 2)
+			if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
+				utils.ErrorResponseSender(w, apperrors.Unauthorized("invalid authorization header format"))
+				return
+			}
 
-		tokenString := parts[1]
+			tokenString := parts[1]
 
-		claims, err := services.ValidateJWT(tokenString)
-		if err != nil {
-			http.Error(w, "Invalid or expired token", http.StatusUnauthorized)
-			return
-		}
-		ctx := context.WithValue(r.Context(), constants.ContextUserIDKey, claims.UserID)
-		ctx = context.WithValue(ctx, constants.ContextUserEmailKey, claims.Email)
-		ctx = context.WithValue(ctx, constants.ContextUserRoleKey, claims.Role)
+			claims, err := tokens.Validate(tokenString)
+			if err != nil {
+				utils.ErrorResponseSender(w, apperrors.Unauthorized("invalid or expired token"))
+				return
+			}
+			ctx := context.WithValue(r.Context(), constants.ContextUserIDKey, claims.UserID)
+			ctx = context.WithValue(ctx, constants.ContextUserEmailKey, claims.Email)
+			ctx = context.WithValue(ctx, constants.ContextUserRoleKey, claims.Role)
 
-		next(w, r.WithContext(ctx))
+			next(w, r.WithContext(ctx))
+		}
 	}
 }
 
@@ -61,3 +68,14 @@ func GetUserRole(ctx context.Context) (constants.Role, error) {
 	}
 	return role, nil
 }
+
+// Timeout gives every request a deadline, so the queries of a slow request are
+// cancelled instead of holding on to a connection. net/http already cancels the
+// request context when the client goes away.
+func Timeout(d time.Duration, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), d)
+		defer cancel()
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -2,12 +2,15 @@ package middleware_test
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"sms/constants"
 	"sms/middleware"
 	"sms/services"
+	"sms/utils"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -29,7 +32,13 @@ func TestJWTAuth(t *testing.T) {
 		w.WriteHeader(http.StatusOK)
 	})
 
-	validToken, err := services.GenerateJWT("u1", "user@example.com", "faculty")
+	tokens, err := services.NewJWT([]byte("0123456789abcdef0123456789abcdef"), time.Hour)
+	assert.NoError(t, err)
+	validToken, err := tokens.Generate("u1", "user@example.com", "faculty")
+	assert.NoError(t, err)
+	otherTokens, err := services.NewJWT([]byte("another-secret-of-at-least-32-bytes"), time.Hour)
+	assert.NoError(t, err)
+	forgedToken, err := otherTokens.Generate("u1", "user@example.com", "admin")
 	assert.NoError(t, err)
 
 	tests := []struct {
@@ -52,6 +61,11 @@ func TestJWTAuth(t *testing.T) {
 			authHeader:     "Bearer invalid-token",
 			expectedStatus: http.StatusUnauthorized,
 		},
+		{
+			name:           "token signed with another secret",
+			authHeader:     "Bearer " + forgedToken,
+			expectedStatus: http.StatusUnauthorized,
+		},
 		{
 			name:           "valid token",
 			authHeader:     "Bearer " + validToken,
@@ -67,13 +81,19 @@ func TestJWTAuth(t *testing.T) {
 			}
 			w := httptest.NewRecorder()
 
-			handler := middleware.JWTAuth(nextHandler)
+			handler := middleware.JWTAuth(tokens)(nextHandler)
 			handler.ServeHTTP(w, req)
 
 			res := w.Result()
 			defer res.Body.Close()
 
 			assert.Equal(t, tt.expectedStatus, res.StatusCode)
+			if tt.expectedStatus == http.StatusUnauthorized {
+				var body utils.CustomResponse
+				assert.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+				assert.Equal(t, "unauthorized", body.Code)
+				assert.Equal(t, http.StatusUnauthorized, body.StatusCode)
+			}
 		})
 	}
 }
@@ -92,3 +112,35 @@ func TestGetUserHelpers_NotFound(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, "user Role not found in context", err.Error())
 }
+
+func TestTimeout(t *testing.T) {
+	var deadline time.Time
+	var hasDeadline bool
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deadline, hasDeadline = r.Context().Deadline()
+		w.WriteHeader(http.StatusOK)
+	})
+
+	start := time.Now()
+	rr := httptest.NewRecorder()
+	middleware.Timeout(time.Second, next).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.True(t, hasDeadline)
+	assert.WithinDuration(t, start.Add(time.Second), deadline, 100*time.Millisecond)
+}
+
+func TestTimeoutCancelsWhenClientLeaves(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cancel()
+		<-r.Context().Done()
+		done <- r.Context().Err()
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+	middleware.Timeout(time.Minute, next).ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.ErrorIs(t, <-done, context.Canceled)
+}
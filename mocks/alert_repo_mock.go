@@ -0,0 +1,107 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../mocks/alert_repo_mock.go -package=mocks -source=interface.go
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "sms/models"
+	alertRepository "sms/repository/alertRepository"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAlertRepositoryI is a mock of AlertRepositoryI interface.
+type MockAlertRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockAlertRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockAlertRepositoryIMockRecorder is the mock recorder for MockAlertRepositoryI.
+type MockAlertRepositoryIMockRecorder struct {
+	mock *MockAlertRepositoryI
+}
+
+// NewMockAlertRepositoryI creates a new mock instance.
+func NewMockAlertRepositoryI(ctrl *gomock.Controller) *MockAlertRepositoryI {
+	mock := &MockAlertRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockAlertRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAlertRepositoryI) EXPECT() *MockAlertRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// GetCurrentSemesters mocks base method.
+func (m *MockAlertRepositoryI) GetCurrentSemesters(ctx context.Context) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentSemesters", ctx)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentSemesters indicates an expected call of GetCurrentSemesters.
+func (mr *MockAlertRepositoryIMockRecorder) GetCurrentSemesters(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentSemesters", reflect.TypeOf((*MockAlertRepositoryI)(nil).GetCurrentSemesters), ctx)
+}
+
+// GetFlags mocks base method.
+func (m *MockAlertRepositoryI) GetFlags(ctx context.Context, semester int) ([]models.AtRiskFlag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFlags", ctx, semester)
+	ret0, _ := ret[0].([]models.AtRiskFlag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFlags indicates an expected call of GetFlags.
+func (mr *MockAlertRepositoryIMockRecorder) GetFlags(ctx, semester any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFlags", reflect.TypeOf((*MockAlertRepositoryI)(nil).GetFlags), ctx, semester)
+}
+
+// GetStudentGrades mocks base method.
+func (m *MockAlertRepositoryI) GetStudentGrades(ctx context.Context, semesters ...int) ([]alertRepository.StudentGrade, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range semesters {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetStudentGrades", varargs...)
+	ret0, _ := ret[0].([]alertRepository.StudentGrade)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStudentGrades indicates an expected call of GetStudentGrades.
+func (mr *MockAlertRepositoryIMockRecorder) GetStudentGrades(ctx any, semesters ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, semesters...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStudentGrades", reflect.TypeOf((*MockAlertRepositoryI)(nil).GetStudentGrades), varargs...)
+}
+
+// ReplaceFlags mocks base method.
+func (m *MockAlertRepositoryI) ReplaceFlags(ctx context.Context, semester int, flags []models.AtRiskFlag) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceFlags", ctx, semester, flags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceFlags indicates an expected call of ReplaceFlags.
+func (mr *MockAlertRepositoryIMockRecorder) ReplaceFlags(ctx, semester, flags any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceFlags", reflect.TypeOf((*MockAlertRepositoryI)(nil).ReplaceFlags), ctx, semester, flags)
+}
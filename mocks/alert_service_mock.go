@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: alert_service_interface.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=../mocks/alert_service_mock.go -package=mocks -source=alert_service_interface.go
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "sms/models"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAlertServiceI is a mock of AlertServiceI interface.
+type MockAlertServiceI struct {
+	ctrl     *gomock.Controller
+	recorder *MockAlertServiceIMockRecorder
+	isgomock struct{}
+}
+
+// MockAlertServiceIMockRecorder is the mock recorder for MockAlertServiceI.
+type MockAlertServiceIMockRecorder struct {
+	mock *MockAlertServiceI
+}
+
+// NewMockAlertServiceI creates a new mock instance.
+func NewMockAlertServiceI(ctrl *gomock.Controller) *MockAlertServiceI {
+	mock := &MockAlertServiceI{ctrl: ctrl}
+	mock.recorder = &MockAlertServiceIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAlertServiceI) EXPECT() *MockAlertServiceIMockRecorder {
+	return m.recorder
+}
+
+// DetectAtRisk mocks base method.
+func (m *MockAlertServiceI) DetectAtRisk(ctx context.Context, semester int, criteria models.AtRiskCriteria) ([]models.AtRiskFlag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetectAtRisk", ctx, semester, criteria)
+	ret0, _ := ret[0].([]models.AtRiskFlag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DetectAtRisk indicates an expected call of DetectAtRisk.
+func (mr *MockAlertServiceIMockRecorder) DetectAtRisk(ctx, semester, criteria any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetectAtRisk", reflect.TypeOf((*MockAlertServiceI)(nil).DetectAtRisk), ctx, semester, criteria)
+}
+
+// GetAtRiskFlags mocks base method.
+func (m *MockAlertServiceI) GetAtRiskFlags(ctx context.Context, semester int) ([]models.AtRiskFlag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAtRiskFlags", ctx, semester)
+	ret0, _ := ret[0].([]models.AtRiskFlag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAtRiskFlags indicates an expected call of GetAtRiskFlags.
+func (mr *MockAlertServiceIMockRecorder) GetAtRiskFlags(ctx, semester any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAtRiskFlags", reflect.TypeOf((*MockAlertServiceI)(nil).GetAtRiskFlags), ctx, semester)
+}
@@ -0,0 +1,150 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../mocks/assessment_repo_mock.go -package=mocks -source=interface.go
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "sms/models"
+	assessmentRepository "sms/repository/assessmentRepository"
+	transaction "sms/repository/transaction"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAssessmentRepositoryI is a mock of AssessmentRepositoryI interface.
+type MockAssessmentRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockAssessmentRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockAssessmentRepositoryIMockRecorder is the mock recorder for MockAssessmentRepositoryI.
+type MockAssessmentRepositoryIMockRecorder struct {
+	mock *MockAssessmentRepositoryI
+}
+
+// NewMockAssessmentRepositoryI creates a new mock instance.
+func NewMockAssessmentRepositoryI(ctrl *gomock.Controller) *MockAssessmentRepositoryI {
+	mock := &MockAssessmentRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockAssessmentRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAssessmentRepositoryI) EXPECT() *MockAssessmentRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// AddAssessment mocks base method.
+func (m *MockAssessmentRepositoryI) AddAssessment(ctx context.Context, assessment models.Assessment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAssessment", ctx, assessment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddAssessment indicates an expected call of AddAssessment.
+func (mr *MockAssessmentRepositoryIMockRecorder) AddAssessment(ctx, assessment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAssessment", reflect.TypeOf((*MockAssessmentRepositoryI)(nil).AddAssessment), ctx, assessment)
+}
+
+// GetAssessment mocks base method.
+func (m *MockAssessmentRepositoryI) GetAssessment(ctx context.Context, assessmentID string) (*models.Assessment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssessment", ctx, assessmentID)
+	ret0, _ := ret[0].(*models.Assessment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssessment indicates an expected call of GetAssessment.
+func (mr *MockAssessmentRepositoryIMockRecorder) GetAssessment(ctx, assessmentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssessment", reflect.TypeOf((*MockAssessmentRepositoryI)(nil).GetAssessment), ctx, assessmentID)
+}
+
+// GetAssessments mocks base method.
+func (m *MockAssessmentRepositoryI) GetAssessments(ctx context.Context, subjectID string, semester int) ([]models.Assessment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssessments", ctx, subjectID, semester)
+	ret0, _ := ret[0].([]models.Assessment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssessments indicates an expected call of GetAssessments.
+func (mr *MockAssessmentRepositoryIMockRecorder) GetAssessments(ctx, subjectID, semester any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssessments", reflect.TypeOf((*MockAssessmentRepositoryI)(nil).GetAssessments), ctx, subjectID, semester)
+}
+
+// GetScores mocks base method.
+func (m *MockAssessmentRepositoryI) GetScores(ctx context.Context, subjectID string, semester int, studentIDs ...string) ([]models.AssessmentScore, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, subjectID, semester}
+	for _, a := range studentIDs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetScores", varargs...)
+	ret0, _ := ret[0].([]models.AssessmentScore)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScores indicates an expected call of GetScores.
+func (mr *MockAssessmentRepositoryIMockRecorder) GetScores(ctx, subjectID, semester any, studentIDs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, subjectID, semester}, studentIDs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScores", reflect.TypeOf((*MockAssessmentRepositoryI)(nil).GetScores), varargs...)
+}
+
+// SaveScores mocks base method.
+func (m *MockAssessmentRepositoryI) SaveScores(ctx context.Context, scores []models.AssessmentScore) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveScores", ctx, scores)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveScores indicates an expected call of SaveScores.
+func (mr *MockAssessmentRepositoryIMockRecorder) SaveScores(ctx, scores any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveScores", reflect.TypeOf((*MockAssessmentRepositoryI)(nil).SaveScores), ctx, scores)
+}
+
+// UpdateAssessment mocks base method.
+func (m *MockAssessmentRepositoryI) UpdateAssessment(ctx context.Context, assessmentID string, weight float64, maxMarks int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAssessment", ctx, assessmentID, weight, maxMarks)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAssessment indicates an expected call of UpdateAssessment.
+func (mr *MockAssessmentRepositoryIMockRecorder) UpdateAssessment(ctx, assessmentID, weight, maxMarks any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAssessment", reflect.TypeOf((*MockAssessmentRepositoryI)(nil).UpdateAssessment), ctx, assessmentID, weight, maxMarks)
+}
+
+// WithTx mocks base method.
+func (m *MockAssessmentRepositoryI) WithTx(tx transaction.Querier) assessmentRepository.AssessmentRepositoryI {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", tx)
+	ret0, _ := ret[0].(assessmentRepository.AssessmentRepositoryI)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockAssessmentRepositoryIMockRecorder) WithTx(tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockAssessmentRepositoryI)(nil).WithTx), tx)
+}
@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../mocks/attendance_repo_mock.go -package=mocks -source=interface.go
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "sms/models"
+	attendanceRepository "sms/repository/attendanceRepository"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAttendanceRepositoryI is a mock of AttendanceRepositoryI interface.
+type MockAttendanceRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttendanceRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockAttendanceRepositoryIMockRecorder is the mock recorder for MockAttendanceRepositoryI.
+type MockAttendanceRepositoryIMockRecorder struct {
+	mock *MockAttendanceRepositoryI
+}
+
+// NewMockAttendanceRepositoryI creates a new mock instance.
+func NewMockAttendanceRepositoryI(ctrl *gomock.Controller) *MockAttendanceRepositoryI {
+	mock := &MockAttendanceRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockAttendanceRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttendanceRepositoryI) EXPECT() *MockAttendanceRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// AddSession mocks base method.
+func (m *MockAttendanceRepositoryI) AddSession(ctx context.Context, session models.AttendanceSession) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSession", ctx, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddSession indicates an expected call of AddSession.
+func (mr *MockAttendanceRepositoryIMockRecorder) AddSession(ctx, session any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSession", reflect.TypeOf((*MockAttendanceRepositoryI)(nil).AddSession), ctx, session)
+}
+
+// GetClassStatusCounts mocks base method.
+func (m *MockAttendanceRepositoryI) GetClassStatusCounts(ctx context.Context, classID, subjectID string, semester int) ([]attendanceRepository.StatusCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClassStatusCounts", ctx, classID, subjectID, semester)
+	ret0, _ := ret[0].([]attendanceRepository.StatusCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClassStatusCounts indicates an expected call of GetClassStatusCounts.
+func (mr *MockAttendanceRepositoryIMockRecorder) GetClassStatusCounts(ctx, classID, subjectID, semester any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClassStatusCounts", reflect.TypeOf((*MockAttendanceRepositoryI)(nil).GetClassStatusCounts), ctx, classID, subjectID, semester)
+}
+
+// GetSession mocks base method.
+func (m *MockAttendanceRepositoryI) GetSession(ctx context.Context, sessionID string) (*models.AttendanceSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", ctx, sessionID)
+	ret0, _ := ret[0].(*models.AttendanceSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockAttendanceRepositoryIMockRecorder) GetSession(ctx, sessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockAttendanceRepositoryI)(nil).GetSession), ctx, sessionID)
+}
+
+// GetStudentStatusCounts mocks base method.
+func (m *MockAttendanceRepositoryI) GetStudentStatusCounts(ctx context.Context, studentID, subjectID string, semester int) ([]attendanceRepository.StatusCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStudentStatusCounts", ctx, studentID, subjectID, semester)
+	ret0, _ := ret[0].([]attendanceRepository.StatusCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStudentStatusCounts indicates an expected call of GetStudentStatusCounts.
+func (mr *MockAttendanceRepositoryIMockRecorder) GetStudentStatusCounts(ctx, studentID, subjectID, semester any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStudentStatusCounts", reflect.TypeOf((*MockAttendanceRepositoryI)(nil).GetStudentStatusCounts), ctx, studentID, subjectID, semester)
+}
+
+// MarkAttendance mocks base method.
+func (m *MockAttendanceRepositoryI) MarkAttendance(ctx context.Context, marks []models.AttendanceMark) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAttendance", ctx, marks)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAttendance indicates an expected call of MarkAttendance.
+func (mr *MockAttendanceRepositoryIMockRecorder) MarkAttendance(ctx, marks any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAttendance", reflect.TypeOf((*MockAttendanceRepositoryI)(nil).MarkAttendance), ctx, marks)
+}
@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: attendance_service_interface.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=../mocks/attendance_service_mock.go -package=mocks -source=attendance_service_interface.go
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "sms/models"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAttendanceServiceI is a mock of AttendanceServiceI interface.
+type MockAttendanceServiceI struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttendanceServiceIMockRecorder
+	isgomock struct{}
+}
+
+// MockAttendanceServiceIMockRecorder is the mock recorder for MockAttendanceServiceI.
+type MockAttendanceServiceIMockRecorder struct {
+	mock *MockAttendanceServiceI
+}
+
+// NewMockAttendanceServiceI creates a new mock instance.
+func NewMockAttendanceServiceI(ctrl *gomock.Controller) *MockAttendanceServiceI {
+	mock := &MockAttendanceServiceI{ctrl: ctrl}
+	mock.recorder = &MockAttendanceServiceIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttendanceServiceI) EXPECT() *MockAttendanceServiceIMockRecorder {
+	return m.recorder
+}
+
+// CanBeGraded mocks base method.
+func (m *MockAttendanceServiceI) CanBeGraded(ctx context.Context, studentID, subjectID string, semester int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CanBeGraded", ctx, studentID, subjectID, semester)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CanBeGraded indicates an expected call of CanBeGraded.
+func (mr *MockAttendanceServiceIMockRecorder) CanBeGraded(ctx, studentID, subjectID, semester any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanBeGraded", reflect.TypeOf((*MockAttendanceServiceI)(nil).CanBeGraded), ctx, studentID, subjectID, semester)
+}
+
+// CreateSession mocks base method.
+func (m *MockAttendanceServiceI) CreateSession(ctx context.Context, classID, subjectID string, semester int, date time.Time, createdBy string) (*models.AttendanceSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", ctx, classID, subjectID, semester, date, createdBy)
+	ret0, _ := ret[0].(*models.AttendanceSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockAttendanceServiceIMockRecorder) CreateSession(ctx, classID, subjectID, semester, date, createdBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockAttendanceServiceI)(nil).CreateSession), ctx, classID, subjectID, semester, date, createdBy)
+}
+
+// GetClassAttendance mocks base method.
+func (m *MockAttendanceServiceI) GetClassAttendance(ctx context.Context, classID, subjectID string, semester int) ([]models.AttendanceSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClassAttendance", ctx, classID, subjectID, semester)
+	ret0, _ := ret[0].([]models.AttendanceSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClassAttendance indicates an expected call of GetClassAttendance.
+func (mr *MockAttendanceServiceIMockRecorder) GetClassAttendance(ctx, classID, subjectID, semester any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClassAttendance", reflect.TypeOf((*MockAttendanceServiceI)(nil).GetClassAttendance), ctx, classID, subjectID, semester)
+}
+
+// GetStudentAttendance mocks base method.
+func (m *MockAttendanceServiceI) GetStudentAttendance(ctx context.Context, studentID, subjectID string, semester int) (*models.AttendanceSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStudentAttendance", ctx, studentID, subjectID, semester)
+	ret0, _ := ret[0].(*models.AttendanceSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStudentAttendance indicates an expected call of GetStudentAttendance.
+func (mr *MockAttendanceServiceIMockRecorder) GetStudentAttendance(ctx, studentID, subjectID, semester any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStudentAttendance", reflect.TypeOf((*MockAttendanceServiceI)(nil).GetStudentAttendance), ctx, studentID, subjectID, semester)
+}
+
+// MarkAttendance mocks base method.
+func (m *MockAttendanceServiceI) MarkAttendance(ctx context.Context, sessionID string, marks []models.AttendanceMark) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAttendance", ctx, sessionID, marks)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAttendance indicates an expected call of MarkAttendance.
+func (mr *MockAttendanceServiceIMockRecorder) MarkAttendance(ctx, sessionID, marks any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAttendance", reflect.TypeOf((*MockAttendanceServiceI)(nil).MarkAttendance), ctx, sessionID, marks)
+}
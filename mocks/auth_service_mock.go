@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	constants "sms/constants"
 	models "sms/models"
 
 	gomock "go.uber.org/mock/gomock"
@@ -41,6 +42,50 @@ func (m *MockAuthServiceI) EXPECT() *MockAuthServiceIMockRecorder {
 	return m.recorder
 }
 
+// CreateAccount mocks base method.
+func (m *MockAuthServiceI) CreateAccount(ctx context.Context, name, email, password string, role constants.Role) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccount", ctx, name, email, password, role)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAccount indicates an expected call of CreateAccount.
+func (mr *MockAuthServiceIMockRecorder) CreateAccount(ctx, name, email, password, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockAuthServiceI)(nil).CreateAccount), ctx, name, email, password, role)
+}
+
+// IssueToken mocks base method.
+func (m *MockAuthServiceI) IssueToken(user models.User) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueToken", user)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueToken indicates an expected call of IssueToken.
+func (mr *MockAuthServiceIMockRecorder) IssueToken(user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueToken", reflect.TypeOf((*MockAuthServiceI)(nil).IssueToken), user)
+}
+
+// ResetPassword mocks base method.
+func (m *MockAuthServiceI) ResetPassword(ctx context.Context, email, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, email, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockAuthServiceIMockRecorder) ResetPassword(ctx, email, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockAuthServiceI)(nil).ResetPassword), ctx, email, password)
+}
+
 // Signup mocks base method.
 func (m *MockAuthServiceI) Signup(ctx context.Context, name, email, password string) (models.User, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../mocks/class_repo_mock.go -package=mocks -source=interface.go
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "sms/models"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockClassRepositoryI is a mock of ClassRepositoryI interface.
+type MockClassRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockClassRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockClassRepositoryIMockRecorder is the mock recorder for MockClassRepositoryI.
+type MockClassRepositoryIMockRecorder struct {
+	mock *MockClassRepositoryI
+}
+
+// NewMockClassRepositoryI creates a new mock instance.
+func NewMockClassRepositoryI(ctrl *gomock.Controller) *MockClassRepositoryI {
+	mock := &MockClassRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockClassRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClassRepositoryI) EXPECT() *MockClassRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// AddClass mocks base method.
+func (m *MockClassRepositoryI) AddClass(ctx context.Context, class models.Class) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddClass", ctx, class)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddClass indicates an expected call of AddClass.
+func (mr *MockClassRepositoryIMockRecorder) AddClass(ctx, class any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddClass", reflect.TypeOf((*MockClassRepositoryI)(nil).AddClass), ctx, class)
+}
+
+// GetClasses mocks base method.
+func (m *MockClassRepositoryI) GetClasses(ctx context.Context) ([]models.Class, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClasses", ctx)
+	ret0, _ := ret[0].([]models.Class)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClasses indicates an expected call of GetClasses.
+func (mr *MockClassRepositoryIMockRecorder) GetClasses(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClasses", reflect.TypeOf((*MockClassRepositoryI)(nil).GetClasses), ctx)
+}
@@ -0,0 +1,144 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../mocks/curriculum_repo_mock.go -package=mocks -source=interface.go
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "sms/models"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCurriculumRepositoryI is a mock of CurriculumRepositoryI interface.
+type MockCurriculumRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockCurriculumRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockCurriculumRepositoryIMockRecorder is the mock recorder for MockCurriculumRepositoryI.
+type MockCurriculumRepositoryIMockRecorder struct {
+	mock *MockCurriculumRepositoryI
+}
+
+// NewMockCurriculumRepositoryI creates a new mock instance.
+func NewMockCurriculumRepositoryI(ctrl *gomock.Controller) *MockCurriculumRepositoryI {
+	mock := &MockCurriculumRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockCurriculumRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCurriculumRepositoryI) EXPECT() *MockCurriculumRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// AddProgram mocks base method.
+func (m *MockCurriculumRepositoryI) AddProgram(ctx context.Context, program models.Program) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddProgram", ctx, program)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddProgram indicates an expected call of AddProgram.
+func (mr *MockCurriculumRepositoryIMockRecorder) AddProgram(ctx, program any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProgram", reflect.TypeOf((*MockCurriculumRepositoryI)(nil).AddProgram), ctx, program)
+}
+
+// AddProgramSubject mocks base method.
+func (m *MockCurriculumRepositoryI) AddProgramSubject(ctx context.Context, subject models.ProgramSubject) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddProgramSubject", ctx, subject)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddProgramSubject indicates an expected call of AddProgramSubject.
+func (mr *MockCurriculumRepositoryIMockRecorder) AddProgramSubject(ctx, subject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProgramSubject", reflect.TypeOf((*MockCurriculumRepositoryI)(nil).AddProgramSubject), ctx, subject)
+}
+
+// GetClassProgram mocks base method.
+func (m *MockCurriculumRepositoryI) GetClassProgram(ctx context.Context, classID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClassProgram", ctx, classID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClassProgram indicates an expected call of GetClassProgram.
+func (mr *MockCurriculumRepositoryIMockRecorder) GetClassProgram(ctx, classID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClassProgram", reflect.TypeOf((*MockCurriculumRepositoryI)(nil).GetClassProgram), ctx, classID)
+}
+
+// GetProgram mocks base method.
+func (m *MockCurriculumRepositoryI) GetProgram(ctx context.Context, programID string) (*models.Program, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProgram", ctx, programID)
+	ret0, _ := ret[0].(*models.Program)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProgram indicates an expected call of GetProgram.
+func (mr *MockCurriculumRepositoryIMockRecorder) GetProgram(ctx, programID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProgram", reflect.TypeOf((*MockCurriculumRepositoryI)(nil).GetProgram), ctx, programID)
+}
+
+// GetProgramSubject mocks base method.
+func (m *MockCurriculumRepositoryI) GetProgramSubject(ctx context.Context, programID, subjectID string) (*models.ProgramSubject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProgramSubject", ctx, programID, subjectID)
+	ret0, _ := ret[0].(*models.ProgramSubject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProgramSubject indicates an expected call of GetProgramSubject.
+func (mr *MockCurriculumRepositoryIMockRecorder) GetProgramSubject(ctx, programID, subjectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProgramSubject", reflect.TypeOf((*MockCurriculumRepositoryI)(nil).GetProgramSubject), ctx, programID, subjectID)
+}
+
+// GetProgramSubjects mocks base method.
+func (m *MockCurriculumRepositoryI) GetProgramSubjects(ctx context.Context, programID string, semester int) ([]models.ProgramSubject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProgramSubjects", ctx, programID, semester)
+	ret0, _ := ret[0].([]models.ProgramSubject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProgramSubjects indicates an expected call of GetProgramSubjects.
+func (mr *MockCurriculumRepositoryIMockRecorder) GetProgramSubjects(ctx, programID, semester any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProgramSubjects", reflect.TypeOf((*MockCurriculumRepositoryI)(nil).GetProgramSubjects), ctx, programID, semester)
+}
+
+// SetClassProgram mocks base method.
+func (m *MockCurriculumRepositoryI) SetClassProgram(ctx context.Context, classID, programID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetClassProgram", ctx, classID, programID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetClassProgram indicates an expected call of SetClassProgram.
+func (mr *MockCurriculumRepositoryIMockRecorder) SetClassProgram(ctx, classID, programID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetClassProgram", reflect.TypeOf((*MockCurriculumRepositoryI)(nil).SetClassProgram), ctx, classID, programID)
+}
@@ -0,0 +1,144 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: curriculum_service_interface.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=../mocks/curriculum_service_mock.go -package=mocks -source=curriculum_service_interface.go
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "sms/models"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCurriculumServiceI is a mock of CurriculumServiceI interface.
+type MockCurriculumServiceI struct {
+	ctrl     *gomock.Controller
+	recorder *MockCurriculumServiceIMockRecorder
+	isgomock struct{}
+}
+
+// MockCurriculumServiceIMockRecorder is the mock recorder for MockCurriculumServiceI.
+type MockCurriculumServiceIMockRecorder struct {
+	mock *MockCurriculumServiceI
+}
+
+// NewMockCurriculumServiceI creates a new mock instance.
+func NewMockCurriculumServiceI(ctrl *gomock.Controller) *MockCurriculumServiceI {
+	mock := &MockCurriculumServiceI{ctrl: ctrl}
+	mock.recorder = &MockCurriculumServiceIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCurriculumServiceI) EXPECT() *MockCurriculumServiceIMockRecorder {
+	return m.recorder
+}
+
+// AddProgramSubject mocks base method.
+func (m *MockCurriculumServiceI) AddProgramSubject(ctx context.Context, subject models.ProgramSubject) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddProgramSubject", ctx, subject)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddProgramSubject indicates an expected call of AddProgramSubject.
+func (mr *MockCurriculumServiceIMockRecorder) AddProgramSubject(ctx, subject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProgramSubject", reflect.TypeOf((*MockCurriculumServiceI)(nil).AddProgramSubject), ctx, subject)
+}
+
+// AssignClassProgram mocks base method.
+func (m *MockCurriculumServiceI) AssignClassProgram(ctx context.Context, classID, programID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignClassProgram", ctx, classID, programID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignClassProgram indicates an expected call of AssignClassProgram.
+func (mr *MockCurriculumServiceIMockRecorder) AssignClassProgram(ctx, classID, programID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignClassProgram", reflect.TypeOf((*MockCurriculumServiceI)(nil).AssignClassProgram), ctx, classID, programID)
+}
+
+// AutoEnrollClass mocks base method.
+func (m *MockCurriculumServiceI) AutoEnrollClass(ctx context.Context, classID string, semester int) ([]models.Enrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AutoEnrollClass", ctx, classID, semester)
+	ret0, _ := ret[0].([]models.Enrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AutoEnrollClass indicates an expected call of AutoEnrollClass.
+func (mr *MockCurriculumServiceIMockRecorder) AutoEnrollClass(ctx, classID, semester any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AutoEnrollClass", reflect.TypeOf((*MockCurriculumServiceI)(nil).AutoEnrollClass), ctx, classID, semester)
+}
+
+// CreateProgram mocks base method.
+func (m *MockCurriculumServiceI) CreateProgram(ctx context.Context, name string, semesters int) (*models.Program, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProgram", ctx, name, semesters)
+	ret0, _ := ret[0].(*models.Program)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProgram indicates an expected call of CreateProgram.
+func (mr *MockCurriculumServiceIMockRecorder) CreateProgram(ctx, name, semesters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProgram", reflect.TypeOf((*MockCurriculumServiceI)(nil).CreateProgram), ctx, name, semesters)
+}
+
+// EnrollElective mocks base method.
+func (m *MockCurriculumServiceI) EnrollElective(ctx context.Context, studentID, subjectID string, semester int) (*models.Enrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollElective", ctx, studentID, subjectID, semester)
+	ret0, _ := ret[0].(*models.Enrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrollElective indicates an expected call of EnrollElective.
+func (mr *MockCurriculumServiceIMockRecorder) EnrollElective(ctx, studentID, subjectID, semester any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollElective", reflect.TypeOf((*MockCurriculumServiceI)(nil).EnrollElective), ctx, studentID, subjectID, semester)
+}
+
+// GetCurriculum mocks base method.
+func (m *MockCurriculumServiceI) GetCurriculum(ctx context.Context, programID string, semester int) ([]models.ProgramSubject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurriculum", ctx, programID, semester)
+	ret0, _ := ret[0].([]models.ProgramSubject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurriculum indicates an expected call of GetCurriculum.
+func (mr *MockCurriculumServiceIMockRecorder) GetCurriculum(ctx, programID, semester any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurriculum", reflect.TypeOf((*MockCurriculumServiceI)(nil).GetCurriculum), ctx, programID, semester)
+}
+
+// ValidateElective mocks base method.
+func (m *MockCurriculumServiceI) ValidateElective(ctx context.Context, studentID, subjectID string, semester int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateElective", ctx, studentID, subjectID, semester)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateElective indicates an expected call of ValidateElective.
+func (mr *MockCurriculumServiceIMockRecorder) ValidateElective(ctx, studentID, subjectID, semester any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateElective", reflect.TypeOf((*MockCurriculumServiceI)(nil).ValidateElective), ctx, studentID, subjectID, semester)
+}
@@ -0,0 +1,117 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../mocks/enrollment_repo_mock.go -package=mocks -source=interface.go
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "sms/models"
+	enrollmentRepository "sms/repository/enrollmentRepository"
+	transaction "sms/repository/transaction"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEnrollmentRepositoryI is a mock of EnrollmentRepositoryI interface.
+type MockEnrollmentRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockEnrollmentRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockEnrollmentRepositoryIMockRecorder is the mock recorder for MockEnrollmentRepositoryI.
+type MockEnrollmentRepositoryIMockRecorder struct {
+	mock *MockEnrollmentRepositoryI
+}
+
+// NewMockEnrollmentRepositoryI creates a new mock instance.
+func NewMockEnrollmentRepositoryI(ctrl *gomock.Controller) *MockEnrollmentRepositoryI {
+	mock := &MockEnrollmentRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockEnrollmentRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEnrollmentRepositoryI) EXPECT() *MockEnrollmentRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// AddEnrollments mocks base method.
+func (m *MockEnrollmentRepositoryI) AddEnrollments(ctx context.Context, enrollments []models.Enrollment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddEnrollments", ctx, enrollments)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddEnrollments indicates an expected call of AddEnrollments.
+func (mr *MockEnrollmentRepositoryIMockRecorder) AddEnrollments(ctx, enrollments any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEnrollments", reflect.TypeOf((*MockEnrollmentRepositoryI)(nil).AddEnrollments), ctx, enrollments)
+}
+
+// DropEnrollment mocks base method.
+func (m *MockEnrollmentRepositoryI) DropEnrollment(ctx context.Context, studentID, subjectID string, semester int, droppedOn time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DropEnrollment", ctx, studentID, subjectID, semester, droppedOn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DropEnrollment indicates an expected call of DropEnrollment.
+func (mr *MockEnrollmentRepositoryIMockRecorder) DropEnrollment(ctx, studentID, subjectID, semester, droppedOn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropEnrollment", reflect.TypeOf((*MockEnrollmentRepositoryI)(nil).DropEnrollment), ctx, studentID, subjectID, semester, droppedOn)
+}
+
+// GetEnrollment mocks base method.
+func (m *MockEnrollmentRepositoryI) GetEnrollment(ctx context.Context, studentID, subjectID string, semester int) (*models.Enrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnrollment", ctx, studentID, subjectID, semester)
+	ret0, _ := ret[0].(*models.Enrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnrollment indicates an expected call of GetEnrollment.
+func (mr *MockEnrollmentRepositoryIMockRecorder) GetEnrollment(ctx, studentID, subjectID, semester any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnrollment", reflect.TypeOf((*MockEnrollmentRepositoryI)(nil).GetEnrollment), ctx, studentID, subjectID, semester)
+}
+
+// GetStudentEnrollments mocks base method.
+func (m *MockEnrollmentRepositoryI) GetStudentEnrollments(ctx context.Context, studentID string, semester int) ([]models.Enrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStudentEnrollments", ctx, studentID, semester)
+	ret0, _ := ret[0].([]models.Enrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStudentEnrollments indicates an expected call of GetStudentEnrollments.
+func (mr *MockEnrollmentRepositoryIMockRecorder) GetStudentEnrollments(ctx, studentID, semester any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStudentEnrollments", reflect.TypeOf((*MockEnrollmentRepositoryI)(nil).GetStudentEnrollments), ctx, studentID, semester)
+}
+
+// WithTx mocks base method.
+func (m *MockEnrollmentRepositoryI) WithTx(tx transaction.Querier) enrollmentRepository.EnrollmentRepositoryI {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", tx)
+	ret0, _ := ret[0].(enrollmentRepository.EnrollmentRepositoryI)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockEnrollmentRepositoryIMockRecorder) WithTx(tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockEnrollmentRepositoryI)(nil).WithTx), tx)
+}
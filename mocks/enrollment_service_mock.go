@@ -0,0 +1,130 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: enrollment_service_interface.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=../mocks/enrollment_service_mock.go -package=mocks -source=enrollment_service_interface.go
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "sms/models"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEnrollmentServiceI is a mock of EnrollmentServiceI interface.
+type MockEnrollmentServiceI struct {
+	ctrl     *gomock.Controller
+	recorder *MockEnrollmentServiceIMockRecorder
+	isgomock struct{}
+}
+
+// MockEnrollmentServiceIMockRecorder is the mock recorder for MockEnrollmentServiceI.
+type MockEnrollmentServiceIMockRecorder struct {
+	mock *MockEnrollmentServiceI
+}
+
+// NewMockEnrollmentServiceI creates a new mock instance.
+func NewMockEnrollmentServiceI(ctrl *gomock.Controller) *MockEnrollmentServiceI {
+	mock := &MockEnrollmentServiceI{ctrl: ctrl}
+	mock.recorder = &MockEnrollmentServiceIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEnrollmentServiceI) EXPECT() *MockEnrollmentServiceIMockRecorder {
+	return m.recorder
+}
+
+// BulkEnroll mocks base method.
+func (m *MockEnrollmentServiceI) BulkEnroll(ctx context.Context, studentIDs, subjectIDs []string, semester int) ([]models.Enrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkEnroll", ctx, studentIDs, subjectIDs, semester)
+	ret0, _ := ret[0].([]models.Enrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkEnroll indicates an expected call of BulkEnroll.
+func (mr *MockEnrollmentServiceIMockRecorder) BulkEnroll(ctx, studentIDs, subjectIDs, semester any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkEnroll", reflect.TypeOf((*MockEnrollmentServiceI)(nil).BulkEnroll), ctx, studentIDs, subjectIDs, semester)
+}
+
+// CanBeGraded mocks base method.
+func (m *MockEnrollmentServiceI) CanBeGraded(ctx context.Context, studentID, subjectID string, semester int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CanBeGraded", ctx, studentID, subjectID, semester)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CanBeGraded indicates an expected call of CanBeGraded.
+func (mr *MockEnrollmentServiceIMockRecorder) CanBeGraded(ctx, studentID, subjectID, semester any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanBeGraded", reflect.TypeOf((*MockEnrollmentServiceI)(nil).CanBeGraded), ctx, studentID, subjectID, semester)
+}
+
+// Drop mocks base method.
+func (m *MockEnrollmentServiceI) Drop(ctx context.Context, studentID, subjectID string, semester int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Drop", ctx, studentID, subjectID, semester)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Drop indicates an expected call of Drop.
+func (mr *MockEnrollmentServiceIMockRecorder) Drop(ctx, studentID, subjectID, semester any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Drop", reflect.TypeOf((*MockEnrollmentServiceI)(nil).Drop), ctx, studentID, subjectID, semester)
+}
+
+// Enroll mocks base method.
+func (m *MockEnrollmentServiceI) Enroll(ctx context.Context, studentID, subjectID string, semester int) (*models.Enrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enroll", ctx, studentID, subjectID, semester)
+	ret0, _ := ret[0].(*models.Enrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Enroll indicates an expected call of Enroll.
+func (mr *MockEnrollmentServiceIMockRecorder) Enroll(ctx, studentID, subjectID, semester any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enroll", reflect.TypeOf((*MockEnrollmentServiceI)(nil).Enroll), ctx, studentID, subjectID, semester)
+}
+
+// EnrollClass mocks base method.
+func (m *MockEnrollmentServiceI) EnrollClass(ctx context.Context, classID string, subjectIDs []string, semester int) ([]models.Enrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollClass", ctx, classID, subjectIDs, semester)
+	ret0, _ := ret[0].([]models.Enrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrollClass indicates an expected call of EnrollClass.
+func (mr *MockEnrollmentServiceIMockRecorder) EnrollClass(ctx, classID, subjectIDs, semester any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollClass", reflect.TypeOf((*MockEnrollmentServiceI)(nil).EnrollClass), ctx, classID, subjectIDs, semester)
+}
+
+// GetStudentEnrollments mocks base method.
+func (m *MockEnrollmentServiceI) GetStudentEnrollments(ctx context.Context, studentID string, semester int) ([]models.Enrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStudentEnrollments", ctx, studentID, semester)
+	ret0, _ := ret[0].([]models.Enrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStudentEnrollments indicates an expected call of GetStudentEnrollments.
+func (mr *MockEnrollmentServiceIMockRecorder) GetStudentEnrollments(ctx, studentID, semester any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStudentEnrollments", reflect.TypeOf((*MockEnrollmentServiceI)(nil).GetStudentEnrollments), ctx, studentID, semester)
+}
@@ -10,8 +10,11 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
+	models "sms/models"
 	gradeRepository "sms/repository/gradesRepository"
+	transaction "sms/repository/transaction"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -41,89 +44,163 @@ func (m *MockGradeRepositoryI) EXPECT() *MockGradeRepositoryIMockRecorder {
 }
 
 // AddGrades mocks base method.
-func (m *MockGradeRepositoryI) AddGrades(studentID, subjectID string, Grade, semester int) error {
+func (m *MockGradeRepositoryI) AddGrades(ctx context.Context, studentID, subjectID string, Grade, semester int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddGrades", studentID, subjectID, Grade, semester)
+	ret := m.ctrl.Call(m, "AddGrades", ctx, studentID, subjectID, Grade, semester)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddGrades indicates an expected call of AddGrades.
-func (mr *MockGradeRepositoryIMockRecorder) AddGrades(studentID, subjectID, Grade, semester any) *gomock.Call {
+func (mr *MockGradeRepositoryIMockRecorder) AddGrades(ctx, studentID, subjectID, Grade, semester any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddGrades", reflect.TypeOf((*MockGradeRepositoryI)(nil).AddGrades), studentID, subjectID, Grade, semester)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddGrades", reflect.TypeOf((*MockGradeRepositoryI)(nil).AddGrades), ctx, studentID, subjectID, Grade, semester)
 }
 
-// GetAverageGrade mocks base method.
-func (m *MockGradeRepositoryI) GetAverageGrade(studentID string, semester int) (float64, error) {
+// GetClassAverage mocks base method.
+func (m *MockGradeRepositoryI) GetClassAverage(ctx context.Context, classID string, semester int) (float64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAverageGrade", studentID, semester)
+	ret := m.ctrl.Call(m, "GetClassAverage", ctx, classID, semester)
 	ret0, _ := ret[0].(float64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAverageGrade indicates an expected call of GetAverageGrade.
-func (mr *MockGradeRepositoryIMockRecorder) GetAverageGrade(studentID, semester any) *gomock.Call {
+// GetClassAverage indicates an expected call of GetClassAverage.
+func (mr *MockGradeRepositoryIMockRecorder) GetClassAverage(ctx, classID, semester any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAverageGrade", reflect.TypeOf((*MockGradeRepositoryI)(nil).GetAverageGrade), studentID, semester)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClassAverage", reflect.TypeOf((*MockGradeRepositoryI)(nil).GetClassAverage), ctx, classID, semester)
 }
 
-// GetClassAverage mocks base method.
-func (m *MockGradeRepositoryI) GetClassAverage(classID string, semester int) (float64, error) {
+// GetClassGrades mocks base method.
+func (m *MockGradeRepositoryI) GetClassGrades(ctx context.Context, classID string, semester int) ([]int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetClassAverage", classID, semester)
-	ret0, _ := ret[0].(float64)
+	ret := m.ctrl.Call(m, "GetClassGrades", ctx, classID, semester)
+	ret0, _ := ret[0].([]int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetClassAverage indicates an expected call of GetClassAverage.
-func (mr *MockGradeRepositoryIMockRecorder) GetClassAverage(classID, semester any) *gomock.Call {
+// GetClassGrades indicates an expected call of GetClassGrades.
+func (mr *MockGradeRepositoryIMockRecorder) GetClassGrades(ctx, classID, semester any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClassGrades", reflect.TypeOf((*MockGradeRepositoryI)(nil).GetClassGrades), ctx, classID, semester)
+}
+
+// GetClassSubjectGrades mocks base method.
+func (m *MockGradeRepositoryI) GetClassSubjectGrades(ctx context.Context, classID, subjectID string, semester int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClassSubjectGrades", ctx, classID, subjectID, semester)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClassSubjectGrades indicates an expected call of GetClassSubjectGrades.
+func (mr *MockGradeRepositoryIMockRecorder) GetClassSubjectGrades(ctx, classID, subjectID, semester any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClassSubjectGrades", reflect.TypeOf((*MockGradeRepositoryI)(nil).GetClassSubjectGrades), ctx, classID, subjectID, semester)
+}
+
+// GetGrade mocks base method.
+func (m *MockGradeRepositoryI) GetGrade(ctx context.Context, studentID, subjectID string) (*models.Grade, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGrade", ctx, studentID, subjectID)
+	ret0, _ := ret[0].(*models.Grade)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGrade indicates an expected call of GetGrade.
+func (mr *MockGradeRepositoryIMockRecorder) GetGrade(ctx, studentID, subjectID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClassAverage", reflect.TypeOf((*MockGradeRepositoryI)(nil).GetClassAverage), classID, semester)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGrade", reflect.TypeOf((*MockGradeRepositoryI)(nil).GetGrade), ctx, studentID, subjectID)
 }
 
 // GetSemesterGrades mocks base method.
-func (m *MockGradeRepositoryI) GetSemesterGrades(studentID string, semester int) ([]int, error) {
+func (m *MockGradeRepositoryI) GetSemesterGrades(ctx context.Context, studentID string, semester int) ([]int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSemesterGrades", studentID, semester)
+	ret := m.ctrl.Call(m, "GetSemesterGrades", ctx, studentID, semester)
 	ret0, _ := ret[0].([]int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetSemesterGrades indicates an expected call of GetSemesterGrades.
-func (mr *MockGradeRepositoryIMockRecorder) GetSemesterGrades(studentID, semester any) *gomock.Call {
+func (mr *MockGradeRepositoryIMockRecorder) GetSemesterGrades(ctx, studentID, semester any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSemesterGrades", reflect.TypeOf((*MockGradeRepositoryI)(nil).GetSemesterGrades), studentID, semester)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSemesterGrades", reflect.TypeOf((*MockGradeRepositoryI)(nil).GetSemesterGrades), ctx, studentID, semester)
+}
+
+// GetStudentAverages mocks base method.
+func (m *MockGradeRepositoryI) GetStudentAverages(ctx context.Context, classID string, semester int, subjectID string) ([]gradeRepository.StudentAverage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStudentAverages", ctx, classID, semester, subjectID)
+	ret0, _ := ret[0].([]gradeRepository.StudentAverage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStudentAverages indicates an expected call of GetStudentAverages.
+func (mr *MockGradeRepositoryIMockRecorder) GetStudentAverages(ctx, classID, semester, subjectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStudentAverages", reflect.TypeOf((*MockGradeRepositoryI)(nil).GetStudentAverages), ctx, classID, semester, subjectID)
+}
+
+// GetStudentGrades mocks base method.
+func (m *MockGradeRepositoryI) GetStudentGrades(ctx context.Context, studentID string) ([]models.Grade, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStudentGrades", ctx, studentID)
+	ret0, _ := ret[0].([]models.Grade)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStudentGrades indicates an expected call of GetStudentGrades.
+func (mr *MockGradeRepositoryIMockRecorder) GetStudentGrades(ctx, studentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStudentGrades", reflect.TypeOf((*MockGradeRepositoryI)(nil).GetStudentGrades), ctx, studentID)
 }
 
 // GetToppers mocks base method.
-func (m *MockGradeRepositoryI) GetToppers(classID string, semester, top int) ([]gradeRepository.StudentAverage, error) {
+func (m *MockGradeRepositoryI) GetToppers(ctx context.Context, classID string, semester, top int) ([]gradeRepository.StudentAverage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetToppers", classID, semester, top)
+	ret := m.ctrl.Call(m, "GetToppers", ctx, classID, semester, top)
 	ret0, _ := ret[0].([]gradeRepository.StudentAverage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetToppers indicates an expected call of GetToppers.
-func (mr *MockGradeRepositoryIMockRecorder) GetToppers(classID, semester, top any) *gomock.Call {
+func (mr *MockGradeRepositoryIMockRecorder) GetToppers(ctx, classID, semester, top any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetToppers", reflect.TypeOf((*MockGradeRepositoryI)(nil).GetToppers), classID, semester, top)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetToppers", reflect.TypeOf((*MockGradeRepositoryI)(nil).GetToppers), ctx, classID, semester, top)
 }
 
 // UpdateGrade mocks base method.
-func (m *MockGradeRepositoryI) UpdateGrade(studentID, subjectID string, newGrade int) error {
+func (m *MockGradeRepositoryI) UpdateGrade(ctx context.Context, studentID, subjectID string, newGrade int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateGrade", studentID, subjectID, newGrade)
+	ret := m.ctrl.Call(m, "UpdateGrade", ctx, studentID, subjectID, newGrade)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateGrade indicates an expected call of UpdateGrade.
-func (mr *MockGradeRepositoryIMockRecorder) UpdateGrade(studentID, subjectID, newGrade any) *gomock.Call {
+func (mr *MockGradeRepositoryIMockRecorder) UpdateGrade(ctx, studentID, subjectID, newGrade any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGrade", reflect.TypeOf((*MockGradeRepositoryI)(nil).UpdateGrade), ctx, studentID, subjectID, newGrade)
+}
+
+// WithTx mocks base method.
+func (m *MockGradeRepositoryI) WithTx(tx transaction.Querier) gradeRepository.GradeRepositoryI {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", tx)
+	ret0, _ := ret[0].(gradeRepository.GradeRepositoryI)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockGradeRepositoryIMockRecorder) WithTx(tx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGrade", reflect.TypeOf((*MockGradeRepositoryI)(nil).UpdateGrade), studentID, subjectID, newGrade)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockGradeRepositoryI)(nil).WithTx), tx)
 }
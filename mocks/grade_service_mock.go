@@ -10,7 +10,10 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
+	constants "sms/constants"
+	models "sms/models"
 	gradeRepository "sms/repository/gradesRepository"
 
 	gomock "go.uber.org/mock/gomock"
@@ -41,59 +44,177 @@ func (m *MockGradeServiceI) EXPECT() *MockGradeServiceIMockRecorder {
 }
 
 // AddGrades mocks base method.
-func (m *MockGradeServiceI) AddGrades(studentID, subjectID string, Grade, semester int) error {
+func (m *MockGradeServiceI) AddGrades(ctx context.Context, studentID, subjectID string, Grade, semester int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddGrades", studentID, subjectID, Grade, semester)
+	ret := m.ctrl.Call(m, "AddGrades", ctx, studentID, subjectID, Grade, semester)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddGrades indicates an expected call of AddGrades.
-func (mr *MockGradeServiceIMockRecorder) AddGrades(studentID, subjectID, Grade, semester any) *gomock.Call {
+func (mr *MockGradeServiceIMockRecorder) AddGrades(ctx, studentID, subjectID, Grade, semester any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddGrades", reflect.TypeOf((*MockGradeServiceI)(nil).AddGrades), studentID, subjectID, Grade, semester)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddGrades", reflect.TypeOf((*MockGradeServiceI)(nil).AddGrades), ctx, studentID, subjectID, Grade, semester)
+}
+
+// CreateAssessment mocks base method.
+func (m *MockGradeServiceI) CreateAssessment(ctx context.Context, assessment models.Assessment) (*models.Assessment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAssessment", ctx, assessment)
+	ret0, _ := ret[0].(*models.Assessment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAssessment indicates an expected call of CreateAssessment.
+func (mr *MockGradeServiceIMockRecorder) CreateAssessment(ctx, assessment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAssessment", reflect.TypeOf((*MockGradeServiceI)(nil).CreateAssessment), ctx, assessment)
+}
+
+// GetAssessments mocks base method.
+func (m *MockGradeServiceI) GetAssessments(ctx context.Context, subjectID string, semester int) ([]models.Assessment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssessments", ctx, subjectID, semester)
+	ret0, _ := ret[0].([]models.Assessment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssessments indicates an expected call of GetAssessments.
+func (mr *MockGradeServiceIMockRecorder) GetAssessments(ctx, subjectID, semester any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssessments", reflect.TypeOf((*MockGradeServiceI)(nil).GetAssessments), ctx, subjectID, semester)
 }
 
 // GetAverageOfClass mocks base method.
-func (m *MockGradeServiceI) GetAverageOfClass(classID string, semester int) (float64, error) {
+func (m *MockGradeServiceI) GetAverageOfClass(ctx context.Context, classID string, semester int) (float64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAverageOfClass", classID, semester)
+	ret := m.ctrl.Call(m, "GetAverageOfClass", ctx, classID, semester)
 	ret0, _ := ret[0].(float64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAverageOfClass indicates an expected call of GetAverageOfClass.
-func (mr *MockGradeServiceIMockRecorder) GetAverageOfClass(classID, semester any) *gomock.Call {
+func (mr *MockGradeServiceIMockRecorder) GetAverageOfClass(ctx, classID, semester any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAverageOfClass", reflect.TypeOf((*MockGradeServiceI)(nil).GetAverageOfClass), ctx, classID, semester)
+}
+
+// GetClassStatistics mocks base method.
+func (m *MockGradeServiceI) GetClassStatistics(ctx context.Context, classID string, semester int, subjectID string, bucketWidth, passMark int) (*models.GradeStatistics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClassStatistics", ctx, classID, semester, subjectID, bucketWidth, passMark)
+	ret0, _ := ret[0].(*models.GradeStatistics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClassStatistics indicates an expected call of GetClassStatistics.
+func (mr *MockGradeServiceIMockRecorder) GetClassStatistics(ctx, classID, semester, subjectID, bucketWidth, passMark any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClassStatistics", reflect.TypeOf((*MockGradeServiceI)(nil).GetClassStatistics), ctx, classID, semester, subjectID, bucketWidth, passMark)
+}
+
+// GetRankList mocks base method.
+func (m *MockGradeServiceI) GetRankList(ctx context.Context, classID string, semester int, subjectID string, method constants.RankingMethod) ([]models.RankEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRankList", ctx, classID, semester, subjectID, method)
+	ret0, _ := ret[0].([]models.RankEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRankList indicates an expected call of GetRankList.
+func (mr *MockGradeServiceIMockRecorder) GetRankList(ctx, classID, semester, subjectID, method any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAverageOfClass", reflect.TypeOf((*MockGradeServiceI)(nil).GetAverageOfClass), classID, semester)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRankList", reflect.TypeOf((*MockGradeServiceI)(nil).GetRankList), ctx, classID, semester, subjectID, method)
+}
+
+// GetStudentRank mocks base method.
+func (m *MockGradeServiceI) GetStudentRank(ctx context.Context, classID string, semester int, subjectID, studentID string, method constants.RankingMethod) (*models.RankEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStudentRank", ctx, classID, semester, subjectID, studentID, method)
+	ret0, _ := ret[0].(*models.RankEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStudentRank indicates an expected call of GetStudentRank.
+func (mr *MockGradeServiceIMockRecorder) GetStudentRank(ctx, classID, semester, subjectID, studentID, method any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStudentRank", reflect.TypeOf((*MockGradeServiceI)(nil).GetStudentRank), ctx, classID, semester, subjectID, studentID, method)
 }
 
 // GetToppers mocks base method.
-func (m *MockGradeServiceI) GetToppers(classID string, semester, top int) ([]gradeRepository.StudentAverage, error) {
+func (m *MockGradeServiceI) GetToppers(ctx context.Context, classID string, semester, top int) ([]gradeRepository.StudentAverage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetToppers", classID, semester, top)
+	ret := m.ctrl.Call(m, "GetToppers", ctx, classID, semester, top)
 	ret0, _ := ret[0].([]gradeRepository.StudentAverage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetToppers indicates an expected call of GetToppers.
-func (mr *MockGradeServiceIMockRecorder) GetToppers(classID, semester, top any) *gomock.Call {
+func (mr *MockGradeServiceIMockRecorder) GetToppers(ctx, classID, semester, top any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetToppers", reflect.TypeOf((*MockGradeServiceI)(nil).GetToppers), ctx, classID, semester, top)
+}
+
+// ImportGrades mocks base method.
+func (m *MockGradeServiceI) ImportGrades(ctx context.Context, grades []models.Grade) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportGrades", ctx, grades)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportGrades indicates an expected call of ImportGrades.
+func (mr *MockGradeServiceIMockRecorder) ImportGrades(ctx, grades any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportGrades", reflect.TypeOf((*MockGradeServiceI)(nil).ImportGrades), ctx, grades)
+}
+
+// RecordScores mocks base method.
+func (m *MockGradeServiceI) RecordScores(ctx context.Context, assessmentID string, scores []models.AssessmentScore) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordScores", ctx, assessmentID, scores)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordScores indicates an expected call of RecordScores.
+func (mr *MockGradeServiceIMockRecorder) RecordScores(ctx, assessmentID, scores any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordScores", reflect.TypeOf((*MockGradeServiceI)(nil).RecordScores), ctx, assessmentID, scores)
+}
+
+// UpdateAssessment mocks base method.
+func (m *MockGradeServiceI) UpdateAssessment(ctx context.Context, assessmentID string, weight float64, maxMarks int) (*models.Assessment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAssessment", ctx, assessmentID, weight, maxMarks)
+	ret0, _ := ret[0].(*models.Assessment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAssessment indicates an expected call of UpdateAssessment.
+func (mr *MockGradeServiceIMockRecorder) UpdateAssessment(ctx, assessmentID, weight, maxMarks any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetToppers", reflect.TypeOf((*MockGradeServiceI)(nil).GetToppers), classID, semester, top)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAssessment", reflect.TypeOf((*MockGradeServiceI)(nil).UpdateAssessment), ctx, assessmentID, weight, maxMarks)
 }
 
 // UpdateGrade mocks base method.
-func (m *MockGradeServiceI) UpdateGrade(studentID, subjectID string, newGrade int) error {
+func (m *MockGradeServiceI) UpdateGrade(ctx context.Context, studentID, subjectID string, newGrade int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateGrade", studentID, subjectID, newGrade)
+	ret := m.ctrl.Call(m, "UpdateGrade", ctx, studentID, subjectID, newGrade)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateGrade indicates an expected call of UpdateGrade.
-func (mr *MockGradeServiceIMockRecorder) UpdateGrade(studentID, subjectID, newGrade any) *gomock.Call {
+func (mr *MockGradeServiceIMockRecorder) UpdateGrade(ctx, studentID, subjectID, newGrade any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGrade", reflect.TypeOf((*MockGradeServiceI)(nil).UpdateGrade), studentID, subjectID, newGrade)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGrade", reflect.TypeOf((*MockGradeServiceI)(nil).UpdateGrade), ctx, studentID, subjectID, newGrade)
 }
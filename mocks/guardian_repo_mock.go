@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../mocks/guardian_repo_mock.go -package=mocks -source=interface.go
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "sms/models"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockGuardianRepositoryI is a mock of GuardianRepositoryI interface.
+type MockGuardianRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockGuardianRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockGuardianRepositoryIMockRecorder is the mock recorder for MockGuardianRepositoryI.
+type MockGuardianRepositoryIMockRecorder struct {
+	mock *MockGuardianRepositoryI
+}
+
+// NewMockGuardianRepositoryI creates a new mock instance.
+func NewMockGuardianRepositoryI(ctrl *gomock.Controller) *MockGuardianRepositoryI {
+	mock := &MockGuardianRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockGuardianRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGuardianRepositoryI) EXPECT() *MockGuardianRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// GetGuardianIDs mocks base method.
+func (m *MockGuardianRepositoryI) GetGuardianIDs(ctx context.Context, studentID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGuardianIDs", ctx, studentID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGuardianIDs indicates an expected call of GetGuardianIDs.
+func (mr *MockGuardianRepositoryIMockRecorder) GetGuardianIDs(ctx, studentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGuardianIDs", reflect.TypeOf((*MockGuardianRepositoryI)(nil).GetGuardianIDs), ctx, studentID)
+}
+
+// GetLinkedStudents mocks base method.
+func (m *MockGuardianRepositoryI) GetLinkedStudents(ctx context.Context, guardianID string) ([]models.Students, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLinkedStudents", ctx, guardianID)
+	ret0, _ := ret[0].([]models.Students)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLinkedStudents indicates an expected call of GetLinkedStudents.
+func (mr *MockGuardianRepositoryIMockRecorder) GetLinkedStudents(ctx, guardianID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLinkedStudents", reflect.TypeOf((*MockGuardianRepositoryI)(nil).GetLinkedStudents), ctx, guardianID)
+}
+
+// IsLinked mocks base method.
+func (m *MockGuardianRepositoryI) IsLinked(ctx context.Context, guardianID, studentID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsLinked", ctx, guardianID, studentID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsLinked indicates an expected call of IsLinked.
+func (mr *MockGuardianRepositoryIMockRecorder) IsLinked(ctx, guardianID, studentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsLinked", reflect.TypeOf((*MockGuardianRepositoryI)(nil).IsLinked), ctx, guardianID, studentID)
+}
+
+// LinkStudent mocks base method.
+func (m *MockGuardianRepositoryI) LinkStudent(ctx context.Context, link models.GuardianLink) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkStudent", ctx, link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkStudent indicates an expected call of LinkStudent.
+func (mr *MockGuardianRepositoryIMockRecorder) LinkStudent(ctx, link any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkStudent", reflect.TypeOf((*MockGuardianRepositoryI)(nil).LinkStudent), ctx, link)
+}
+
+// UnlinkStudent mocks base method.
+func (m *MockGuardianRepositoryI) UnlinkStudent(ctx context.Context, guardianID, studentID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlinkStudent", ctx, guardianID, studentID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlinkStudent indicates an expected call of UnlinkStudent.
+func (mr *MockGuardianRepositoryIMockRecorder) UnlinkStudent(ctx, guardianID, studentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlinkStudent", reflect.TypeOf((*MockGuardianRepositoryI)(nil).UnlinkStudent), ctx, guardianID, studentID)
+}
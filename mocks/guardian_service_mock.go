@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: guardian_service_interface.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=../mocks/guardian_service_mock.go -package=mocks -source=guardian_service_interface.go
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "sms/models"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockGuardianServiceI is a mock of GuardianServiceI interface.
+type MockGuardianServiceI struct {
+	ctrl     *gomock.Controller
+	recorder *MockGuardianServiceIMockRecorder
+	isgomock struct{}
+}
+
+// MockGuardianServiceIMockRecorder is the mock recorder for MockGuardianServiceI.
+type MockGuardianServiceIMockRecorder struct {
+	mock *MockGuardianServiceI
+}
+
+// NewMockGuardianServiceI creates a new mock instance.
+func NewMockGuardianServiceI(ctrl *gomock.Controller) *MockGuardianServiceI {
+	mock := &MockGuardianServiceI{ctrl: ctrl}
+	mock.recorder = &MockGuardianServiceIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGuardianServiceI) EXPECT() *MockGuardianServiceIMockRecorder {
+	return m.recorder
+}
+
+// CanViewStudent mocks base method.
+func (m *MockGuardianServiceI) CanViewStudent(ctx context.Context, guardianID, studentID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CanViewStudent", ctx, guardianID, studentID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CanViewStudent indicates an expected call of CanViewStudent.
+func (mr *MockGuardianServiceIMockRecorder) CanViewStudent(ctx, guardianID, studentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanViewStudent", reflect.TypeOf((*MockGuardianServiceI)(nil).CanViewStudent), ctx, guardianID, studentID)
+}
+
+// GetLinkedStudents mocks base method.
+func (m *MockGuardianServiceI) GetLinkedStudents(ctx context.Context, guardianID string) ([]models.Students, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLinkedStudents", ctx, guardianID)
+	ret0, _ := ret[0].([]models.Students)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLinkedStudents indicates an expected call of GetLinkedStudents.
+func (mr *MockGuardianServiceIMockRecorder) GetLinkedStudents(ctx, guardianID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLinkedStudents", reflect.TypeOf((*MockGuardianServiceI)(nil).GetLinkedStudents), ctx, guardianID)
+}
+
+// LinkStudent mocks base method.
+func (m *MockGuardianServiceI) LinkStudent(ctx context.Context, link models.GuardianLink) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkStudent", ctx, link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkStudent indicates an expected call of LinkStudent.
+func (mr *MockGuardianServiceIMockRecorder) LinkStudent(ctx, link any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkStudent", reflect.TypeOf((*MockGuardianServiceI)(nil).LinkStudent), ctx, link)
+}
+
+// UnlinkStudent mocks base method.
+func (m *MockGuardianServiceI) UnlinkStudent(ctx context.Context, guardianID, studentID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlinkStudent", ctx, guardianID, studentID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlinkStudent indicates an expected call of UnlinkStudent.
+func (mr *MockGuardianServiceIMockRecorder) UnlinkStudent(ctx, guardianID, studentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlinkStudent", reflect.TypeOf((*MockGuardianServiceI)(nil).UnlinkStudent), ctx, guardianID, studentID)
+}
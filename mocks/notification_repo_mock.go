@@ -0,0 +1,160 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../mocks/notification_repo_mock.go -package=mocks -source=interface.go
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	constants "sms/constants"
+	models "sms/models"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockNotificationRepositoryI is a mock of NotificationRepositoryI interface.
+type MockNotificationRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockNotificationRepositoryIMockRecorder is the mock recorder for MockNotificationRepositoryI.
+type MockNotificationRepositoryIMockRecorder struct {
+	mock *MockNotificationRepositoryI
+}
+
+// NewMockNotificationRepositoryI creates a new mock instance.
+func NewMockNotificationRepositoryI(ctrl *gomock.Controller) *MockNotificationRepositoryI {
+	mock := &MockNotificationRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockNotificationRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationRepositoryI) EXPECT() *MockNotificationRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// AddNotifications mocks base method.
+func (m *MockNotificationRepositoryI) AddNotifications(ctx context.Context, notifications []models.Notification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddNotifications", ctx, notifications)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddNotifications indicates an expected call of AddNotifications.
+func (mr *MockNotificationRepositoryIMockRecorder) AddNotifications(ctx, notifications any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddNotifications", reflect.TypeOf((*MockNotificationRepositoryI)(nil).AddNotifications), ctx, notifications)
+}
+
+// GetDueNotifications mocks base method.
+func (m *MockNotificationRepositoryI) GetDueNotifications(ctx context.Context, now time.Time, limit int) ([]models.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueNotifications", ctx, now, limit)
+	ret0, _ := ret[0].([]models.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueNotifications indicates an expected call of GetDueNotifications.
+func (mr *MockNotificationRepositoryIMockRecorder) GetDueNotifications(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueNotifications", reflect.TypeOf((*MockNotificationRepositoryI)(nil).GetDueNotifications), ctx, now, limit)
+}
+
+// GetInbox mocks base method.
+func (m *MockNotificationRepositoryI) GetInbox(ctx context.Context, userID string) ([]models.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInbox", ctx, userID)
+	ret0, _ := ret[0].([]models.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInbox indicates an expected call of GetInbox.
+func (mr *MockNotificationRepositoryIMockRecorder) GetInbox(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInbox", reflect.TypeOf((*MockNotificationRepositoryI)(nil).GetInbox), ctx, userID)
+}
+
+// GetPreferences mocks base method.
+func (m *MockNotificationRepositoryI) GetPreferences(ctx context.Context, userID string) ([]models.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreferences", ctx, userID)
+	ret0, _ := ret[0].([]models.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreferences indicates an expected call of GetPreferences.
+func (mr *MockNotificationRepositoryIMockRecorder) GetPreferences(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreferences", reflect.TypeOf((*MockNotificationRepositoryI)(nil).GetPreferences), ctx, userID)
+}
+
+// MarkRead mocks base method.
+func (m *MockNotificationRepositoryI) MarkRead(ctx context.Context, userID, notificationID string, readAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRead", ctx, userID, notificationID, readAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MockNotificationRepositoryIMockRecorder) MarkRead(ctx, userID, notificationID, readAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*MockNotificationRepositoryI)(nil).MarkRead), ctx, userID, notificationID, readAt)
+}
+
+// MarkSent mocks base method.
+func (m *MockNotificationRepositoryI) MarkSent(ctx context.Context, notificationID string, sentAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkSent", ctx, notificationID, sentAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkSent indicates an expected call of MarkSent.
+func (mr *MockNotificationRepositoryIMockRecorder) MarkSent(ctx, notificationID, sentAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSent", reflect.TypeOf((*MockNotificationRepositoryI)(nil).MarkSent), ctx, notificationID, sentAt)
+}
+
+// RecordFailure mocks base method.
+func (m *MockNotificationRepositoryI) RecordFailure(ctx context.Context, notificationID string, status constants.NotificationStatus, attempts int, nextAttemptAt time.Time, lastError string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordFailure", ctx, notificationID, status, attempts, nextAttemptAt, lastError)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordFailure indicates an expected call of RecordFailure.
+func (mr *MockNotificationRepositoryIMockRecorder) RecordFailure(ctx, notificationID, status, attempts, nextAttemptAt, lastError any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFailure", reflect.TypeOf((*MockNotificationRepositoryI)(nil).RecordFailure), ctx, notificationID, status, attempts, nextAttemptAt, lastError)
+}
+
+// SetPreference mocks base method.
+func (m *MockNotificationRepositoryI) SetPreference(ctx context.Context, pref models.NotificationPreference) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPreference", ctx, pref)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPreference indicates an expected call of SetPreference.
+func (mr *MockNotificationRepositoryIMockRecorder) SetPreference(ctx, pref any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPreference", reflect.TypeOf((*MockNotificationRepositoryI)(nil).SetPreference), ctx, pref)
+}
@@ -0,0 +1,145 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: notification_service_interface.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=../mocks/notification_service_mock.go -package=mocks -source=notification_service_interface.go
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	constants "sms/constants"
+	models "sms/models"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockNotificationServiceI is a mock of NotificationServiceI interface.
+type MockNotificationServiceI struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationServiceIMockRecorder
+	isgomock struct{}
+}
+
+// MockNotificationServiceIMockRecorder is the mock recorder for MockNotificationServiceI.
+type MockNotificationServiceIMockRecorder struct {
+	mock *MockNotificationServiceI
+}
+
+// NewMockNotificationServiceI creates a new mock instance.
+func NewMockNotificationServiceI(ctrl *gomock.Controller) *MockNotificationServiceI {
+	mock := &MockNotificationServiceI{ctrl: ctrl}
+	mock.recorder = &MockNotificationServiceIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationServiceI) EXPECT() *MockNotificationServiceIMockRecorder {
+	return m.recorder
+}
+
+// GetInbox mocks base method.
+func (m *MockNotificationServiceI) GetInbox(ctx context.Context, userID string) ([]models.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInbox", ctx, userID)
+	ret0, _ := ret[0].([]models.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInbox indicates an expected call of GetInbox.
+func (mr *MockNotificationServiceIMockRecorder) GetInbox(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInbox", reflect.TypeOf((*MockNotificationServiceI)(nil).GetInbox), ctx, userID)
+}
+
+// GetPreferences mocks base method.
+func (m *MockNotificationServiceI) GetPreferences(ctx context.Context, userID string) ([]models.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreferences", ctx, userID)
+	ret0, _ := ret[0].([]models.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreferences indicates an expected call of GetPreferences.
+func (mr *MockNotificationServiceIMockRecorder) GetPreferences(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreferences", reflect.TypeOf((*MockNotificationServiceI)(nil).GetPreferences), ctx, userID)
+}
+
+// MarkRead mocks base method.
+func (m *MockNotificationServiceI) MarkRead(ctx context.Context, userID, notificationID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRead", ctx, userID, notificationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MockNotificationServiceIMockRecorder) MarkRead(ctx, userID, notificationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*MockNotificationServiceI)(nil).MarkRead), ctx, userID, notificationID)
+}
+
+// Notify mocks base method.
+func (m *MockNotificationServiceI) Notify(ctx context.Context, userID string, event constants.NotificationEvent, data map[string]any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", ctx, userID, event, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MockNotificationServiceIMockRecorder) Notify(ctx, userID, event, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockNotificationServiceI)(nil).Notify), ctx, userID, event, data)
+}
+
+// NotifyGuardians mocks base method.
+func (m *MockNotificationServiceI) NotifyGuardians(ctx context.Context, studentID string, event constants.NotificationEvent, data map[string]any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyGuardians", ctx, studentID, event, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyGuardians indicates an expected call of NotifyGuardians.
+func (mr *MockNotificationServiceIMockRecorder) NotifyGuardians(ctx, studentID, event, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyGuardians", reflect.TypeOf((*MockNotificationServiceI)(nil).NotifyGuardians), ctx, studentID, event, data)
+}
+
+// ProcessOutbox mocks base method.
+func (m *MockNotificationServiceI) ProcessOutbox(ctx context.Context, now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessOutbox", ctx, now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProcessOutbox indicates an expected call of ProcessOutbox.
+func (mr *MockNotificationServiceIMockRecorder) ProcessOutbox(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessOutbox", reflect.TypeOf((*MockNotificationServiceI)(nil).ProcessOutbox), ctx, now)
+}
+
+// SetPreference mocks base method.
+func (m *MockNotificationServiceI) SetPreference(ctx context.Context, pref models.NotificationPreference) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPreference", ctx, pref)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPreference indicates an expected call of SetPreference.
+func (mr *MockNotificationServiceIMockRecorder) SetPreference(ctx, pref any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPreference", reflect.TypeOf((*MockNotificationServiceI)(nil).SetPreference), ctx, pref)
+}
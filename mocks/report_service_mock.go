@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: report_service_interface.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=../mocks/report_service_mock.go -package=mocks -source=report_service_interface.go
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "sms/models"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReportServiceI is a mock of ReportServiceI interface.
+type MockReportServiceI struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportServiceIMockRecorder
+	isgomock struct{}
+}
+
+// MockReportServiceIMockRecorder is the mock recorder for MockReportServiceI.
+type MockReportServiceIMockRecorder struct {
+	mock *MockReportServiceI
+}
+
+// NewMockReportServiceI creates a new mock instance.
+func NewMockReportServiceI(ctrl *gomock.Controller) *MockReportServiceI {
+	mock := &MockReportServiceI{ctrl: ctrl}
+	mock.recorder = &MockReportServiceIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportServiceI) EXPECT() *MockReportServiceIMockRecorder {
+	return m.recorder
+}
+
+// GetProgressReport mocks base method.
+func (m *MockReportServiceI) GetProgressReport(ctx context.Context, studentID string) (*models.ProgressReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProgressReport", ctx, studentID)
+	ret0, _ := ret[0].(*models.ProgressReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProgressReport indicates an expected call of GetProgressReport.
+func (mr *MockReportServiceIMockRecorder) GetProgressReport(ctx, studentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProgressReport", reflect.TypeOf((*MockReportServiceI)(nil).GetProgressReport), ctx, studentID)
+}
+
+// GetStudentGrades mocks base method.
+func (m *MockReportServiceI) GetStudentGrades(ctx context.Context, studentID string) ([]models.Grade, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStudentGrades", ctx, studentID)
+	ret0, _ := ret[0].([]models.Grade)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStudentGrades indicates an expected call of GetStudentGrades.
+func (mr *MockReportServiceIMockRecorder) GetStudentGrades(ctx, studentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStudentGrades", reflect.TypeOf((*MockReportServiceI)(nil).GetStudentGrades), ctx, studentID)
+}
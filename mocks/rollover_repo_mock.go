@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../mocks/rollover_repo_mock.go -package=mocks -source=interface.go
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "sms/models"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRolloverRepositoryI is a mock of RolloverRepositoryI interface.
+type MockRolloverRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockRolloverRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockRolloverRepositoryIMockRecorder is the mock recorder for MockRolloverRepositoryI.
+type MockRolloverRepositoryIMockRecorder struct {
+	mock *MockRolloverRepositoryI
+}
+
+// NewMockRolloverRepositoryI creates a new mock instance.
+func NewMockRolloverRepositoryI(ctrl *gomock.Controller) *MockRolloverRepositoryI {
+	mock := &MockRolloverRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockRolloverRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRolloverRepositoryI) EXPECT() *MockRolloverRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// CountClassStudents mocks base method.
+func (m *MockRolloverRepositoryI) CountClassStudents(ctx context.Context, classID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountClassStudents", ctx, classID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountClassStudents indicates an expected call of CountClassStudents.
+func (mr *MockRolloverRepositoryIMockRecorder) CountClassStudents(ctx, classID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountClassStudents", reflect.TypeOf((*MockRolloverRepositoryI)(nil).CountClassStudents), ctx, classID)
+}
+
+// GetClass mocks base method.
+func (m *MockRolloverRepositoryI) GetClass(ctx context.Context, classID string) (*models.Class, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClass", ctx, classID)
+	ret0, _ := ret[0].(*models.Class)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClass indicates an expected call of GetClass.
+func (mr *MockRolloverRepositoryIMockRecorder) GetClass(ctx, classID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClass", reflect.TypeOf((*MockRolloverRepositoryI)(nil).GetClass), ctx, classID)
+}
+
+// GetRollover mocks base method.
+func (m *MockRolloverRepositoryI) GetRollover(ctx context.Context, rolloverID string) (*models.Rollover, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRollover", ctx, rolloverID)
+	ret0, _ := ret[0].(*models.Rollover)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRollover indicates an expected call of GetRollover.
+func (mr *MockRolloverRepositoryIMockRecorder) GetRollover(ctx, rolloverID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRollover", reflect.TypeOf((*MockRolloverRepositoryI)(nil).GetRollover), ctx, rolloverID)
+}
+
+// SaveRollover mocks base method.
+func (m *MockRolloverRepositoryI) SaveRollover(ctx context.Context, rollover models.Rollover) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRollover", ctx, rollover)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveRollover indicates an expected call of SaveRollover.
+func (mr *MockRolloverRepositoryIMockRecorder) SaveRollover(ctx, rollover any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRollover", reflect.TypeOf((*MockRolloverRepositoryI)(nil).SaveRollover), ctx, rollover)
+}
+
+// UndoRollover mocks base method.
+func (m *MockRolloverRepositoryI) UndoRollover(ctx context.Context, rollover models.Rollover, undoneAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UndoRollover", ctx, rollover, undoneAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UndoRollover indicates an expected call of UndoRollover.
+func (mr *MockRolloverRepositoryIMockRecorder) UndoRollover(ctx, rollover, undoneAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UndoRollover", reflect.TypeOf((*MockRolloverRepositoryI)(nil).UndoRollover), ctx, rollover, undoneAt)
+}
@@ -0,0 +1,102 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: rollover_service_interface.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=../mocks/rollover_service_mock.go -package=mocks -source=rollover_service_interface.go
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "sms/models"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRolloverServiceI is a mock of RolloverServiceI interface.
+type MockRolloverServiceI struct {
+	ctrl     *gomock.Controller
+	recorder *MockRolloverServiceIMockRecorder
+	isgomock struct{}
+}
+
+// MockRolloverServiceIMockRecorder is the mock recorder for MockRolloverServiceI.
+type MockRolloverServiceIMockRecorder struct {
+	mock *MockRolloverServiceI
+}
+
+// NewMockRolloverServiceI creates a new mock instance.
+func NewMockRolloverServiceI(ctrl *gomock.Controller) *MockRolloverServiceI {
+	mock := &MockRolloverServiceI{ctrl: ctrl}
+	mock.recorder = &MockRolloverServiceIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRolloverServiceI) EXPECT() *MockRolloverServiceIMockRecorder {
+	return m.recorder
+}
+
+// GetRollover mocks base method.
+func (m *MockRolloverServiceI) GetRollover(ctx context.Context, rolloverID string) (*models.Rollover, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRollover", ctx, rolloverID)
+	ret0, _ := ret[0].(*models.Rollover)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRollover indicates an expected call of GetRollover.
+func (mr *MockRolloverServiceIMockRecorder) GetRollover(ctx, rolloverID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRollover", reflect.TypeOf((*MockRolloverServiceI)(nil).GetRollover), ctx, rolloverID)
+}
+
+// PreviewRollover mocks base method.
+func (m *MockRolloverServiceI) PreviewRollover(ctx context.Context, classID string, semester int, targetClassID string, criteria models.RolloverCriteria) (*models.Rollover, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewRollover", ctx, classID, semester, targetClassID, criteria)
+	ret0, _ := ret[0].(*models.Rollover)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewRollover indicates an expected call of PreviewRollover.
+func (mr *MockRolloverServiceIMockRecorder) PreviewRollover(ctx, classID, semester, targetClassID, criteria any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewRollover", reflect.TypeOf((*MockRolloverServiceI)(nil).PreviewRollover), ctx, classID, semester, targetClassID, criteria)
+}
+
+// Rollover mocks base method.
+func (m *MockRolloverServiceI) Rollover(ctx context.Context, classID string, semester int, targetClassID string, criteria models.RolloverCriteria, createdBy string) (*models.Rollover, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rollover", ctx, classID, semester, targetClassID, criteria, createdBy)
+	ret0, _ := ret[0].(*models.Rollover)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rollover indicates an expected call of Rollover.
+func (mr *MockRolloverServiceIMockRecorder) Rollover(ctx, classID, semester, targetClassID, criteria, createdBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollover", reflect.TypeOf((*MockRolloverServiceI)(nil).Rollover), ctx, classID, semester, targetClassID, criteria, createdBy)
+}
+
+// UndoRollover mocks base method.
+func (m *MockRolloverServiceI) UndoRollover(ctx context.Context, rolloverID string) (*models.Rollover, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UndoRollover", ctx, rolloverID)
+	ret0, _ := ret[0].(*models.Rollover)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UndoRollover indicates an expected call of UndoRollover.
+func (mr *MockRolloverServiceIMockRecorder) UndoRollover(ctx, rolloverID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UndoRollover", reflect.TypeOf((*MockRolloverServiceI)(nil).UndoRollover), ctx, rolloverID)
+}
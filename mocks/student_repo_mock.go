@@ -10,8 +10,11 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
 	models "sms/models"
+	studentsRepository "sms/repository/studentRepository"
+	transaction "sms/repository/transaction"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -41,74 +44,88 @@ func (m *MockStudentRepositoryI) EXPECT() *MockStudentRepositoryIMockRecorder {
 }
 
 // AddStudent mocks base method.
-func (m *MockStudentRepositoryI) AddStudent(uuid, rollNumber, name, classID string, semester int) error {
+func (m *MockStudentRepositoryI) AddStudent(ctx context.Context, uuid, rollNumber, name, classID string, semester int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddStudent", uuid, rollNumber, name, classID, semester)
+	ret := m.ctrl.Call(m, "AddStudent", ctx, uuid, rollNumber, name, classID, semester)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddStudent indicates an expected call of AddStudent.
-func (mr *MockStudentRepositoryIMockRecorder) AddStudent(uuid, rollNumber, name, classID, semester any) *gomock.Call {
+func (mr *MockStudentRepositoryIMockRecorder) AddStudent(ctx, uuid, rollNumber, name, classID, semester any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddStudent", reflect.TypeOf((*MockStudentRepositoryI)(nil).AddStudent), uuid, rollNumber, name, classID, semester)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddStudent", reflect.TypeOf((*MockStudentRepositoryI)(nil).AddStudent), ctx, uuid, rollNumber, name, classID, semester)
 }
 
-// GetAllStudentsOfClass mocks base method.
-func (m *MockStudentRepositoryI) GetAllStudentsOfClass(classID string, semester int) ([]models.Students, error) {
+// GetStudentByID mocks base method.
+func (m *MockStudentRepositoryI) GetStudentByID(ctx context.Context, studentID string) (*models.Students, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllStudentsOfClass", classID, semester)
-	ret0, _ := ret[0].([]models.Students)
+	ret := m.ctrl.Call(m, "GetStudentByID", ctx, studentID)
+	ret0, _ := ret[0].(*models.Students)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllStudentsOfClass indicates an expected call of GetAllStudentsOfClass.
-func (mr *MockStudentRepositoryIMockRecorder) GetAllStudentsOfClass(classID, semester any) *gomock.Call {
+// GetStudentByID indicates an expected call of GetStudentByID.
+func (mr *MockStudentRepositoryIMockRecorder) GetStudentByID(ctx, studentID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllStudentsOfClass", reflect.TypeOf((*MockStudentRepositoryI)(nil).GetAllStudentsOfClass), classID, semester)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStudentByID", reflect.TypeOf((*MockStudentRepositoryI)(nil).GetStudentByID), ctx, studentID)
 }
 
-// GetStudentByID mocks base method.
-func (m *MockStudentRepositoryI) GetStudentByID(studentID string) (*models.Students, error) {
+// GetStudentByRollNumber mocks base method.
+func (m *MockStudentRepositoryI) GetStudentByRollNumber(ctx context.Context, rollNumber string) (*models.Students, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetStudentByID", studentID)
+	ret := m.ctrl.Call(m, "GetStudentByRollNumber", ctx, rollNumber)
 	ret0, _ := ret[0].(*models.Students)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetStudentByID indicates an expected call of GetStudentByID.
-func (mr *MockStudentRepositoryIMockRecorder) GetStudentByID(studentID any) *gomock.Call {
+// GetStudentByRollNumber indicates an expected call of GetStudentByRollNumber.
+func (mr *MockStudentRepositoryIMockRecorder) GetStudentByRollNumber(ctx, rollNumber any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStudentByID", reflect.TypeOf((*MockStudentRepositoryI)(nil).GetStudentByID), studentID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStudentByRollNumber", reflect.TypeOf((*MockStudentRepositoryI)(nil).GetStudentByRollNumber), ctx, rollNumber)
 }
 
-// GetStudentByRollNumber mocks base method.
-func (m *MockStudentRepositoryI) GetStudentByRollNumber(rollNumber string) (*models.Students, error) {
+// GetStudentsByClass mocks base method.
+func (m *MockStudentRepositoryI) GetStudentsByClass(ctx context.Context, classID string) ([]models.Students, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetStudentByRollNumber", rollNumber)
-	ret0, _ := ret[0].(*models.Students)
+	ret := m.ctrl.Call(m, "GetStudentsByClass", ctx, classID)
+	ret0, _ := ret[0].([]models.Students)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetStudentByRollNumber indicates an expected call of GetStudentByRollNumber.
-func (mr *MockStudentRepositoryIMockRecorder) GetStudentByRollNumber(rollNumber any) *gomock.Call {
+// GetStudentsByClass indicates an expected call of GetStudentsByClass.
+func (mr *MockStudentRepositoryIMockRecorder) GetStudentsByClass(ctx, classID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStudentByRollNumber", reflect.TypeOf((*MockStudentRepositoryI)(nil).GetStudentByRollNumber), rollNumber)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStudentsByClass", reflect.TypeOf((*MockStudentRepositoryI)(nil).GetStudentsByClass), ctx, classID)
 }
 
 // UpdateStudent mocks base method.
-func (m *MockStudentRepositoryI) UpdateStudent(studentID, name, rollnumber, classID string, semester int) error {
+func (m *MockStudentRepositoryI) UpdateStudent(ctx context.Context, studentID, name, rollnumber, classID string, semester int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateStudent", studentID, name, rollnumber, classID, semester)
+	ret := m.ctrl.Call(m, "UpdateStudent", ctx, studentID, name, rollnumber, classID, semester)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateStudent indicates an expected call of UpdateStudent.
-func (mr *MockStudentRepositoryIMockRecorder) UpdateStudent(studentID, name, rollnumber, classID, semester any) *gomock.Call {
+func (mr *MockStudentRepositoryIMockRecorder) UpdateStudent(ctx, studentID, name, rollnumber, classID, semester any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStudent", reflect.TypeOf((*MockStudentRepositoryI)(nil).UpdateStudent), ctx, studentID, name, rollnumber, classID, semester)
+}
+
+// WithTx mocks base method.
+func (m *MockStudentRepositoryI) WithTx(tx transaction.Querier) studentsRepository.StudentRepositoryI {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", tx)
+	ret0, _ := ret[0].(studentsRepository.StudentRepositoryI)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockStudentRepositoryIMockRecorder) WithTx(tx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStudent", reflect.TypeOf((*MockStudentRepositoryI)(nil).UpdateStudent), studentID, name, rollnumber, classID, semester)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockStudentRepositoryI)(nil).WithTx), tx)
 }
@@ -10,6 +10,7 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
 	models "sms/models"
 
@@ -41,30 +42,46 @@ func (m *MockStudentServiceI) EXPECT() *MockStudentServiceIMockRecorder {
 }
 
 // CreateStudent mocks base method.
-func (m *MockStudentServiceI) CreateStudent(rollNumber, name, classID string, semester int) (*models.Students, error) {
+func (m *MockStudentServiceI) CreateStudent(ctx context.Context, rollNumber, name, classID string, semester int) (*models.Students, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateStudent", rollNumber, name, classID, semester)
+	ret := m.ctrl.Call(m, "CreateStudent", ctx, rollNumber, name, classID, semester)
 	ret0, _ := ret[0].(*models.Students)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateStudent indicates an expected call of CreateStudent.
-func (mr *MockStudentServiceIMockRecorder) CreateStudent(rollNumber, name, classID, semester any) *gomock.Call {
+func (mr *MockStudentServiceIMockRecorder) CreateStudent(ctx, rollNumber, name, classID, semester any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStudent", reflect.TypeOf((*MockStudentServiceI)(nil).CreateStudent), rollNumber, name, classID, semester)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStudent", reflect.TypeOf((*MockStudentServiceI)(nil).CreateStudent), ctx, rollNumber, name, classID, semester)
+}
+
+// CreateStudentWithEnrollments mocks base method.
+func (m *MockStudentServiceI) CreateStudentWithEnrollments(ctx context.Context, rollNumber, name, classID string, semester int, subjectIDs []string) (*models.Students, []models.Enrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStudentWithEnrollments", ctx, rollNumber, name, classID, semester, subjectIDs)
+	ret0, _ := ret[0].(*models.Students)
+	ret1, _ := ret[1].([]models.Enrollment)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateStudentWithEnrollments indicates an expected call of CreateStudentWithEnrollments.
+func (mr *MockStudentServiceIMockRecorder) CreateStudentWithEnrollments(ctx, rollNumber, name, classID, semester, subjectIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStudentWithEnrollments", reflect.TypeOf((*MockStudentServiceI)(nil).CreateStudentWithEnrollments), ctx, rollNumber, name, classID, semester, subjectIDs)
 }
 
 // UpdateStudent mocks base method.
-func (m *MockStudentServiceI) UpdateStudent(studentID, name, rollnumber, classID string, semester int) error {
+func (m *MockStudentServiceI) UpdateStudent(ctx context.Context, studentID, name, rollnumber, classID string, semester int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateStudent", studentID, name, rollnumber, classID, semester)
+	ret := m.ctrl.Call(m, "UpdateStudent", ctx, studentID, name, rollnumber, classID, semester)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateStudent indicates an expected call of UpdateStudent.
-func (mr *MockStudentServiceIMockRecorder) UpdateStudent(studentID, name, rollnumber, classID, semester any) *gomock.Call {
+func (mr *MockStudentServiceIMockRecorder) UpdateStudent(ctx, studentID, name, rollnumber, classID, semester any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStudent", reflect.TypeOf((*MockStudentServiceI)(nil).UpdateStudent), studentID, name, rollnumber, classID, semester)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStudent", reflect.TypeOf((*MockStudentServiceI)(nil).UpdateStudent), ctx, studentID, name, rollnumber, classID, semester)
 }
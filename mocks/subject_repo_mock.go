@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../mocks/subject_repo_mock.go -package=mocks -source=interface.go
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "sms/models"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSubjectRepositoryI is a mock of SubjectRepositoryI interface.
+type MockSubjectRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubjectRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockSubjectRepositoryIMockRecorder is the mock recorder for MockSubjectRepositoryI.
+type MockSubjectRepositoryIMockRecorder struct {
+	mock *MockSubjectRepositoryI
+}
+
+// NewMockSubjectRepositoryI creates a new mock instance.
+func NewMockSubjectRepositoryI(ctrl *gomock.Controller) *MockSubjectRepositoryI {
+	mock := &MockSubjectRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockSubjectRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubjectRepositoryI) EXPECT() *MockSubjectRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// AddSubject mocks base method.
+func (m *MockSubjectRepositoryI) AddSubject(ctx context.Context, subject models.Subject) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSubject", ctx, subject)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddSubject indicates an expected call of AddSubject.
+func (mr *MockSubjectRepositoryIMockRecorder) AddSubject(ctx, subject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSubject", reflect.TypeOf((*MockSubjectRepositoryI)(nil).AddSubject), ctx, subject)
+}
+
+// GetSubject mocks base method.
+func (m *MockSubjectRepositoryI) GetSubject(ctx context.Context, subjectID string) (*models.Subject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubject", ctx, subjectID)
+	ret0, _ := ret[0].(*models.Subject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubject indicates an expected call of GetSubject.
+func (mr *MockSubjectRepositoryIMockRecorder) GetSubject(ctx, subjectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubject", reflect.TypeOf((*MockSubjectRepositoryI)(nil).GetSubject), ctx, subjectID)
+}
+
+// GetSubjects mocks base method.
+func (m *MockSubjectRepositoryI) GetSubjects(ctx context.Context) ([]models.Subject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubjects", ctx)
+	ret0, _ := ret[0].([]models.Subject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubjects indicates an expected call of GetSubjects.
+func (mr *MockSubjectRepositoryIMockRecorder) GetSubjects(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubjects", reflect.TypeOf((*MockSubjectRepositoryI)(nil).GetSubjects), ctx)
+}
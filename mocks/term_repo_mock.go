@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../mocks/term_repo_mock.go -package=mocks -source=interface.go
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	constants "sms/constants"
+	models "sms/models"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTermRepositoryI is a mock of TermRepositoryI interface.
+type MockTermRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockTermRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockTermRepositoryIMockRecorder is the mock recorder for MockTermRepositoryI.
+type MockTermRepositoryIMockRecorder struct {
+	mock *MockTermRepositoryI
+}
+
+// NewMockTermRepositoryI creates a new mock instance.
+func NewMockTermRepositoryI(ctrl *gomock.Controller) *MockTermRepositoryI {
+	mock := &MockTermRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockTermRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTermRepositoryI) EXPECT() *MockTermRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// AddTerm mocks base method.
+func (m *MockTermRepositoryI) AddTerm(ctx context.Context, term models.AcademicTerm) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTerm", ctx, term)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTerm indicates an expected call of AddTerm.
+func (mr *MockTermRepositoryIMockRecorder) AddTerm(ctx, term any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTerm", reflect.TypeOf((*MockTermRepositoryI)(nil).AddTerm), ctx, term)
+}
+
+// GetTerm mocks base method.
+func (m *MockTermRepositoryI) GetTerm(ctx context.Context, termID string) (*models.AcademicTerm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTerm", ctx, termID)
+	ret0, _ := ret[0].(*models.AcademicTerm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTerm indicates an expected call of GetTerm.
+func (mr *MockTermRepositoryIMockRecorder) GetTerm(ctx, termID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTerm", reflect.TypeOf((*MockTermRepositoryI)(nil).GetTerm), ctx, termID)
+}
+
+// GetTerms mocks base method.
+func (m *MockTermRepositoryI) GetTerms(ctx context.Context, year int) ([]models.AcademicTerm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTerms", ctx, year)
+	ret0, _ := ret[0].([]models.AcademicTerm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTerms indicates an expected call of GetTerms.
+func (mr *MockTermRepositoryIMockRecorder) GetTerms(ctx, year any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTerms", reflect.TypeOf((*MockTermRepositoryI)(nil).GetTerms), ctx, year)
+}
+
+// GetTermsForSemester mocks base method.
+func (m *MockTermRepositoryI) GetTermsForSemester(ctx context.Context, semester int) ([]models.AcademicTerm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTermsForSemester", ctx, semester)
+	ret0, _ := ret[0].([]models.AcademicTerm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTermsForSemester indicates an expected call of GetTermsForSemester.
+func (mr *MockTermRepositoryIMockRecorder) GetTermsForSemester(ctx, semester any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTermsForSemester", reflect.TypeOf((*MockTermRepositoryI)(nil).GetTermsForSemester), ctx, semester)
+}
+
+// SetGradeEntryWindow mocks base method.
+func (m *MockTermRepositoryI) SetGradeEntryWindow(ctx context.Context, termID string, start, end time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetGradeEntryWindow", ctx, termID, start, end)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetGradeEntryWindow indicates an expected call of SetGradeEntryWindow.
+func (mr *MockTermRepositoryIMockRecorder) SetGradeEntryWindow(ctx, termID, start, end any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGradeEntryWindow", reflect.TypeOf((*MockTermRepositoryI)(nil).SetGradeEntryWindow), ctx, termID, start, end)
+}
+
+// SetTermStatus mocks base method.
+func (m *MockTermRepositoryI) SetTermStatus(ctx context.Context, termID string, status constants.TermStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTermStatus", ctx, termID, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTermStatus indicates an expected call of SetTermStatus.
+func (mr *MockTermRepositoryIMockRecorder) SetTermStatus(ctx, termID, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTermStatus", reflect.TypeOf((*MockTermRepositoryI)(nil).SetTermStatus), ctx, termID, status)
+}
@@ -0,0 +1,133 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: term_service_interface.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=../mocks/term_service_mock.go -package=mocks -source=term_service_interface.go
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	constants "sms/constants"
+	models "sms/models"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTermServiceI is a mock of TermServiceI interface.
+type MockTermServiceI struct {
+	ctrl     *gomock.Controller
+	recorder *MockTermServiceIMockRecorder
+	isgomock struct{}
+}
+
+// MockTermServiceIMockRecorder is the mock recorder for MockTermServiceI.
+type MockTermServiceIMockRecorder struct {
+	mock *MockTermServiceI
+}
+
+// NewMockTermServiceI creates a new mock instance.
+func NewMockTermServiceI(ctrl *gomock.Controller) *MockTermServiceI {
+	mock := &MockTermServiceI{ctrl: ctrl}
+	mock.recorder = &MockTermServiceIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTermServiceI) EXPECT() *MockTermServiceIMockRecorder {
+	return m.recorder
+}
+
+// CanEnterGrades mocks base method.
+func (m *MockTermServiceI) CanEnterGrades(ctx context.Context, semester int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CanEnterGrades", ctx, semester)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CanEnterGrades indicates an expected call of CanEnterGrades.
+func (mr *MockTermServiceIMockRecorder) CanEnterGrades(ctx, semester any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanEnterGrades", reflect.TypeOf((*MockTermServiceI)(nil).CanEnterGrades), ctx, semester)
+}
+
+// CreateTerm mocks base method.
+func (m *MockTermServiceI) CreateTerm(ctx context.Context, term models.AcademicTerm) (*models.AcademicTerm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTerm", ctx, term)
+	ret0, _ := ret[0].(*models.AcademicTerm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTerm indicates an expected call of CreateTerm.
+func (mr *MockTermServiceIMockRecorder) CreateTerm(ctx, term any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTerm", reflect.TypeOf((*MockTermServiceI)(nil).CreateTerm), ctx, term)
+}
+
+// GetTerm mocks base method.
+func (m *MockTermServiceI) GetTerm(ctx context.Context, termID string) (*models.AcademicTerm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTerm", ctx, termID)
+	ret0, _ := ret[0].(*models.AcademicTerm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTerm indicates an expected call of GetTerm.
+func (mr *MockTermServiceIMockRecorder) GetTerm(ctx, termID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTerm", reflect.TypeOf((*MockTermServiceI)(nil).GetTerm), ctx, termID)
+}
+
+// GetTerms mocks base method.
+func (m *MockTermServiceI) GetTerms(ctx context.Context, year int) ([]models.AcademicTerm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTerms", ctx, year)
+	ret0, _ := ret[0].([]models.AcademicTerm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTerms indicates an expected call of GetTerms.
+func (mr *MockTermServiceIMockRecorder) GetTerms(ctx, year any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTerms", reflect.TypeOf((*MockTermServiceI)(nil).GetTerms), ctx, year)
+}
+
+// SetGradeEntryWindow mocks base method.
+func (m *MockTermServiceI) SetGradeEntryWindow(ctx context.Context, termID string, start, end time.Time) (*models.AcademicTerm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetGradeEntryWindow", ctx, termID, start, end)
+	ret0, _ := ret[0].(*models.AcademicTerm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetGradeEntryWindow indicates an expected call of SetGradeEntryWindow.
+func (mr *MockTermServiceIMockRecorder) SetGradeEntryWindow(ctx, termID, start, end any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGradeEntryWindow", reflect.TypeOf((*MockTermServiceI)(nil).SetGradeEntryWindow), ctx, termID, start, end)
+}
+
+// SetTermStatus mocks base method.
+func (m *MockTermServiceI) SetTermStatus(ctx context.Context, termID string, status constants.TermStatus) (*models.AcademicTerm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTermStatus", ctx, termID, status)
+	ret0, _ := ret[0].(*models.AcademicTerm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetTermStatus indicates an expected call of SetTermStatus.
+func (mr *MockTermServiceIMockRecorder) SetTermStatus(ctx, termID, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTermStatus", reflect.TypeOf((*MockTermServiceI)(nil).SetTermStatus), ctx, termID, status)
+}
@@ -0,0 +1,232 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../mocks/timetable_repo_mock.go -package=mocks -source=interface.go
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "sms/models"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTimetableRepositoryI is a mock of TimetableRepositoryI interface.
+type MockTimetableRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockTimetableRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockTimetableRepositoryIMockRecorder is the mock recorder for MockTimetableRepositoryI.
+type MockTimetableRepositoryIMockRecorder struct {
+	mock *MockTimetableRepositoryI
+}
+
+// NewMockTimetableRepositoryI creates a new mock instance.
+func NewMockTimetableRepositoryI(ctrl *gomock.Controller) *MockTimetableRepositoryI {
+	mock := &MockTimetableRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockTimetableRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTimetableRepositoryI) EXPECT() *MockTimetableRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// AddFacultyAssignment mocks base method.
+func (m *MockTimetableRepositoryI) AddFacultyAssignment(ctx context.Context, assignment models.FacultyAssignment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFacultyAssignment", ctx, assignment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddFacultyAssignment indicates an expected call of AddFacultyAssignment.
+func (mr *MockTimetableRepositoryIMockRecorder) AddFacultyAssignment(ctx, assignment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFacultyAssignment", reflect.TypeOf((*MockTimetableRepositoryI)(nil).AddFacultyAssignment), ctx, assignment)
+}
+
+// AddRoom mocks base method.
+func (m *MockTimetableRepositoryI) AddRoom(ctx context.Context, room models.Room) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRoom", ctx, room)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddRoom indicates an expected call of AddRoom.
+func (mr *MockTimetableRepositoryIMockRecorder) AddRoom(ctx, room any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRoom", reflect.TypeOf((*MockTimetableRepositoryI)(nil).AddRoom), ctx, room)
+}
+
+// AddSlot mocks base method.
+func (m *MockTimetableRepositoryI) AddSlot(ctx context.Context, slot models.TimetableSlot) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSlot", ctx, slot)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddSlot indicates an expected call of AddSlot.
+func (mr *MockTimetableRepositoryIMockRecorder) AddSlot(ctx, slot any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSlot", reflect.TypeOf((*MockTimetableRepositoryI)(nil).AddSlot), ctx, slot)
+}
+
+// DeleteSlot mocks base method.
+func (m *MockTimetableRepositoryI) DeleteSlot(ctx context.Context, slotID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSlot", ctx, slotID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSlot indicates an expected call of DeleteSlot.
+func (mr *MockTimetableRepositoryIMockRecorder) DeleteSlot(ctx, slotID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSlot", reflect.TypeOf((*MockTimetableRepositoryI)(nil).DeleteSlot), ctx, slotID)
+}
+
+// GetClassFacultyIDs mocks base method.
+func (m *MockTimetableRepositoryI) GetClassFacultyIDs(ctx context.Context, classID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClassFacultyIDs", ctx, classID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClassFacultyIDs indicates an expected call of GetClassFacultyIDs.
+func (mr *MockTimetableRepositoryIMockRecorder) GetClassFacultyIDs(ctx, classID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClassFacultyIDs", reflect.TypeOf((*MockTimetableRepositoryI)(nil).GetClassFacultyIDs), ctx, classID)
+}
+
+// GetClassHomeRoom mocks base method.
+func (m *MockTimetableRepositoryI) GetClassHomeRoom(ctx context.Context, classID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClassHomeRoom", ctx, classID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClassHomeRoom indicates an expected call of GetClassHomeRoom.
+func (mr *MockTimetableRepositoryIMockRecorder) GetClassHomeRoom(ctx, classID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClassHomeRoom", reflect.TypeOf((*MockTimetableRepositoryI)(nil).GetClassHomeRoom), ctx, classID)
+}
+
+// GetClassSlots mocks base method.
+func (m *MockTimetableRepositoryI) GetClassSlots(ctx context.Context, classID string) ([]models.TimetableSlot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClassSlots", ctx, classID)
+	ret0, _ := ret[0].([]models.TimetableSlot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClassSlots indicates an expected call of GetClassSlots.
+func (mr *MockTimetableRepositoryIMockRecorder) GetClassSlots(ctx, classID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClassSlots", reflect.TypeOf((*MockTimetableRepositoryI)(nil).GetClassSlots), ctx, classID)
+}
+
+// GetFacultyAssignment mocks base method.
+func (m *MockTimetableRepositoryI) GetFacultyAssignment(ctx context.Context, facultyID, classID, subjectID string) (*models.FacultyAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFacultyAssignment", ctx, facultyID, classID, subjectID)
+	ret0, _ := ret[0].(*models.FacultyAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFacultyAssignment indicates an expected call of GetFacultyAssignment.
+func (mr *MockTimetableRepositoryIMockRecorder) GetFacultyAssignment(ctx, facultyID, classID, subjectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFacultyAssignment", reflect.TypeOf((*MockTimetableRepositoryI)(nil).GetFacultyAssignment), ctx, facultyID, classID, subjectID)
+}
+
+// GetFacultySlots mocks base method.
+func (m *MockTimetableRepositoryI) GetFacultySlots(ctx context.Context, facultyID string) ([]models.TimetableSlot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFacultySlots", ctx, facultyID)
+	ret0, _ := ret[0].([]models.TimetableSlot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFacultySlots indicates an expected call of GetFacultySlots.
+func (mr *MockTimetableRepositoryIMockRecorder) GetFacultySlots(ctx, facultyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFacultySlots", reflect.TypeOf((*MockTimetableRepositoryI)(nil).GetFacultySlots), ctx, facultyID)
+}
+
+// GetOverlappingSlots mocks base method.
+func (m *MockTimetableRepositoryI) GetOverlappingSlots(ctx context.Context, slot models.TimetableSlot) ([]models.TimetableSlot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverlappingSlots", ctx, slot)
+	ret0, _ := ret[0].([]models.TimetableSlot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOverlappingSlots indicates an expected call of GetOverlappingSlots.
+func (mr *MockTimetableRepositoryIMockRecorder) GetOverlappingSlots(ctx, slot any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverlappingSlots", reflect.TypeOf((*MockTimetableRepositoryI)(nil).GetOverlappingSlots), ctx, slot)
+}
+
+// GetRoom mocks base method.
+func (m *MockTimetableRepositoryI) GetRoom(ctx context.Context, roomID string) (*models.Room, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoom", ctx, roomID)
+	ret0, _ := ret[0].(*models.Room)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoom indicates an expected call of GetRoom.
+func (mr *MockTimetableRepositoryIMockRecorder) GetRoom(ctx, roomID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoom", reflect.TypeOf((*MockTimetableRepositoryI)(nil).GetRoom), ctx, roomID)
+}
+
+// GetSlot mocks base method.
+func (m *MockTimetableRepositoryI) GetSlot(ctx context.Context, slotID string) (*models.TimetableSlot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSlot", ctx, slotID)
+	ret0, _ := ret[0].(*models.TimetableSlot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSlot indicates an expected call of GetSlot.
+func (mr *MockTimetableRepositoryIMockRecorder) GetSlot(ctx, slotID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSlot", reflect.TypeOf((*MockTimetableRepositoryI)(nil).GetSlot), ctx, slotID)
+}
+
+// SetClassHomeRoom mocks base method.
+func (m *MockTimetableRepositoryI) SetClassHomeRoom(ctx context.Context, classID, roomID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetClassHomeRoom", ctx, classID, roomID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetClassHomeRoom indicates an expected call of SetClassHomeRoom.
+func (mr *MockTimetableRepositoryIMockRecorder) SetClassHomeRoom(ctx, classID, roomID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetClassHomeRoom", reflect.TypeOf((*MockTimetableRepositoryI)(nil).SetClassHomeRoom), ctx, classID, roomID)
+}
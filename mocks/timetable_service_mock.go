@@ -0,0 +1,175 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: timetable_service_interface.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=../mocks/timetable_service_mock.go -package=mocks -source=timetable_service_interface.go
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "sms/models"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTimetableServiceI is a mock of TimetableServiceI interface.
+type MockTimetableServiceI struct {
+	ctrl     *gomock.Controller
+	recorder *MockTimetableServiceIMockRecorder
+	isgomock struct{}
+}
+
+// MockTimetableServiceIMockRecorder is the mock recorder for MockTimetableServiceI.
+type MockTimetableServiceIMockRecorder struct {
+	mock *MockTimetableServiceI
+}
+
+// NewMockTimetableServiceI creates a new mock instance.
+func NewMockTimetableServiceI(ctrl *gomock.Controller) *MockTimetableServiceI {
+	mock := &MockTimetableServiceI{ctrl: ctrl}
+	mock.recorder = &MockTimetableServiceIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTimetableServiceI) EXPECT() *MockTimetableServiceIMockRecorder {
+	return m.recorder
+}
+
+// AddSlot mocks base method.
+func (m *MockTimetableServiceI) AddSlot(ctx context.Context, slot models.TimetableSlot) (*models.TimetableSlot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSlot", ctx, slot)
+	ret0, _ := ret[0].(*models.TimetableSlot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddSlot indicates an expected call of AddSlot.
+func (mr *MockTimetableServiceIMockRecorder) AddSlot(ctx, slot any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSlot", reflect.TypeOf((*MockTimetableServiceI)(nil).AddSlot), ctx, slot)
+}
+
+// AssignFaculty mocks base method.
+func (m *MockTimetableServiceI) AssignFaculty(ctx context.Context, assignment models.FacultyAssignment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignFaculty", ctx, assignment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignFaculty indicates an expected call of AssignFaculty.
+func (mr *MockTimetableServiceIMockRecorder) AssignFaculty(ctx, assignment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignFaculty", reflect.TypeOf((*MockTimetableServiceI)(nil).AssignFaculty), ctx, assignment)
+}
+
+// ClassCalendar mocks base method.
+func (m *MockTimetableServiceI) ClassCalendar(ctx context.Context, classID string, from, until time.Time) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClassCalendar", ctx, classID, from, until)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClassCalendar indicates an expected call of ClassCalendar.
+func (mr *MockTimetableServiceIMockRecorder) ClassCalendar(ctx, classID, from, until any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClassCalendar", reflect.TypeOf((*MockTimetableServiceI)(nil).ClassCalendar), ctx, classID, from, until)
+}
+
+// CreateRoom mocks base method.
+func (m *MockTimetableServiceI) CreateRoom(ctx context.Context, name string, capacity int) (*models.Room, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRoom", ctx, name, capacity)
+	ret0, _ := ret[0].(*models.Room)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRoom indicates an expected call of CreateRoom.
+func (mr *MockTimetableServiceIMockRecorder) CreateRoom(ctx, name, capacity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRoom", reflect.TypeOf((*MockTimetableServiceI)(nil).CreateRoom), ctx, name, capacity)
+}
+
+// FacultyCalendar mocks base method.
+func (m *MockTimetableServiceI) FacultyCalendar(ctx context.Context, facultyID string, from, until time.Time) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FacultyCalendar", ctx, facultyID, from, until)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FacultyCalendar indicates an expected call of FacultyCalendar.
+func (mr *MockTimetableServiceIMockRecorder) FacultyCalendar(ctx, facultyID, from, until any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FacultyCalendar", reflect.TypeOf((*MockTimetableServiceI)(nil).FacultyCalendar), ctx, facultyID, from, until)
+}
+
+// GetClassTimetable mocks base method.
+func (m *MockTimetableServiceI) GetClassTimetable(ctx context.Context, classID string) ([]models.TimetableSlot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClassTimetable", ctx, classID)
+	ret0, _ := ret[0].([]models.TimetableSlot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClassTimetable indicates an expected call of GetClassTimetable.
+func (mr *MockTimetableServiceIMockRecorder) GetClassTimetable(ctx, classID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClassTimetable", reflect.TypeOf((*MockTimetableServiceI)(nil).GetClassTimetable), ctx, classID)
+}
+
+// GetFacultyTimetable mocks base method.
+func (m *MockTimetableServiceI) GetFacultyTimetable(ctx context.Context, facultyID string) ([]models.TimetableSlot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFacultyTimetable", ctx, facultyID)
+	ret0, _ := ret[0].([]models.TimetableSlot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFacultyTimetable indicates an expected call of GetFacultyTimetable.
+func (mr *MockTimetableServiceIMockRecorder) GetFacultyTimetable(ctx, facultyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFacultyTimetable", reflect.TypeOf((*MockTimetableServiceI)(nil).GetFacultyTimetable), ctx, facultyID)
+}
+
+// RemoveSlot mocks base method.
+func (m *MockTimetableServiceI) RemoveSlot(ctx context.Context, slotID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveSlot", ctx, slotID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveSlot indicates an expected call of RemoveSlot.
+func (mr *MockTimetableServiceIMockRecorder) RemoveSlot(ctx, slotID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSlot", reflect.TypeOf((*MockTimetableServiceI)(nil).RemoveSlot), ctx, slotID)
+}
+
+// SetClassHomeRoom mocks base method.
+func (m *MockTimetableServiceI) SetClassHomeRoom(ctx context.Context, classID, roomID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetClassHomeRoom", ctx, classID, roomID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetClassHomeRoom indicates an expected call of SetClassHomeRoom.
+func (mr *MockTimetableServiceIMockRecorder) SetClassHomeRoom(ctx, classID, roomID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetClassHomeRoom", reflect.TypeOf((*MockTimetableServiceI)(nil).SetClassHomeRoom), ctx, classID, roomID)
+}
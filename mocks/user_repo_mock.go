@@ -1,20 +1,30 @@
 // Code generated by MockGen. DO NOT EDIT.
 // Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../mocks/user_repo_mock.go -package=mocks -source=interface.go
+//
 
 // Package mocks is a generated GoMock package.
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
+	constants "sms/constants"
 	models "sms/models"
+	transaction "sms/repository/transaction"
+	userrepository "sms/repository/userRepository"
 
-	gomock "github.com/golang/mock/gomock"
+	gomock "go.uber.org/mock/gomock"
 )
 
 // MockUserRepositoryI is a mock of UserRepositoryI interface.
 type MockUserRepositoryI struct {
 	ctrl     *gomock.Controller
 	recorder *MockUserRepositoryIMockRecorder
+	isgomock struct{}
 }
 
 // MockUserRepositoryIMockRecorder is the mock recorder for MockUserRepositoryI.
@@ -35,30 +45,102 @@ func (m *MockUserRepositoryI) EXPECT() *MockUserRepositoryIMockRecorder {
 }
 
 // AddUser mocks base method.
-func (m *MockUserRepositoryI) AddUser(id, name, email, password string) error {
+func (m *MockUserRepositoryI) AddUser(ctx context.Context, id, name, email, password string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddUser", id, name, email, password)
+	ret := m.ctrl.Call(m, "AddUser", ctx, id, name, email, password)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddUser indicates an expected call of AddUser.
-func (mr *MockUserRepositoryIMockRecorder) AddUser(id, name, email, password interface{}) *gomock.Call {
+func (mr *MockUserRepositoryIMockRecorder) AddUser(ctx, id, name, email, password any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUser", reflect.TypeOf((*MockUserRepositoryI)(nil).AddUser), id, name, email, password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUser", reflect.TypeOf((*MockUserRepositoryI)(nil).AddUser), ctx, id, name, email, password)
+}
+
+// AddUserWithRole mocks base method.
+func (m *MockUserRepositoryI) AddUserWithRole(ctx context.Context, id, name, email, password string, role constants.Role) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddUserWithRole", ctx, id, name, email, password, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddUserWithRole indicates an expected call of AddUserWithRole.
+func (mr *MockUserRepositoryIMockRecorder) AddUserWithRole(ctx, id, name, email, password, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUserWithRole", reflect.TypeOf((*MockUserRepositoryI)(nil).AddUserWithRole), ctx, id, name, email, password, role)
 }
 
 // GetUserByEmailID mocks base method.
-func (m *MockUserRepositoryI) GetUserByEmailID(email string) (*models.User, error) {
+func (m *MockUserRepositoryI) GetUserByEmailID(ctx context.Context, email string) (*models.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByEmailID", email)
+	ret := m.ctrl.Call(m, "GetUserByEmailID", ctx, email)
 	ret0, _ := ret[0].(*models.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetUserByEmailID indicates an expected call of GetUserByEmailID.
-func (mr *MockUserRepositoryIMockRecorder) GetUserByEmailID(email interface{}) *gomock.Call {
+func (mr *MockUserRepositoryIMockRecorder) GetUserByEmailID(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmailID", reflect.TypeOf((*MockUserRepositoryI)(nil).GetUserByEmailID), ctx, email)
+}
+
+// GetUserByID mocks base method.
+func (m *MockUserRepositoryI) GetUserByID(ctx context.Context, userID string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", ctx, userID)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockUserRepositoryIMockRecorder) GetUserByID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockUserRepositoryI)(nil).GetUserByID), ctx, userID)
+}
+
+// GetUserIDsByRole mocks base method.
+func (m *MockUserRepositoryI) GetUserIDsByRole(ctx context.Context, role constants.Role) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserIDsByRole", ctx, role)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserIDsByRole indicates an expected call of GetUserIDsByRole.
+func (mr *MockUserRepositoryIMockRecorder) GetUserIDsByRole(ctx, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIDsByRole", reflect.TypeOf((*MockUserRepositoryI)(nil).GetUserIDsByRole), ctx, role)
+}
+
+// UpdatePassword mocks base method.
+func (m *MockUserRepositoryI) UpdatePassword(ctx context.Context, userID, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", ctx, userID, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockUserRepositoryIMockRecorder) UpdatePassword(ctx, userID, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockUserRepositoryI)(nil).UpdatePassword), ctx, userID, password)
+}
+
+// WithTx mocks base method.
+func (m *MockUserRepositoryI) WithTx(tx transaction.Querier) userrepository.UserRepositoryI {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", tx)
+	ret0, _ := ret[0].(userrepository.UserRepositoryI)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockUserRepositoryIMockRecorder) WithTx(tx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmailID", reflect.TypeOf((*MockUserRepositoryI)(nil).GetUserByEmailID), email)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockUserRepositoryI)(nil).WithTx), tx)
 }
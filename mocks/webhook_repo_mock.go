@@ -0,0 +1,204 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../mocks/webhook_repo_mock.go -package=mocks -source=interface.go
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	constants "sms/constants"
+	models "sms/models"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWebhookRepositoryI is a mock of WebhookRepositoryI interface.
+type MockWebhookRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookRepositoryIMockRecorder is the mock recorder for MockWebhookRepositoryI.
+type MockWebhookRepositoryIMockRecorder struct {
+	mock *MockWebhookRepositoryI
+}
+
+// NewMockWebhookRepositoryI creates a new mock instance.
+func NewMockWebhookRepositoryI(ctrl *gomock.Controller) *MockWebhookRepositoryI {
+	mock := &MockWebhookRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockWebhookRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookRepositoryI) EXPECT() *MockWebhookRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// AddDeliveries mocks base method.
+func (m *MockWebhookRepositoryI) AddDeliveries(ctx context.Context, deliveries []models.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDeliveries", ctx, deliveries)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddDeliveries indicates an expected call of AddDeliveries.
+func (mr *MockWebhookRepositoryIMockRecorder) AddDeliveries(ctx, deliveries any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDeliveries", reflect.TypeOf((*MockWebhookRepositoryI)(nil).AddDeliveries), ctx, deliveries)
+}
+
+// AddSubscription mocks base method.
+func (m *MockWebhookRepositoryI) AddSubscription(ctx context.Context, sub models.WebhookSubscription) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSubscription", ctx, sub)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddSubscription indicates an expected call of AddSubscription.
+func (mr *MockWebhookRepositoryIMockRecorder) AddSubscription(ctx, sub any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSubscription", reflect.TypeOf((*MockWebhookRepositoryI)(nil).AddSubscription), ctx, sub)
+}
+
+// GetDeliveries mocks base method.
+func (m *MockWebhookRepositoryI) GetDeliveries(ctx context.Context, subscriptionID string, limit int) ([]models.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveries", ctx, subscriptionID, limit)
+	ret0, _ := ret[0].([]models.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveries indicates an expected call of GetDeliveries.
+func (mr *MockWebhookRepositoryIMockRecorder) GetDeliveries(ctx, subscriptionID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveries", reflect.TypeOf((*MockWebhookRepositoryI)(nil).GetDeliveries), ctx, subscriptionID, limit)
+}
+
+// GetDelivery mocks base method.
+func (m *MockWebhookRepositoryI) GetDelivery(ctx context.Context, deliveryID string) (*models.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelivery", ctx, deliveryID)
+	ret0, _ := ret[0].(*models.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelivery indicates an expected call of GetDelivery.
+func (mr *MockWebhookRepositoryIMockRecorder) GetDelivery(ctx, deliveryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelivery", reflect.TypeOf((*MockWebhookRepositoryI)(nil).GetDelivery), ctx, deliveryID)
+}
+
+// GetDueDeliveries mocks base method.
+func (m *MockWebhookRepositoryI) GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueDeliveries", ctx, now, limit)
+	ret0, _ := ret[0].([]models.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueDeliveries indicates an expected call of GetDueDeliveries.
+func (mr *MockWebhookRepositoryIMockRecorder) GetDueDeliveries(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueDeliveries", reflect.TypeOf((*MockWebhookRepositoryI)(nil).GetDueDeliveries), ctx, now, limit)
+}
+
+// GetSubscription mocks base method.
+func (m *MockWebhookRepositoryI) GetSubscription(ctx context.Context, subscriptionID string) (*models.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscription", ctx, subscriptionID)
+	ret0, _ := ret[0].(*models.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscription indicates an expected call of GetSubscription.
+func (mr *MockWebhookRepositoryIMockRecorder) GetSubscription(ctx, subscriptionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscription", reflect.TypeOf((*MockWebhookRepositoryI)(nil).GetSubscription), ctx, subscriptionID)
+}
+
+// GetSubscriptions mocks base method.
+func (m *MockWebhookRepositoryI) GetSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscriptions", ctx)
+	ret0, _ := ret[0].([]models.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscriptions indicates an expected call of GetSubscriptions.
+func (mr *MockWebhookRepositoryIMockRecorder) GetSubscriptions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriptions", reflect.TypeOf((*MockWebhookRepositoryI)(nil).GetSubscriptions), ctx)
+}
+
+// GetSubscriptionsForEvent mocks base method.
+func (m *MockWebhookRepositoryI) GetSubscriptionsForEvent(ctx context.Context, event constants.WebhookEvent) ([]models.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscriptionsForEvent", ctx, event)
+	ret0, _ := ret[0].([]models.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscriptionsForEvent indicates an expected call of GetSubscriptionsForEvent.
+func (mr *MockWebhookRepositoryIMockRecorder) GetSubscriptionsForEvent(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriptionsForEvent", reflect.TypeOf((*MockWebhookRepositoryI)(nil).GetSubscriptionsForEvent), ctx, event)
+}
+
+// RecordAttempt mocks base method.
+func (m *MockWebhookRepositoryI) RecordAttempt(ctx context.Context, delivery models.WebhookDelivery, attempt models.WebhookAttempt) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordAttempt", ctx, delivery, attempt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordAttempt indicates an expected call of RecordAttempt.
+func (mr *MockWebhookRepositoryIMockRecorder) RecordAttempt(ctx, delivery, attempt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordAttempt", reflect.TypeOf((*MockWebhookRepositoryI)(nil).RecordAttempt), ctx, delivery, attempt)
+}
+
+// ResetDelivery mocks base method.
+func (m *MockWebhookRepositoryI) ResetDelivery(ctx context.Context, deliveryID string, nextAttemptAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetDelivery", ctx, deliveryID, nextAttemptAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetDelivery indicates an expected call of ResetDelivery.
+func (mr *MockWebhookRepositoryIMockRecorder) ResetDelivery(ctx, deliveryID, nextAttemptAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetDelivery", reflect.TypeOf((*MockWebhookRepositoryI)(nil).ResetDelivery), ctx, deliveryID, nextAttemptAt)
+}
+
+// SetSubscriptionActive mocks base method.
+func (m *MockWebhookRepositoryI) SetSubscriptionActive(ctx context.Context, subscriptionID string, active bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSubscriptionActive", ctx, subscriptionID, active)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSubscriptionActive indicates an expected call of SetSubscriptionActive.
+func (mr *MockWebhookRepositoryIMockRecorder) SetSubscriptionActive(ctx, subscriptionID, active any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubscriptionActive", reflect.TypeOf((*MockWebhookRepositoryI)(nil).SetSubscriptionActive), ctx, subscriptionID, active)
+}
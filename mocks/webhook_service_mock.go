@@ -0,0 +1,176 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: webhook_service_interface.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=../mocks/webhook_service_mock.go -package=mocks -source=webhook_service_interface.go
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	constants "sms/constants"
+	models "sms/models"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWebhookServiceI is a mock of WebhookServiceI interface.
+type MockWebhookServiceI struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookServiceIMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookServiceIMockRecorder is the mock recorder for MockWebhookServiceI.
+type MockWebhookServiceIMockRecorder struct {
+	mock *MockWebhookServiceI
+}
+
+// NewMockWebhookServiceI creates a new mock instance.
+func NewMockWebhookServiceI(ctrl *gomock.Controller) *MockWebhookServiceI {
+	mock := &MockWebhookServiceI{ctrl: ctrl}
+	mock.recorder = &MockWebhookServiceIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookServiceI) EXPECT() *MockWebhookServiceIMockRecorder {
+	return m.recorder
+}
+
+// CreateSubscription mocks base method.
+func (m *MockWebhookServiceI) CreateSubscription(ctx context.Context, sub models.WebhookSubscription) (*models.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSubscription", ctx, sub)
+	ret0, _ := ret[0].(*models.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSubscription indicates an expected call of CreateSubscription.
+func (mr *MockWebhookServiceIMockRecorder) CreateSubscription(ctx, sub any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubscription", reflect.TypeOf((*MockWebhookServiceI)(nil).CreateSubscription), ctx, sub)
+}
+
+// GetDeliveries mocks base method.
+func (m *MockWebhookServiceI) GetDeliveries(ctx context.Context, subscriptionID string) ([]models.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveries", ctx, subscriptionID)
+	ret0, _ := ret[0].([]models.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveries indicates an expected call of GetDeliveries.
+func (mr *MockWebhookServiceIMockRecorder) GetDeliveries(ctx, subscriptionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveries", reflect.TypeOf((*MockWebhookServiceI)(nil).GetDeliveries), ctx, subscriptionID)
+}
+
+// GetDelivery mocks base method.
+func (m *MockWebhookServiceI) GetDelivery(ctx context.Context, deliveryID string) (*models.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelivery", ctx, deliveryID)
+	ret0, _ := ret[0].(*models.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelivery indicates an expected call of GetDelivery.
+func (mr *MockWebhookServiceIMockRecorder) GetDelivery(ctx, deliveryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelivery", reflect.TypeOf((*MockWebhookServiceI)(nil).GetDelivery), ctx, deliveryID)
+}
+
+// GetSubscription mocks base method.
+func (m *MockWebhookServiceI) GetSubscription(ctx context.Context, subscriptionID string) (*models.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscription", ctx, subscriptionID)
+	ret0, _ := ret[0].(*models.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscription indicates an expected call of GetSubscription.
+func (mr *MockWebhookServiceIMockRecorder) GetSubscription(ctx, subscriptionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscription", reflect.TypeOf((*MockWebhookServiceI)(nil).GetSubscription), ctx, subscriptionID)
+}
+
+// GetSubscriptions mocks base method.
+func (m *MockWebhookServiceI) GetSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscriptions", ctx)
+	ret0, _ := ret[0].([]models.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscriptions indicates an expected call of GetSubscriptions.
+func (mr *MockWebhookServiceIMockRecorder) GetSubscriptions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriptions", reflect.TypeOf((*MockWebhookServiceI)(nil).GetSubscriptions), ctx)
+}
+
+// ProcessDeliveries mocks base method.
+func (m *MockWebhookServiceI) ProcessDeliveries(ctx context.Context, now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessDeliveries", ctx, now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProcessDeliveries indicates an expected call of ProcessDeliveries.
+func (mr *MockWebhookServiceIMockRecorder) ProcessDeliveries(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessDeliveries", reflect.TypeOf((*MockWebhookServiceI)(nil).ProcessDeliveries), ctx, now)
+}
+
+// Publish mocks base method.
+func (m *MockWebhookServiceI) Publish(ctx context.Context, event constants.WebhookEvent, data any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, event, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockWebhookServiceIMockRecorder) Publish(ctx, event, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockWebhookServiceI)(nil).Publish), ctx, event, data)
+}
+
+// Redeliver mocks base method.
+func (m *MockWebhookServiceI) Redeliver(ctx context.Context, deliveryID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeliver", ctx, deliveryID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Redeliver indicates an expected call of Redeliver.
+func (mr *MockWebhookServiceIMockRecorder) Redeliver(ctx, deliveryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeliver", reflect.TypeOf((*MockWebhookServiceI)(nil).Redeliver), ctx, deliveryID)
+}
+
+// SetSubscriptionActive mocks base method.
+func (m *MockWebhookServiceI) SetSubscriptionActive(ctx context.Context, subscriptionID string, active bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSubscriptionActive", ctx, subscriptionID, active)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSubscriptionActive indicates an expected call of SetSubscriptionActive.
+func (mr *MockWebhookServiceIMockRecorder) SetSubscriptionActive(ctx, subscriptionID, active any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubscriptionActive", reflect.TypeOf((*MockWebhookServiceI)(nil).SetSubscriptionActive), ctx, subscriptionID, active)
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+type AtRiskCriteria struct {
+	MinAverage        float64
+	MaxDrop           float64
+	PassMark          int
+	MaxFailedSubjects int
+}
+
+type AtRiskFlag struct {
+	FlagID    string
+	StudentID string
+	ClassID   string
+	Semester  int
+	Reasons   []string
+	CreatedAt time.Time
+}
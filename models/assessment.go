@@ -0,0 +1,21 @@
+package models
+
+import "sms/constants"
+
+// Assessment is a graded component of a subject in a semester. Weight is the
+// component's share of the final grade in percent.
+type Assessment struct {
+	AssessmentID string
+	SubjectID    string
+	Semester     int
+	Name         string
+	Kind         constants.AssessmentKind
+	Weight       float64
+	MaxMarks     int
+}
+
+type AssessmentScore struct {
+	AssessmentID string
+	StudentID    string
+	Marks        float64
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"sms/constants"
+	"time"
+)
+
+type AttendanceSession struct {
+	SessionID string
+	ClassID   string
+	SubjectID string
+	Semester  int
+	Date      time.Time
+	CreatedBy string
+}
+
+type AttendanceMark struct {
+	SessionID string
+	StudentID string
+	Status    constants.AttendanceStatus
+}
+
+type AttendanceSummary struct {
+	StudentID  string
+	SubjectID  string
+	Semester   int
+	Total      int
+	Present    int
+	Absent     int
+	Late       int
+	Excused    int
+	Percentage float64
+}
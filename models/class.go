@@ -4,4 +4,6 @@ type Class struct {
 	ClassID    string
 	Capacity   int
 	OccupiedBy string
+	ProgramID  string
+	HomeRoomID string
 }
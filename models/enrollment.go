@@ -0,0 +1,15 @@
+package models
+
+import (
+	"sms/constants"
+	"time"
+)
+
+type Enrollment struct {
+	StudentID string
+	SubjectID string
+	Semester  int
+	Status    constants.EnrollmentStatus
+	AddedOn   time.Time
+	DroppedOn *time.Time
+}
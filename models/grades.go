@@ -4,5 +4,5 @@ type Grade struct {
 	SubjectID string
 	StudentID string
 	Grade     int
-	Semester  string
+	Semester  int
 }
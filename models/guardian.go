@@ -0,0 +1,7 @@
+package models
+
+type GuardianLink struct {
+	GuardianID   string
+	StudentID    string
+	Relationship string
+}
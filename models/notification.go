@@ -0,0 +1,32 @@
+package models
+
+import (
+	"sms/constants"
+	"time"
+)
+
+// Notification is a rendered message in the outbox. Target is the email address
+// or webhook URL it goes to and is empty for the in-app inbox.
+type Notification struct {
+	NotificationID string
+	UserID         string
+	Event          constants.NotificationEvent
+	Channel        constants.NotificationChannel
+	Target         string
+	Subject        string
+	Body           string
+	Status         constants.NotificationStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastError      string
+	CreatedAt      time.Time
+	SentAt         *time.Time
+	ReadAt         *time.Time
+}
+
+type NotificationPreference struct {
+	UserID  string
+	Channel constants.NotificationChannel
+	Enabled bool
+	Target  string
+}
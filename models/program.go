@@ -0,0 +1,18 @@
+package models
+
+import "sms/constants"
+
+type Program struct {
+	ProgramID string
+	Name      string
+	Semesters int
+}
+
+type ProgramSubject struct {
+	ProgramID     string
+	SubjectID     string
+	Semester      int
+	Kind          constants.SubjectKind
+	Credits       int
+	Prerequisites []string
+}
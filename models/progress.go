@@ -0,0 +1,25 @@
+package models
+
+import "sms/constants"
+
+// SemesterProgress is a student's standing in one semester. Subjects change
+// from semester to semester, so BelowPreviousAverage lists the subjects graded
+// below the previous semester's overall average rather than subjects whose own
+// grade fell.
+type SemesterProgress struct {
+	Semester             int
+	Average              float64
+	ClassAverage         float64
+	ChangeFromPrevious   float64
+	Grades               []Grade
+	BelowPreviousAverage []string
+}
+
+type ProgressReport struct {
+	StudentID  string
+	Name       string
+	RollNumber string
+	ClassID    string
+	Trend      constants.Trend
+	Semesters  []SemesterProgress
+}
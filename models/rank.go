@@ -0,0 +1,9 @@
+package models
+
+type RankEntry struct {
+	StudentID   string
+	StudentName string
+	Average     float64
+	Rank        int
+	Percentile  float64
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"sms/constants"
+	"time"
+)
+
+type RolloverCriteria struct {
+	PassMark          int
+	MinAverage        float64
+	MaxFailedSubjects int
+}
+
+type RolloverStudent struct {
+	StudentID    string
+	FromClassID  string
+	ToClassID    string
+	FromSemester int
+	ToSemester   int
+	Outcome      constants.RolloverOutcome
+	Reasons      []string
+}
+
+type Rollover struct {
+	RolloverID    string
+	ClassID       string
+	Semester      int
+	TargetClassID string
+	CreatedBy     string
+	CreatedAt     time.Time
+	UndoUntil     time.Time
+	UndoneAt      *time.Time
+	DryRun        bool
+	Students      []RolloverStudent
+}
@@ -0,0 +1,24 @@
+package models
+
+type HistogramBucket struct {
+	Lower int
+	Upper int
+	Count int
+}
+
+type GradeStatistics struct {
+	ClassID   string
+	SubjectID string
+	Semester  int
+	Count     int
+	Average   float64
+	Median    float64
+	StdDev    float64
+	Min       int
+	Max       int
+	Q1        float64
+	Q3        float64
+	PassMark  int
+	PassRate  float64
+	Histogram []HistogramBucket
+}
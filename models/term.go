@@ -0,0 +1,20 @@
+package models
+
+import (
+	"sms/constants"
+	"time"
+)
+
+// AcademicTerm is a dated term of an academic year. Semesters lists the student
+// semesters taught during the term; grade entry dates are inclusive.
+type AcademicTerm struct {
+	TermID          string
+	Year            int
+	TermNumber      int
+	Semesters       []int
+	StartDate       time.Time
+	EndDate         time.Time
+	GradeEntryStart time.Time
+	GradeEntryEnd   time.Time
+	Status          constants.TermStatus
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+type Room struct {
+	RoomID   string
+	Name     string
+	Capacity int
+}
+
+type FacultyAssignment struct {
+	FacultyID string
+	ClassID   string
+	SubjectID string
+}
+
+// TimetableSlot is a weekly period. StartTime and EndTime are "HH:MM" in the
+// school's local time.
+type TimetableSlot struct {
+	SlotID      string
+	ClassID     string
+	SubjectID   string
+	SubjectName string
+	FacultyID   string
+	RoomID      string
+	RoomName    string
+	Weekday     time.Weekday
+	StartTime   string
+	EndTime     string
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"sms/constants"
+	"time"
+)
+
+type WebhookSubscription struct {
+	SubscriptionID string
+	URL            string
+	Secret         string
+	Events         []constants.WebhookEvent
+	Active         bool
+	CreatedAt      time.Time
+}
+
+// WebhookDelivery is one event queued for one subscription. Payload is the exact
+// JSON body that is signed and posted.
+type WebhookDelivery struct {
+	DeliveryID     string
+	SubscriptionID string
+	Event          constants.WebhookEvent
+	Payload        string
+	Status         constants.DeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+	Log            []WebhookAttempt
+}
+
+// WebhookAttempt logs one try of a delivery. StatusCode is 0 when no response came back.
+type WebhookAttempt struct {
+	DeliveryID  string
+	AttemptedAt time.Time
+	StatusCode  int
+	Error       string
+	DurationMS  int64
+}
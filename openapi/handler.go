@@ -0,0 +1,55 @@
+package openapi
+
+import (
+	"embed"
+	"encoding/json"
+	"net/http"
+)
+
+//go:embed swagger.html
+var swaggerUI []byte
+
+// swaggerAssets is Swagger UI 5.18.2 from the swagger-ui-dist package, under
+// its Apache license. To update it, replace the files with those of a newer
+// release.
+//
+//go:embed swagger-ui
+var swaggerAssets embed.FS
+
+// JSON is the document as it is served, indented so that it diffs well.
+func (d *Document) JSON() ([]byte, error) {
+	b, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+// Handler serves the document. It is encoded once, up front.
+func Handler(d *Document) http.Handler {
+	b, err := d.JSON()
+	if err != nil {
+		panic("openapi: " + err.Error())
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(b)
+	})
+}
+
+// UIHandler serves a Swagger UI page for the document at openapi.json next to
+// it. The page and Swagger UI are embedded, so it loads nothing from elsewhere.
+func UIHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(swaggerUI)
+	})
+}
+
+// UIAssetHandler serves the scripts and styles of the page UIHandler serves,
+// from a route with a {file} path parameter under it.
+func UIAssetHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFileFS(w, r, swaggerAssets, "swagger-ui/"+r.PathValue("file"))
+	})
+}
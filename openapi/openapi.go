@@ -0,0 +1,242 @@
+// Package openapi builds an OpenAPI 3.1 document from a list of routes and the
+// Go types of their request and response bodies, and serves it together with a
+// Swagger UI page.
+package openapi
+
+import (
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Route describes one endpoint. Body and Data are values of the request body
+// and of the data field of a successful response; nil means there is none.
+type Route struct {
+	Method  string
+	Path    string
+	Summary string
+	Tag     string
+	// Public routes don't need a bearer token.
+	Public bool
+	// Params lists the query parameters and any path parameter that isn't a
+	// string. The other path parameters are filled in from Path.
+	Params []Param
+	Body   any
+	Status int
+	Data   any
+	// ContentType is set for routes that answer with something other than the
+	// JSON envelope, such as a calendar file.
+	ContentType string
+}
+
+// Pattern is the route as http.ServeMux patterns write it.
+func (r Route) Pattern() string {
+	return r.Method + " " + r.Path
+}
+
+type Param struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Required    bool    `json:"required,omitempty"`
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// PathParam is a path parameter of the same type as v.
+func PathParam(name string, v any) Param {
+	return Param{Name: name, In: "path", Required: true, Schema: newSchemas().of(v)}
+}
+
+// Query is an optional query parameter of the same type as v.
+func Query(name string, v any, description string) Param {
+	return Param{Name: name, In: "query", Description: description, Schema: newSchemas().of(v)}
+}
+
+// RequiredQuery is a query parameter that must be given.
+func RequiredQuery(name string, v any, description string) Param {
+	p := Query(name, v, description)
+	p.Required = true
+	return p
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+type Document struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       Info                             `json:"info"`
+	Paths      map[string]map[string]*Operation `json:"paths"`
+	Components Components                       `json:"components"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes"`
+}
+
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+type Operation struct {
+	OperationID string                `json:"operationId"`
+	Summary     string                `json:"summary,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Security    []map[string][]string `json:"security,omitempty"`
+	Parameters  []Param               `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+const (
+	bearerAuth   = "bearerAuth"
+	jsonType     = "application/json"
+	envelopeName = "Response"
+)
+
+var pathParam = regexp.MustCompile(`\{(\w+)\}`)
+
+// Build documents routes. envelope is the body every JSON response is wrapped
+// in; the data of each route is described as its "data" property.
+func Build(info Info, envelope any, routes []Route) *Document {
+	s := newSchemas()
+	s.components[envelopeName] = s.object(reflectType(envelope))
+	doc := &Document{
+		OpenAPI: "3.1.0",
+		Info:    info,
+		Paths:   map[string]map[string]*Operation{},
+		Components: Components{
+			Schemas:         s.components,
+			SecuritySchemes: map[string]SecurityScheme{bearerAuth: {Type: "http", Scheme: "bearer", BearerFormat: "JWT"}},
+		},
+	}
+	for _, r := range routes {
+		if doc.Paths[r.Path] == nil {
+			doc.Paths[r.Path] = map[string]*Operation{}
+		}
+		doc.Paths[r.Path][strings.ToLower(r.Method)] = s.operation(r)
+	}
+	return doc
+}
+
+func (s *schemas) operation(r Route) *Operation {
+	op := &Operation{
+		OperationID: operationID(r),
+		Summary:     r.Summary,
+		Parameters:  params(r),
+		Responses: map[string]*Response{
+			strconv.Itoa(r.Status): s.success(r),
+			"default":              {Description: "error", Content: jsonContent(envelopeRef())},
+		},
+	}
+	if r.Tag != "" {
+		op.Tags = []string{r.Tag}
+	}
+	if !r.Public {
+		op.Security = []map[string][]string{{bearerAuth: {}}}
+	}
+	if r.Body != nil {
+		op.RequestBody = &RequestBody{Required: true, Content: jsonContent(s.of(r.Body))}
+	}
+	return op
+}
+
+func (s *schemas) success(r Route) *Response {
+	resp := &Response{Description: http.StatusText(r.Status)}
+	switch {
+	case r.ContentType != "":
+		resp.Content = map[string]MediaType{r.ContentType: {Schema: &Schema{Type: "string"}}}
+	case r.Data == nil:
+		resp.Content = jsonContent(envelopeRef())
+	default:
+		data := &Schema{Type: "object", Properties: map[string]*Schema{"data": s.of(r.Data)}}
+		resp.Content = jsonContent(&Schema{AllOf: []*Schema{envelopeRef(), data}})
+	}
+	return resp
+}
+
+// params lists the path parameters of r in the order they appear, then its
+// query parameters.
+func params(r Route) []Param {
+	given := map[string]Param{}
+	var query []Param
+	for _, p := range r.Params {
+		if p.In == "path" {
+			given[p.Name] = p
+		} else {
+			query = append(query, p)
+		}
+	}
+	var ps []Param
+	for _, m := range pathParam.FindAllStringSubmatch(r.Path, -1) {
+		p, ok := given[m[1]]
+		if !ok {
+			p = PathParam(m[1], "")
+		}
+		ps = append(ps, p)
+	}
+	return append(ps, query...)
+}
+
+// operationID is the method followed by the static parts of the path, like
+// postApiV1GradesImport, with the path parameters as "By" parts.
+func operationID(r Route) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(r.Method))
+	for _, part := range strings.Split(r.Path, "/") {
+		if m := pathParam.FindStringSubmatch(part); m != nil {
+			b.WriteString("By" + title(m[1]))
+			continue
+		}
+		for _, word := range strings.FieldsFunc(part, func(r rune) bool { return r == '-' || r == '.' }) {
+			b.WriteString(title(word))
+		}
+	}
+	return b.String()
+}
+
+func title(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+func envelopeRef() *Schema {
+	return &Schema{Ref: "#/components/schemas/" + envelopeName}
+}
+
+func jsonContent(sc *Schema) map[string]MediaType {
+	return map[string]MediaType{jsonType: {Schema: sc}}
+}
+
+// Patterns are the ServeMux patterns of routes, sorted.
+func Patterns(routes []Route) []string {
+	patterns := make([]string, len(routes))
+	for i, r := range routes {
+		patterns[i] = r.Pattern()
+	}
+	sort.Strings(patterns)
+	return patterns
+}
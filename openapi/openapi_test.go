@@ -0,0 +1,131 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"sms/openapi"
+	"testing"
+	"time"
+)
+
+type item struct {
+	SubjectID string `json:"subjectID" validate:"required"`
+	Grade     int    `json:"grade" validate:"min=0,max=100"`
+}
+
+type request struct {
+	Name    string     `json:"name" validate:"required,max=20"`
+	Kind    string     `json:"kind,omitempty" validate:"oneof=exam quiz"`
+	Due     *time.Time `json:"due"`
+	Items   []item     `json:"items"`
+	Ignored string     `json:"-"`
+}
+
+type result struct {
+	ID      string
+	Created time.Time
+}
+
+func TestBuild(t *testing.T) {
+	doc := openapi.Build(openapi.Info{Title: "test", Version: "1"}, struct {
+		Message string `json:"message"`
+		Data    any    `json:"data,omitempty"`
+	}{}, []openapi.Route{
+		{Method: "POST", Path: "/things/{thingID}/semesters/{semester}", Tag: "things",
+			Params: []openapi.Param{openapi.PathParam("semester", 0), openapi.Query("dry_run", false, "")},
+			Body:   request{}, Status: http.StatusCreated, Data: []result{}},
+		{Method: "POST", Path: "/login", Public: true, Status: http.StatusOK},
+	})
+	b, err := doc.JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	checks := []struct {
+		path string
+		want any
+	}{
+		{"openapi", "3.1.0"},
+		{"paths./things/{thingID}/semesters/{semester}.post.operationId", "postThingsByThingIDSemestersBySemester"},
+		{"paths./things/{thingID}/semesters/{semester}.post.parameters.0.name", "thingID"},
+		{"paths./things/{thingID}/semesters/{semester}.post.parameters.0.schema.type", "string"},
+		{"paths./things/{thingID}/semesters/{semester}.post.parameters.1.schema.type", "integer"},
+		{"paths./things/{thingID}/semesters/{semester}.post.parameters.2.in", "query"},
+		{"paths./things/{thingID}/semesters/{semester}.post.requestBody.content.application/json.schema.$ref", "#/components/schemas/request"},
+		{"paths./things/{thingID}/semesters/{semester}.post.responses.201.content.application/json.schema.allOf.1.properties.data.items.$ref", "#/components/schemas/result"},
+		{"paths./things/{thingID}/semesters/{semester}.post.security.0.bearerAuth", []any{}},
+		{"paths./login.post.security", nil},
+		{"components.schemas.request.required", []any{"name"}},
+		{"components.schemas.request.properties.name.maxLength", 20.0},
+		{"components.schemas.request.properties.kind.enum", []any{"exam", "quiz"}},
+		{"components.schemas.request.properties.due.type", []any{"string", "null"}},
+		{"components.schemas.request.properties.due.format", "date-time"},
+		{"components.schemas.request.properties.Ignored", nil},
+		{"components.schemas.request.additionalProperties", false},
+		{"components.schemas.item.properties.grade.maximum", 100.0},
+		{"components.schemas.item.required", []any{"subjectID"}},
+		{"components.schemas.result.properties.ID.type", "string"},
+		{"components.schemas.Response.properties.message.type", "string"},
+	}
+	for _, c := range checks {
+		if v := lookup(got, c.path); !equal(v, c.want) {
+			t.Errorf("%s: expected %v, got %v", c.path, c.want, v)
+		}
+	}
+}
+
+// lookup follows a dotted path through decoded JSON. Keys may contain dots
+// themselves, so the longest key that matches wins.
+func lookup(v any, path string) any {
+	for path != "" {
+		switch node := v.(type) {
+		case map[string]any:
+			found := false
+			for i := len(path); i > 0; i-- {
+				if i < len(path) && path[i] != '.' {
+					continue
+				}
+				if next, ok := node[path[:i]]; ok {
+					v, path, found = next, trimDot(path[i:]), true
+					break
+				}
+			}
+			if !found {
+				return nil
+			}
+		case []any:
+			i := 0
+			for i < len(path) && path[i] != '.' {
+				i++
+			}
+			idx := 0
+			for _, c := range path[:i] {
+				idx = idx*10 + int(c-'0')
+			}
+			if idx >= len(node) {
+				return nil
+			}
+			v, path = node[idx], trimDot(path[i:])
+		default:
+			return nil
+		}
+	}
+	return v
+}
+
+func trimDot(s string) string {
+	if s != "" && s[0] == '.' {
+		return s[1:]
+	}
+	return s
+}
+
+func equal(a, b any) bool {
+	ja, _ := json.Marshal(a)
+	jb, _ := json.Marshal(b)
+	return string(ja) == string(jb)
+}
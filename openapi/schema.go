@@ -0,0 +1,211 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schema is a JSON Schema as OpenAPI 3.1 uses it.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 any                `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties any                `json:"additionalProperties,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+}
+
+var (
+	timeType    = reflect.TypeOf(time.Time{})
+	rawJSONType = reflect.TypeOf(json.RawMessage{})
+)
+
+// schemas turns Go types into schemas. Named structs go to components once and
+// are referenced from everywhere else.
+type schemas struct {
+	components map[string]*Schema
+	names      map[reflect.Type]string
+}
+
+func newSchemas() *schemas {
+	return &schemas{components: map[string]*Schema{}, names: map[reflect.Type]string{}}
+}
+
+// of is the schema of values like v; nil has none.
+func (s *schemas) of(v any) *Schema {
+	if v == nil {
+		return nil
+	}
+	return s.schema(reflect.TypeOf(v))
+}
+
+func reflectType(v any) reflect.Type {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+func (s *schemas) schema(t reflect.Type) *Schema {
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case rawJSONType:
+		return &Schema{}
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		sc := s.schema(t.Elem())
+		if typ, ok := sc.Type.(string); ok {
+			sc.Type = []string{typ, "null"}
+		}
+		return sc
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: s.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: s.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return s.object(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + s.component(t)}
+	}
+	// interfaces can hold anything
+	return &Schema{}
+}
+
+// component registers a named struct and returns its name. Types from different
+// packages that share a name are told apart by the package.
+func (s *schemas) component(t reflect.Type) string {
+	if name, ok := s.names[t]; ok {
+		return name
+	}
+	name := t.Name()
+	if _, taken := s.components[name]; taken {
+		name = pkgName(t) + "." + name
+	}
+	s.names[t] = name
+	s.components[name] = nil // reserved, so recursive types end
+	s.components[name] = s.object(t)
+	return name
+}
+
+func pkgName(t reflect.Type) string {
+	path := t.PkgPath()
+	return path[strings.LastIndex(path, "/")+1:]
+}
+
+// object is the schema of a struct, with its fields named and required the way
+// encoding/json and the validation package treat them. Fields of embedded
+// structs are promoted.
+func (s *schemas) object(t reflect.Type) *Schema {
+	sc := &Schema{Type: "object", Properties: map[string]*Schema{}, AdditionalProperties: false}
+	s.fields(t, sc)
+	return sc
+}
+
+func (s *schemas) fields(t reflect.Type, sc *Schema) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			s.fields(f.Type, sc)
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		prop := s.schema(f.Type)
+		if required := applyRules(prop, f.Tag.Get("validate")); required {
+			sc.Required = append(sc.Required, name)
+		}
+		sc.Properties[name] = prop
+	}
+}
+
+// applyRules copies the validate rules of a field onto its schema and reports
+// whether the field is required.
+func applyRules(sc *Schema, tag string) bool {
+	required := false
+	for _, rule := range strings.Split(tag, ",") {
+		name, arg, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required":
+			required = true
+		case "min", "max":
+			n, err := strconv.ParseFloat(arg, 64)
+			if err != nil {
+				continue
+			}
+			bound(sc, name, n)
+		case "oneof":
+			sc.Enum = strings.Fields(arg)
+		}
+	}
+	return required
+}
+
+func bound(sc *Schema, name string, n float64) {
+	typ, _ := sc.Type.(string)
+	if types, ok := sc.Type.([]string); ok {
+		typ = types[0]
+	}
+	switch typ {
+	case "integer", "number":
+		if name == "min" {
+			sc.Minimum = &n
+		} else {
+			sc.Maximum = &n
+		}
+	case "string":
+		if name == "min" {
+			sc.MinLength = intPtr(n)
+		} else {
+			sc.MaxLength = intPtr(n)
+		}
+	case "array":
+		if name == "min" {
+			sc.MinItems = intPtr(n)
+		} else {
+			sc.MaxItems = intPtr(n)
+		}
+	}
+}
+
+func intPtr(n float64) *int {
+	i := int(n)
+	return &i
+}
@@ -0,0 +1,116 @@
+package alertRepository
+
+import (
+	"context"
+	"sms/models"
+	"sms/repository/transaction"
+	"strings"
+)
+
+// reasonSeparator joins the reasons of a flag into the single Reasons column.
+const reasonSeparator = "; "
+
+type AlertRepo struct {
+	db transaction.Querier
+}
+
+type StudentGrade struct {
+	StudentID string
+	ClassID   string
+	SubjectID string
+	Grade     int
+	Semester  int
+}
+
+func NewAlertRepo(db transaction.Querier) *AlertRepo {
+	return &AlertRepo{db}
+}
+
+func (ar *AlertRepo) GetStudentGrades(ctx context.Context, semesters ...int) ([]StudentGrade, error) {
+	if len(semesters) == 0 {
+		return nil, nil
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(semesters)), ",")
+	stmt := `select s.StudentID, s.ClassID, g.SubjectID, g.Grade, g.semester from grades g
+	join students s on s.StudentID=g.StudentID where g.semester in (` + placeholders + `)
+	order by s.StudentID, g.semester`
+
+	args := make([]any, len(semesters))
+	for i, semester := range semesters {
+		args[i] = semester
+	}
+	rows, err := ar.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var grades []StudentGrade
+	for rows.Next() {
+		var sg StudentGrade
+		if err := rows.Scan(&sg.StudentID, &sg.ClassID, &sg.SubjectID, &sg.Grade, &sg.Semester); err != nil {
+			return nil, err
+		}
+		grades = append(grades, sg)
+	}
+	return grades, rows.Err()
+}
+
+// GetCurrentSemesters returns the semesters students are in now.
+func (ar *AlertRepo) GetCurrentSemesters(ctx context.Context) ([]int, error) {
+	rows, err := ar.db.QueryContext(ctx, `select distinct semester from students order by semester`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var semesters []int
+	for rows.Next() {
+		var semester int
+		if err := rows.Scan(&semester); err != nil {
+			return nil, err
+		}
+		semesters = append(semesters, semester)
+	}
+	return semesters, rows.Err()
+}
+
+// ReplaceFlags swaps the stored flags of a semester for the result of a new scan.
+func (ar *AlertRepo) ReplaceFlags(ctx context.Context, semester int, flags []models.AtRiskFlag) error {
+	return transaction.RunInTx(ctx, ar.db, func(tx transaction.Querier) error {
+		if _, err := tx.ExecContext(ctx, `delete from at_risk_flag where semester=?`, semester); err != nil {
+			return err
+		}
+		for _, f := range flags {
+			_, err := tx.ExecContext(ctx, `insert into at_risk_flag values(?,?,?,?,?,?)`,
+				f.FlagID, f.StudentID, f.ClassID, f.Semester, strings.Join(f.Reasons, reasonSeparator), f.CreatedAt)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetFlags returns the flags of a semester, or of every semester when semester is 0.
+func (ar *AlertRepo) GetFlags(ctx context.Context, semester int) ([]models.AtRiskFlag, error) {
+	stmt := `select FlagID, StudentID, ClassID, semester, Reasons, CreatedAt from at_risk_flag
+	where (?=0 or semester=?) order by semester, ClassID, StudentID`
+	rows, err := ar.db.QueryContext(ctx, stmt, semester, semester)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var flags []models.AtRiskFlag
+	for rows.Next() {
+		var f models.AtRiskFlag
+		var reasons string
+		if err := rows.Scan(&f.FlagID, &f.StudentID, &f.ClassID, &f.Semester, &reasons, &f.CreatedAt); err != nil {
+			return nil, err
+		}
+		f.Reasons = strings.Split(reasons, reasonSeparator)
+		flags = append(flags, f)
+	}
+	return flags, rows.Err()
+}
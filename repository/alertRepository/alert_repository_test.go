@@ -0,0 +1,140 @@
+package alertRepository_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"regexp"
+	"sms/models"
+	alertRepository "sms/repository/alertRepository"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestGetStudentGrades(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := alertRepository.NewAlertRepo(db)
+
+	rows := sqlmock.NewRows([]string{"StudentID", "ClassID", "SubjectID", "Grade", "semester"}).
+		AddRow("S001", "C1", "sub1", 35, 1).
+		AddRow("S001", "C1", "sub2", 80, 2)
+	mock.ExpectQuery(regexp.QuoteMeta(`select s.StudentID, s.ClassID, g.SubjectID, g.Grade, g.semester from grades g
+	join students s on s.StudentID=g.StudentID where g.semester in (?,?)
+	order by s.StudentID, g.semester`)).
+		WithArgs(2, 1).
+		WillReturnRows(rows)
+
+	grades, err := repo.GetStudentGrades(context.Background(), 2, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []alertRepository.StudentGrade{
+		{StudentID: "S001", ClassID: "C1", SubjectID: "sub1", Grade: 35, Semester: 1},
+		{StudentID: "S001", ClassID: "C1", SubjectID: "sub2", Grade: 80, Semester: 2},
+	}
+	if !reflect.DeepEqual(grades, expected) {
+		t.Errorf("expected %v, got %v", expected, grades)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetCurrentSemesters(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := alertRepository.NewAlertRepo(db)
+	mock.ExpectQuery(regexp.QuoteMeta(`select distinct semester from students order by semester`)).
+		WillReturnRows(sqlmock.NewRows([]string{"semester"}).AddRow(1).AddRow(3))
+
+	semesters, err := repo.GetCurrentSemesters(context.Background())
+	if err != nil || !reflect.DeepEqual(semesters, []int{1, 3}) {
+		t.Errorf("expected semesters 1 and 3, got %v, %v", semesters, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestReplaceFlags(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := alertRepository.NewAlertRepo(db)
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	flags := []models.AtRiskFlag{
+		{FlagID: "f1", StudentID: "S001", ClassID: "C1", Semester: 2, Reasons: []string{"a", "b"}, CreatedAt: createdAt},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`delete from at_risk_flag where semester=?`)).
+		WithArgs(2).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(regexp.QuoteMeta(`insert into at_risk_flag values(?,?,?,?,?,?)`)).
+		WithArgs("f1", "S001", "C1", 2, "a; b", createdAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	if err := repo.ReplaceFlags(context.Background(), 2, flags); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`delete from at_risk_flag where semester=?`)).
+		WithArgs(3).
+		WillReturnError(errors.New("db connection lost"))
+	mock.ExpectRollback()
+
+	if err := repo.ReplaceFlags(context.Background(), 3, nil); err == nil {
+		t.Errorf("expected error, got nil")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetFlags(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := alertRepository.NewAlertRepo(db)
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	rows := sqlmock.NewRows([]string{"FlagID", "StudentID", "ClassID", "semester", "Reasons", "CreatedAt"}).
+		AddRow("f1", "S001", "C1", 2, "a; b", createdAt)
+	mock.ExpectQuery(regexp.QuoteMeta(`select FlagID, StudentID, ClassID, semester, Reasons, CreatedAt from at_risk_flag
+	where (?=0 or semester=?) order by semester, ClassID, StudentID`)).
+		WithArgs(0, 0).
+		WillReturnRows(rows)
+
+	flags, err := repo.GetFlags(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []models.AtRiskFlag{
+		{FlagID: "f1", StudentID: "S001", ClassID: "C1", Semester: 2, Reasons: []string{"a", "b"}, CreatedAt: createdAt},
+	}
+	if !reflect.DeepEqual(flags, expected) {
+		t.Errorf("expected %v, got %v", expected, flags)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,14 @@
+package alertRepository
+
+import (
+	"context"
+	"sms/models"
+)
+
+//go:generate mockgen -destination=../../mocks/alert_repo_mock.go -package=mocks -source=interface.go
+type AlertRepositoryI interface {
+	GetStudentGrades(ctx context.Context, semesters ...int) ([]StudentGrade, error)
+	GetCurrentSemesters(ctx context.Context) ([]int, error)
+	ReplaceFlags(ctx context.Context, semester int, flags []models.AtRiskFlag) error
+	GetFlags(ctx context.Context, semester int) ([]models.AtRiskFlag, error)
+}
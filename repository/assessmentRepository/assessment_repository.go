@@ -0,0 +1,111 @@
+package assessmentRepository
+
+import (
+	"context"
+	"database/sql"
+	"sms/models"
+	"sms/repository/transaction"
+	"strings"
+)
+
+type AssessmentRepo struct {
+	db transaction.Querier
+}
+
+func NewAssessmentRepo(db transaction.Querier) *AssessmentRepo {
+	return &AssessmentRepo{db}
+}
+
+// WithTx returns an AssessmentRepo that runs its statements on tx.
+func (ar *AssessmentRepo) WithTx(tx transaction.Querier) AssessmentRepositoryI {
+	return NewAssessmentRepo(tx)
+}
+
+func (ar *AssessmentRepo) AddAssessment(ctx context.Context, a models.Assessment) error {
+	stmt := `insert into assessment values(?,?,?,?,?,?,?)`
+	_, err := ar.db.ExecContext(ctx, stmt, a.AssessmentID, a.SubjectID, a.Semester, a.Name, a.Kind, a.Weight, a.MaxMarks)
+	return err
+}
+
+func (ar *AssessmentRepo) GetAssessment(ctx context.Context, assessmentID string) (*models.Assessment, error) {
+	stmt := `select AssessmentID, SubjectID, semester, Name, Kind, Weight, MaxMarks from assessment where AssessmentID=?`
+	var a models.Assessment
+	err := ar.db.QueryRowContext(ctx, stmt, assessmentID).Scan(&a.AssessmentID, &a.SubjectID, &a.Semester, &a.Name, &a.Kind, &a.Weight, &a.MaxMarks)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (ar *AssessmentRepo) GetAssessments(ctx context.Context, subjectID string, semester int) ([]models.Assessment, error) {
+	stmt := `select AssessmentID, SubjectID, semester, Name, Kind, Weight, MaxMarks from assessment
+	where SubjectID=? and semester=? order by Kind, Name`
+	rows, err := ar.db.QueryContext(ctx, stmt, subjectID, semester)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var assessments []models.Assessment
+	for rows.Next() {
+		var a models.Assessment
+		if err := rows.Scan(&a.AssessmentID, &a.SubjectID, &a.Semester, &a.Name, &a.Kind, &a.Weight, &a.MaxMarks); err != nil {
+			return nil, err
+		}
+		assessments = append(assessments, a)
+	}
+	return assessments, rows.Err()
+}
+
+func (ar *AssessmentRepo) UpdateAssessment(ctx context.Context, assessmentID string, weight float64, maxMarks int) error {
+	_, err := ar.db.ExecContext(ctx, `update assessment set Weight=?, MaxMarks=? where AssessmentID=?`, weight, maxMarks, assessmentID)
+	return err
+}
+
+// SaveScores records the scores in one transaction, overwriting earlier scores
+// of the same student for the same assessment.
+func (ar *AssessmentRepo) SaveScores(ctx context.Context, scores []models.AssessmentScore) error {
+	return transaction.RunInTx(ctx, ar.db, func(tx transaction.Querier) error {
+		stmt := `insert into assessment_score values(?,?,?) on conflict(AssessmentID, StudentID) do update set Marks=excluded.Marks`
+		for _, s := range scores {
+			if _, err := tx.ExecContext(ctx, stmt, s.AssessmentID, s.StudentID, s.Marks); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetScores returns the scores of every assessment of a subject in a semester,
+// limited to the given students when any are given.
+func (ar *AssessmentRepo) GetScores(ctx context.Context, subjectID string, semester int, studentIDs ...string) ([]models.AssessmentScore, error) {
+	stmt := `select s.AssessmentID, s.StudentID, s.Marks from assessment_score s
+	join assessment a on a.AssessmentID=s.AssessmentID where a.SubjectID=? and a.semester=?`
+	args := []any{subjectID, semester}
+	if len(studentIDs) > 0 {
+		stmt += ` and s.StudentID in (` + strings.TrimSuffix(strings.Repeat("?,", len(studentIDs)), ",") + `)`
+		for _, id := range studentIDs {
+			args = append(args, id)
+		}
+	}
+	stmt += ` order by s.StudentID, s.AssessmentID`
+
+	rows, err := ar.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var scores []models.AssessmentScore
+	for rows.Next() {
+		var s models.AssessmentScore
+		if err := rows.Scan(&s.AssessmentID, &s.StudentID, &s.Marks); err != nil {
+			return nil, err
+		}
+		scores = append(scores, s)
+	}
+	return scores, rows.Err()
+}
@@ -0,0 +1,136 @@
+package assessmentRepository_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"regexp"
+	"sms/constants"
+	"sms/models"
+	assessmentRepository "sms/repository/assessmentRepository"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestAddAssessment_And_GetAssessment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := assessmentRepository.NewAssessmentRepo(db)
+	assessment := models.Assessment{AssessmentID: "a1", SubjectID: "sub1", Semester: 1, Name: "Midterm", Kind: constants.Midterm, Weight: 30, MaxMarks: 50}
+
+	mock.ExpectExec(regexp.QuoteMeta(`insert into assessment values(?,?,?,?,?,?,?)`)).
+		WithArgs("a1", "sub1", 1, "Midterm", constants.Midterm, 30.0, 50).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`select AssessmentID, SubjectID, semester, Name, Kind, Weight, MaxMarks from assessment where AssessmentID=?`)).
+		WithArgs("a1").
+		WillReturnRows(sqlmock.NewRows([]string{"AssessmentID", "SubjectID", "semester", "Name", "Kind", "Weight", "MaxMarks"}).
+			AddRow("a1", "sub1", 1, "Midterm", "midterm", 30.0, 50))
+
+	if err := repo.AddAssessment(context.Background(), assessment); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := repo.GetAssessment(context.Background(), "a1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *got != assessment {
+		t.Errorf("expected %+v, got %+v", assessment, *got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestSaveScores(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := assessmentRepository.NewAssessmentRepo(db)
+	stmt := regexp.QuoteMeta(`insert into assessment_score values(?,?,?) on conflict(AssessmentID, StudentID) do update set Marks=excluded.Marks`)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(stmt).WithArgs("a1", "s1", 45.5).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(stmt).WithArgs("a1", "s2", 30.0).WillReturnError(errors.New("foreign key constraint failed"))
+	mock.ExpectRollback()
+
+	err = repo.SaveScores(context.Background(), []models.AssessmentScore{
+		{AssessmentID: "a1", StudentID: "s1", Marks: 45.5},
+		{AssessmentID: "a1", StudentID: "s2", Marks: 30},
+	})
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetScores(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := assessmentRepository.NewAssessmentRepo(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`select s.AssessmentID, s.StudentID, s.Marks from assessment_score s
+	join assessment a on a.AssessmentID=s.AssessmentID where a.SubjectID=? and a.semester=? and s.StudentID in (?,?) order by s.StudentID, s.AssessmentID`)).
+		WithArgs("sub1", 1, "s1", "s2").
+		WillReturnRows(sqlmock.NewRows([]string{"AssessmentID", "StudentID", "Marks"}).
+			AddRow("a1", "s1", 40.0).
+			AddRow("a2", "s1", 18.0))
+
+	scores, err := repo.GetScores(context.Background(), "sub1", 1, "s1", "s2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []models.AssessmentScore{
+		{AssessmentID: "a1", StudentID: "s1", Marks: 40},
+		{AssessmentID: "a2", StudentID: "s1", Marks: 18},
+	}
+	if !reflect.DeepEqual(scores, expected) {
+		t.Errorf("expected %+v, got %+v", expected, scores)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetAssessments_And_UpdateAssessment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := assessmentRepository.NewAssessmentRepo(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`select AssessmentID, SubjectID, semester, Name, Kind, Weight, MaxMarks from assessment
+	where SubjectID=? and semester=? order by Kind, Name`)).
+		WithArgs("sub1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"AssessmentID", "SubjectID", "semester", "Name", "Kind", "Weight", "MaxMarks"}).
+			AddRow("a2", "sub1", 1, "Final", "final", 70.0, 100))
+	mock.ExpectExec(regexp.QuoteMeta(`update assessment set Weight=?, MaxMarks=? where AssessmentID=?`)).
+		WithArgs(60.0, 80, "a2").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assessments, err := repo.GetAssessments(context.Background(), "sub1", 1)
+	if err != nil || len(assessments) != 1 || assessments[0].Kind != constants.Final {
+		t.Errorf("unexpected assessments %+v, %v", assessments, err)
+	}
+	if err := repo.UpdateAssessment(context.Background(), "a2", 60, 80); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
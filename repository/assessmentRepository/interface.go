@@ -0,0 +1,18 @@
+package assessmentRepository
+
+import (
+	"context"
+	"sms/models"
+	"sms/repository/transaction"
+)
+
+//go:generate mockgen -destination=../../mocks/assessment_repo_mock.go -package=mocks -source=interface.go
+type AssessmentRepositoryI interface {
+	WithTx(tx transaction.Querier) AssessmentRepositoryI
+	AddAssessment(ctx context.Context, assessment models.Assessment) error
+	GetAssessment(ctx context.Context, assessmentID string) (*models.Assessment, error)
+	GetAssessments(ctx context.Context, subjectID string, semester int) ([]models.Assessment, error)
+	UpdateAssessment(ctx context.Context, assessmentID string, weight float64, maxMarks int) error
+	SaveScores(ctx context.Context, scores []models.AssessmentScore) error
+	GetScores(ctx context.Context, subjectID string, semester int, studentIDs ...string) ([]models.AssessmentScore, error)
+}
@@ -0,0 +1,104 @@
+package attendanceRepository
+
+import (
+	"context"
+	"database/sql"
+	"sms/constants"
+	"sms/models"
+	"sms/repository/transaction"
+)
+
+// sessionClass is the class st was in during the semester of session s: the class
+// a rollover that hasn't been undone moved them out of at the end of it, or their
+// class now.
+const sessionClass = `coalesce((select rs.FromClassID from rollover_student rs join rollover r on r.RolloverID=rs.RolloverID
+	where rs.StudentID=st.StudentID and rs.FromSemester=s.semester and r.UndoneAt is null
+	order by r.CreatedAt desc limit 1), st.ClassID)`
+
+type AttendanceRepo struct {
+	db transaction.Querier
+}
+
+type StatusCount struct {
+	StudentID string
+	Status    constants.AttendanceStatus
+	Count     int
+}
+
+func NewAttendanceRepo(db transaction.Querier) *AttendanceRepo {
+	return &AttendanceRepo{db}
+}
+
+func (ar *AttendanceRepo) AddSession(ctx context.Context, session models.AttendanceSession) error {
+	stmt := `insert into attendance_session values(?,?,?,?,?,?)`
+	_, err := ar.db.ExecContext(ctx, stmt, session.SessionID, session.ClassID, session.SubjectID, session.Semester, session.Date, session.CreatedBy)
+	return err
+}
+
+func (ar *AttendanceRepo) GetSession(ctx context.Context, sessionID string) (*models.AttendanceSession, error) {
+	stmt := `select SessionID, ClassID, SubjectID, semester, Date, CreatedBy from attendance_session where SessionID=?`
+	var s models.AttendanceSession
+	err := ar.db.QueryRowContext(ctx, stmt, sessionID).Scan(&s.SessionID, &s.ClassID, &s.SubjectID, &s.Semester, &s.Date, &s.CreatedBy)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &s, nil
+}
+
+// MarkAttendance records the marks in one transaction, overwriting earlier marks
+// of the same student in the same session.
+func (ar *AttendanceRepo) MarkAttendance(ctx context.Context, marks []models.AttendanceMark) error {
+	return transaction.RunInTx(ctx, ar.db, func(tx transaction.Querier) error {
+		stmt := `insert into attendance values(?,?,?) on conflict(SessionID, StudentID) do update set Status=excluded.Status`
+		for _, m := range marks {
+			if _, err := tx.ExecContext(ctx, stmt, m.SessionID, m.StudentID, m.Status); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetStudentStatusCounts counts the student's marks in the sessions of the class
+// they were in that semester, so a rollover doesn't lose the earlier semesters.
+// A session the student wasn't marked in counts as absent.
+func (ar *AttendanceRepo) GetStudentStatusCounts(ctx context.Context, studentID, subjectID string, semester int) ([]StatusCount, error) {
+	stmt := `select st.StudentID, coalesce(a.Status, 'absent'), count(*) from students st
+	join attendance_session s on s.ClassID=` + sessionClass + `
+	left join attendance a on a.SessionID=s.SessionID and a.StudentID=st.StudentID
+	where st.StudentID=? and (?='' or s.SubjectID=?) and s.semester=?
+	group by st.StudentID, coalesce(a.Status, 'absent')`
+	return ar.queryStatusCounts(ctx, stmt, studentID, subjectID, subjectID, semester)
+}
+
+// GetClassStatusCounts counts the marks of every student who was in the class
+// that semester like GetStudentStatusCounts, ordered by student.
+func (ar *AttendanceRepo) GetClassStatusCounts(ctx context.Context, classID, subjectID string, semester int) ([]StatusCount, error) {
+	stmt := `select st.StudentID, coalesce(a.Status, 'absent'), count(*) from students st
+	join attendance_session s on s.ClassID=` + sessionClass + `
+	left join attendance a on a.SessionID=s.SessionID and a.StudentID=st.StudentID
+	where s.ClassID=? and (?='' or s.SubjectID=?) and s.semester=?
+	group by st.StudentID, coalesce(a.Status, 'absent') order by st.StudentID`
+	return ar.queryStatusCounts(ctx, stmt, classID, subjectID, subjectID, semester)
+}
+
+func (ar *AttendanceRepo) queryStatusCounts(ctx context.Context, stmt string, args ...any) ([]StatusCount, error) {
+	rows, err := ar.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var counts []StatusCount
+	for rows.Next() {
+		var sc StatusCount
+		if err := rows.Scan(&sc.StudentID, &sc.Status, &sc.Count); err != nil {
+			return nil, err
+		}
+		counts = append(counts, sc)
+	}
+	return counts, rows.Err()
+}
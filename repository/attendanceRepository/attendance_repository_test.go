@@ -0,0 +1,165 @@
+package attendanceRepository_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"regexp"
+	"sms/constants"
+	"sms/models"
+	attendanceRepository "sms/repository/attendanceRepository"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestAddSession(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := attendanceRepository.NewAttendanceRepo(db)
+	date := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec(regexp.QuoteMeta(`insert into attendance_session values(?,?,?,?,?,?)`)).
+		WithArgs("sess1", "C1", "sub1", 1, date, "fac1").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err = repo.AddSession(context.Background(), models.AttendanceSession{SessionID: "sess1", ClassID: "C1", SubjectID: "sub1", Semester: 1, Date: date, CreatedBy: "fac1"})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetSession(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := attendanceRepository.NewAttendanceRepo(db)
+	date := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	stmt := regexp.QuoteMeta(`select SessionID, ClassID, SubjectID, semester, Date, CreatedBy from attendance_session where SessionID=?`)
+
+	mock.ExpectQuery(stmt).
+		WithArgs("sess1").
+		WillReturnRows(sqlmock.NewRows([]string{"SessionID", "ClassID", "SubjectID", "semester", "Date", "CreatedBy"}).
+			AddRow("sess1", "C1", "sub1", 1, date, "fac1"))
+	mock.ExpectQuery(stmt).
+		WithArgs("missing").
+		WillReturnRows(sqlmock.NewRows([]string{"SessionID", "ClassID", "SubjectID", "semester", "Date", "CreatedBy"}))
+
+	session, err := repo.GetSession(context.Background(), "sess1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if session == nil || session.ClassID != "C1" || !session.Date.Equal(date) {
+		t.Errorf("unexpected session: %+v", session)
+	}
+
+	session, err = repo.GetSession(context.Background(), "missing")
+	if err != nil || session != nil {
+		t.Errorf("expected nil session and nil error, got %+v, %v", session, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestMarkAttendance(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := attendanceRepository.NewAttendanceRepo(db)
+	stmt := regexp.QuoteMeta(`insert into attendance values(?,?,?) on conflict(SessionID, StudentID) do update set Status=excluded.Status`)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(stmt).WithArgs("sess1", "s1", constants.Present).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(stmt).WithArgs("sess1", "s2", constants.Absent).WillReturnError(errors.New("constraint failed"))
+	mock.ExpectRollback()
+
+	err = repo.MarkAttendance(context.Background(), []models.AttendanceMark{
+		{SessionID: "sess1", StudentID: "s1", Status: constants.Present},
+		{SessionID: "sess1", StudentID: "s2", Status: constants.Absent},
+	})
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetClassStatusCounts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := attendanceRepository.NewAttendanceRepo(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`select st.StudentID, coalesce(a.Status, 'absent'), count(*) from students st
+	join attendance_session s on s.ClassID=coalesce((select rs.FromClassID from rollover_student rs join rollover r on r.RolloverID=rs.RolloverID
+	where rs.StudentID=st.StudentID and rs.FromSemester=s.semester and r.UndoneAt is null
+	order by r.CreatedAt desc limit 1), st.ClassID)
+	left join attendance a on a.SessionID=s.SessionID and a.StudentID=st.StudentID
+	where s.ClassID=? and (?='' or s.SubjectID=?) and s.semester=?
+	group by st.StudentID, coalesce(a.Status, 'absent') order by st.StudentID`)).
+		WithArgs("C1", "", "", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"StudentID", "Status", "count"}).
+			AddRow("s1", "present", 8).
+			AddRow("s1", "absent", 2))
+
+	counts, err := repo.GetClassStatusCounts(context.Background(), "C1", "", 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []attendanceRepository.StatusCount{
+		{StudentID: "s1", Status: constants.Present, Count: 8},
+		{StudentID: "s1", Status: constants.Absent, Count: 2},
+	}
+	if !reflect.DeepEqual(counts, expected) {
+		t.Errorf("expected %v, got %v", expected, counts)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetStudentStatusCounts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := attendanceRepository.NewAttendanceRepo(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`select st.StudentID, coalesce(a.Status, 'absent'), count(*) from students st
+	join attendance_session s on s.ClassID=coalesce((select rs.FromClassID from rollover_student rs join rollover r on r.RolloverID=rs.RolloverID
+	where rs.StudentID=st.StudentID and rs.FromSemester=s.semester and r.UndoneAt is null
+	order by r.CreatedAt desc limit 1), st.ClassID)
+	left join attendance a on a.SessionID=s.SessionID and a.StudentID=st.StudentID
+	where st.StudentID=? and (?='' or s.SubjectID=?) and s.semester=?
+	group by st.StudentID, coalesce(a.Status, 'absent')`)).
+		WithArgs("s1", "sub1", "sub1", 2).
+		WillReturnError(errors.New("db connection lost"))
+
+	if _, err := repo.GetStudentStatusCounts(context.Background(), "s1", "sub1", 2); err == nil {
+		t.Errorf("expected error, got nil")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,15 @@
+package attendanceRepository
+
+import (
+	"context"
+	"sms/models"
+)
+
+//go:generate mockgen -destination=../../mocks/attendance_repo_mock.go -package=mocks -source=interface.go
+type AttendanceRepositoryI interface {
+	AddSession(ctx context.Context, session models.AttendanceSession) error
+	GetSession(ctx context.Context, sessionID string) (*models.AttendanceSession, error)
+	MarkAttendance(ctx context.Context, marks []models.AttendanceMark) error
+	GetStudentStatusCounts(ctx context.Context, studentID, subjectID string, semester int) ([]StatusCount, error)
+	GetClassStatusCounts(ctx context.Context, classID, subjectID string, semester int) ([]StatusCount, error)
+}
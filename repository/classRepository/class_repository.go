@@ -0,0 +1,52 @@
+package classRepository
+
+import (
+	"context"
+	"database/sql"
+	"sms/models"
+	"sms/repository/transaction"
+)
+
+type ClassRepo struct {
+	db transaction.Querier
+}
+
+func NewClassRepo(db transaction.Querier) *ClassRepo {
+	return &ClassRepo{db}
+}
+
+// AddClass stores a class with its capacity. The program and home room are
+// optional and left null when empty.
+func (cr *ClassRepo) AddClass(ctx context.Context, class models.Class) error {
+	_, err := cr.db.ExecContext(ctx, `insert into class (ClassID, Capacity, ProgramID, HomeRoomID) values(?,?,?,?)`,
+		class.ClassID, class.Capacity, nullIfEmpty(class.ProgramID), nullIfEmpty(class.HomeRoomID))
+	return err
+}
+
+func (cr *ClassRepo) GetClasses(ctx context.Context) ([]models.Class, error) {
+	rows, err := cr.db.QueryContext(ctx, `select ClassID, Capacity, ProgramID, HomeRoomID from class order by ClassID`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var classes []models.Class
+	for rows.Next() {
+		var c models.Class
+		var capacity sql.NullInt64
+		var programID, homeRoomID sql.NullString
+		if err := rows.Scan(&c.ClassID, &capacity, &programID, &homeRoomID); err != nil {
+			return nil, err
+		}
+		c.Capacity, c.ProgramID, c.HomeRoomID = int(capacity.Int64), programID.String, homeRoomID.String
+		classes = append(classes, c)
+	}
+	return classes, rows.Err()
+}
+
+func nullIfEmpty(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
@@ -0,0 +1,57 @@
+package classRepository_test
+
+import (
+	"context"
+	"regexp"
+	"sms/models"
+	"sms/repository/classRepository"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestAddClass(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(`insert into class (ClassID, Capacity, ProgramID, HomeRoomID) values(?,?,?,?)`)).
+		WithArgs("C1", 60, "BTECH", nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	repo := classRepository.NewClassRepo(db)
+	if err := repo.AddClass(context.Background(), models.Class{ClassID: "C1", Capacity: 60, ProgramID: "BTECH"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetClasses(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %s", err)
+	}
+	defer db.Close()
+
+	rows := sqlmock.NewRows([]string{"ClassID", "Capacity", "ProgramID", "HomeRoomID"}).
+		AddRow("C1", 60, "BTECH", "R101").
+		AddRow("C2", nil, nil, nil)
+	mock.ExpectQuery(regexp.QuoteMeta(`select ClassID, Capacity, ProgramID, HomeRoomID from class order by ClassID`)).WillReturnRows(rows)
+
+	repo := classRepository.NewClassRepo(db)
+	classes, err := repo.GetClasses(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []models.Class{{ClassID: "C1", Capacity: 60, ProgramID: "BTECH", HomeRoomID: "R101"}, {ClassID: "C2"}}
+	if len(classes) != 2 || classes[0] != want[0] || classes[1] != want[1] {
+		t.Errorf("expected %+v, got %+v", want, classes)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
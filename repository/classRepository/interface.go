@@ -0,0 +1,12 @@
+package classRepository
+
+import (
+	"context"
+	"sms/models"
+)
+
+//go:generate mockgen -destination=../../mocks/class_repo_mock.go -package=mocks -source=interface.go
+type ClassRepositoryI interface {
+	AddClass(ctx context.Context, class models.Class) error
+	GetClasses(ctx context.Context) ([]models.Class, error)
+}
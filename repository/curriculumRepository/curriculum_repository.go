@@ -0,0 +1,149 @@
+package curriculumRepository
+
+import (
+	"context"
+	"database/sql"
+	"sms/apperrors"
+	"sms/models"
+	"sms/repository/transaction"
+)
+
+type CurriculumRepo struct {
+	db transaction.Querier
+}
+
+func NewCurriculumRepo(db transaction.Querier) *CurriculumRepo {
+	return &CurriculumRepo{db}
+}
+
+func (cr *CurriculumRepo) AddProgram(ctx context.Context, program models.Program) error {
+	_, err := cr.db.ExecContext(ctx, `insert into program values(?,?,?)`, program.ProgramID, program.Name, program.Semesters)
+	return err
+}
+
+func (cr *CurriculumRepo) GetProgram(ctx context.Context, programID string) (*models.Program, error) {
+	stmt := `select ProgramID, Name, Semesters from program where ProgramID=?`
+	var p models.Program
+	err := cr.db.QueryRowContext(ctx, stmt, programID).Scan(&p.ProgramID, &p.Name, &p.Semesters)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &p, nil
+}
+
+// AddProgramSubject stores the subject and its prerequisites in one transaction.
+func (cr *CurriculumRepo) AddProgramSubject(ctx context.Context, subject models.ProgramSubject) error {
+	return transaction.RunInTx(ctx, cr.db, func(tx transaction.Querier) error {
+		_, err := tx.ExecContext(ctx, `insert into program_subject values(?,?,?,?,?)`,
+			subject.ProgramID, subject.SubjectID, subject.Semester, subject.Kind, subject.Credits)
+		if err != nil {
+			return err
+		}
+		for _, prerequisite := range subject.Prerequisites {
+			_, err := tx.ExecContext(ctx, `insert into subject_prerequisite values(?,?,?)`, subject.ProgramID, subject.SubjectID, prerequisite)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (cr *CurriculumRepo) GetProgramSubject(ctx context.Context, programID, subjectID string) (*models.ProgramSubject, error) {
+	stmt := `select ProgramID, SubjectID, semester, Kind, Credits from program_subject where ProgramID=? and SubjectID=?`
+	var ps models.ProgramSubject
+	err := cr.db.QueryRowContext(ctx, stmt, programID, subjectID).Scan(&ps.ProgramID, &ps.SubjectID, &ps.Semester, &ps.Kind, &ps.Credits)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	ps.Prerequisites, err = cr.getPrerequisites(ctx, programID, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	return &ps, nil
+}
+
+func (cr *CurriculumRepo) GetProgramSubjects(ctx context.Context, programID string, semester int) ([]models.ProgramSubject, error) {
+	stmt := `select ProgramID, SubjectID, semester, Kind, Credits from program_subject
+	where ProgramID=? and semester=? order by Kind, SubjectID`
+	rows, err := cr.db.QueryContext(ctx, stmt, programID, semester)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subjects []models.ProgramSubject
+	for rows.Next() {
+		var ps models.ProgramSubject
+		if err := rows.Scan(&ps.ProgramID, &ps.SubjectID, &ps.Semester, &ps.Kind, &ps.Credits); err != nil {
+			return nil, err
+		}
+		subjects = append(subjects, ps)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range subjects {
+		subjects[i].Prerequisites, err = cr.getPrerequisites(ctx, programID, subjects[i].SubjectID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return subjects, nil
+}
+
+func (cr *CurriculumRepo) getPrerequisites(ctx context.Context, programID, subjectID string) ([]string, error) {
+	stmt := `select PrerequisiteID from subject_prerequisite where ProgramID=? and SubjectID=? order by PrerequisiteID`
+	rows, err := cr.db.QueryContext(ctx, stmt, programID, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var prerequisites []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		prerequisites = append(prerequisites, id)
+	}
+	return prerequisites, rows.Err()
+}
+
+// GetClassProgram returns the program a class follows, or "" when it has none.
+func (cr *CurriculumRepo) GetClassProgram(ctx context.Context, classID string) (string, error) {
+	var programID sql.NullString
+	err := cr.db.QueryRowContext(ctx, `select ProgramID from class where ClassID=?`, classID).Scan(&programID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", err
+	}
+	return programID.String, nil
+}
+
+// SetClassProgram assigns a program to a class and reports a class that does
+// not exist as not found.
+func (cr *CurriculumRepo) SetClassProgram(ctx context.Context, classID, programID string) error {
+	res, err := cr.db.ExecContext(ctx, `update class set ProgramID=? where ClassID=?`, programID, classID)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return apperrors.NotFound("class not found")
+	}
+	return nil
+}
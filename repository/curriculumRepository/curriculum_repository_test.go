@@ -0,0 +1,169 @@
+package curriculumRepository_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"regexp"
+	"sms/apperrors"
+	"sms/constants"
+	"sms/models"
+	curriculumRepository "sms/repository/curriculumRepository"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestAddProgram_And_GetProgram(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := curriculumRepository.NewCurriculumRepo(db)
+
+	mock.ExpectExec(regexp.QuoteMeta(`insert into program values(?,?,?)`)).
+		WithArgs("p1", "B.Tech CSE", 8).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`select ProgramID, Name, Semesters from program where ProgramID=?`)).
+		WithArgs("p1").
+		WillReturnRows(sqlmock.NewRows([]string{"ProgramID", "Name", "Semesters"}).AddRow("p1", "B.Tech CSE", 8))
+
+	if err := repo.AddProgram(context.Background(), models.Program{ProgramID: "p1", Name: "B.Tech CSE", Semesters: 8}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	program, err := repo.GetProgram(context.Background(), "p1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if program == nil || program.Name != "B.Tech CSE" {
+		t.Errorf("unexpected program: %+v", program)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestAddProgramSubject(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := curriculumRepository.NewCurriculumRepo(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`insert into program_subject values(?,?,?,?,?)`)).
+		WithArgs("p1", "ml", 5, constants.ElectiveSubject, 3).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`insert into subject_prerequisite values(?,?,?)`)).
+		WithArgs("p1", "ml", "maths").
+		WillReturnError(errors.New("foreign key constraint failed"))
+	mock.ExpectRollback()
+
+	err = repo.AddProgramSubject(context.Background(), models.ProgramSubject{ProgramID: "p1", SubjectID: "ml", Semester: 5, Kind: constants.ElectiveSubject, Credits: 3, Prerequisites: []string{"maths"}})
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetProgramSubjects(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := curriculumRepository.NewCurriculumRepo(db)
+	prerequisites := regexp.QuoteMeta(`select PrerequisiteID from subject_prerequisite where ProgramID=? and SubjectID=? order by PrerequisiteID`)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`select ProgramID, SubjectID, semester, Kind, Credits from program_subject
+	where ProgramID=? and semester=? order by Kind, SubjectID`)).
+		WithArgs("p1", 5).
+		WillReturnRows(sqlmock.NewRows([]string{"ProgramID", "SubjectID", "semester", "Kind", "Credits"}).
+			AddRow("p1", "os", 5, "core", 4).
+			AddRow("p1", "ml", 5, "elective", 3))
+	mock.ExpectQuery(prerequisites).WithArgs("p1", "os").WillReturnRows(sqlmock.NewRows([]string{"PrerequisiteID"}))
+	mock.ExpectQuery(prerequisites).WithArgs("p1", "ml").WillReturnRows(sqlmock.NewRows([]string{"PrerequisiteID"}).AddRow("maths"))
+
+	subjects, err := repo.GetProgramSubjects(context.Background(), "p1", 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []models.ProgramSubject{
+		{ProgramID: "p1", SubjectID: "os", Semester: 5, Kind: constants.CoreSubject, Credits: 4},
+		{ProgramID: "p1", SubjectID: "ml", Semester: 5, Kind: constants.ElectiveSubject, Credits: 3, Prerequisites: []string{"maths"}},
+	}
+	if !reflect.DeepEqual(subjects, expected) {
+		t.Errorf("expected %+v, got %+v", expected, subjects)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetProgramSubject(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := curriculumRepository.NewCurriculumRepo(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`select ProgramID, SubjectID, semester, Kind, Credits from program_subject where ProgramID=? and SubjectID=?`)).
+		WithArgs("p1", "missing").
+		WillReturnRows(sqlmock.NewRows([]string{"ProgramID", "SubjectID", "semester", "Kind", "Credits"}))
+
+	subject, err := repo.GetProgramSubject(context.Background(), "p1", "missing")
+	if err != nil || subject != nil {
+		t.Errorf("expected nil subject and error, got %+v, %v", subject, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestClassProgram(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := curriculumRepository.NewCurriculumRepo(db)
+
+	mock.ExpectExec(regexp.QuoteMeta(`update class set ProgramID=? where ClassID=?`)).
+		WithArgs("p1", "C1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`select ProgramID from class where ClassID=?`)).
+		WithArgs("C1").
+		WillReturnRows(sqlmock.NewRows([]string{"ProgramID"}).AddRow("p1"))
+	mock.ExpectQuery(regexp.QuoteMeta(`select ProgramID from class where ClassID=?`)).
+		WithArgs("C2").
+		WillReturnRows(sqlmock.NewRows([]string{"ProgramID"}).AddRow(nil))
+	mock.ExpectExec(regexp.QuoteMeta(`update class set ProgramID=? where ClassID=?`)).
+		WithArgs("p1", "C9").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := repo.SetClassProgram(context.Background(), "C1", "p1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if programID, err := repo.GetClassProgram(context.Background(), "C1"); err != nil || programID != "p1" {
+		t.Errorf("expected p1, got %q, %v", programID, err)
+	}
+	if programID, err := repo.GetClassProgram(context.Background(), "C2"); err != nil || programID != "" {
+		t.Errorf("expected empty program, got %q, %v", programID, err)
+	}
+	if err := repo.SetClassProgram(context.Background(), "C9", "p1"); !errors.Is(err, apperrors.ErrNotFound) {
+		t.Errorf("expected not found for an unknown class, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,17 @@
+package curriculumRepository
+
+import (
+	"context"
+	"sms/models"
+)
+
+//go:generate mockgen -destination=../../mocks/curriculum_repo_mock.go -package=mocks -source=interface.go
+type CurriculumRepositoryI interface {
+	AddProgram(ctx context.Context, program models.Program) error
+	GetProgram(ctx context.Context, programID string) (*models.Program, error)
+	AddProgramSubject(ctx context.Context, subject models.ProgramSubject) error
+	GetProgramSubject(ctx context.Context, programID, subjectID string) (*models.ProgramSubject, error)
+	GetProgramSubjects(ctx context.Context, programID string, semester int) ([]models.ProgramSubject, error)
+	GetClassProgram(ctx context.Context, classID string) (string, error)
+	SetClassProgram(ctx context.Context, classID, programID string) error
+}
@@ -0,0 +1,86 @@
+package enrollmentRepository
+
+import (
+	"context"
+	"database/sql"
+	"sms/models"
+	"sms/repository/transaction"
+	"time"
+)
+
+type EnrollmentRepo struct {
+	db transaction.Querier
+}
+
+func NewEnrollmentRepo(db transaction.Querier) *EnrollmentRepo {
+	return &EnrollmentRepo{db}
+}
+
+// WithTx returns an EnrollmentRepo that runs its statements on tx.
+func (er *EnrollmentRepo) WithTx(tx transaction.Querier) EnrollmentRepositoryI {
+	return NewEnrollmentRepo(tx)
+}
+
+// AddEnrollments enrolls every row in one transaction. Re-enrolling a dropped
+// subject reactivates the existing row.
+func (er *EnrollmentRepo) AddEnrollments(ctx context.Context, enrollments []models.Enrollment) error {
+	return transaction.RunInTx(ctx, er.db, func(tx transaction.Querier) error {
+		stmt := `insert into enrollment values(?,?,?,?,?,?)
+		on conflict(StudentID, SubjectID, semester) do update set Status=excluded.Status, AddedOn=excluded.AddedOn, DroppedOn=null`
+		for _, e := range enrollments {
+			if _, err := tx.ExecContext(ctx, stmt, e.StudentID, e.SubjectID, e.Semester, e.Status, e.AddedOn, e.DroppedOn); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (er *EnrollmentRepo) GetEnrollment(ctx context.Context, studentID, subjectID string, semester int) (*models.Enrollment, error) {
+	stmt := `select StudentID, SubjectID, semester, Status, AddedOn, DroppedOn from enrollment where StudentID=? and SubjectID=? and semester=?`
+	var e models.Enrollment
+	var droppedOn sql.NullTime
+	err := er.db.QueryRowContext(ctx, stmt, studentID, subjectID, semester).Scan(&e.StudentID, &e.SubjectID, &e.Semester, &e.Status, &e.AddedOn, &droppedOn)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if droppedOn.Valid {
+		e.DroppedOn = &droppedOn.Time
+	}
+	return &e, nil
+}
+
+func (er *EnrollmentRepo) DropEnrollment(ctx context.Context, studentID, subjectID string, semester int, droppedOn time.Time) error {
+	stmt := `update enrollment set Status='dropped', DroppedOn=? where StudentID=? and SubjectID=? and semester=?`
+	_, err := er.db.ExecContext(ctx, stmt, droppedOn, studentID, subjectID, semester)
+	return err
+}
+
+// GetStudentEnrollments returns the student's enrollments of a semester, or of
+// every semester when semester is 0.
+func (er *EnrollmentRepo) GetStudentEnrollments(ctx context.Context, studentID string, semester int) ([]models.Enrollment, error) {
+	stmt := `select StudentID, SubjectID, semester, Status, AddedOn, DroppedOn from enrollment
+	where StudentID=? and (?=0 or semester=?) order by semester, SubjectID`
+	rows, err := er.db.QueryContext(ctx, stmt, studentID, semester, semester)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var enrollments []models.Enrollment
+	for rows.Next() {
+		var e models.Enrollment
+		var droppedOn sql.NullTime
+		if err := rows.Scan(&e.StudentID, &e.SubjectID, &e.Semester, &e.Status, &e.AddedOn, &droppedOn); err != nil {
+			return nil, err
+		}
+		if droppedOn.Valid {
+			e.DroppedOn = &droppedOn.Time
+		}
+		enrollments = append(enrollments, e)
+	}
+	return enrollments, rows.Err()
+}
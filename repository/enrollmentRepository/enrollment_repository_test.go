@@ -0,0 +1,167 @@
+package enrollmentRepository_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"sms/constants"
+	"sms/models"
+	enrollmentRepository "sms/repository/enrollmentRepository"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestAddEnrollments(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := enrollmentRepository.NewEnrollmentRepo(db)
+	addedOn := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	stmt := regexp.QuoteMeta(`insert into enrollment values(?,?,?,?,?,?)
+	on conflict(StudentID, SubjectID, semester) do update set Status=excluded.Status, AddedOn=excluded.AddedOn, DroppedOn=null`)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(stmt).WithArgs("s1", "sub1", 1, constants.Enrolled, addedOn, nil).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(stmt).WithArgs("s2", "sub1", 1, constants.Enrolled, addedOn, nil).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err = repo.AddEnrollments(context.Background(), []models.Enrollment{
+		{StudentID: "s1", SubjectID: "sub1", Semester: 1, Status: constants.Enrolled, AddedOn: addedOn},
+		{StudentID: "s2", SubjectID: "sub1", Semester: 1, Status: constants.Enrolled, AddedOn: addedOn},
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetEnrollment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := enrollmentRepository.NewEnrollmentRepo(db)
+	addedOn := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	droppedOn := addedOn.AddDate(0, 0, 10)
+	stmt := regexp.QuoteMeta(`select StudentID, SubjectID, semester, Status, AddedOn, DroppedOn from enrollment where StudentID=? and SubjectID=? and semester=?`)
+	columns := []string{"StudentID", "SubjectID", "semester", "Status", "AddedOn", "DroppedOn"}
+
+	mock.ExpectQuery(stmt).WithArgs("s1", "sub1", 1).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow("s1", "sub1", 1, "dropped", addedOn, droppedOn))
+	mock.ExpectQuery(stmt).WithArgs("s2", "sub1", 1).
+		WillReturnRows(sqlmock.NewRows(columns))
+
+	enrollment, err := repo.GetEnrollment(context.Background(), "s1", "sub1", 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if enrollment.Status != constants.Dropped || enrollment.DroppedOn == nil || !enrollment.DroppedOn.Equal(droppedOn) {
+		t.Errorf("unexpected enrollment: %+v", enrollment)
+	}
+
+	enrollment, err = repo.GetEnrollment(context.Background(), "s2", "sub1", 1)
+	if err != nil || enrollment != nil {
+		t.Errorf("expected nil enrollment and error, got %+v, %v", enrollment, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestDropEnrollment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := enrollmentRepository.NewEnrollmentRepo(db)
+	droppedOn := time.Date(2025, 8, 10, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec(regexp.QuoteMeta(`update enrollment set Status='dropped', DroppedOn=? where StudentID=? and SubjectID=? and semester=?`)).
+		WithArgs(droppedOn, "s1", "sub1", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := repo.DropEnrollment(context.Background(), "s1", "sub1", 1, droppedOn); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetStudentEnrollments(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := enrollmentRepository.NewEnrollmentRepo(db)
+	addedOn := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	stmt := regexp.QuoteMeta(`select StudentID, SubjectID, semester, Status, AddedOn, DroppedOn from enrollment
+	where StudentID=? and (?=0 or semester=?) order by semester, SubjectID`)
+
+	mock.ExpectQuery(stmt).WithArgs("s1", 0, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"StudentID", "SubjectID", "semester", "Status", "AddedOn", "DroppedOn"}).
+			AddRow("s1", "sub1", 1, "completed", addedOn, nil).
+			AddRow("s1", "sub2", 2, "enrolled", addedOn, nil))
+	mock.ExpectQuery(stmt).WithArgs("s2", 1, 1).WillReturnError(errors.New("db connection lost"))
+
+	enrollments, err := repo.GetStudentEnrollments(context.Background(), "s1", 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(enrollments) != 2 || enrollments[0].Status != constants.Completed || enrollments[1].DroppedOn != nil {
+		t.Errorf("unexpected enrollments: %+v", enrollments)
+	}
+
+	if _, err := repo.GetStudentEnrollments(context.Background(), "s2", 1); err == nil {
+		t.Errorf("expected error, got nil")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestEnrollmentRepoWithTx(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	addedOn := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`insert into students values(?,?,?,?,?)`)).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`insert into enrollment values(?,?,?,?,?,?)`)).
+		WithArgs("s1", "sub1", 1, constants.Enrolled, addedOn, nil).WillReturnError(errors.New("no such subject"))
+	mock.ExpectRollback()
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := tx.ExecContext(context.Background(), `insert into students values(?,?,?,?,?)`, "s1", "Anu", "101", "C1", 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	repo := enrollmentRepository.NewEnrollmentRepo(db).WithTx(tx)
+	err = repo.AddEnrollments(context.Background(), []models.Enrollment{{StudentID: "s1", SubjectID: "sub1", Semester: 1, Status: constants.Enrolled, AddedOn: addedOn}})
+	if err == nil {
+		t.Errorf("expected error")
+	}
+	tx.Rollback()
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,17 @@
+package enrollmentRepository
+
+import (
+	"context"
+	"sms/models"
+	"sms/repository/transaction"
+	"time"
+)
+
+//go:generate mockgen -destination=../../mocks/enrollment_repo_mock.go -package=mocks -source=interface.go
+type EnrollmentRepositoryI interface {
+	WithTx(tx transaction.Querier) EnrollmentRepositoryI
+	AddEnrollments(ctx context.Context, enrollments []models.Enrollment) error
+	GetEnrollment(ctx context.Context, studentID, subjectID string, semester int) (*models.Enrollment, error)
+	DropEnrollment(ctx context.Context, studentID, subjectID string, semester int, droppedOn time.Time) error
+	GetStudentEnrollments(ctx context.Context, studentID string, semester int) ([]models.Enrollment, error)
+}
@@ -1,9 +1,14 @@
 package gradeRepository
 
-import "database/sql"
+import (
+	"context"
+	"database/sql"
+	"sms/models"
+	"sms/repository/transaction"
+)
 
 type GradeRepo struct {
-	db *sql.DB
+	db transaction.Querier
 }
 type StudentAverage struct {
 	StudentID   string
@@ -11,13 +16,18 @@ type StudentAverage struct {
 	Average     float64
 }
 
-func NewGradeRepo(db *sql.DB) *GradeRepo {
+func NewGradeRepo(db transaction.Querier) *GradeRepo {
 	return &GradeRepo{db}
 }
 
-func (gr *GradeRepo) GetSemesterGrades(studentID string, semester int) ([]int, error) {
+// WithTx returns a GradeRepo that runs its statements on tx.
+func (gr *GradeRepo) WithTx(tx transaction.Querier) GradeRepositoryI {
+	return NewGradeRepo(tx)
+}
+
+func (gr *GradeRepo) GetSemesterGrades(ctx context.Context, studentID string, semester int) ([]int, error) {
 	stmt := `select Grade from grades where StudentID=? and semester=?`
-	rows, err := gr.db.Query(stmt, studentID, semester)
+	rows, err := gr.db.QueryContext(ctx, stmt, studentID, semester)
 	if err != nil {
 		return nil, err
 	}
@@ -34,16 +44,16 @@ func (gr *GradeRepo) GetSemesterGrades(studentID string, semester int) ([]int, e
 	return grades, err
 }
 
-func (gr *GradeRepo) AddGrades(studentID string, subjectID string, Grade int, semester int) error {
+func (gr *GradeRepo) AddGrades(ctx context.Context, studentID string, subjectID string, Grade int, semester int) error {
 	stmt := `insert into grades values(?,?,?,?)`
-	_, err := gr.db.Exec(stmt, subjectID, studentID, Grade, semester)
+	_, err := gr.db.ExecContext(ctx, stmt, subjectID, studentID, Grade, semester)
 
 	return err
 }
 
-func (gr *GradeRepo) UpdateGrade(studentID string, subjectID string, newGrade int) error {
+func (gr *GradeRepo) UpdateGrade(ctx context.Context, studentID string, subjectID string, newGrade int) error {
 	stmt := `update grades set Grade=? where StudentID=? and SubjectID=?`
-	_, err := gr.db.Exec(stmt, newGrade, studentID, subjectID)
+	_, err := gr.db.ExecContext(ctx, stmt, newGrade, studentID, subjectID)
 	return err
 }
 
@@ -54,23 +64,25 @@ func (gr *GradeRepo) UpdateGrade(studentID string, subjectID string, newGrade in
 // 	return avg, err
 // }
 
-func (gr *GradeRepo) GetClassAverage(classID string, semester int) (float64, error) {
+func (gr *GradeRepo) GetClassAverage(ctx context.Context, classID string, semester int) (float64, error) {
 	stmt := `select avg(g.grade) from grades g join students s on s.StudentID=g.StudentID where s.classID=? and g.semester=?`
 	var avg float64
-	err := gr.db.QueryRow(stmt, classID, semester).Scan(&avg)
+	err := gr.db.QueryRowContext(ctx, stmt, classID, semester).Scan(&avg)
 	return avg, err
 }
 
-func (gr *GradeRepo) GetToppers(classID string, semester, top int) ([]StudentAverage, error) {
+func (gr *GradeRepo) GetToppers(ctx context.Context, classID string, semester, top int) ([]StudentAverage, error) {
 
 	stmt := `select s.StudentID, s.Name, avg(g.grade) as average from grades g
 	join students s on s.StudentID=g.StudentID where s.ClassID=? and g.semester=?
-	group by s.StudentID, s.Name 
-	order by average DESC limit ?`
-	rows, err := gr.db.Query(stmt, classID, semester, top)
+	group by s.StudentID, s.Name, s.RollNumber
+	order by average DESC, s.RollNumber, s.StudentID limit ?`
+	rows, err := gr.db.QueryContext(ctx, stmt, classID, semester, top)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
+
 	var students []StudentAverage
 	for rows.Next() {
 		var sa StudentAverage
@@ -82,3 +94,88 @@ func (gr *GradeRepo) GetToppers(classID string, semester, top int) ([]StudentAve
 	return students, nil
 
 }
+
+func (gr *GradeRepo) GetClassGrades(ctx context.Context, classID string, semester int) ([]int, error) {
+	stmt := `select g.Grade from grades g join students s on s.StudentID=g.StudentID where s.ClassID=? and g.semester=?`
+	return gr.queryGrades(ctx, stmt, classID, semester)
+}
+
+func (gr *GradeRepo) GetClassSubjectGrades(ctx context.Context, classID, subjectID string, semester int) ([]int, error) {
+	stmt := `select g.Grade from grades g join students s on s.StudentID=g.StudentID where s.ClassID=? and g.SubjectID=? and g.semester=?`
+	return gr.queryGrades(ctx, stmt, classID, subjectID, semester)
+}
+
+func (gr *GradeRepo) queryGrades(ctx context.Context, stmt string, args ...any) ([]int, error) {
+	rows, err := gr.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var grades []int
+	for rows.Next() {
+		var grade int
+		if err := rows.Scan(&grade); err != nil {
+			return nil, err
+		}
+		grades = append(grades, grade)
+	}
+	return grades, rows.Err()
+}
+
+// GetStudentAverages returns every student's average for the semester, optionally
+// restricted to one subject. Ties on the average are ordered by roll number and
+// then student ID so the result is deterministic.
+func (gr *GradeRepo) GetStudentAverages(ctx context.Context, classID string, semester int, subjectID string) ([]StudentAverage, error) {
+	stmt := `select s.StudentID, s.Name, avg(g.grade) as average from grades g
+	join students s on s.StudentID=g.StudentID where s.ClassID=? and g.semester=? and (?='' or g.SubjectID=?)
+	group by s.StudentID, s.Name, s.RollNumber
+	order by average DESC, s.RollNumber, s.StudentID`
+	rows, err := gr.db.QueryContext(ctx, stmt, classID, semester, subjectID, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var students []StudentAverage
+	for rows.Next() {
+		var sa StudentAverage
+		if err := rows.Scan(&sa.StudentID, &sa.StudentName, &sa.Average); err != nil {
+			return nil, err
+		}
+		students = append(students, sa)
+	}
+	return students, rows.Err()
+}
+
+func (gr *GradeRepo) GetStudentGrades(ctx context.Context, studentID string) ([]models.Grade, error) {
+	stmt := `select SubjectID, StudentID, Grade, semester from grades where StudentID=? order by semester, SubjectID`
+	rows, err := gr.db.QueryContext(ctx, stmt, studentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var grades []models.Grade
+	for rows.Next() {
+		var g models.Grade
+		if err := rows.Scan(&g.SubjectID, &g.StudentID, &g.Grade, &g.Semester); err != nil {
+			return nil, err
+		}
+		grades = append(grades, g)
+	}
+	return grades, rows.Err()
+}
+
+func (gr *GradeRepo) GetGrade(ctx context.Context, studentID, subjectID string) (*models.Grade, error) {
+	stmt := `select SubjectID, StudentID, Grade, semester from grades where StudentID=? and SubjectID=?`
+	var g models.Grade
+	err := gr.db.QueryRowContext(ctx, stmt, studentID, subjectID).Scan(&g.SubjectID, &g.StudentID, &g.Grade, &g.Semester)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &g, nil
+}
@@ -1,12 +1,15 @@
 package gradeRepository_test
 
 import (
+	"context"
 	"errors"
 	"reflect"
 	"regexp"
+	"sms/models"
 	gradeRepository "sms/repository/gradesRepository"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 )
@@ -30,7 +33,7 @@ func TestGetSemesterGrades(t *testing.T) {
 		WithArgs(studentID, semester).
 		WillReturnRows(rows)
 
-	grades, err := repo.GetSemesterGrades(studentID, semester)
+	grades, err := repo.GetSemesterGrades(context.Background(), studentID, semester)
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
@@ -67,7 +70,7 @@ func TestAddGrades(t *testing.T) {
 		WithArgs(subjectID, studentID, grade, semester).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	err = repo.AddGrades(studentID, subjectID, grade, semester)
+	err = repo.AddGrades(context.Background(), studentID, subjectID, grade, semester)
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
@@ -92,7 +95,7 @@ func TestUpdateGrade(t *testing.T) {
 		WithArgs(newGrade, studentID, subjectID).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	err = repo.UpdateGrade(studentID, subjectID, newGrade)
+	err = repo.UpdateGrade(context.Background(), studentID, subjectID, newGrade)
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
@@ -147,7 +150,7 @@ func TestGetClassAverage(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
-			avg, err := repo.GetClassAverage(tt.classID, tt.semester)
+			avg, err := repo.GetClassAverage(context.Background(), tt.classID, tt.semester)
 			if tt.expectedError != nil {
 				if err == nil {
 					t.Errorf("expected error: %v, got nil", tt.expectedError)
@@ -210,8 +213,8 @@ func TestGetToppers(t *testing.T) {
 					AddRow("S003", "Charlie", 88.5)
 				mock.ExpectQuery(regexp.QuoteMeta(`select s.StudentID, s.Name, avg(g.grade) as average from grades g
 				join students s on s.StudentID=g.StudentID where s.ClassID=? and g.semester=?
-				group by s.StudentID, s.Name 
-				order by average DESC limit ?`)).
+				group by s.StudentID, s.Name, s.RollNumber
+				order by average DESC, s.RollNumber, s.StudentID limit ?`)).
 					WithArgs("CS101", 1, 3).
 					WillReturnRows(rows)
 			},
@@ -226,8 +229,8 @@ func TestGetToppers(t *testing.T) {
 			mockSetup: func() {
 				mock.ExpectQuery(regexp.QuoteMeta(`select s.StudentID, s.Name, avg(g.grade) as average from grades g
 				join students s on s.StudentID=g.StudentID where s.ClassID=? and g.semester=?
-				group by s.StudentID, s.Name 
-				order by average DESC limit ?`)).
+				group by s.StudentID, s.Name, s.RollNumber
+				order by average DESC, s.RollNumber, s.StudentID limit ?`)).
 					WithArgs("CS102", 2, 5).
 					WillReturnError(errors.New("db connection lost"))
 			},
@@ -243,8 +246,8 @@ func TestGetToppers(t *testing.T) {
 				rows := sqlmock.NewRows([]string{"StudentID", "Name", "average"})
 				mock.ExpectQuery(regexp.QuoteMeta(`select s.StudentID, s.Name, avg(g.grade) as average from grades g
 				join students s on s.StudentID=g.StudentID where s.ClassID=? and g.semester=?
-				group by s.StudentID, s.Name 
-				order by average DESC limit ?`)).
+				group by s.StudentID, s.Name, s.RollNumber
+				order by average DESC, s.RollNumber, s.StudentID limit ?`)).
 					WithArgs("CS103", 3, 10).
 					WillReturnRows(rows)
 			},
@@ -257,7 +260,7 @@ func TestGetToppers(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
 
-			toppers, err := repo.GetToppers(tt.classID, tt.semester, tt.top)
+			toppers, err := repo.GetToppers(context.Background(), tt.classID, tt.semester, tt.top)
 
 			if tt.expectedError != nil {
 				if err == nil {
@@ -280,3 +283,176 @@ func TestGetToppers(t *testing.T) {
 		})
 	}
 }
+
+func TestGetClassGrades(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := gradeRepository.NewGradeRepo(db)
+
+	rows := sqlmock.NewRows([]string{"Grade"}).AddRow(70).AddRow(55)
+	mock.ExpectQuery(regexp.QuoteMeta(`select g.Grade from grades g join students s on s.StudentID=g.StudentID where s.ClassID=? and g.semester=?`)).
+		WithArgs("CS101", 1).
+		WillReturnRows(rows)
+
+	grades, err := repo.GetClassGrades(context.Background(), "CS101", 1)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(grades, []int{70, 55}) {
+		t.Errorf("expected grades [70 55], got %v", grades)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetClassSubjectGrades(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := gradeRepository.NewGradeRepo(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`select g.Grade from grades g join students s on s.StudentID=g.StudentID where s.ClassID=? and g.SubjectID=? and g.semester=?`)).
+		WithArgs("CS101", "sub1", 2).
+		WillReturnError(errors.New("db connection lost"))
+
+	_, err = repo.GetClassSubjectGrades(context.Background(), "CS101", "sub1", 2)
+	if err == nil || err.Error() != "db connection lost" {
+		t.Errorf("expected db connection lost error, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetStudentAverages(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := gradeRepository.NewGradeRepo(db)
+
+	rows := sqlmock.NewRows([]string{"StudentID", "Name", "average"}).
+		AddRow("S001", "Alice", 90.0).
+		AddRow("S002", "Bob", 90.0)
+	mock.ExpectQuery(regexp.QuoteMeta(`select s.StudentID, s.Name, avg(g.grade) as average from grades g
+	join students s on s.StudentID=g.StudentID where s.ClassID=? and g.semester=? and (?='' or g.SubjectID=?)
+	group by s.StudentID, s.Name, s.RollNumber
+	order by average DESC, s.RollNumber, s.StudentID`)).
+		WithArgs("CS101", 1, "sub1", "sub1").
+		WillReturnRows(rows)
+
+	averages, err := repo.GetStudentAverages(context.Background(), "CS101", 1, "sub1")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	expected := []gradeRepository.StudentAverage{
+		{StudentID: "S001", StudentName: "Alice", Average: 90.0},
+		{StudentID: "S002", StudentName: "Bob", Average: 90.0},
+	}
+	if !reflect.DeepEqual(averages, expected) {
+		t.Errorf("expected averages %v, got %v", expected, averages)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetStudentGrades(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := gradeRepository.NewGradeRepo(db)
+
+	rows := sqlmock.NewRows([]string{"SubjectID", "StudentID", "Grade", "semester"}).
+		AddRow("sub1", "S001", 80, 1).
+		AddRow("sub2", "S001", 70, 2)
+	mock.ExpectQuery(regexp.QuoteMeta(`select SubjectID, StudentID, Grade, semester from grades where StudentID=? order by semester, SubjectID`)).
+		WithArgs("S001").
+		WillReturnRows(rows)
+
+	grades, err := repo.GetStudentGrades(context.Background(), "S001")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	expected := []models.Grade{
+		{SubjectID: "sub1", StudentID: "S001", Grade: 80, Semester: 1},
+		{SubjectID: "sub2", StudentID: "S001", Grade: 70, Semester: 2},
+	}
+	if !reflect.DeepEqual(grades, expected) {
+		t.Errorf("expected grades %v, got %v", expected, grades)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetGrade(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := gradeRepository.NewGradeRepo(db)
+	stmt := regexp.QuoteMeta(`select SubjectID, StudentID, Grade, semester from grades where StudentID=? and SubjectID=?`)
+
+	mock.ExpectQuery(stmt).
+		WithArgs("S001", "sub1").
+		WillReturnRows(sqlmock.NewRows([]string{"SubjectID", "StudentID", "Grade", "semester"}).AddRow("sub1", "S001", 80, 3))
+	mock.ExpectQuery(stmt).
+		WithArgs("S001", "missing").
+		WillReturnRows(sqlmock.NewRows([]string{"SubjectID", "StudentID", "Grade", "semester"}))
+
+	grade, err := repo.GetGrade(context.Background(), "S001", "sub1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := models.Grade{SubjectID: "sub1", StudentID: "S001", Grade: 80, Semester: 3}
+	if *grade != expected {
+		t.Errorf("expected grade %v, got %v", expected, *grade)
+	}
+	if grade, err := repo.GetGrade(context.Background(), "S001", "missing"); err != nil || grade != nil {
+		t.Errorf("expected nil grade and error, got %v, %v", grade, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetGrade_Cancelled(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := gradeRepository.NewGradeRepo(db)
+	stmt := regexp.QuoteMeta(`select SubjectID, StudentID, Grade, semester from grades where StudentID=? and SubjectID=?`)
+	mock.ExpectQuery(stmt).
+		WithArgs("S001", "sub1").
+		WillDelayFor(time.Second).
+		WillReturnRows(sqlmock.NewRows([]string{"SubjectID", "StudentID", "Grade", "semester"}).AddRow("sub1", "S001", 80, 3))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := repo.GetGrade(ctx, "S001", "sub1"); err == nil {
+		t.Errorf("expected the query to be cancelled")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected the query to stop at the deadline, took %v", elapsed)
+	}
+}
@@ -1,10 +1,22 @@
 package gradeRepository
 
+import (
+	"context"
+	"sms/models"
+	"sms/repository/transaction"
+)
+
 //go:generate mockgen -destination=../../mocks/grade_repo_mock.go -package=mocks -source=interface.go
 type GradeRepositoryI interface {
-	GetSemesterGrades(studentID string, semester int) ([]int, error)
-	AddGrades(studentID string, subjectID string, Grade int, semester int) error
-	UpdateGrade(studentID string, subjectID string, newGrade int) error
-	GetClassAverage(classID string, semester int) (float64, error)
-	GetToppers(classID string, semester, top int) ([]StudentAverage, error)
+	WithTx(tx transaction.Querier) GradeRepositoryI
+	GetSemesterGrades(ctx context.Context, studentID string, semester int) ([]int, error)
+	AddGrades(ctx context.Context, studentID string, subjectID string, Grade int, semester int) error
+	UpdateGrade(ctx context.Context, studentID string, subjectID string, newGrade int) error
+	GetClassAverage(ctx context.Context, classID string, semester int) (float64, error)
+	GetToppers(ctx context.Context, classID string, semester, top int) ([]StudentAverage, error)
+	GetClassGrades(ctx context.Context, classID string, semester int) ([]int, error)
+	GetClassSubjectGrades(ctx context.Context, classID, subjectID string, semester int) ([]int, error)
+	GetStudentGrades(ctx context.Context, studentID string) ([]models.Grade, error)
+	GetStudentAverages(ctx context.Context, classID string, semester int, subjectID string) ([]StudentAverage, error)
+	GetGrade(ctx context.Context, studentID, subjectID string) (*models.Grade, error)
 }
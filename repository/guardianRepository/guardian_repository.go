@@ -0,0 +1,70 @@
+package guardianRepository
+
+import (
+	"context"
+	"sms/models"
+	"sms/repository/transaction"
+)
+
+type GuardianRepo struct {
+	db transaction.Querier
+}
+
+func NewGuardianRepo(db transaction.Querier) *GuardianRepo {
+	return &GuardianRepo{db}
+}
+
+func (gr *GuardianRepo) LinkStudent(ctx context.Context, link models.GuardianLink) error {
+	stmt := `insert into guardian_student values(?,?,?) on conflict(GuardianID, StudentID) do update set Relationship=excluded.Relationship`
+	_, err := gr.db.ExecContext(ctx, stmt, link.GuardianID, link.StudentID, link.Relationship)
+	return err
+}
+
+func (gr *GuardianRepo) UnlinkStudent(ctx context.Context, guardianID, studentID string) error {
+	_, err := gr.db.ExecContext(ctx, `delete from guardian_student where GuardianID=? and StudentID=?`, guardianID, studentID)
+	return err
+}
+
+func (gr *GuardianRepo) IsLinked(ctx context.Context, guardianID, studentID string) (bool, error) {
+	var count int
+	err := gr.db.QueryRowContext(ctx, `select count(*) from guardian_student where GuardianID=? and StudentID=?`, guardianID, studentID).Scan(&count)
+	return count > 0, err
+}
+
+func (gr *GuardianRepo) GetLinkedStudents(ctx context.Context, guardianID string) ([]models.Students, error) {
+	stmt := `select s.StudentID, s.Name, s.RollNumber, s.ClassID, s.semester from students s
+	join guardian_student g on g.StudentID=s.StudentID where g.GuardianID=? order by s.Name`
+	rows, err := gr.db.QueryContext(ctx, stmt, guardianID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var students []models.Students
+	for rows.Next() {
+		var s models.Students
+		if err := rows.Scan(&s.StudentID, &s.Name, &s.RollNumber, &s.ClassID, &s.Semester); err != nil {
+			return nil, err
+		}
+		students = append(students, s)
+	}
+	return students, rows.Err()
+}
+
+func (gr *GuardianRepo) GetGuardianIDs(ctx context.Context, studentID string) ([]string, error) {
+	rows, err := gr.db.QueryContext(ctx, `select GuardianID from guardian_student where StudentID=? order by GuardianID`, studentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
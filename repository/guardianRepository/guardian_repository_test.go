@@ -0,0 +1,124 @@
+package guardianRepository_test
+
+import (
+	"context"
+	"regexp"
+	"sms/models"
+	guardianRepository "sms/repository/guardianRepository"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestLinkStudent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := guardianRepository.NewGuardianRepo(db)
+	stmt := regexp.QuoteMeta(`insert into guardian_student values(?,?,?) on conflict(GuardianID, StudentID) do update set Relationship=excluded.Relationship`)
+	mock.ExpectExec(stmt).WithArgs("g1", "s1", "mother").WillReturnResult(sqlmock.NewResult(1, 1))
+
+	if err := repo.LinkStudent(context.Background(), models.GuardianLink{GuardianID: "g1", StudentID: "s1", Relationship: "mother"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestUnlinkStudent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := guardianRepository.NewGuardianRepo(db)
+	mock.ExpectExec(regexp.QuoteMeta(`delete from guardian_student where GuardianID=? and StudentID=?`)).
+		WithArgs("g1", "s1").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := repo.UnlinkStudent(context.Background(), "g1", "s1"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestIsLinked(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := guardianRepository.NewGuardianRepo(db)
+	query := regexp.QuoteMeta(`select count(*) from guardian_student where GuardianID=? and StudentID=?`)
+	mock.ExpectQuery(query).WithArgs("g1", "s1").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(query).WithArgs("g1", "s2").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	linked, err := repo.IsLinked(context.Background(), "g1", "s1")
+	if err != nil || !linked {
+		t.Errorf("expected s1 to be linked, got %v, %v", linked, err)
+	}
+	linked, err = repo.IsLinked(context.Background(), "g1", "s2")
+	if err != nil || linked {
+		t.Errorf("expected s2 not to be linked, got %v, %v", linked, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetLinkedStudents(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := guardianRepository.NewGuardianRepo(db)
+	query := regexp.QuoteMeta(`select s.StudentID, s.Name, s.RollNumber, s.ClassID, s.semester from students s
+	join guardian_student g on g.StudentID=s.StudentID where g.GuardianID=? order by s.Name`)
+	rows := sqlmock.NewRows([]string{"StudentID", "Name", "RollNumber", "ClassID", "semester"}).
+		AddRow("s1", "Anu", 1, "C1", 2).
+		AddRow("s2", "Bala", 4, "C3", 1)
+	mock.ExpectQuery(query).WithArgs("g1").WillReturnRows(rows)
+
+	students, err := repo.GetLinkedStudents(context.Background(), "g1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(students) != 2 || students[0].StudentID != "s1" || students[1].ClassID != "C3" {
+		t.Errorf("unexpected students: %+v", students)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetGuardianIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := guardianRepository.NewGuardianRepo(db)
+	mock.ExpectQuery(regexp.QuoteMeta(`select GuardianID from guardian_student where StudentID=? order by GuardianID`)).
+		WithArgs("s1").WillReturnRows(sqlmock.NewRows([]string{"GuardianID"}).AddRow("g1").AddRow("g2"))
+
+	ids, err := repo.GetGuardianIDs(context.Background(), "s1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ids) != 2 || ids[0] != "g1" || ids[1] != "g2" {
+		t.Errorf("unexpected guardian IDs: %v", ids)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
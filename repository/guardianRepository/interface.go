@@ -0,0 +1,15 @@
+package guardianRepository
+
+import (
+	"context"
+	"sms/models"
+)
+
+//go:generate mockgen -destination=../../mocks/guardian_repo_mock.go -package=mocks -source=interface.go
+type GuardianRepositoryI interface {
+	LinkStudent(ctx context.Context, link models.GuardianLink) error
+	UnlinkStudent(ctx context.Context, guardianID, studentID string) error
+	IsLinked(ctx context.Context, guardianID, studentID string) (bool, error)
+	GetLinkedStudents(ctx context.Context, guardianID string) ([]models.Students, error)
+	GetGuardianIDs(ctx context.Context, studentID string) ([]string, error)
+}
@@ -0,0 +1,20 @@
+package notificationRepository
+
+import (
+	"context"
+	"sms/constants"
+	"sms/models"
+	"time"
+)
+
+//go:generate mockgen -destination=../../mocks/notification_repo_mock.go -package=mocks -source=interface.go
+type NotificationRepositoryI interface {
+	AddNotifications(ctx context.Context, notifications []models.Notification) error
+	GetDueNotifications(ctx context.Context, now time.Time, limit int) ([]models.Notification, error)
+	MarkSent(ctx context.Context, notificationID string, sentAt time.Time) error
+	RecordFailure(ctx context.Context, notificationID string, status constants.NotificationStatus, attempts int, nextAttemptAt time.Time, lastError string) error
+	GetInbox(ctx context.Context, userID string) ([]models.Notification, error)
+	MarkRead(ctx context.Context, userID, notificationID string, readAt time.Time) (bool, error)
+	GetPreferences(ctx context.Context, userID string) ([]models.NotificationPreference, error)
+	SetPreference(ctx context.Context, pref models.NotificationPreference) error
+}
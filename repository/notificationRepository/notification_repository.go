@@ -0,0 +1,124 @@
+package notificationRepository
+
+import (
+	"context"
+	"database/sql"
+	"sms/constants"
+	"sms/models"
+	"sms/repository/transaction"
+	"time"
+)
+
+const notificationColumns = `NotificationID, UserID, Event, Channel, Target, Subject, Body, Status, Attempts, NextAttemptAt, LastError, CreatedAt, SentAt, ReadAt`
+
+type NotificationRepo struct {
+	db transaction.Querier
+}
+
+func NewNotificationRepo(db transaction.Querier) *NotificationRepo {
+	return &NotificationRepo{db}
+}
+
+// AddNotifications writes notifications to the outbox in one transaction.
+func (nr *NotificationRepo) AddNotifications(ctx context.Context, notifications []models.Notification) error {
+	return transaction.RunInTx(ctx, nr.db, func(tx transaction.Querier) error {
+		for _, n := range notifications {
+			_, err := tx.ExecContext(ctx, `insert into notification (NotificationID, UserID, Event, Channel, Target, Subject, Body, Status, Attempts, NextAttemptAt, LastError, CreatedAt)
+			values(?,?,?,?,?,?,?,?,?,?,?,?)`,
+				n.NotificationID, n.UserID, n.Event, n.Channel, n.Target, n.Subject, n.Body, n.Status, n.Attempts, n.NextAttemptAt, n.LastError, n.CreatedAt)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetDueNotifications returns the pending notifications whose next attempt is due, oldest first.
+func (nr *NotificationRepo) GetDueNotifications(ctx context.Context, now time.Time, limit int) ([]models.Notification, error) {
+	stmt := `select ` + notificationColumns + ` from notification
+	where Status=? and NextAttemptAt<=? order by NextAttemptAt, CreatedAt limit ?`
+	return nr.queryNotifications(ctx, stmt, constants.NotificationPending, now, limit)
+}
+
+func (nr *NotificationRepo) MarkSent(ctx context.Context, notificationID string, sentAt time.Time) error {
+	_, err := nr.db.ExecContext(ctx, `update notification set Status=?, Attempts=Attempts+1, SentAt=?, LastError='' where NotificationID=?`,
+		constants.NotificationSent, sentAt, notificationID)
+	return err
+}
+
+func (nr *NotificationRepo) RecordFailure(ctx context.Context, notificationID string, status constants.NotificationStatus, attempts int, nextAttemptAt time.Time, lastError string) error {
+	_, err := nr.db.ExecContext(ctx, `update notification set Status=?, Attempts=?, NextAttemptAt=?, LastError=? where NotificationID=?`,
+		status, attempts, nextAttemptAt, lastError, notificationID)
+	return err
+}
+
+// GetInbox returns the delivered in-app notifications of a user, newest first.
+func (nr *NotificationRepo) GetInbox(ctx context.Context, userID string) ([]models.Notification, error) {
+	stmt := `select ` + notificationColumns + ` from notification
+	where UserID=? and Channel=? and Status=? order by CreatedAt desc`
+	return nr.queryNotifications(ctx, stmt, userID, constants.ChannelInApp, constants.NotificationSent)
+}
+
+// MarkRead reports whether the user has an in-app notification with the ID.
+func (nr *NotificationRepo) MarkRead(ctx context.Context, userID, notificationID string, readAt time.Time) (bool, error) {
+	res, err := nr.db.ExecContext(ctx, `update notification set ReadAt=coalesce(ReadAt, ?) where NotificationID=? and UserID=? and Channel=? and Status=?`,
+		readAt, notificationID, userID, constants.ChannelInApp, constants.NotificationSent)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+func (nr *NotificationRepo) GetPreferences(ctx context.Context, userID string) ([]models.NotificationPreference, error) {
+	rows, err := nr.db.QueryContext(ctx, `select UserID, Channel, Enabled, Target from notification_preference where UserID=? order by Channel`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var prefs []models.NotificationPreference
+	for rows.Next() {
+		var p models.NotificationPreference
+		if err := rows.Scan(&p.UserID, &p.Channel, &p.Enabled, &p.Target); err != nil {
+			return nil, err
+		}
+		prefs = append(prefs, p)
+	}
+	return prefs, rows.Err()
+}
+
+func (nr *NotificationRepo) SetPreference(ctx context.Context, pref models.NotificationPreference) error {
+	stmt := `insert into notification_preference values(?,?,?,?)
+	on conflict(UserID, Channel) do update set Enabled=excluded.Enabled, Target=excluded.Target`
+	_, err := nr.db.ExecContext(ctx, stmt, pref.UserID, pref.Channel, pref.Enabled, pref.Target)
+	return err
+}
+
+func (nr *NotificationRepo) queryNotifications(ctx context.Context, stmt string, args ...any) ([]models.Notification, error) {
+	rows, err := nr.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var notifications []models.Notification
+	for rows.Next() {
+		var n models.Notification
+		var sentAt, readAt sql.NullTime
+		err := rows.Scan(&n.NotificationID, &n.UserID, &n.Event, &n.Channel, &n.Target, &n.Subject, &n.Body, &n.Status,
+			&n.Attempts, &n.NextAttemptAt, &n.LastError, &n.CreatedAt, &sentAt, &readAt)
+		if err != nil {
+			return nil, err
+		}
+		if sentAt.Valid {
+			n.SentAt = &sentAt.Time
+		}
+		if readAt.Valid {
+			n.ReadAt = &readAt.Time
+		}
+		notifications = append(notifications, n)
+	}
+	return notifications, rows.Err()
+}
@@ -0,0 +1,184 @@
+package notificationRepository_test
+
+import (
+	"context"
+	"regexp"
+	"sms/constants"
+	"sms/models"
+	notificationRepository "sms/repository/notificationRepository"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+var notificationColumns = []string{"NotificationID", "UserID", "Event", "Channel", "Target", "Subject", "Body", "Status",
+	"Attempts", "NextAttemptAt", "LastError", "CreatedAt", "SentAt", "ReadAt"}
+
+func TestAddNotifications(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := notificationRepository.NewNotificationRepo(db)
+	now := time.Date(2026, 9, 1, 10, 0, 0, 0, time.UTC)
+	stmt := regexp.QuoteMeta(`insert into notification (NotificationID, UserID, Event, Channel, Target, Subject, Body, Status, Attempts, NextAttemptAt, LastError, CreatedAt)
+		values(?,?,?,?,?,?,?,?,?,?,?,?)`)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(stmt).WithArgs("n1", "u1", constants.EventGradePosted, constants.ChannelInApp, "", "subject", "body", constants.NotificationPending, 0, now, "", now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(stmt).WithArgs("n2", "u1", constants.EventGradePosted, constants.ChannelEmail, "u1@example.com", "subject", "body", constants.NotificationPending, 0, now, "", now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err = repo.AddNotifications(context.Background(), []models.Notification{
+		{NotificationID: "n1", UserID: "u1", Event: constants.EventGradePosted, Channel: constants.ChannelInApp, Subject: "subject", Body: "body",
+			Status: constants.NotificationPending, NextAttemptAt: now, CreatedAt: now},
+		{NotificationID: "n2", UserID: "u1", Event: constants.EventGradePosted, Channel: constants.ChannelEmail, Target: "u1@example.com", Subject: "subject", Body: "body",
+			Status: constants.NotificationPending, NextAttemptAt: now, CreatedAt: now},
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetDueNotifications(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := notificationRepository.NewNotificationRepo(db)
+	now := time.Date(2026, 9, 1, 10, 0, 0, 0, time.UTC)
+	query := regexp.QuoteMeta(`select NotificationID, UserID, Event, Channel, Target, Subject, Body, Status, Attempts, NextAttemptAt, LastError, CreatedAt, SentAt, ReadAt from notification
+	where Status=? and NextAttemptAt<=? order by NextAttemptAt, CreatedAt limit ?`)
+	rows := sqlmock.NewRows(notificationColumns).
+		AddRow("n1", "u1", "grade_posted", "email", "u1@example.com", "subject", "body", "pending", 2, now, "timeout", now.Add(-time.Hour), nil, nil)
+	mock.ExpectQuery(query).WithArgs(constants.NotificationPending, now, 50).WillReturnRows(rows)
+
+	due, err := repo.GetDueNotifications(context.Background(), now, 50)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(due) != 1 || due[0].Attempts != 2 || due[0].Channel != constants.ChannelEmail || due[0].SentAt != nil {
+		t.Errorf("unexpected notifications: %+v", due)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestMarkSentAndRecordFailure(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := notificationRepository.NewNotificationRepo(db)
+	now := time.Date(2026, 9, 1, 10, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec(regexp.QuoteMeta(`update notification set Status=?, Attempts=Attempts+1, SentAt=?, LastError='' where NotificationID=?`)).
+		WithArgs(constants.NotificationSent, now, "n1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`update notification set Status=?, Attempts=?, NextAttemptAt=?, LastError=? where NotificationID=?`)).
+		WithArgs(constants.NotificationPending, 1, now.Add(time.Minute), "timeout", "n2").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := repo.MarkSent(context.Background(), "n1", now); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := repo.RecordFailure(context.Background(), "n2", constants.NotificationPending, 1, now.Add(time.Minute), "timeout"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetInbox(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := notificationRepository.NewNotificationRepo(db)
+	now := time.Date(2026, 9, 1, 10, 0, 0, 0, time.UTC)
+	query := regexp.QuoteMeta(`select NotificationID, UserID, Event, Channel, Target, Subject, Body, Status, Attempts, NextAttemptAt, LastError, CreatedAt, SentAt, ReadAt from notification
+	where UserID=? and Channel=? and Status=? order by CreatedAt desc`)
+	rows := sqlmock.NewRows(notificationColumns).
+		AddRow("n2", "u1", "at_risk", "in_app", "", "subject", "body", "sent", 1, now, "", now, now, nil).
+		AddRow("n1", "u1", "grade_posted", "in_app", "", "subject", "body", "sent", 1, now, "", now, now, now)
+	mock.ExpectQuery(query).WithArgs("u1", constants.ChannelInApp, constants.NotificationSent).WillReturnRows(rows)
+
+	inbox, err := repo.GetInbox(context.Background(), "u1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(inbox) != 2 || inbox[0].ReadAt != nil || inbox[1].ReadAt == nil || inbox[0].SentAt == nil {
+		t.Errorf("unexpected inbox: %+v", inbox)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestMarkRead(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := notificationRepository.NewNotificationRepo(db)
+	now := time.Date(2026, 9, 1, 10, 0, 0, 0, time.UTC)
+	stmt := regexp.QuoteMeta(`update notification set ReadAt=coalesce(ReadAt, ?) where NotificationID=? and UserID=? and Channel=? and Status=?`)
+	mock.ExpectExec(stmt).WithArgs(now, "n1", "u1", constants.ChannelInApp, constants.NotificationSent).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(stmt).WithArgs(now, "n1", "u2", constants.ChannelInApp, constants.NotificationSent).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if found, err := repo.MarkRead(context.Background(), "u1", "n1", now); err != nil || !found {
+		t.Errorf("expected notification to be marked read, got %v, %v", found, err)
+	}
+	if found, err := repo.MarkRead(context.Background(), "u2", "n1", now); err != nil || found {
+		t.Errorf("expected another user's notification not to be found, got %v, %v", found, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestPreferences(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := notificationRepository.NewNotificationRepo(db)
+	mock.ExpectExec(regexp.QuoteMeta(`insert into notification_preference values(?,?,?,?)
+	on conflict(UserID, Channel) do update set Enabled=excluded.Enabled, Target=excluded.Target`)).
+		WithArgs("u1", constants.ChannelWebhook, true, "https://example.com/hook").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`select UserID, Channel, Enabled, Target from notification_preference where UserID=? order by Channel`)).
+		WithArgs("u1").WillReturnRows(sqlmock.NewRows([]string{"UserID", "Channel", "Enabled", "Target"}).AddRow("u1", "webhook", true, "https://example.com/hook"))
+
+	err = repo.SetPreference(context.Background(), models.NotificationPreference{UserID: "u1", Channel: constants.ChannelWebhook, Enabled: true, Target: "https://example.com/hook"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	prefs, err := repo.GetPreferences(context.Background(), "u1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(prefs) != 1 || !prefs[0].Enabled || prefs[0].Target != "https://example.com/hook" {
+		t.Errorf("unexpected preferences: %+v", prefs)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,16 @@
+package rolloverRepository
+
+import (
+	"context"
+	"sms/models"
+	"time"
+)
+
+//go:generate mockgen -destination=../../mocks/rollover_repo_mock.go -package=mocks -source=interface.go
+type RolloverRepositoryI interface {
+	GetClass(ctx context.Context, classID string) (*models.Class, error)
+	CountClassStudents(ctx context.Context, classID string) (int, error)
+	SaveRollover(ctx context.Context, rollover models.Rollover) error
+	GetRollover(ctx context.Context, rolloverID string) (*models.Rollover, error)
+	UndoRollover(ctx context.Context, rollover models.Rollover, undoneAt time.Time) error
+}
@@ -0,0 +1,146 @@
+package rolloverRepository
+
+import (
+	"context"
+	"database/sql"
+	"sms/apperrors"
+	"sms/models"
+	"sms/repository/transaction"
+	"strings"
+	"time"
+)
+
+// reasonSeparator joins the reasons of a student outcome into the single Reasons column.
+const reasonSeparator = "; "
+
+type RolloverRepo struct {
+	db transaction.Querier
+}
+
+func NewRolloverRepo(db transaction.Querier) *RolloverRepo {
+	return &RolloverRepo{db}
+}
+
+func (rr *RolloverRepo) GetClass(ctx context.Context, classID string) (*models.Class, error) {
+	stmt := `select ClassID, Capacity from class where ClassID=?`
+	var c models.Class
+	var capacity sql.NullInt64
+	err := rr.db.QueryRowContext(ctx, stmt, classID).Scan(&c.ClassID, &capacity)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	c.Capacity = int(capacity.Int64)
+	return &c, nil
+}
+
+func (rr *RolloverRepo) CountClassStudents(ctx context.Context, classID string) (int, error) {
+	var count int
+	err := rr.db.QueryRowContext(ctx, `select count(*) from students where ClassID=?`, classID).Scan(&count)
+	return count, err
+}
+
+// SaveRollover records a rollover and moves every promoted student in one transaction.
+func (rr *RolloverRepo) SaveRollover(ctx context.Context, rollover models.Rollover) error {
+	return transaction.RunInTx(ctx, rr.db, func(tx transaction.Querier) error {
+		_, err := tx.ExecContext(ctx, `insert into rollover (RolloverID, ClassID, semester, TargetClassID, CreatedBy, CreatedAt, UndoUntil) values(?,?,?,?,?,?,?)`,
+			rollover.RolloverID, rollover.ClassID, rollover.Semester, rollover.TargetClassID, rollover.CreatedBy, rollover.CreatedAt, rollover.UndoUntil)
+		if err != nil {
+			return err
+		}
+		for _, s := range rollover.Students {
+			_, err := tx.ExecContext(ctx, `insert into rollover_student values(?,?,?,?,?,?,?,?)`,
+				rollover.RolloverID, s.StudentID, s.FromClassID, s.ToClassID, s.FromSemester, s.ToSemester, s.Outcome, strings.Join(s.Reasons, reasonSeparator))
+			if err != nil {
+				return err
+			}
+			if s.FromClassID == s.ToClassID && s.FromSemester == s.ToSemester {
+				continue
+			}
+			if err := moveStudent(ctx, tx, s.StudentID, s.FromClassID, s.FromSemester, s.ToClassID, s.ToSemester); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (rr *RolloverRepo) GetRollover(ctx context.Context, rolloverID string) (*models.Rollover, error) {
+	stmt := `select RolloverID, ClassID, semester, TargetClassID, CreatedBy, CreatedAt, UndoUntil, UndoneAt from rollover where RolloverID=?`
+	var r models.Rollover
+	var undoneAt sql.NullTime
+	err := rr.db.QueryRowContext(ctx, stmt, rolloverID).Scan(&r.RolloverID, &r.ClassID, &r.Semester, &r.TargetClassID, &r.CreatedBy, &r.CreatedAt, &r.UndoUntil, &undoneAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if undoneAt.Valid {
+		r.UndoneAt = &undoneAt.Time
+	}
+
+	rows, err := rr.db.QueryContext(ctx, `select StudentID, FromClassID, ToClassID, FromSemester, ToSemester, Outcome, Reasons from rollover_student
+	where RolloverID=? order by StudentID`, rolloverID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var s models.RolloverStudent
+		var reasons string
+		if err := rows.Scan(&s.StudentID, &s.FromClassID, &s.ToClassID, &s.FromSemester, &s.ToSemester, &s.Outcome, &reasons); err != nil {
+			return nil, err
+		}
+		if reasons != "" {
+			s.Reasons = strings.Split(reasons, reasonSeparator)
+		}
+		r.Students = append(r.Students, s)
+	}
+	return &r, rows.Err()
+}
+
+// UndoRollover moves the students of a rollover back and marks it undone. It fails
+// without changing anything if a student was moved again after the rollover.
+func (rr *RolloverRepo) UndoRollover(ctx context.Context, rollover models.Rollover, undoneAt time.Time) error {
+	return transaction.RunInTx(ctx, rr.db, func(tx transaction.Querier) error {
+		for _, s := range rollover.Students {
+			if s.FromClassID == s.ToClassID && s.FromSemester == s.ToSemester {
+				continue
+			}
+			if err := moveStudent(ctx, tx, s.StudentID, s.ToClassID, s.ToSemester, s.FromClassID, s.FromSemester); err != nil {
+				return err
+			}
+		}
+		res, err := tx.ExecContext(ctx, `update rollover set UndoneAt=? where RolloverID=? and UndoneAt is null`, undoneAt, rollover.RolloverID)
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return err
+		} else if n != 1 {
+			return apperrors.Conflict("rollover %s was already undone", rollover.RolloverID)
+		}
+		return nil
+	})
+}
+
+// moveStudent updates a student only if they are still where the caller expects them.
+func moveStudent(ctx context.Context, tx transaction.Querier, studentID, fromClassID string, fromSemester int, toClassID string, toSemester int) error {
+	res, err := tx.ExecContext(ctx, `update students set ClassID=?, semester=? where StudentID=? and ClassID=? and semester=?`,
+		toClassID, toSemester, studentID, fromClassID, fromSemester)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n != 1 {
+		return apperrors.Conflict("student %s is no longer in class %s semester %d", studentID, fromClassID, fromSemester)
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package rolloverRepository_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"regexp"
+	"sms/apperrors"
+	"sms/constants"
+	"sms/models"
+	rolloverRepository "sms/repository/rolloverRepository"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+var rollover = models.Rollover{
+	RolloverID:    "r1",
+	ClassID:       "C1",
+	Semester:      1,
+	TargetClassID: "C2",
+	CreatedBy:     "admin1",
+	CreatedAt:     time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC),
+	UndoUntil:     time.Date(2026, 6, 2, 0, 0, 0, 0, time.UTC),
+	Students: []models.RolloverStudent{
+		{StudentID: "s1", FromClassID: "C1", ToClassID: "C2", FromSemester: 1, ToSemester: 2, Outcome: constants.Promoted},
+		{StudentID: "s2", FromClassID: "C1", ToClassID: "C1", FromSemester: 1, ToSemester: 1, Outcome: constants.Detained, Reasons: []string{"failed 3 subjects, at most 2 allowed"}},
+	},
+}
+
+func TestSaveRollover(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := rolloverRepository.NewRolloverRepo(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`insert into rollover (RolloverID, ClassID, semester, TargetClassID, CreatedBy, CreatedAt, UndoUntil) values(?,?,?,?,?,?,?)`)).
+		WithArgs("r1", "C1", 1, "C2", "admin1", rollover.CreatedAt, rollover.UndoUntil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`insert into rollover_student values(?,?,?,?,?,?,?,?)`)).
+		WithArgs("r1", "s1", "C1", "C2", 1, 2, constants.Promoted, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`update students set ClassID=?, semester=? where StudentID=? and ClassID=? and semester=?`)).
+		WithArgs("C2", 2, "s1", "C1", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`insert into rollover_student values(?,?,?,?,?,?,?,?)`)).
+		WithArgs("r1", "s2", "C1", "C1", 1, 1, constants.Detained, "failed 3 subjects, at most 2 allowed").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	if err := repo.SaveRollover(context.Background(), rollover); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestSaveRollover_StudentMoved(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := rolloverRepository.NewRolloverRepo(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`insert into rollover`)).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`insert into rollover_student values(?,?,?,?,?,?,?,?)`)).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`update students set ClassID=?, semester=? where StudentID=? and ClassID=? and semester=?`)).
+		WithArgs("C2", 2, "s1", "C1", 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	if err := repo.SaveRollover(context.Background(), rollover); !errors.Is(err, apperrors.ErrConflict) {
+		t.Errorf("expected a conflict, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetRollover(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := rolloverRepository.NewRolloverRepo(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`select RolloverID, ClassID, semester, TargetClassID, CreatedBy, CreatedAt, UndoUntil, UndoneAt from rollover where RolloverID=?`)).
+		WithArgs("r1").
+		WillReturnRows(sqlmock.NewRows([]string{"RolloverID", "ClassID", "semester", "TargetClassID", "CreatedBy", "CreatedAt", "UndoUntil", "UndoneAt"}).
+			AddRow("r1", "C1", 1, "C2", "admin1", rollover.CreatedAt, rollover.UndoUntil, nil))
+	mock.ExpectQuery(regexp.QuoteMeta(`select StudentID, FromClassID, ToClassID, FromSemester, ToSemester, Outcome, Reasons from rollover_student
+	where RolloverID=? order by StudentID`)).
+		WithArgs("r1").
+		WillReturnRows(sqlmock.NewRows([]string{"StudentID", "FromClassID", "ToClassID", "FromSemester", "ToSemester", "Outcome", "Reasons"}).
+			AddRow("s1", "C1", "C2", 1, 2, "promoted", "").
+			AddRow("s2", "C1", "C1", 1, 1, "detained", "failed 3 subjects, at most 2 allowed"))
+
+	got, err := repo.GetRollover(context.Background(), "r1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(*got, rollover) {
+		t.Errorf("expected %+v, got %+v", rollover, *got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestUndoRollover(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := rolloverRepository.NewRolloverRepo(db)
+	undoneAt := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`update students set ClassID=?, semester=? where StudentID=? and ClassID=? and semester=?`)).
+		WithArgs("C1", 1, "s1", "C2", 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`update rollover set UndoneAt=? where RolloverID=? and UndoneAt is null`)).
+		WithArgs(undoneAt, "r1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err := repo.UndoRollover(context.Background(), rollover, undoneAt); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestUndoRollover_AlreadyUndone(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := rolloverRepository.NewRolloverRepo(db)
+	undoneAt := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`update students set ClassID=?, semester=? where StudentID=? and ClassID=? and semester=?`)).
+		WithArgs("C1", 1, "s1", "C2", 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`update rollover set UndoneAt=? where RolloverID=? and UndoneAt is null`)).
+		WithArgs(undoneAt, "r1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	if err := repo.UndoRollover(context.Background(), rollover, undoneAt); !errors.Is(err, apperrors.ErrConflict) {
+		t.Errorf("expected a conflict, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetClass_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := rolloverRepository.NewRolloverRepo(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`select ClassID, Capacity from class where ClassID=?`)).
+		WithArgs("missing").
+		WillReturnRows(sqlmock.NewRows([]string{"ClassID", "Capacity"}))
+
+	class, err := repo.GetClass(context.Background(), "missing")
+	if err != nil || class != nil {
+		t.Errorf("expected nil class and error, got %+v, %v", class, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,17 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+)
+
+// Backup writes a consistent copy of the database to path while it stays in
+// use. Only SQLite can do this from inside the server; PostgreSQL databases are
+// backed up with pg_dump.
+func Backup(ctx context.Context, db *DB, path string) error {
+	if db.dialect != SQLite {
+		return fmt.Errorf("backing up %s databases is not supported, use pg_dump", db.dialect)
+	}
+	_, err := db.db.ExecContext(ctx, `vacuum into ?`, path)
+	return err
+}
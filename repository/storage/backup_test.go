@@ -0,0 +1,42 @@
+package storage_test
+
+import (
+	"context"
+	"path/filepath"
+	"sms/models"
+	"sms/repository/storage"
+	"sms/repository/subjectRepository"
+	"testing"
+)
+
+func TestBackup(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	if err := storage.Migrate(ctx, db); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	if err := subjectRepository.NewSubjectRepo(db).AddSubject(ctx, models.Subject{SubjectID: "MATH", SubjectName: "Engineering Mathematics"}); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "backup.db")
+	if err := storage.Backup(ctx, db, path); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	copied, err := storage.Open(storage.SQLite, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer copied.Close()
+	subjects, err := subjectRepository.NewSubjectRepo(copied).GetSubjects(ctx)
+	if err != nil || len(subjects) != 1 || subjects[0].SubjectID != "MATH" {
+		t.Errorf("expected the backup to hold the subject, got %+v, %v", subjects, err)
+	}
+
+	if err := storage.Backup(ctx, db, path); err == nil {
+		t.Error("expected an error when the backup file exists already")
+	}
+	if err := storage.Backup(ctx, storage.New(nil, storage.Postgres), path); err == nil {
+		t.Error("expected postgres backups to be refused")
+	}
+}
@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Dialect is the SQL flavour of a database the repositories run on.
+type Dialect string
+
+const (
+	SQLite   Dialect = "sqlite"
+	Postgres Dialect = "postgres"
+)
+
+// ParseDialect reads a driver name from config. An empty name means SQLite.
+func ParseDialect(name string) (Dialect, error) {
+	switch Dialect(strings.ToLower(name)) {
+	case "", SQLite:
+		return SQLite, nil
+	case Postgres, "postgresql", "pgx":
+		return Postgres, nil
+	}
+	return "", fmt.Errorf("unsupported database driver %q", name)
+}
+
+// driverName is the database/sql driver registered for the dialect.
+func (d Dialect) driverName() string {
+	if d == Postgres {
+		return "pgx"
+	}
+	return "sqlite"
+}
+
+// Rebind rewrites the ? placeholders the repositories are written with into
+// the dialect's own. Question marks inside quoted strings and identifiers are
+// left alone.
+func (d Dialect) Rebind(query string) string {
+	if d != Postgres || !strings.Contains(query, "?") {
+		return query
+	}
+	var b strings.Builder
+	b.Grow(len(query) + 8)
+	n := 0
+	var quote byte
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '?':
+			n++
+			b.WriteByte('$')
+			b.WriteString(strconv.Itoa(n))
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
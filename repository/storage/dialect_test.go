@@ -0,0 +1,61 @@
+package storage_test
+
+import (
+	"sms/repository/storage"
+	"testing"
+)
+
+func TestRebind(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect storage.Dialect
+		query   string
+		want    string
+	}{
+		{
+			name:    "sqlite keeps question marks",
+			dialect: storage.SQLite,
+			query:   `select Grade from grades where StudentID=? and semester=?`,
+			want:    `select Grade from grades where StudentID=? and semester=?`,
+		},
+		{
+			name:    "postgres numbers placeholders",
+			dialect: storage.Postgres,
+			query:   `insert into grades values(?,?,?,?)`,
+			want:    `insert into grades values($1,$2,$3,$4)`,
+		},
+		{
+			name:    "postgres skips quoted strings and identifiers",
+			dialect: storage.Postgres,
+			query:   `select "who?" from "user" where Name='why?' and Email=?`,
+			want:    `select "who?" from "user" where Name='why?' and Email=$1`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.dialect.Rebind(tt.query); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseDialect(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    storage.Dialect
+		wantErr bool
+	}{
+		{"", storage.SQLite, false},
+		{"sqlite", storage.SQLite, false},
+		{"postgres", storage.Postgres, false},
+		{"PostgreSQL", storage.Postgres, false},
+		{"mysql", "", true},
+	}
+	for _, tt := range tests {
+		got, err := storage.ParseDialect(tt.name)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseDialect(%q) = %q, %v", tt.name, got, err)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"errors"
+	"sms/apperrors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+type constraint int
+
+const (
+	noConstraint constraint = iota
+	uniqueConstraint
+	foreignKeyConstraint
+	valueConstraint
+)
+
+// violated reports which kind of constraint, if any, err says a statement broke.
+func violated(err error) constraint {
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) {
+		switch sqliteErr.Code() {
+		case sqlite3.SQLITE_CONSTRAINT_UNIQUE, sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY:
+			return uniqueConstraint
+		case sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY:
+			return foreignKeyConstraint
+		case sqlite3.SQLITE_CONSTRAINT_CHECK, sqlite3.SQLITE_CONSTRAINT_NOTNULL:
+			return valueConstraint
+		}
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23505":
+			return uniqueConstraint
+		case "23503":
+			return foreignKeyConstraint
+		case "23514", "23502":
+			return valueConstraint
+		}
+	}
+	return noConstraint
+}
+
+// translate turns constraint violations into typed errors, so a duplicate row is
+// reported as a conflict the same way on every dialect. Other errors are
+// returned as they are.
+func translate(err error) error {
+	switch violated(err) {
+	case uniqueConstraint:
+		return apperrors.Conflict("record already exists").Because(err)
+	case foreignKeyConstraint:
+		return apperrors.Validation("referenced record does not exist").Because(err)
+	case valueConstraint:
+		return apperrors.Validation("invalid or missing value").Because(err)
+	}
+	return err
+}
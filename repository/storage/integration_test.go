@@ -0,0 +1,671 @@
+package storage_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sms/apperrors"
+	"sms/constants"
+	"sms/models"
+	alertRepository "sms/repository/alertRepository"
+	assessmentRepository "sms/repository/assessmentRepository"
+	attendanceRepository "sms/repository/attendanceRepository"
+	"sms/repository/classRepository"
+	curriculumRepository "sms/repository/curriculumRepository"
+	enrollmentRepository "sms/repository/enrollmentRepository"
+	gradeRepository "sms/repository/gradesRepository"
+	guardianRepository "sms/repository/guardianRepository"
+	notificationRepository "sms/repository/notificationRepository"
+	rolloverRepository "sms/repository/rolloverRepository"
+	"sms/repository/storage"
+	studentsRepository "sms/repository/studentRepository"
+	"sms/repository/subjectRepository"
+	termRepository "sms/repository/termRepository"
+	timetableRepository "sms/repository/timetableRepository"
+	"sms/repository/transaction"
+	userrepository "sms/repository/userRepository"
+	webhookRepository "sms/repository/webhookRepository"
+	"testing"
+	"time"
+
+	embeddedpostgres "github.com/fergusstrange/embedded-postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+// The suite runs the repositories against a real database of every dialect.
+// SQLite always runs. Postgres runs against the server SMS_TEST_POSTGRES_DSN
+// points at, or against a throwaway local instance when SMS_TEST_POSTGRES is
+// "embedded", and is skipped otherwise.
+func TestRepositories(t *testing.T) {
+	backends := []struct {
+		dialect storage.Dialect
+		open    func(t *testing.T) *storage.DB
+	}{
+		{storage.SQLite, openSQLite},
+		{storage.Postgres, openPostgres},
+	}
+	for _, b := range backends {
+		t.Run(string(b.dialect), func(t *testing.T) {
+			db := b.open(t)
+			if err := storage.Migrate(context.Background(), db); err != nil {
+				t.Fatalf("failed to migrate: %v", err)
+			}
+			// a second run finds everything in place
+			if err := storage.Migrate(context.Background(), db); err != nil {
+				t.Fatalf("failed to migrate twice: %v", err)
+			}
+			seed(t, db)
+
+			t.Run("users", func(t *testing.T) { testUsers(t, db) })
+			t.Run("classes and subjects", func(t *testing.T) { testClassesAndSubjects(t, db) })
+			t.Run("grades", func(t *testing.T) { testGrades(t, db) })
+			t.Run("transactions", func(t *testing.T) { testTransactions(t, db) })
+			t.Run("constraints", func(t *testing.T) { testConstraints(t, db) })
+			t.Run("enrollments", func(t *testing.T) { testEnrollments(t, db) })
+			t.Run("attendance", func(t *testing.T) { testAttendance(t, db) })
+			t.Run("terms", func(t *testing.T) { testTerms(t, db) })
+			t.Run("notifications", func(t *testing.T) { testNotifications(t, db) })
+			t.Run("webhooks", func(t *testing.T) { testWebhooks(t, db) })
+			t.Run("curriculum", func(t *testing.T) { testCurriculum(t, db) })
+			t.Run("assessments", func(t *testing.T) { testAssessments(t, db) })
+			t.Run("alerts", func(t *testing.T) { testAlerts(t, db) })
+			t.Run("guardians", func(t *testing.T) { testGuardians(t, db) })
+			t.Run("timetable", func(t *testing.T) { testTimetable(t, db) })
+			t.Run("rollovers", func(t *testing.T) { testRollovers(t, db) })
+		})
+	}
+}
+
+func openSQLite(t *testing.T) *storage.DB {
+	db, err := storage.Open(storage.SQLite, filepath.Join(t.TempDir(), "sms.db"))
+	if err != nil {
+		t.Fatalf("failed to open sqlite: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// openPostgres gives the test a schema of its own so runs against a shared
+// server don't see each other's rows.
+func openPostgres(t *testing.T) *storage.DB {
+	dsn := os.Getenv("SMS_TEST_POSTGRES_DSN")
+	if dsn == "" && os.Getenv("SMS_TEST_POSTGRES") == "embedded" {
+		dsn = startEmbeddedPostgres(t)
+	}
+	if dsn == "" {
+		t.Skip("set SMS_TEST_POSTGRES_DSN or SMS_TEST_POSTGRES=embedded to run against postgres")
+	}
+
+	admin, err := storage.Open(storage.Postgres, dsn)
+	if err != nil {
+		t.Fatalf("failed to open postgres: %v", err)
+	}
+	t.Cleanup(func() { admin.Close() })
+	schema := "sms_test_" + uuid.NewString()[:8]
+	if _, err := admin.ExecContext(context.Background(), `create schema `+schema); err != nil {
+		t.Skipf("postgres is unavailable: %v", err)
+	}
+	t.Cleanup(func() { admin.ExecContext(context.Background(), `drop schema `+schema+` cascade`) })
+
+	config, err := pgx.ParseConfig(dsn)
+	if err != nil {
+		t.Fatalf("failed to parse dsn: %v", err)
+	}
+	config.RuntimeParams["search_path"] = schema
+	conn, err := sql.Open("pgx", stdlib.RegisterConnConfig(config))
+	if err != nil {
+		t.Fatalf("failed to open postgres: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return storage.New(conn, storage.Postgres)
+}
+
+// startEmbeddedPostgres runs a local server from downloaded binaries, skipping
+// the test when they can't be fetched or started.
+func startEmbeddedPostgres(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("no free port for embedded postgres: %v", err)
+	}
+	port := l.Addr().(*net.TCPAddr).Port
+	l.Close()
+
+	dir := t.TempDir()
+	pg := embeddedpostgres.NewDatabase(embeddedpostgres.DefaultConfig().
+		Port(uint32(port)).
+		RuntimePath(filepath.Join(dir, "runtime")).
+		DataPath(filepath.Join(dir, "data")).
+		Logger(nil))
+	if err := pg.Start(); err != nil {
+		t.Skipf("embedded postgres is unavailable: %v", err)
+	}
+	t.Cleanup(func() { pg.Stop() })
+	return fmt.Sprintf("host=127.0.0.1 port=%d user=postgres password=postgres dbname=postgres sslmode=disable", port)
+}
+
+// seed adds the class, subjects, students and faculty account the other
+// subtests build on.
+func seed(t *testing.T, db *storage.DB) {
+	ctx := context.Background()
+	if err := classRepository.NewClassRepo(db).AddClass(ctx, models.Class{ClassID: "C1", Capacity: 60}); err != nil {
+		t.Fatalf("failed to add class: %v", err)
+	}
+	subjects := subjectRepository.NewSubjectRepo(db)
+	for _, s := range []models.Subject{
+		{SubjectID: "MATH", SubjectName: "Engineering Mathematics"},
+		{SubjectID: "PHY", SubjectName: "Physics"},
+	} {
+		if err := subjects.AddSubject(ctx, s); err != nil {
+			t.Fatalf("failed to add subject: %v", err)
+		}
+	}
+	students := studentsRepository.NewStudentRepo(db)
+	for _, s := range []models.Students{
+		{StudentID: "s1", RollNumber: "101", Name: "Anu", ClassID: "C1", Semester: 1},
+		{StudentID: "s2", RollNumber: "102", Name: "Ravi", ClassID: "C1", Semester: 1},
+	} {
+		if err := students.AddStudent(ctx, s.StudentID, s.RollNumber, s.Name, s.ClassID, s.Semester); err != nil {
+			t.Fatalf("failed to add student: %v", err)
+		}
+	}
+	users := userrepository.NewUserRepo(db)
+	if err := users.AddUserWithRole(ctx, "f1", "Meera", "meera@example.com", "hash", constants.Faculty); err != nil {
+		t.Fatalf("failed to add user: %v", err)
+	}
+}
+
+func testUsers(t *testing.T, db *storage.DB) {
+	user, err := userrepository.NewUserRepo(db).GetUserByEmailID(context.Background(), "meera@example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user.UserID != "f1" || user.Role != constants.Faculty {
+		t.Errorf("unexpected user: %+v", user)
+	}
+
+	users := userrepository.NewUserRepo(db)
+	if err := users.UpdatePassword(context.Background(), "f1", "newhash"); err != nil {
+		t.Fatalf("failed to update password: %v", err)
+	}
+	if user, err := users.GetUserByID(context.Background(), "f1"); err != nil || user.Password != "newhash" {
+		t.Errorf("expected the new password, got %+v, %v", user, err)
+	}
+	if ids, err := users.GetUserIDsByRole(context.Background(), constants.Faculty); err != nil || len(ids) != 1 || ids[0] != "f1" {
+		t.Errorf("expected faculty f1, got %v (%v)", ids, err)
+	}
+}
+
+func testClassesAndSubjects(t *testing.T, db *storage.DB) {
+	ctx := context.Background()
+	classes := classRepository.NewClassRepo(db)
+	if err := classes.AddClass(ctx, models.Class{ClassID: "C0", Capacity: 30}); err != nil {
+		t.Fatalf("failed to add class: %v", err)
+	}
+	got, err := classes.GetClasses(ctx)
+	if err != nil || len(got) < 2 || got[0] != (models.Class{ClassID: "C0", Capacity: 30}) {
+		t.Errorf("unexpected classes: %+v (%v)", got, err)
+	}
+	if err := classes.AddClass(ctx, models.Class{ClassID: "C0"}); !errors.Is(err, apperrors.ErrConflict) {
+		t.Errorf("expected a conflict for a duplicate class, got %v", err)
+	}
+
+	subjectRepo := subjectRepository.NewSubjectRepo(db)
+	subjects, err := subjectRepo.GetSubjects(ctx)
+	if err != nil || len(subjects) != 2 || subjects[0].SubjectID != "MATH" {
+		t.Errorf("unexpected subjects: %+v (%v)", subjects, err)
+	}
+	if subject, err := subjectRepo.GetSubject(ctx, "PHY"); err != nil || subject == nil || subject.SubjectID != "PHY" {
+		t.Errorf("unexpected subject: %+v (%v)", subject, err)
+	}
+	if subject, err := subjectRepo.GetSubject(ctx, "NONE"); err != nil || subject != nil {
+		t.Errorf("expected no subject, got %+v (%v)", subject, err)
+	}
+}
+
+func testGrades(t *testing.T, db *storage.DB) {
+	ctx := context.Background()
+	repo := gradeRepository.NewGradeRepo(db)
+	for _, g := range []models.Grade{
+		{StudentID: "s1", SubjectID: "MATH", Grade: 80, Semester: 1},
+		{StudentID: "s1", SubjectID: "PHY", Grade: 90, Semester: 1},
+		{StudentID: "s2", SubjectID: "MATH", Grade: 70, Semester: 1},
+		{StudentID: "s2", SubjectID: "PHY", Grade: 75, Semester: 1},
+	} {
+		if err := repo.AddGrades(ctx, g.StudentID, g.SubjectID, g.Grade, g.Semester); err != nil {
+			t.Fatalf("failed to add grade: %v", err)
+		}
+	}
+
+	avg, err := repo.GetClassAverage(ctx, "C1", 1)
+	if err != nil || avg != 78.75 {
+		t.Errorf("expected class average 78.75, got %v (%v)", avg, err)
+	}
+	toppers, err := repo.GetToppers(ctx, "C1", 1, 1)
+	if err != nil || len(toppers) != 1 || toppers[0].StudentID != "s1" || toppers[0].Average != 85 {
+		t.Errorf("unexpected toppers: %+v (%v)", toppers, err)
+	}
+	averages, err := repo.GetStudentAverages(ctx, "C1", 1, "MATH")
+	if err != nil || len(averages) != 2 || averages[1].StudentID != "s2" || averages[1].Average != 70 {
+		t.Errorf("unexpected subject averages: %+v (%v)", averages, err)
+	}
+}
+
+func testTransactions(t *testing.T, db *storage.DB) {
+	ctx := context.Background()
+	grades := gradeRepository.NewGradeRepo(db)
+	tm := transaction.NewTxManager(db)
+
+	failure := errors.New("import failed")
+	err := tm.WithinTx(ctx, func(tx transaction.Querier) error {
+		if err := grades.WithTx(tx).UpdateGrade(ctx, "s1", "MATH", 10); err != nil {
+			return err
+		}
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("expected %v, got %v", failure, err)
+	}
+	if g, err := grades.GetGrade(ctx, "s1", "MATH"); err != nil || g.Grade != 80 {
+		t.Errorf("expected the update to be rolled back, got %+v (%v)", g, err)
+	}
+
+	err = tm.WithinTx(ctx, func(tx transaction.Querier) error {
+		return grades.WithTx(tx).UpdateGrade(ctx, "s1", "MATH", 82)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if g, err := grades.GetGrade(ctx, "s1", "MATH"); err != nil || g.Grade != 82 {
+		t.Errorf("expected the update to be committed, got %+v (%v)", g, err)
+	}
+}
+
+func testConstraints(t *testing.T, db *storage.DB) {
+	err := gradeRepository.NewGradeRepo(db).AddGrades(context.Background(), "s1", "MATH", 50, 1)
+	if !errors.Is(err, apperrors.ErrConflict) {
+		t.Errorf("expected a duplicate grade to conflict, got %v", err)
+	}
+}
+
+func testEnrollments(t *testing.T, db *storage.DB) {
+	ctx := context.Background()
+	repo := enrollmentRepository.NewEnrollmentRepo(db)
+	addedOn := time.Date(2026, 7, 1, 9, 0, 0, 0, time.UTC)
+	enrollments := []models.Enrollment{
+		{StudentID: "s1", SubjectID: "MATH", Semester: 1, Status: constants.Enrolled, AddedOn: addedOn},
+		{StudentID: "s1", SubjectID: "PHY", Semester: 1, Status: constants.Enrolled, AddedOn: addedOn},
+	}
+	if err := repo.AddEnrollments(ctx, enrollments); err != nil {
+		t.Fatalf("failed to enroll: %v", err)
+	}
+	if err := repo.DropEnrollment(ctx, "s1", "PHY", 1, addedOn.Add(24*time.Hour)); err != nil {
+		t.Fatalf("failed to drop: %v", err)
+	}
+	// enrolling again upserts over the dropped row
+	if err := repo.AddEnrollments(ctx, enrollments[1:]); err != nil {
+		t.Fatalf("failed to re-enroll: %v", err)
+	}
+
+	got, err := repo.GetStudentEnrollments(ctx, "s1", 0)
+	if err != nil || len(got) != 2 {
+		t.Fatalf("expected 2 enrollments, got %+v (%v)", got, err)
+	}
+	if got[1].Status != constants.Enrolled || got[1].DroppedOn != nil || !got[1].AddedOn.Equal(addedOn) {
+		t.Errorf("unexpected re-enrollment: %+v", got[1])
+	}
+}
+
+func testAttendance(t *testing.T, db *storage.DB) {
+	ctx := context.Background()
+	repo := attendanceRepository.NewAttendanceRepo(db)
+	session := models.AttendanceSession{SessionID: "a1", ClassID: "C1", SubjectID: "MATH", Semester: 1,
+		Date: time.Date(2026, 7, 6, 0, 0, 0, 0, time.UTC), CreatedBy: "f1"}
+	if err := repo.AddSession(ctx, session); err != nil {
+		t.Fatalf("failed to add session: %v", err)
+	}
+	marks := []models.AttendanceMark{
+		{SessionID: "a1", StudentID: "s1", Status: constants.Absent},
+		{SessionID: "a1", StudentID: "s2", Status: constants.Present},
+	}
+	if err := repo.MarkAttendance(ctx, marks); err != nil {
+		t.Fatalf("failed to mark: %v", err)
+	}
+	marks[0].Status = constants.Late
+	if err := repo.MarkAttendance(ctx, marks[:1]); err != nil {
+		t.Fatalf("failed to correct mark: %v", err)
+	}
+
+	counts, err := repo.GetClassStatusCounts(ctx, "C1", "", 1)
+	if err != nil || len(counts) < 2 {
+		t.Fatalf("expected counts of s1 and s2, got %+v (%v)", counts, err)
+	}
+	if counts[0].StudentID != "s1" || counts[0].Status != constants.Late || counts[0].Count != 1 {
+		t.Errorf("unexpected count: %+v", counts[0])
+	}
+
+	// a session s1 wasn't marked in counts as absent
+	session.SessionID, session.Date = "a2", session.Date.AddDate(0, 0, 1)
+	if err := repo.AddSession(ctx, session); err != nil {
+		t.Fatalf("failed to add session: %v", err)
+	}
+	if err := repo.MarkAttendance(ctx, []models.AttendanceMark{{SessionID: "a2", StudentID: "s2", Status: constants.Present}}); err != nil {
+		t.Fatalf("failed to mark: %v", err)
+	}
+	counts, err = repo.GetStudentStatusCounts(ctx, "s1", "MATH", 1)
+	if err != nil {
+		t.Fatalf("failed to count: %v", err)
+	}
+	want := map[constants.AttendanceStatus]int{constants.Late: 1, constants.Absent: 1}
+	if len(counts) != len(want) {
+		t.Fatalf("expected %v, got %+v", want, counts)
+	}
+	for _, c := range counts {
+		if c.Count != want[c.Status] {
+			t.Errorf("expected %v, got %+v", want, counts)
+		}
+	}
+}
+
+func testTerms(t *testing.T, db *storage.DB) {
+	ctx := context.Background()
+	repo := termRepository.NewTermRepo(db)
+	term := models.AcademicTerm{TermID: "t1", Year: 2026, TermNumber: 1, Semesters: []int{1, 3},
+		StartDate: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2026, 11, 30, 0, 0, 0, 0, time.UTC),
+		GradeEntryStart: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC), GradeEntryEnd: time.Date(2026, 12, 15, 0, 0, 0, 0, time.UTC),
+		Status: constants.TermOpen}
+	if err := repo.AddTerm(ctx, term); err != nil {
+		t.Fatalf("failed to add term: %v", err)
+	}
+
+	terms, err := repo.GetTerms(ctx, 0)
+	if err != nil || len(terms) != 1 || len(terms[0].Semesters) != 2 || !terms[0].GradeEntryEnd.Equal(term.GradeEntryEnd) {
+		t.Errorf("unexpected terms: %+v (%v)", terms, err)
+	}
+	terms, err = repo.GetTermsForSemester(ctx, 3)
+	if err != nil || len(terms) != 1 {
+		t.Errorf("expected the term for semester 3, got %+v (%v)", terms, err)
+	}
+}
+
+func testNotifications(t *testing.T, db *storage.DB) {
+	ctx := context.Background()
+	repo := notificationRepository.NewNotificationRepo(db)
+	now := time.Date(2026, 9, 1, 10, 0, 0, 0, time.UTC)
+	notifications := []models.Notification{
+		{NotificationID: "n1", UserID: "f1", Event: constants.EventGradePosted, Channel: constants.ChannelInApp,
+			Subject: "Grade posted", Body: "MATH: 80", Status: constants.NotificationPending, NextAttemptAt: now, CreatedAt: now},
+		{NotificationID: "n2", UserID: "f1", Event: constants.EventGradePosted, Channel: constants.ChannelInApp,
+			Subject: "Grade posted", Body: "PHY: 90", Status: constants.NotificationPending, NextAttemptAt: now.Add(time.Hour), CreatedAt: now},
+	}
+	if err := repo.AddNotifications(ctx, notifications); err != nil {
+		t.Fatalf("failed to add notifications: %v", err)
+	}
+
+	due, err := repo.GetDueNotifications(ctx, now, 10)
+	if err != nil || len(due) != 1 || due[0].NotificationID != "n1" {
+		t.Fatalf("expected n1 to be due, got %+v (%v)", due, err)
+	}
+	if err := repo.MarkSent(ctx, "n1", now); err != nil {
+		t.Fatalf("failed to mark sent: %v", err)
+	}
+	if ok, err := repo.MarkRead(ctx, "f1", "n1", now.Add(time.Minute)); err != nil || !ok {
+		t.Fatalf("expected n1 to be marked read, got %v (%v)", ok, err)
+	}
+	inbox, err := repo.GetInbox(ctx, "f1")
+	if err != nil || len(inbox) != 1 || inbox[0].ReadAt == nil || inbox[0].Attempts != 1 {
+		t.Errorf("unexpected inbox: %+v (%v)", inbox, err)
+	}
+}
+
+func testWebhooks(t *testing.T, db *storage.DB) {
+	ctx := context.Background()
+	repo := webhookRepository.NewWebhookRepo(db)
+	now := time.Date(2026, 9, 1, 10, 0, 0, 0, time.UTC)
+	for _, sub := range []models.WebhookSubscription{
+		{SubscriptionID: "w1", URL: "https://lms.example.com/hook", Secret: "secret", Active: true, CreatedAt: now,
+			Events: []constants.WebhookEvent{constants.WebhookGradePosted}},
+		{SubscriptionID: "w2", URL: "https://old.example.com/hook", Secret: "secret", Active: false, CreatedAt: now,
+			Events: []constants.WebhookEvent{constants.WebhookGradePosted}},
+	} {
+		if err := repo.AddSubscription(ctx, sub); err != nil {
+			t.Fatalf("failed to add subscription: %v", err)
+		}
+	}
+
+	subs, err := repo.GetSubscriptionsForEvent(ctx, constants.WebhookGradePosted)
+	if err != nil || len(subs) != 1 || subs[0].SubscriptionID != "w1" || !subs[0].Active {
+		t.Errorf("expected only the active subscription, got %+v (%v)", subs, err)
+	}
+}
+
+func testCurriculum(t *testing.T, db *storage.DB) {
+	ctx := context.Background()
+	repo := curriculumRepository.NewCurriculumRepo(db)
+	if err := repo.AddProgram(ctx, models.Program{ProgramID: "BTECH", Name: "B.Tech", Semesters: 8}); err != nil {
+		t.Fatalf("failed to add program: %v", err)
+	}
+	for _, ps := range []models.ProgramSubject{
+		{ProgramID: "BTECH", SubjectID: "MATH", Semester: 1, Kind: constants.CoreSubject, Credits: 4},
+		{ProgramID: "BTECH", SubjectID: "PHY", Semester: 2, Kind: constants.ElectiveSubject, Credits: 3, Prerequisites: []string{"MATH"}},
+	} {
+		if err := repo.AddProgramSubject(ctx, ps); err != nil {
+			t.Fatalf("failed to add program subject: %v", err)
+		}
+	}
+
+	subject, err := repo.GetProgramSubject(ctx, "BTECH", "PHY")
+	if err != nil || subject == nil || subject.Kind != constants.ElectiveSubject || len(subject.Prerequisites) != 1 || subject.Prerequisites[0] != "MATH" {
+		t.Errorf("unexpected program subject: %+v (%v)", subject, err)
+	}
+	subjects, err := repo.GetProgramSubjects(ctx, "BTECH", 1)
+	if err != nil || len(subjects) != 1 || subjects[0].SubjectID != "MATH" {
+		t.Errorf("expected MATH in semester 1, got %+v (%v)", subjects, err)
+	}
+
+	if err := repo.SetClassProgram(ctx, "C1", "BTECH"); err != nil {
+		t.Fatalf("failed to set program: %v", err)
+	}
+	if programID, err := repo.GetClassProgram(ctx, "C1"); err != nil || programID != "BTECH" {
+		t.Errorf("expected BTECH, got %q (%v)", programID, err)
+	}
+	// setting the same program again still finds the class
+	if err := repo.SetClassProgram(ctx, "C1", "BTECH"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := repo.SetClassProgram(ctx, "C9", "BTECH"); !errors.Is(err, apperrors.ErrNotFound) {
+		t.Errorf("expected not found for an unknown class, got %v", err)
+	}
+}
+
+func testAssessments(t *testing.T, db *storage.DB) {
+	ctx := context.Background()
+	repo := assessmentRepository.NewAssessmentRepo(db)
+	for _, a := range []models.Assessment{
+		{AssessmentID: "mid", SubjectID: "PHY", Semester: 2, Name: "Midterm", Kind: constants.Midterm, Weight: 30, MaxMarks: 50},
+		{AssessmentID: "final", SubjectID: "PHY", Semester: 2, Name: "Final", Kind: constants.Final, Weight: 70, MaxMarks: 100},
+	} {
+		if err := repo.AddAssessment(ctx, a); err != nil {
+			t.Fatalf("failed to add assessment: %v", err)
+		}
+	}
+	if err := repo.UpdateAssessment(ctx, "mid", 40, 60); err != nil {
+		t.Fatalf("failed to update assessment: %v", err)
+	}
+	if a, err := repo.GetAssessment(ctx, "mid"); err != nil || a == nil || a.Weight != 40 || a.MaxMarks != 60 {
+		t.Errorf("unexpected assessment: %+v (%v)", a, err)
+	}
+
+	// scores are saved in the caller's transaction and overwrite earlier ones
+	err := transaction.NewTxManager(db).WithinTx(ctx, func(tx transaction.Querier) error {
+		return repo.WithTx(tx).SaveScores(ctx, []models.AssessmentScore{
+			{AssessmentID: "mid", StudentID: "s1", Marks: 40},
+			{AssessmentID: "mid", StudentID: "s2", Marks: 30},
+		})
+	})
+	if err != nil {
+		t.Fatalf("failed to save scores: %v", err)
+	}
+	if err := repo.SaveScores(ctx, []models.AssessmentScore{{AssessmentID: "mid", StudentID: "s1", Marks: 45.5}}); err != nil {
+		t.Fatalf("failed to correct score: %v", err)
+	}
+	scores, err := repo.GetScores(ctx, "PHY", 2, "s1")
+	if err != nil || len(scores) != 1 || scores[0].Marks != 45.5 {
+		t.Errorf("expected the corrected score, got %+v (%v)", scores, err)
+	}
+	if scores, err := repo.GetScores(ctx, "PHY", 2); err != nil || len(scores) != 2 {
+		t.Errorf("expected the scores of both students, got %+v (%v)", scores, err)
+	}
+}
+
+func testAlerts(t *testing.T, db *storage.DB) {
+	ctx := context.Background()
+	repo := alertRepository.NewAlertRepo(db)
+	grades, err := repo.GetStudentGrades(ctx, 1)
+	if err != nil || len(grades) != 4 || grades[0].StudentID != "s1" || grades[0].ClassID != "C1" {
+		t.Errorf("unexpected grades: %+v (%v)", grades, err)
+	}
+	if semesters, err := repo.GetCurrentSemesters(ctx); err != nil || len(semesters) != 1 || semesters[0] != 1 {
+		t.Errorf("expected semester 1, got %v (%v)", semesters, err)
+	}
+
+	createdAt := time.Date(2026, 9, 1, 10, 0, 0, 0, time.UTC)
+	flag := models.AtRiskFlag{FlagID: "r1", StudentID: "s2", ClassID: "C1", Semester: 1,
+		Reasons: []string{"average 72.50 is below 75", "failed 1 subjects"}, CreatedAt: createdAt}
+	if err := repo.ReplaceFlags(ctx, 1, []models.AtRiskFlag{flag, {FlagID: "r0", StudentID: "s1", ClassID: "C1", Semester: 1, CreatedAt: createdAt}}); err != nil {
+		t.Fatalf("failed to save flags: %v", err)
+	}
+	// a new scan replaces the flags of the semester
+	if err := repo.ReplaceFlags(ctx, 1, []models.AtRiskFlag{flag}); err != nil {
+		t.Fatalf("failed to replace flags: %v", err)
+	}
+	flags, err := repo.GetFlags(ctx, 1)
+	if err != nil || len(flags) != 1 || flags[0].FlagID != "r1" || len(flags[0].Reasons) != 2 || !flags[0].CreatedAt.Equal(createdAt) {
+		t.Errorf("unexpected flags: %+v (%v)", flags, err)
+	}
+}
+
+func testGuardians(t *testing.T, db *storage.DB) {
+	ctx := context.Background()
+	if err := userrepository.NewUserRepo(db).AddUserWithRole(ctx, "g1", "Lakshmi", "lakshmi@example.com", "hash", constants.Guardian); err != nil {
+		t.Fatalf("failed to add guardian: %v", err)
+	}
+	repo := guardianRepository.NewGuardianRepo(db)
+	for _, studentID := range []string{"s2", "s1"} {
+		if err := repo.LinkStudent(ctx, models.GuardianLink{GuardianID: "g1", StudentID: studentID, Relationship: "mother"}); err != nil {
+			t.Fatalf("failed to link %s: %v", studentID, err)
+		}
+	}
+
+	students, err := repo.GetLinkedStudents(ctx, "g1")
+	if err != nil || len(students) != 2 || students[0].Name != "Anu" {
+		t.Errorf("expected Anu and Ravi, got %+v (%v)", students, err)
+	}
+	if ids, err := repo.GetGuardianIDs(ctx, "s1"); err != nil || len(ids) != 1 || ids[0] != "g1" {
+		t.Errorf("expected g1, got %v (%v)", ids, err)
+	}
+	if err := repo.UnlinkStudent(ctx, "g1", "s2"); err != nil {
+		t.Fatalf("failed to unlink: %v", err)
+	}
+	if linked, err := repo.IsLinked(ctx, "g1", "s2"); err != nil || linked {
+		t.Errorf("expected s2 to be unlinked, got %v (%v)", linked, err)
+	}
+	if linked, err := repo.IsLinked(ctx, "g1", "s1"); err != nil || !linked {
+		t.Errorf("expected s1 to stay linked, got %v (%v)", linked, err)
+	}
+}
+
+func testTimetable(t *testing.T, db *storage.DB) {
+	ctx := context.Background()
+	repo := timetableRepository.NewTimetableRepo(db)
+	if err := repo.AddRoom(ctx, models.Room{RoomID: "R1", Name: "Room 101", Capacity: 60}); err != nil {
+		t.Fatalf("failed to add room: %v", err)
+	}
+	if err := repo.SetClassHomeRoom(ctx, "C1", "R1"); err != nil {
+		t.Fatalf("failed to set home room: %v", err)
+	}
+	if roomID, err := repo.GetClassHomeRoom(ctx, "C1"); err != nil || roomID != "R1" {
+		t.Errorf("expected R1, got %q (%v)", roomID, err)
+	}
+	if err := repo.AddFacultyAssignment(ctx, models.FacultyAssignment{FacultyID: "f1", ClassID: "C1", SubjectID: "MATH"}); err != nil {
+		t.Fatalf("failed to assign faculty: %v", err)
+	}
+	if a, err := repo.GetFacultyAssignment(ctx, "f1", "C1", "MATH"); err != nil || a == nil {
+		t.Errorf("expected the assignment, got %+v (%v)", a, err)
+	}
+	if ids, err := repo.GetClassFacultyIDs(ctx, "C1"); err != nil || len(ids) != 1 || ids[0] != "f1" {
+		t.Errorf("expected f1, got %v (%v)", ids, err)
+	}
+
+	for _, slot := range []models.TimetableSlot{
+		{SlotID: "t2", ClassID: "C1", SubjectID: "MATH", FacultyID: "f1", RoomID: "R1", Weekday: time.Monday, StartTime: "10:00", EndTime: "11:00"},
+		{SlotID: "t1", ClassID: "C1", SubjectID: "MATH", FacultyID: "f1", RoomID: "R1", Weekday: time.Monday, StartTime: "09:00", EndTime: "10:00"},
+	} {
+		if err := repo.AddSlot(ctx, slot); err != nil {
+			t.Fatalf("failed to add slot: %v", err)
+		}
+	}
+	slots, err := repo.GetClassSlots(ctx, "C1")
+	if err != nil || len(slots) != 2 || slots[0].SlotID != "t1" || slots[0].SubjectName != "Engineering Mathematics" || slots[0].RoomName != "Room 101" || slots[0].Weekday != time.Monday {
+		t.Errorf("unexpected class slots: %+v (%v)", slots, err)
+	}
+	overlapping, err := repo.GetOverlappingSlots(ctx, models.TimetableSlot{ClassID: "C9", FacultyID: "f1", RoomID: "R9", Weekday: time.Monday, StartTime: "09:30", EndTime: "10:30"})
+	if err != nil || len(overlapping) != 2 {
+		t.Errorf("expected both slots of f1 to overlap, got %+v (%v)", overlapping, err)
+	}
+	if err := repo.DeleteSlot(ctx, "t2"); err != nil {
+		t.Fatalf("failed to delete slot: %v", err)
+	}
+	if slots, err := repo.GetFacultySlots(ctx, "f1"); err != nil || len(slots) != 1 {
+		t.Errorf("expected one slot left, got %+v (%v)", slots, err)
+	}
+}
+
+func testRollovers(t *testing.T, db *storage.DB) {
+	ctx := context.Background()
+	if err := classRepository.NewClassRepo(db).AddClass(ctx, models.Class{ClassID: "C2", Capacity: 60}); err != nil {
+		t.Fatalf("failed to add class: %v", err)
+	}
+	repo := rolloverRepository.NewRolloverRepo(db)
+	createdAt := time.Date(2026, 12, 20, 10, 0, 0, 0, time.UTC)
+	rollover := models.Rollover{RolloverID: "ro1", ClassID: "C1", Semester: 1, TargetClassID: "C2", CreatedBy: "f1",
+		CreatedAt: createdAt, UndoUntil: createdAt.Add(24 * time.Hour),
+		Students: []models.RolloverStudent{
+			{StudentID: "s1", FromClassID: "C1", ToClassID: "C2", FromSemester: 1, ToSemester: 2, Outcome: constants.Promoted},
+			{StudentID: "s2", FromClassID: "C1", ToClassID: "C1", FromSemester: 1, ToSemester: 1, Outcome: constants.Detained,
+				Reasons: []string{"failed 2 subjects"}},
+		}}
+	if err := repo.SaveRollover(ctx, rollover); err != nil {
+		t.Fatalf("failed to save rollover: %v", err)
+	}
+	if n, err := repo.CountClassStudents(ctx, "C2"); err != nil || n != 1 {
+		t.Errorf("expected s1 to move to C2, got %d (%v)", n, err)
+	}
+	// s1 keeps the attendance of the semester spent in C1
+	attendance := attendanceRepository.NewAttendanceRepo(db)
+	if counts, err := attendance.GetStudentStatusCounts(ctx, "s1", "MATH", 1); err != nil || len(counts) != 2 {
+		t.Errorf("expected s1's marks in C1, got %+v (%v)", counts, err)
+	}
+	if counts, err := attendance.GetClassStatusCounts(ctx, "C1", "", 1); err != nil || len(counts) == 0 || counts[0].StudentID != "s1" {
+		t.Errorf("expected s1 among C1's students in semester 1, got %+v (%v)", counts, err)
+	}
+
+	saved, err := repo.GetRollover(ctx, "ro1")
+	if err != nil || saved == nil || len(saved.Students) != 2 || saved.Students[1].Reasons[0] != "failed 2 subjects" || saved.UndoneAt != nil {
+		t.Fatalf("unexpected rollover: %+v (%v)", saved, err)
+	}
+	if err := repo.UndoRollover(ctx, *saved, createdAt.Add(time.Hour)); err != nil {
+		t.Fatalf("failed to undo: %v", err)
+	}
+	if n, err := repo.CountClassStudents(ctx, "C2"); err != nil || n != 0 {
+		t.Errorf("expected s1 back in C1, got %d in C2 (%v)", n, err)
+	}
+	if err := repo.UndoRollover(ctx, *saved, createdAt.Add(2*time.Hour)); !errors.Is(err, apperrors.ErrConflict) {
+		t.Errorf("expected a second undo to conflict, got %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+)
+
+// Each dialect has its own directory of migrations. A file is one schema
+// version, numbered by the digits its name starts with, and runs once.
+//
+//go:embed migrations
+var migrations embed.FS
+
+// Migrate brings the schema up to the latest version. The versions already
+// applied are kept in schema_migration; a database from before that table
+// existed starts at version 0, which is why the first migration only creates the
+// baseline tables that are missing. Each version runs in its own transaction
+// together with its schema_migration row, so a failed one is retried whole on
+// the next run.
+func Migrate(ctx context.Context, db *DB) error {
+	if _, err := db.ExecContext(ctx, `create table if not exists schema_migration(
+Version integer PRIMARY KEY,
+Name Text not null
+)`); err != nil {
+		return err
+	}
+	applied, err := appliedVersions(ctx, db)
+	if err != nil {
+		return err
+	}
+	dir := "migrations/" + string(db.dialect)
+	files, err := fs.ReadDir(migrations, dir)
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		version, err := migrationVersion(f.Name())
+		if err != nil {
+			return err
+		}
+		if applied[version] {
+			continue
+		}
+		script, err := migrations.ReadFile(dir + "/" + f.Name())
+		if err != nil {
+			return err
+		}
+		if err := migrateTo(ctx, db, version, f.Name(), string(script)); err != nil {
+			return fmt.Errorf("migration %s: %w", f.Name(), err)
+		}
+	}
+	return nil
+}
+
+func appliedVersions(ctx context.Context, db *DB) (map[int]bool, error) {
+	rows, err := db.QueryContext(ctx, `select Version from schema_migration`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	applied := map[int]bool{}
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		applied[version] = true
+	}
+	return applied, rows.Err()
+}
+
+func migrationVersion(name string) (int, error) {
+	digits, _, _ := strings.Cut(name, "_")
+	version, err := strconv.Atoi(digits)
+	if err != nil {
+		return 0, fmt.Errorf("migration %s is not numbered", name)
+	}
+	return version, nil
+}
+
+func migrateTo(ctx context.Context, db *DB, version int, name, script string) error {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	for _, stmt := range strings.Split(script, ";") {
+		if strings.TrimSpace(stmt) == "" {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `insert into schema_migration(Version, Name) values(?, ?)`, version, name); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
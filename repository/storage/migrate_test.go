@@ -0,0 +1,105 @@
+package storage_test
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"sms/constants"
+	"sms/models"
+	"sms/repository/classRepository"
+	curriculumRepository "sms/repository/curriculumRepository"
+	"sms/repository/storage"
+	"sms/repository/subjectRepository"
+	userrepository "sms/repository/userRepository"
+	"testing"
+)
+
+// baselineSchema is the schema databases were created with before Migrate kept
+// versions, rows included.
+const baselineSchema = `
+CREATE TABLE subject(
+SubjectID Text PRIMARY KEY,
+SubjectName Text
+);
+CREATE TABLE user(
+UserID Text PRIMARY Key,
+Name Text Not NUll,
+Email Text Not Null,
+Password Text Not Null,
+Role Text Not Null Check(Role In ('faculty','student','admin'))DEFAULT 'faculty'
+);
+CREATE TABLE class(
+ClassID Text PRIMARY Key,
+Capacity Integer,
+OccupiedBy Text
+);
+CREATE TABLE students(
+StudentID text PRIMARY KEY,
+Name text not null,
+RollNumber Text UNIQUE NOT NULL,
+ClassID Text not null,
+semester integer not null,
+FOREIGN key (ClassID) REFERENCES class(ClassID)
+);
+CREATE TABLE grades(
+SubjectID text not null,
+StudentID Text not null,
+Grade INTEGER not NULL,
+semester integer not null,
+PRIMARY KEY(SubjectID,StudentID),
+FOREIGN Key(SubjectID) REFERENCES subject(SubjectID),
+FOREIGN Key(StudentID) REFERENCES students(StudentID)
+);
+insert into subject values('MATH', 'Engineering Mathematics');
+insert into user values('admin', 'Rohith', 'admin@example.com', 'hash', 'admin');
+insert into class (ClassID, Capacity) values('C1', 60);
+`
+
+func TestMigrate_FromBaseline(t *testing.T) {
+	ctx := context.Background()
+	conn, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "sms.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	if _, err := conn.ExecContext(ctx, baselineSchema); err != nil {
+		t.Fatalf("failed to create the baseline schema: %v", err)
+	}
+	db := storage.New(conn, storage.SQLite)
+
+	if err := storage.Migrate(ctx, db); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	if err := storage.Migrate(ctx, db); err != nil {
+		t.Fatalf("failed to migrate twice: %v", err)
+	}
+	var versions int
+	if err := conn.QueryRowContext(ctx, `select count(*) from schema_migration`).Scan(&versions); err != nil || versions != 4 {
+		t.Errorf("expected 4 versions applied, got %d, %v", versions, err)
+	}
+
+	subjects, err := subjectRepository.NewSubjectRepo(db).GetSubjects(ctx)
+	if err != nil || len(subjects) != 1 || subjects[0].SubjectName != "Engineering Mathematics" {
+		t.Errorf("expected the subject to keep its name, got %+v, %v", subjects, err)
+	}
+
+	users := userrepository.NewUserRepo(db)
+	if u, err := users.GetUserByID(ctx, "admin"); err != nil || u == nil || u.Role != constants.Admin {
+		t.Errorf("expected the admin to survive the user rebuild, got %+v, %v", u, err)
+	}
+	if err := users.AddUserWithRole(ctx, "g1", "Guardian", "g1@example.com", "hash", constants.Guardian); err != nil {
+		t.Errorf("expected guardians to be accepted, got %v", err)
+	}
+
+	curriculum := curriculumRepository.NewCurriculumRepo(db)
+	if err := curriculum.AddProgram(ctx, models.Program{ProgramID: "BTECH", Name: "B.Tech", Semesters: 8}); err != nil {
+		t.Fatalf("failed to add program: %v", err)
+	}
+	if err := curriculum.SetClassProgram(ctx, "C1", "BTECH"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	classes, err := classRepository.NewClassRepo(db).GetClasses(ctx)
+	if err != nil || len(classes) != 1 || classes[0].ProgramID != "BTECH" || classes[0].Capacity != 60 {
+		t.Errorf("expected C1 to keep its capacity and take the program, got %+v, %v", classes, err)
+	}
+}
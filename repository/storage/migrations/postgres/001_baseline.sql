@@ -0,0 +1,37 @@
+create table if not exists "user"(
+UserID Text PRIMARY Key,
+Name Text Not NUll,
+Email Text Not Null,
+Password Text Not Null,
+Role Text Not Null Check(Role In ('faculty','student','admin')) DEFAULT 'faculty'
+);
+
+create table if not exists class(
+ClassID Text PRIMARY Key,
+Capacity Integer,
+OccupiedBy Text
+);
+
+create table if not exists subject(
+SubjectID Text PRIMARY KEY,
+SubjectName Text
+);
+
+create table if not exists students(
+StudentID text PRIMARY KEY,
+Name text not null,
+RollNumber Text UNIQUE NOT NULL,
+ClassID Text not null,
+semester integer not null,
+FOREIGN key (ClassID) REFERENCES class(ClassID)
+);
+
+create table if not exists grades(
+SubjectID text not null,
+StudentID Text not null,
+Grade INTEGER not NULL,
+semester integer not null,
+PRIMARY KEY(SubjectID,StudentID),
+FOREIGN Key(SubjectID) REFERENCES subject(SubjectID),
+FOREIGN Key(StudentID) REFERENCES students(StudentID)
+);
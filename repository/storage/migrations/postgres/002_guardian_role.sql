@@ -0,0 +1,3 @@
+alter table "user" drop constraint if exists user_role_check;
+
+alter table "user" add constraint user_role_check Check(Role In ('faculty','student','admin','guardian'));
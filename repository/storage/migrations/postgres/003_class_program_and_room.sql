@@ -0,0 +1,15 @@
+create table if not exists program(
+ProgramID Text PRIMARY KEY,
+Name Text not null UNIQUE,
+Semesters integer not null
+);
+
+create table if not exists room(
+RoomID Text PRIMARY KEY,
+Name Text not null UNIQUE,
+Capacity integer not null
+);
+
+alter table class add column if not exists ProgramID Text REFERENCES program(ProgramID);
+
+alter table class add column if not exists HomeRoomID Text REFERENCES room(RoomID);
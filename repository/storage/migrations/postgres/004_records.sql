@@ -0,0 +1,228 @@
+create table if not exists at_risk_flag(
+FlagID Text PRIMARY KEY,
+StudentID Text not null,
+ClassID Text not null,
+semester integer not null,
+Reasons Text not null,
+CreatedAt timestamptz not null,
+FOREIGN Key(StudentID) REFERENCES students(StudentID)
+);
+
+create table if not exists attendance_session(
+SessionID Text PRIMARY KEY,
+ClassID Text not null,
+SubjectID Text not null,
+semester integer not null,
+Date timestamptz not null,
+CreatedBy Text not null,
+FOREIGN Key(ClassID) REFERENCES class(ClassID),
+FOREIGN Key(SubjectID) REFERENCES subject(SubjectID),
+FOREIGN Key(CreatedBy) REFERENCES "user"(UserID)
+);
+
+create table if not exists attendance(
+SessionID Text not null,
+StudentID Text not null,
+Status Text not null Check(Status In ('present','absent','late','excused')),
+PRIMARY KEY(SessionID,StudentID),
+FOREIGN Key(SessionID) REFERENCES attendance_session(SessionID),
+FOREIGN Key(StudentID) REFERENCES students(StudentID)
+);
+
+create table if not exists enrollment(
+StudentID Text not null,
+SubjectID Text not null,
+semester integer not null,
+Status Text not null Check(Status In ('enrolled','dropped','completed')) DEFAULT 'enrolled',
+AddedOn timestamptz not null,
+DroppedOn timestamptz,
+PRIMARY KEY(StudentID,SubjectID,semester),
+FOREIGN Key(StudentID) REFERENCES students(StudentID),
+FOREIGN Key(SubjectID) REFERENCES subject(SubjectID)
+);
+
+create table if not exists program_subject(
+ProgramID Text not null,
+SubjectID Text not null,
+semester integer not null,
+Kind Text not null Check(Kind In ('core','elective')),
+Credits integer not null,
+PRIMARY KEY(ProgramID,SubjectID),
+FOREIGN Key(ProgramID) REFERENCES program(ProgramID),
+FOREIGN Key(SubjectID) REFERENCES subject(SubjectID)
+);
+
+create table if not exists subject_prerequisite(
+ProgramID Text not null,
+SubjectID Text not null,
+PrerequisiteID Text not null,
+PRIMARY KEY(ProgramID,SubjectID,PrerequisiteID),
+FOREIGN Key(ProgramID,SubjectID) REFERENCES program_subject(ProgramID,SubjectID),
+FOREIGN Key(PrerequisiteID) REFERENCES subject(SubjectID)
+);
+
+create table if not exists rollover(
+RolloverID Text PRIMARY KEY,
+ClassID Text not null,
+semester integer not null,
+TargetClassID Text not null,
+CreatedBy Text not null,
+CreatedAt timestamptz not null,
+UndoUntil timestamptz not null,
+UndoneAt timestamptz,
+FOREIGN Key(ClassID) REFERENCES class(ClassID),
+FOREIGN Key(TargetClassID) REFERENCES class(ClassID),
+FOREIGN Key(CreatedBy) REFERENCES "user"(UserID)
+);
+
+create table if not exists rollover_student(
+RolloverID Text not null,
+StudentID Text not null,
+FromClassID Text not null,
+ToClassID Text not null,
+FromSemester integer not null,
+ToSemester integer not null,
+Outcome Text not null Check(Outcome In ('promoted','flagged','detained')),
+Reasons Text not null,
+PRIMARY KEY(RolloverID,StudentID),
+FOREIGN Key(RolloverID) REFERENCES rollover(RolloverID),
+FOREIGN Key(StudentID) REFERENCES students(StudentID)
+);
+
+create table if not exists academic_term(
+TermID Text PRIMARY KEY,
+Year integer not null,
+TermNumber integer not null,
+StartDate timestamptz not null,
+EndDate timestamptz not null,
+GradeEntryStart timestamptz not null,
+GradeEntryEnd timestamptz not null,
+Status Text not null Check(Status In ('open','closed')) DEFAULT 'open',
+UNIQUE(Year,TermNumber)
+);
+
+create table if not exists academic_term_semester(
+TermID Text not null,
+semester integer not null,
+PRIMARY KEY(TermID,semester),
+FOREIGN Key(TermID) REFERENCES academic_term(TermID)
+);
+
+create table if not exists assessment(
+AssessmentID Text PRIMARY KEY,
+SubjectID Text not null,
+semester integer not null,
+Name Text not null,
+Kind Text not null Check(Kind In ('midterm','assignment','lab','final')),
+Weight double precision not null,
+MaxMarks integer not null,
+UNIQUE(SubjectID,semester,Name),
+FOREIGN Key(SubjectID) REFERENCES subject(SubjectID)
+);
+
+create table if not exists assessment_score(
+AssessmentID Text not null,
+StudentID Text not null,
+Marks double precision not null,
+PRIMARY KEY(AssessmentID,StudentID),
+FOREIGN Key(AssessmentID) REFERENCES assessment(AssessmentID),
+FOREIGN Key(StudentID) REFERENCES students(StudentID)
+);
+
+create table if not exists faculty_assignment(
+FacultyID Text not null,
+ClassID Text not null,
+SubjectID Text not null,
+PRIMARY KEY(FacultyID,ClassID,SubjectID),
+FOREIGN Key(FacultyID) REFERENCES "user"(UserID),
+FOREIGN Key(ClassID) REFERENCES class(ClassID),
+FOREIGN Key(SubjectID) REFERENCES subject(SubjectID)
+);
+
+create table if not exists timetable_slot(
+SlotID Text PRIMARY KEY,
+ClassID Text not null,
+SubjectID Text not null,
+FacultyID Text not null,
+RoomID Text not null,
+Weekday integer not null Check(Weekday between 0 and 6),
+StartTime Text not null,
+EndTime Text not null,
+FOREIGN Key(FacultyID,ClassID,SubjectID) REFERENCES faculty_assignment(FacultyID,ClassID,SubjectID),
+FOREIGN Key(RoomID) REFERENCES room(RoomID)
+);
+
+create table if not exists guardian_student(
+GuardianID Text not null,
+StudentID Text not null,
+Relationship Text,
+PRIMARY KEY(GuardianID,StudentID),
+FOREIGN Key(GuardianID) REFERENCES "user"(UserID),
+FOREIGN Key(StudentID) REFERENCES students(StudentID)
+);
+
+create table if not exists notification(
+NotificationID Text PRIMARY KEY,
+UserID Text not null,
+Event Text not null,
+Channel Text not null Check(Channel In ('in_app','email','webhook')),
+Target Text not null DEFAULT '',
+Subject Text not null,
+Body Text not null,
+Status Text not null Check(Status In ('pending','sent','failed')) DEFAULT 'pending',
+Attempts integer not null DEFAULT 0,
+NextAttemptAt timestamptz not null,
+LastError Text not null DEFAULT '',
+CreatedAt timestamptz not null,
+SentAt timestamptz,
+ReadAt timestamptz,
+FOREIGN Key(UserID) REFERENCES "user"(UserID)
+);
+create index if not exists notification_due on notification(Status, NextAttemptAt);
+
+create table if not exists notification_preference(
+UserID Text not null,
+Channel Text not null Check(Channel In ('in_app','email','webhook')),
+Enabled Boolean not null,
+Target Text not null DEFAULT '',
+PRIMARY KEY(UserID,Channel),
+FOREIGN Key(UserID) REFERENCES "user"(UserID)
+);
+
+create table if not exists webhook_subscription(
+SubscriptionID Text PRIMARY KEY,
+URL Text not null,
+Secret Text not null,
+Active Boolean not null DEFAULT true,
+CreatedAt timestamptz not null
+);
+
+create table if not exists webhook_subscription_event(
+SubscriptionID Text not null,
+Event Text not null Check(Event In ('grade.posted','grade.changed','student.created','student.updated')),
+PRIMARY KEY(SubscriptionID,Event),
+FOREIGN Key(SubscriptionID) REFERENCES webhook_subscription(SubscriptionID)
+);
+
+create table if not exists webhook_delivery(
+DeliveryID Text PRIMARY KEY,
+SubscriptionID Text not null,
+Event Text not null,
+Payload Text not null,
+Status Text not null Check(Status In ('pending','delivered','failed')) DEFAULT 'pending',
+Attempts integer not null DEFAULT 0,
+NextAttemptAt timestamptz not null,
+CreatedAt timestamptz not null,
+DeliveredAt timestamptz,
+FOREIGN Key(SubscriptionID) REFERENCES webhook_subscription(SubscriptionID)
+);
+create index if not exists webhook_delivery_due on webhook_delivery(Status, NextAttemptAt);
+
+create table if not exists webhook_attempt(
+DeliveryID Text not null,
+AttemptedAt timestamptz not null,
+StatusCode integer not null,
+Error Text not null DEFAULT '',
+DurationMS integer not null,
+FOREIGN Key(DeliveryID) REFERENCES webhook_delivery(DeliveryID)
+);
@@ -0,0 +1,15 @@
+-- sqlite can't alter a check constraint in place, so the table is rebuilt.
+create table user_new(
+UserID Text PRIMARY Key,
+Name Text Not NUll,
+Email Text Not Null,
+Password Text Not Null,
+Role Text Not Null Check(Role In ('faculty','student','admin','guardian')) DEFAULT 'faculty'
+);
+
+insert into user_new(UserID, Name, Email, Password, Role)
+select UserID, Name, Email, Password, Role from "user";
+
+drop table "user";
+
+alter table user_new rename to "user";
@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"sms/repository/transaction"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	_ "modernc.org/sqlite"
+)
+
+// DB is a connection pool that speaks its dialect. The repositories write their
+// statements once with ? placeholders and DB rebinds them before they reach the
+// driver, on the pool and inside transactions begun from it. Constraint
+// violations come back as apperrors, whatever the driver.
+type DB struct {
+	db      *sql.DB
+	dialect Dialect
+}
+
+// Open opens a pool for the dialect. Like sql.Open it does not connect, so a bad
+// DSN shows up on the first Ping or query.
+func Open(dialect Dialect, dsn string) (*DB, error) {
+	db, err := sql.Open(dialect.driverName(), dsn)
+	if err != nil {
+		return nil, err
+	}
+	return New(db, dialect), nil
+}
+
+// New wraps a pool that is already open.
+func New(db *sql.DB, dialect Dialect) *DB {
+	return &DB{db, dialect}
+}
+
+func (db *DB) Dialect() Dialect {
+	return db.dialect
+}
+
+func (db *DB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	res, err := db.db.ExecContext(ctx, db.dialect.Rebind(query), args...)
+	return res, translate(err)
+}
+
+func (db *DB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return db.db.QueryContext(ctx, db.dialect.Rebind(query), args...)
+}
+
+func (db *DB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return db.db.QueryRowContext(ctx, db.dialect.Rebind(query), args...)
+}
+
+// Begin starts a transaction whose statements are rebound like the pool's.
+func (db *DB) Begin(ctx context.Context) (transaction.Tx, error) {
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{tx, db.dialect}, nil
+}
+
+func (db *DB) Ping() error {
+	return db.db.Ping()
+}
+
+func (db *DB) PingContext(ctx context.Context) error {
+	return db.db.PingContext(ctx)
+}
+
+func (db *DB) Close() error {
+	return db.db.Close()
+}
+
+// Tx is a transaction begun from a DB.
+type Tx struct {
+	tx      *sql.Tx
+	dialect Dialect
+}
+
+func (tx *Tx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	res, err := tx.tx.ExecContext(ctx, tx.dialect.Rebind(query), args...)
+	return res, translate(err)
+}
+
+func (tx *Tx) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return tx.tx.QueryContext(ctx, tx.dialect.Rebind(query), args...)
+}
+
+func (tx *Tx) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return tx.tx.QueryRowContext(ctx, tx.dialect.Rebind(query), args...)
+}
+
+func (tx *Tx) Commit() error {
+	return tx.tx.Commit()
+}
+
+func (tx *Tx) Rollback() error {
+	return tx.tx.Rollback()
+}
@@ -1,11 +1,17 @@
 package studentsRepository
 
-import "sms/models"
+import (
+	"context"
+	"sms/models"
+	"sms/repository/transaction"
+)
 
 //go:generate mockgen -destination=../../mocks/student_repo_mock.go -package=mocks -source=interface.go
 type StudentRepositoryI interface {
-	AddStudent(uuid, rollNumber, name, classID string, semester int) error
-	UpdateStudent(studentID, name, rollnumber, classID string, semester int) error
-	GetStudentByID(studentID string) (*models.Students, error)
-	GetStudentByRollNumber(rollNumber string) (*models.Students, error)
+	WithTx(tx transaction.Querier) StudentRepositoryI
+	AddStudent(ctx context.Context, uuid, rollNumber, name, classID string, semester int) error
+	UpdateStudent(ctx context.Context, studentID, name, rollnumber, classID string, semester int) error
+	GetStudentByID(ctx context.Context, studentID string) (*models.Students, error)
+	GetStudentByRollNumber(ctx context.Context, rollNumber string) (*models.Students, error)
+	GetStudentsByClass(ctx context.Context, classID string) ([]models.Students, error)
 }
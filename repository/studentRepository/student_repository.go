@@ -1,32 +1,39 @@
 package studentsRepository
 
 import (
+	"context"
 	"database/sql"
 	"sms/models"
+	"sms/repository/transaction"
 )
 
 type StudentRepo struct {
-	db *sql.DB
+	db transaction.Querier
 }
 
-func NewStudentRepo(db *sql.DB) *StudentRepo {
+func NewStudentRepo(db transaction.Querier) *StudentRepo {
 	return &StudentRepo{db}
 }
 
-func (sr *StudentRepo) AddStudent(uuid, rollNumber, name, classID string, semester int) error {
-	_, err := sr.db.Exec(`insert into students values(?,?,?,?,?)`, uuid, name, rollNumber, classID, semester)
+// WithTx returns a StudentRepo that runs its statements on tx.
+func (sr *StudentRepo) WithTx(tx transaction.Querier) StudentRepositoryI {
+	return NewStudentRepo(tx)
+}
+
+func (sr *StudentRepo) AddStudent(ctx context.Context, uuid, rollNumber, name, classID string, semester int) error {
+	_, err := sr.db.ExecContext(ctx, `insert into students values(?,?,?,?,?)`, uuid, name, rollNumber, classID, semester)
 	return err
 }
 
-func (sr *StudentRepo) UpdateStudent(studentID, name, rollnumber, classID string, semester int) error {
+func (sr *StudentRepo) UpdateStudent(ctx context.Context, studentID, name, rollnumber, classID string, semester int) error {
 	stmt := `update students set Name=?,RollNumber=?,ClassID=?,semester=? where StudentID=?`
-	_, err := sr.db.Exec(stmt, name, rollnumber, classID, semester, studentID)
+	_, err := sr.db.ExecContext(ctx, stmt, name, rollnumber, classID, semester, studentID)
 	return err
 }
 
-func (sr *StudentRepo) GetStudentByID(studentID string) (*models.Students, error) {
+func (sr *StudentRepo) GetStudentByID(ctx context.Context, studentID string) (*models.Students, error) {
 	stmt := `select StudentID,Name,RollNumber,ClassID,semester from students where StudentID=?`
-	row := sr.db.QueryRow(stmt, studentID)
+	row := sr.db.QueryRowContext(ctx, stmt, studentID)
 	var s models.Students
 	err := row.Scan(&s.StudentID, &s.Name, &s.RollNumber, &s.ClassID, &s.Semester)
 	if err != nil {
@@ -37,9 +44,9 @@ func (sr *StudentRepo) GetStudentByID(studentID string) (*models.Students, error
 	}
 	return &s, nil
 }
-func (sr *StudentRepo) GetStudentByRollNumber(rollNumber string) (*models.Students, error) {
+func (sr *StudentRepo) GetStudentByRollNumber(ctx context.Context, rollNumber string) (*models.Students, error) {
 	stmt := `select StudentID,Name,RollNumber,ClassID,semester from students where RollNumber=?`
-	row := sr.db.QueryRow(stmt, rollNumber)
+	row := sr.db.QueryRowContext(ctx, stmt, rollNumber)
 	var s models.Students
 	err := row.Scan(&s.StudentID, &s.Name, &s.RollNumber, &s.ClassID, &s.Semester)
 	if err != nil {
@@ -50,3 +57,22 @@ func (sr *StudentRepo) GetStudentByRollNumber(rollNumber string) (*models.Studen
 	}
 	return &s, nil
 }
+
+func (sr *StudentRepo) GetStudentsByClass(ctx context.Context, classID string) ([]models.Students, error) {
+	stmt := `select StudentID,Name,RollNumber,ClassID,semester from students where ClassID=? order by RollNumber`
+	rows, err := sr.db.QueryContext(ctx, stmt, classID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var students []models.Students
+	for rows.Next() {
+		var s models.Students
+		if err := rows.Scan(&s.StudentID, &s.Name, &s.RollNumber, &s.ClassID, &s.Semester); err != nil {
+			return nil, err
+		}
+		students = append(students, s)
+	}
+	return students, rows.Err()
+}
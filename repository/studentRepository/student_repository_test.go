@@ -1,6 +1,7 @@
 package studentsRepository_test
 
 import (
+	"context"
 	"regexp"
 	studentsRepository "sms/repository/studentRepository"
 	"testing"
@@ -21,7 +22,7 @@ func TestAddStudent(t *testing.T) {
 		WithArgs("1", "Rohith", "RN1", "C1", 1).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	err = repo.AddStudent("1", "RN1", "Rohith", "C1", 1)
+	err = repo.AddStudent(context.Background(), "1", "RN1", "Rohith", "C1", 1)
 	if err != nil {
 		t.Fatalf("unexpected error: %s", err)
 	}
@@ -43,7 +44,7 @@ func TestGetStudentByID(t *testing.T) {
 		WithArgs("1").
 		WillReturnRows(row)
 
-	student, err := repo.GetStudentByID("1")
+	student, err := repo.GetStudentByID(context.Background(), "1")
 	if err != nil {
 		t.Fatalf("unexpected error: %s", err)
 	}
@@ -70,7 +71,7 @@ func TestUpdateStudent(t *testing.T) {
 		WithArgs("RohithUpdated", "RN1", "C1", 1, "1").
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	err = repo.UpdateStudent("1", "RohithUpdated", "RN1", "C1", 1)
+	err = repo.UpdateStudent(context.Background(), "1", "RohithUpdated", "RN1", "C1", 1)
 	if err != nil {
 		t.Fatalf("unexpected error: %s", err)
 	}
@@ -92,7 +93,7 @@ func TestGetStudentByRollNumber(t *testing.T) {
 		WithArgs("RN1").
 		WillReturnRows(row)
 
-	student, err := repo.GetStudentByRollNumber("RN1")
+	student, err := repo.GetStudentByRollNumber(context.Background(), "RN1")
 	if err != nil {
 		t.Fatalf("unexpected error: %s", err)
 	}
@@ -105,3 +106,31 @@ func TestGetStudentByRollNumber(t *testing.T) {
 		t.Errorf("expected student name Rohith, got %s", student.Name)
 	}
 }
+
+func TestGetStudentsByClass(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %s", err)
+	}
+	defer db.Close()
+
+	repo := studentsRepository.NewStudentRepo(db)
+
+	rows := sqlmock.NewRows([]string{"StudentID", "Name", "RollNumber", "ClassID", "semester"}).
+		AddRow("1", "Rohith", "RN1", "C1", 1).
+		AddRow("2", "Ravi", "RN2", "C1", 1)
+	mock.ExpectQuery(regexp.QuoteMeta("select StudentID,Name,RollNumber,ClassID,semester from students where ClassID=? order by RollNumber")).
+		WithArgs("C1").
+		WillReturnRows(rows)
+
+	students, err := repo.GetStudentsByClass(context.Background(), "C1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(students) != 2 || students[1].Name != "Ravi" {
+		t.Errorf("unexpected students: %v", students)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,13 @@
+package subjectRepository
+
+import (
+	"context"
+	"sms/models"
+)
+
+//go:generate mockgen -destination=../../mocks/subject_repo_mock.go -package=mocks -source=interface.go
+type SubjectRepositoryI interface {
+	AddSubject(ctx context.Context, subject models.Subject) error
+	GetSubjects(ctx context.Context) ([]models.Subject, error)
+	GetSubject(ctx context.Context, subjectID string) (*models.Subject, error)
+}
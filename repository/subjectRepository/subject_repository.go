@@ -0,0 +1,52 @@
+package subjectRepository
+
+import (
+	"context"
+	"database/sql"
+	"sms/models"
+	"sms/repository/transaction"
+)
+
+type SubjectRepo struct {
+	db transaction.Querier
+}
+
+func NewSubjectRepo(db transaction.Querier) *SubjectRepo {
+	return &SubjectRepo{db}
+}
+
+func (sr *SubjectRepo) AddSubject(ctx context.Context, subject models.Subject) error {
+	_, err := sr.db.ExecContext(ctx, `insert into subject values(?,?)`, subject.SubjectID, subject.SubjectName)
+	return err
+}
+
+func (sr *SubjectRepo) GetSubjects(ctx context.Context) ([]models.Subject, error) {
+	rows, err := sr.db.QueryContext(ctx, `select SubjectID, SubjectName from subject order by SubjectID`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subjects []models.Subject
+	for rows.Next() {
+		var s models.Subject
+		if err := rows.Scan(&s.SubjectID, &s.SubjectName); err != nil {
+			return nil, err
+		}
+		subjects = append(subjects, s)
+	}
+	return subjects, rows.Err()
+}
+
+// GetSubject returns nil when there is no such subject.
+func (sr *SubjectRepo) GetSubject(ctx context.Context, subjectID string) (*models.Subject, error) {
+	var s models.Subject
+	err := sr.db.QueryRowContext(ctx, `select SubjectID, SubjectName from subject where SubjectID=?`, subjectID).Scan(&s.SubjectID, &s.SubjectName)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
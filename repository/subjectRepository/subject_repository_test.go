@@ -0,0 +1,82 @@
+package subjectRepository_test
+
+import (
+	"context"
+	"regexp"
+	"sms/models"
+	"sms/repository/subjectRepository"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestAddSubject(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %s", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(`insert into subject values(?,?)`)).
+		WithArgs("MATH", "Engineering Mathematics").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	repo := subjectRepository.NewSubjectRepo(db)
+	if err := repo.AddSubject(context.Background(), models.Subject{SubjectID: "MATH", SubjectName: "Engineering Mathematics"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetSubjects(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %s", err)
+	}
+	defer db.Close()
+
+	rows := sqlmock.NewRows([]string{"SubjectID", "SubjectName"}).
+		AddRow("MATH", "Engineering Mathematics").
+		AddRow("PHY", "Physics")
+	mock.ExpectQuery(regexp.QuoteMeta(`select SubjectID, SubjectName from subject order by SubjectID`)).WillReturnRows(rows)
+
+	repo := subjectRepository.NewSubjectRepo(db)
+	subjects, err := repo.GetSubjects(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(subjects) != 2 || subjects[1].SubjectName != "Physics" {
+		t.Errorf("unexpected subjects: %+v", subjects)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetSubject(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %s", err)
+	}
+	defer db.Close()
+
+	query := regexp.QuoteMeta(`select SubjectID, SubjectName from subject where SubjectID=?`)
+	mock.ExpectQuery(query).WithArgs("MATH").
+		WillReturnRows(sqlmock.NewRows([]string{"SubjectID", "SubjectName"}).AddRow("MATH", "Engineering Mathematics"))
+	mock.ExpectQuery(query).WithArgs("NONE").WillReturnRows(sqlmock.NewRows([]string{"SubjectID", "SubjectName"}))
+
+	repo := subjectRepository.NewSubjectRepo(db)
+	subject, err := repo.GetSubject(context.Background(), "MATH")
+	if err != nil || subject == nil || subject.SubjectName != "Engineering Mathematics" {
+		t.Errorf("unexpected subject %+v, error %v", subject, err)
+	}
+	subject, err = repo.GetSubject(context.Background(), "NONE")
+	if err != nil || subject != nil {
+		t.Errorf("expected no subject, got %+v, error %v", subject, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
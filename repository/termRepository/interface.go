@@ -0,0 +1,18 @@
+package termRepository
+
+import (
+	"context"
+	"sms/constants"
+	"sms/models"
+	"time"
+)
+
+//go:generate mockgen -destination=../../mocks/term_repo_mock.go -package=mocks -source=interface.go
+type TermRepositoryI interface {
+	AddTerm(ctx context.Context, term models.AcademicTerm) error
+	GetTerm(ctx context.Context, termID string) (*models.AcademicTerm, error)
+	GetTerms(ctx context.Context, year int) ([]models.AcademicTerm, error)
+	GetTermsForSemester(ctx context.Context, semester int) ([]models.AcademicTerm, error)
+	SetTermStatus(ctx context.Context, termID string, status constants.TermStatus) error
+	SetGradeEntryWindow(ctx context.Context, termID string, start, end time.Time) error
+}
@@ -0,0 +1,111 @@
+package termRepository
+
+import (
+	"context"
+	"sms/constants"
+	"sms/models"
+	"sms/repository/transaction"
+	"time"
+)
+
+const termColumns = `t.TermID, t.Year, t.TermNumber, t.StartDate, t.EndDate, t.GradeEntryStart, t.GradeEntryEnd, t.Status`
+
+type TermRepo struct {
+	db transaction.Querier
+}
+
+func NewTermRepo(db transaction.Querier) *TermRepo {
+	return &TermRepo{db}
+}
+
+func (tr *TermRepo) AddTerm(ctx context.Context, term models.AcademicTerm) error {
+	return transaction.RunInTx(ctx, tr.db, func(tx transaction.Querier) error {
+		_, err := tx.ExecContext(ctx, `insert into academic_term values(?,?,?,?,?,?,?,?)`,
+			term.TermID, term.Year, term.TermNumber, term.StartDate, term.EndDate, term.GradeEntryStart, term.GradeEntryEnd, term.Status)
+		if err != nil {
+			return err
+		}
+		for _, semester := range term.Semesters {
+			if _, err := tx.ExecContext(ctx, `insert into academic_term_semester values(?,?)`, term.TermID, semester); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (tr *TermRepo) GetTerm(ctx context.Context, termID string) (*models.AcademicTerm, error) {
+	terms, err := tr.queryTerms(ctx, `select `+termColumns+` from academic_term t where t.TermID=?`, termID)
+	if err != nil || len(terms) == 0 {
+		return nil, err
+	}
+	return &terms[0], nil
+}
+
+// GetTerms returns the terms of a year, or of every year when year is 0.
+func (tr *TermRepo) GetTerms(ctx context.Context, year int) ([]models.AcademicTerm, error) {
+	stmt := `select ` + termColumns + ` from academic_term t where (?=0 or t.Year=?) order by t.Year, t.TermNumber`
+	return tr.queryTerms(ctx, stmt, year, year)
+}
+
+func (tr *TermRepo) GetTermsForSemester(ctx context.Context, semester int) ([]models.AcademicTerm, error) {
+	stmt := `select ` + termColumns + ` from academic_term t
+	join academic_term_semester ts on ts.TermID=t.TermID where ts.semester=? order by t.Year, t.TermNumber`
+	return tr.queryTerms(ctx, stmt, semester)
+}
+
+func (tr *TermRepo) SetTermStatus(ctx context.Context, termID string, status constants.TermStatus) error {
+	_, err := tr.db.ExecContext(ctx, `update academic_term set Status=? where TermID=?`, status, termID)
+	return err
+}
+
+func (tr *TermRepo) SetGradeEntryWindow(ctx context.Context, termID string, start, end time.Time) error {
+	_, err := tr.db.ExecContext(ctx, `update academic_term set GradeEntryStart=?, GradeEntryEnd=? where TermID=?`, start, end, termID)
+	return err
+}
+
+func (tr *TermRepo) queryTerms(ctx context.Context, stmt string, args ...any) ([]models.AcademicTerm, error) {
+	rows, err := tr.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var terms []models.AcademicTerm
+	for rows.Next() {
+		var t models.AcademicTerm
+		if err := rows.Scan(&t.TermID, &t.Year, &t.TermNumber, &t.StartDate, &t.EndDate, &t.GradeEntryStart, &t.GradeEntryEnd, &t.Status); err != nil {
+			return nil, err
+		}
+		terms = append(terms, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	for i := range terms {
+		if terms[i].Semesters, err = tr.getSemesters(ctx, terms[i].TermID); err != nil {
+			return nil, err
+		}
+	}
+	return terms, nil
+}
+
+func (tr *TermRepo) getSemesters(ctx context.Context, termID string) ([]int, error) {
+	rows, err := tr.db.QueryContext(ctx, `select semester from academic_term_semester where TermID=? order by semester`, termID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var semesters []int
+	for rows.Next() {
+		var semester int
+		if err := rows.Scan(&semester); err != nil {
+			return nil, err
+		}
+		semesters = append(semesters, semester)
+	}
+	return semesters, rows.Err()
+}
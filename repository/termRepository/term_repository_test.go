@@ -0,0 +1,136 @@
+package termRepository_test
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"sms/constants"
+	"sms/models"
+	termRepository "sms/repository/termRepository"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+var term = models.AcademicTerm{
+	TermID:          "t1",
+	Year:            2026,
+	TermNumber:      1,
+	Semesters:       []int{1, 3},
+	StartDate:       time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC),
+	EndDate:         time.Date(2026, 11, 30, 0, 0, 0, 0, time.UTC),
+	GradeEntryStart: time.Date(2026, 11, 15, 0, 0, 0, 0, time.UTC),
+	GradeEntryEnd:   time.Date(2026, 12, 15, 0, 0, 0, 0, time.UTC),
+	Status:          constants.TermOpen,
+}
+
+var termColumns = []string{"TermID", "Year", "TermNumber", "StartDate", "EndDate", "GradeEntryStart", "GradeEntryEnd", "Status"}
+
+func TestAddTerm(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := termRepository.NewTermRepo(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`insert into academic_term values(?,?,?,?,?,?,?,?)`)).
+		WithArgs("t1", 2026, 1, term.StartDate, term.EndDate, term.GradeEntryStart, term.GradeEntryEnd, constants.TermOpen).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`insert into academic_term_semester values(?,?)`)).
+		WithArgs("t1", 1).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`insert into academic_term_semester values(?,?)`)).
+		WithArgs("t1", 3).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	if err := repo.AddTerm(context.Background(), term); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetTermsForSemester(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := termRepository.NewTermRepo(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`select t.TermID, t.Year, t.TermNumber, t.StartDate, t.EndDate, t.GradeEntryStart, t.GradeEntryEnd, t.Status from academic_term t
+	join academic_term_semester ts on ts.TermID=t.TermID where ts.semester=? order by t.Year, t.TermNumber`)).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows(termColumns).
+			AddRow("t1", 2026, 1, term.StartDate, term.EndDate, term.GradeEntryStart, term.GradeEntryEnd, "open"))
+	mock.ExpectQuery(regexp.QuoteMeta(`select semester from academic_term_semester where TermID=? order by semester`)).
+		WithArgs("t1").
+		WillReturnRows(sqlmock.NewRows([]string{"semester"}).AddRow(1).AddRow(3))
+
+	terms, err := repo.GetTermsForSemester(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(terms, []models.AcademicTerm{term}) {
+		t.Errorf("expected %+v, got %+v", []models.AcademicTerm{term}, terms)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetTerm_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := termRepository.NewTermRepo(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`from academic_term t where t.TermID=?`)).
+		WithArgs("missing").
+		WillReturnRows(sqlmock.NewRows(termColumns))
+
+	got, err := repo.GetTerm(context.Background(), "missing")
+	if err != nil || got != nil {
+		t.Errorf("expected nil term and error, got %+v, %v", got, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestSetTermStatus_And_SetGradeEntryWindow(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := termRepository.NewTermRepo(db)
+
+	mock.ExpectExec(regexp.QuoteMeta(`update academic_term set Status=? where TermID=?`)).
+		WithArgs(constants.TermClosed, "t1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`update academic_term set GradeEntryStart=?, GradeEntryEnd=? where TermID=?`)).
+		WithArgs(term.GradeEntryStart, term.GradeEntryEnd, "t1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := repo.SetTermStatus(context.Background(), "t1", constants.TermClosed); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := repo.SetGradeEntryWindow(context.Background(), "t1", term.GradeEntryStart, term.GradeEntryEnd); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,23 @@
+package timetableRepository
+
+import (
+	"context"
+	"sms/models"
+)
+
+//go:generate mockgen -destination=../../mocks/timetable_repo_mock.go -package=mocks -source=interface.go
+type TimetableRepositoryI interface {
+	AddRoom(ctx context.Context, room models.Room) error
+	GetRoom(ctx context.Context, roomID string) (*models.Room, error)
+	GetClassHomeRoom(ctx context.Context, classID string) (string, error)
+	SetClassHomeRoom(ctx context.Context, classID, roomID string) error
+	AddFacultyAssignment(ctx context.Context, assignment models.FacultyAssignment) error
+	GetFacultyAssignment(ctx context.Context, facultyID, classID, subjectID string) (*models.FacultyAssignment, error)
+	GetClassFacultyIDs(ctx context.Context, classID string) ([]string, error)
+	AddSlot(ctx context.Context, slot models.TimetableSlot) error
+	GetSlot(ctx context.Context, slotID string) (*models.TimetableSlot, error)
+	DeleteSlot(ctx context.Context, slotID string) error
+	GetOverlappingSlots(ctx context.Context, slot models.TimetableSlot) ([]models.TimetableSlot, error)
+	GetClassSlots(ctx context.Context, classID string) ([]models.TimetableSlot, error)
+	GetFacultySlots(ctx context.Context, facultyID string) ([]models.TimetableSlot, error)
+}
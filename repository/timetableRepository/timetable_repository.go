@@ -0,0 +1,151 @@
+package timetableRepository
+
+import (
+	"context"
+	"database/sql"
+	"sms/models"
+	"sms/repository/transaction"
+)
+
+const slotColumns = `t.SlotID, t.ClassID, t.SubjectID, sub.SubjectName, t.FacultyID, t.RoomID, r.Name, t.Weekday, t.StartTime, t.EndTime`
+
+// slotTables joins a slot to the subject and room it names.
+const slotTables = `timetable_slot t join subject sub on sub.SubjectID=t.SubjectID join room r on r.RoomID=t.RoomID`
+
+type TimetableRepo struct {
+	db transaction.Querier
+}
+
+func NewTimetableRepo(db transaction.Querier) *TimetableRepo {
+	return &TimetableRepo{db}
+}
+
+func (tr *TimetableRepo) AddRoom(ctx context.Context, room models.Room) error {
+	_, err := tr.db.ExecContext(ctx, `insert into room values(?,?,?)`, room.RoomID, room.Name, room.Capacity)
+	return err
+}
+
+func (tr *TimetableRepo) GetRoom(ctx context.Context, roomID string) (*models.Room, error) {
+	var r models.Room
+	err := tr.db.QueryRowContext(ctx, `select RoomID, Name, Capacity from room where RoomID=?`, roomID).Scan(&r.RoomID, &r.Name, &r.Capacity)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &r, nil
+}
+
+// GetClassHomeRoom returns the room a class is usually taught in, or "" when it has none.
+func (tr *TimetableRepo) GetClassHomeRoom(ctx context.Context, classID string) (string, error) {
+	var roomID sql.NullString
+	err := tr.db.QueryRowContext(ctx, `select HomeRoomID from class where ClassID=?`, classID).Scan(&roomID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", err
+	}
+	return roomID.String, nil
+}
+
+func (tr *TimetableRepo) SetClassHomeRoom(ctx context.Context, classID, roomID string) error {
+	_, err := tr.db.ExecContext(ctx, `update class set HomeRoomID=? where ClassID=?`, roomID, classID)
+	return err
+}
+
+func (tr *TimetableRepo) AddFacultyAssignment(ctx context.Context, a models.FacultyAssignment) error {
+	_, err := tr.db.ExecContext(ctx, `insert into faculty_assignment values(?,?,?)`, a.FacultyID, a.ClassID, a.SubjectID)
+	return err
+}
+
+func (tr *TimetableRepo) GetFacultyAssignment(ctx context.Context, facultyID, classID, subjectID string) (*models.FacultyAssignment, error) {
+	stmt := `select FacultyID, ClassID, SubjectID from faculty_assignment where FacultyID=? and ClassID=? and SubjectID=?`
+	var a models.FacultyAssignment
+	err := tr.db.QueryRowContext(ctx, stmt, facultyID, classID, subjectID).Scan(&a.FacultyID, &a.ClassID, &a.SubjectID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &a, nil
+}
+
+// GetClassFacultyIDs returns the faculty assigned to teach the class, whatever the subject.
+func (tr *TimetableRepo) GetClassFacultyIDs(ctx context.Context, classID string) ([]string, error) {
+	rows, err := tr.db.QueryContext(ctx, `select distinct FacultyID from faculty_assignment where ClassID=? order by FacultyID`, classID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func (tr *TimetableRepo) AddSlot(ctx context.Context, slot models.TimetableSlot) error {
+	_, err := tr.db.ExecContext(ctx, `insert into timetable_slot values(?,?,?,?,?,?,?,?)`,
+		slot.SlotID, slot.ClassID, slot.SubjectID, slot.FacultyID, slot.RoomID, int(slot.Weekday), slot.StartTime, slot.EndTime)
+	return err
+}
+
+func (tr *TimetableRepo) GetSlot(ctx context.Context, slotID string) (*models.TimetableSlot, error) {
+	slots, err := tr.querySlots(ctx, `select `+slotColumns+` from `+slotTables+` where t.SlotID=?`, slotID)
+	if err != nil || len(slots) == 0 {
+		return nil, err
+	}
+	return &slots[0], nil
+}
+
+func (tr *TimetableRepo) DeleteSlot(ctx context.Context, slotID string) error {
+	_, err := tr.db.ExecContext(ctx, `delete from timetable_slot where SlotID=?`, slotID)
+	return err
+}
+
+// GetOverlappingSlots returns the slots on the same weekday and overlapping in
+// time that share the class, the faculty or the room of the given slot.
+func (tr *TimetableRepo) GetOverlappingSlots(ctx context.Context, slot models.TimetableSlot) ([]models.TimetableSlot, error) {
+	stmt := `select ` + slotColumns + ` from ` + slotTables + `
+	where t.Weekday=? and t.StartTime<? and t.EndTime>? and (t.ClassID=? or t.FacultyID=? or t.RoomID=?)
+	order by t.StartTime`
+	return tr.querySlots(ctx, stmt, int(slot.Weekday), slot.EndTime, slot.StartTime, slot.ClassID, slot.FacultyID, slot.RoomID)
+}
+
+func (tr *TimetableRepo) GetClassSlots(ctx context.Context, classID string) ([]models.TimetableSlot, error) {
+	stmt := `select ` + slotColumns + ` from ` + slotTables + `
+	where t.ClassID=? order by t.Weekday, t.StartTime`
+	return tr.querySlots(ctx, stmt, classID)
+}
+
+func (tr *TimetableRepo) GetFacultySlots(ctx context.Context, facultyID string) ([]models.TimetableSlot, error) {
+	stmt := `select ` + slotColumns + ` from ` + slotTables + `
+	where t.FacultyID=? order by t.Weekday, t.StartTime`
+	return tr.querySlots(ctx, stmt, facultyID)
+}
+
+func (tr *TimetableRepo) querySlots(ctx context.Context, stmt string, args ...any) ([]models.TimetableSlot, error) {
+	rows, err := tr.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var slots []models.TimetableSlot
+	for rows.Next() {
+		var s models.TimetableSlot
+		if err := rows.Scan(&s.SlotID, &s.ClassID, &s.SubjectID, &s.SubjectName, &s.FacultyID, &s.RoomID, &s.RoomName, &s.Weekday, &s.StartTime, &s.EndTime); err != nil {
+			return nil, err
+		}
+		slots = append(slots, s)
+	}
+	return slots, rows.Err()
+}
@@ -0,0 +1,136 @@
+package timetableRepository_test
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"sms/models"
+	timetableRepository "sms/repository/timetableRepository"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+var slotColumns = []string{"SlotID", "ClassID", "SubjectID", "SubjectName", "FacultyID", "RoomID", "Name", "Weekday", "StartTime", "EndTime"}
+
+func TestAddSlot(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := timetableRepository.NewTimetableRepo(db)
+
+	mock.ExpectExec(regexp.QuoteMeta(`insert into timetable_slot values(?,?,?,?,?,?,?,?)`)).
+		WithArgs("sl1", "C1", "sub1", "f1", "r1", 1, "09:00", "10:00").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err = repo.AddSlot(context.Background(), models.TimetableSlot{SlotID: "sl1", ClassID: "C1", SubjectID: "sub1", FacultyID: "f1", RoomID: "r1", Weekday: time.Monday, StartTime: "09:00", EndTime: "10:00"})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetOverlappingSlots(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := timetableRepository.NewTimetableRepo(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`select t.SlotID, t.ClassID, t.SubjectID, sub.SubjectName, t.FacultyID, t.RoomID, r.Name, t.Weekday, t.StartTime, t.EndTime from timetable_slot t join subject sub on sub.SubjectID=t.SubjectID join room r on r.RoomID=t.RoomID
+	where t.Weekday=? and t.StartTime<? and t.EndTime>? and (t.ClassID=? or t.FacultyID=? or t.RoomID=?)
+	order by t.StartTime`)).
+		WithArgs(1, "10:00", "09:00", "C1", "f1", "r1").
+		WillReturnRows(sqlmock.NewRows(slotColumns).AddRow("sl0", "C2", "sub2", "Physics", "f2", "r1", "Lab 1", 1, "09:30", "10:30"))
+
+	slots, err := repo.GetOverlappingSlots(context.Background(), models.TimetableSlot{ClassID: "C1", FacultyID: "f1", RoomID: "r1", Weekday: time.Monday, StartTime: "09:00", EndTime: "10:00"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []models.TimetableSlot{{SlotID: "sl0", ClassID: "C2", SubjectID: "sub2", SubjectName: "Physics", FacultyID: "f2", RoomID: "r1", RoomName: "Lab 1", Weekday: time.Monday, StartTime: "09:30", EndTime: "10:30"}}
+	if !reflect.DeepEqual(slots, expected) {
+		t.Errorf("expected %+v, got %+v", expected, slots)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetFacultyAssignment_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := timetableRepository.NewTimetableRepo(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`select FacultyID, ClassID, SubjectID from faculty_assignment where FacultyID=? and ClassID=? and SubjectID=?`)).
+		WithArgs("f1", "C1", "sub1").
+		WillReturnRows(sqlmock.NewRows([]string{"FacultyID", "ClassID", "SubjectID"}))
+
+	assignment, err := repo.GetFacultyAssignment(context.Background(), "f1", "C1", "sub1")
+	if err != nil || assignment != nil {
+		t.Errorf("expected nil assignment and error, got %+v, %v", assignment, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetClassFacultyIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := timetableRepository.NewTimetableRepo(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`select distinct FacultyID from faculty_assignment where ClassID=? order by FacultyID`)).
+		WithArgs("C1").
+		WillReturnRows(sqlmock.NewRows([]string{"FacultyID"}).AddRow("f1").AddRow("f2"))
+
+	ids, err := repo.GetClassFacultyIDs(context.Background(), "C1")
+	if err != nil || !reflect.DeepEqual(ids, []string{"f1", "f2"}) {
+		t.Errorf("expected f1 and f2, got %v, %v", ids, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestClassHomeRoom(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := timetableRepository.NewTimetableRepo(db)
+
+	mock.ExpectExec(regexp.QuoteMeta(`update class set HomeRoomID=? where ClassID=?`)).
+		WithArgs("r1", "C1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`select HomeRoomID from class where ClassID=?`)).
+		WithArgs("C1").
+		WillReturnRows(sqlmock.NewRows([]string{"HomeRoomID"}).AddRow("r1"))
+
+	if err := repo.SetClassHomeRoom(context.Background(), "C1", "r1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if roomID, err := repo.GetClassHomeRoom(context.Background(), "C1"); err != nil || roomID != "r1" {
+		t.Errorf("expected r1, got %q, %v", roomID, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
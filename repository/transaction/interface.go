@@ -0,0 +1,19 @@
+package transaction
+
+import "context"
+
+// Manager runs a unit of work in one transaction. Repositories that take part
+// in one have a WithTx method returning a copy that runs its statements on the
+// given Querier, so a service combines several of them inside fn:
+//
+//	tm.WithinTx(ctx, func(tx transaction.Querier) error {
+//		if err := students.WithTx(tx).AddStudent(...); err != nil {
+//			return err
+//		}
+//		return enrollments.WithTx(tx).AddEnrollments(...)
+//	})
+//
+//go:generate mockgen -destination=../../mocks/tx_manager_mock.go -package=mocks -source=interface.go
+type Manager interface {
+	WithinTx(ctx context.Context, fn func(tx Querier) error) error
+}
@@ -0,0 +1,70 @@
+package transaction
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Querier is what the repositories need from a database handle. *sql.DB, *sql.Tx
+// and the storage package's dialect-aware DB and Tx satisfy it, so a repository
+// runs the same statements on the pool or inside a transaction.
+type Querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// Tx is a transaction RunInTx commits or rolls back.
+type Tx interface {
+	Querier
+	Commit() error
+	Rollback() error
+}
+
+// Beginner is a pool that hands out its own Tx, like storage.DB.
+type Beginner interface {
+	Begin(ctx context.Context) (Tx, error)
+}
+
+type sqlBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// TxManager runs units of work in one transaction on the pool.
+type TxManager struct {
+	db Querier
+}
+
+func NewTxManager(db Querier) *TxManager {
+	return &TxManager{db}
+}
+
+// WithinTx commits when fn returns nil and rolls back when it fails or panics.
+// Cancelling ctx rolls the transaction back.
+func (tm *TxManager) WithinTx(ctx context.Context, fn func(tx Querier) error) error {
+	return RunInTx(ctx, tm.db, fn)
+}
+
+// RunInTx runs fn in a new transaction when q is a pool. When q is already a
+// transaction fn joins it, and committing is left to whoever began it.
+func RunInTx(ctx context.Context, q Querier, fn func(tx Querier) error) error {
+	var tx Tx
+	var err error
+	switch db := q.(type) {
+	case Beginner:
+		tx, err = db.Begin(ctx)
+	case sqlBeginner:
+		tx, err = db.BeginTx(ctx, nil)
+	default:
+		return fn(q)
+	}
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
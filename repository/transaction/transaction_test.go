@@ -0,0 +1,106 @@
+package transaction_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"sms/repository/transaction"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestWithinTx_Commits(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	tm := transaction.NewTxManager(db)
+	stmt := regexp.QuoteMeta(`insert into students values(?,?,?,?,?)`)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(stmt).WithArgs("s1", "Anu", "101", "C1", 1).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(stmt).WithArgs("s2", "Ravi", "102", "C1", 1).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err = tm.WithinTx(context.Background(), func(tx transaction.Querier) error {
+		if _, err := tx.ExecContext(context.Background(), `insert into students values(?,?,?,?,?)`, "s1", "Anu", "101", "C1", 1); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(context.Background(), `insert into students values(?,?,?,?,?)`, "s2", "Ravi", "102", "C1", 1)
+		return err
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestWithinTx_RollsBack(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	tm := transaction.NewTxManager(db)
+	failure := errors.New("enrollment failed")
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	if err := tm.WithinTx(context.Background(), func(transaction.Querier) error { return failure }); !errors.Is(err, failure) {
+		t.Errorf("expected %v, got %v", failure, err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("expected the panic to reach the caller")
+			}
+		}()
+		tm.WithinTx(context.Background(), func(transaction.Querier) error { panic("boom") })
+	}()
+
+	mock.ExpectBegin().WillReturnError(errors.New("database is locked"))
+	if err := tm.WithinTx(context.Background(), func(transaction.Querier) error { return nil }); err == nil {
+		t.Errorf("expected begin error")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRunInTx_JoinsOpenTransaction(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	stmt := regexp.QuoteMeta(`delete from grades where StudentID=?`)
+	mock.ExpectBegin()
+	mock.ExpectExec(stmt).WithArgs("s1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = transaction.NewTxManager(db).WithinTx(context.Background(), func(outer transaction.Querier) error {
+		return transaction.RunInTx(context.Background(), outer, func(inner transaction.Querier) error {
+			if inner != outer {
+				t.Errorf("expected the inner unit of work to run on the outer transaction")
+			}
+			_, err := inner.ExecContext(context.Background(), `delete from grades where StudentID=?`, "s1")
+			return err
+		})
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
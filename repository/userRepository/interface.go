@@ -1,9 +1,19 @@
 package userrepository
 
-import "sms/models"
+import (
+	"context"
+	"sms/constants"
+	"sms/models"
+	"sms/repository/transaction"
+)
 
 //go:generate mockgen -destination=../../mocks/user_repo_mock.go -package=mocks -source=interface.go
 type UserRepositoryI interface {
-	AddUser(id string, name, email, password string) error
-	GetUserByEmailID(email string) (*models.User, error)
+	WithTx(tx transaction.Querier) UserRepositoryI
+	AddUser(ctx context.Context, id string, name, email, password string) error
+	AddUserWithRole(ctx context.Context, id string, name, email, password string, role constants.Role) error
+	GetUserByEmailID(ctx context.Context, email string) (*models.User, error)
+	GetUserByID(ctx context.Context, userID string) (*models.User, error)
+	GetUserIDsByRole(ctx context.Context, role constants.Role) ([]string, error)
+	UpdatePassword(ctx context.Context, userID, password string) error
 }
@@ -1,29 +1,40 @@
 package userrepository
 
 import (
+	"context"
 	"database/sql"
 	"log"
 	"sms/constants"
 	"sms/models"
+	"sms/repository/transaction"
 )
 
 type UserRepo struct {
-	db *sql.DB
+	db transaction.Querier
 }
 
-func NewUserRepo(db *sql.DB) *UserRepo {
+func NewUserRepo(db transaction.Querier) *UserRepo {
 	return &UserRepo{db: db}
 }
 
-func (ur *UserRepo) AddUser(id string, name, email, password string) error {
-	stmt := `insert into user values(?,?,?,?,?)`
-	_, err := ur.db.Exec(stmt, id, name, email, password, "faculty")
+// WithTx returns a UserRepo that runs its statements on tx.
+func (ur *UserRepo) WithTx(tx transaction.Querier) UserRepositoryI {
+	return NewUserRepo(tx)
+}
+
+func (ur *UserRepo) AddUser(ctx context.Context, id string, name, email, password string) error {
+	return ur.AddUserWithRole(ctx, id, name, email, password, constants.Faculty)
+}
+
+func (ur *UserRepo) AddUserWithRole(ctx context.Context, id string, name, email, password string, role constants.Role) error {
+	stmt := `insert into "user" values(?,?,?,?,?)`
+	_, err := ur.db.ExecContext(ctx, stmt, id, name, email, password, role)
 	return err
 }
 
-func (ur *UserRepo) GetUserByEmailID(email string) (*models.User, error) {
-	stmt := `select UserID, Name, Email, Password, Role from user where Email=?`
-	row := ur.db.QueryRow(stmt, email)
+func (ur *UserRepo) GetUserByEmailID(ctx context.Context, email string) (*models.User, error) {
+	stmt := `select UserID, Name, Email, Password, Role from "user" where Email=?`
+	row := ur.db.QueryRowContext(ctx, stmt, email)
 	var user models.User
 	err := row.Scan(&user.UserID, &user.Name, &user.Email, &user.Password, &user.Role)
 	user.Role = constants.Role(user.Role)
@@ -36,3 +47,41 @@ func (ur *UserRepo) GetUserByEmailID(email string) (*models.User, error) {
 	}
 	return &user, nil
 }
+
+func (ur *UserRepo) GetUserByID(ctx context.Context, userID string) (*models.User, error) {
+	stmt := `select UserID, Name, Email, Password, Role from "user" where UserID=?`
+	var user models.User
+	err := ur.db.QueryRowContext(ctx, stmt, userID).Scan(&user.UserID, &user.Name, &user.Email, &user.Password, &user.Role)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetUserIDsByRole returns the IDs of every user with the role.
+func (ur *UserRepo) GetUserIDsByRole(ctx context.Context, role constants.Role) ([]string, error) {
+	rows, err := ur.db.QueryContext(ctx, `select UserID from "user" where Role=? order by UserID`, role)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// UpdatePassword replaces the stored password hash of a user.
+func (ur *UserRepo) UpdatePassword(ctx context.Context, userID, password string) error {
+	_, err := ur.db.ExecContext(ctx, `update "user" set Password=? where UserID=?`, password, userID)
+	return err
+}
@@ -1,7 +1,10 @@
 package userrepository_test
 
 import (
+	"context"
+	"database/sql"
 	"regexp"
+	"sms/constants"
 	userrepository "sms/repository/userRepository"
 	"testing"
 
@@ -17,11 +20,11 @@ func TestAddUser(t *testing.T) {
 
 	repo := userrepository.NewUserRepo(db)
 
-	mock.ExpectExec(regexp.QuoteMeta("insert into user values(?,?,?,?,?)")).
+	mock.ExpectExec(regexp.QuoteMeta("insert into \"user\" values(?,?,?,?,?)")).
 		WithArgs("1", "Rohith", "rohith@example.com", "hashedpass", "faculty").
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	err = repo.AddUser("1", "Rohith", "rohith@example.com", "hashedpass")
+	err = repo.AddUser(context.Background(), "1", "Rohith", "rohith@example.com", "hashedpass")
 	if err != nil {
 		t.Fatalf("unexpected error: %s", err)
 	}
@@ -43,11 +46,11 @@ func TestGetUserByEmailID(t *testing.T) {
 	rows := sqlmock.NewRows([]string{"UserID", "Name", "Email", "Password", "Role"}).
 		AddRow("1", "Rohith", "rohith@example.com", "hashedpass", "faculty")
 
-	mock.ExpectQuery(regexp.QuoteMeta("select UserID, Name, Email, Password, Role from user where Email=?")).
+	mock.ExpectQuery(regexp.QuoteMeta("select UserID, Name, Email, Password, Role from \"user\" where Email=?")).
 		WithArgs("rohith@example.com").
 		WillReturnRows(rows)
 
-	user, err := repo.GetUserByEmailID("rohith@example.com")
+	user, err := repo.GetUserByEmailID(context.Background(), "rohith@example.com")
 	if err != nil {
 		t.Fatalf("unexpected error: %s", err)
 	}
@@ -64,3 +67,108 @@ func TestGetUserByEmailID(t *testing.T) {
 		t.Errorf("there were unfulfilled expectations: %s", err)
 	}
 }
+
+func TestAddUserWithRole(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %s", err)
+	}
+	defer db.Close()
+
+	repo := userrepository.NewUserRepo(db)
+
+	mock.ExpectExec(regexp.QuoteMeta("insert into \"user\" values(?,?,?,?,?)")).
+		WithArgs("2", "Lakshmi", "lakshmi@example.com", "hashedpass", "guardian").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err = repo.AddUserWithRole(context.Background(), "2", "Lakshmi", "lakshmi@example.com", "hashedpass", constants.Guardian)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetUserByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %s", err)
+	}
+	defer db.Close()
+
+	repo := userrepository.NewUserRepo(db)
+	query := regexp.QuoteMeta("select UserID, Name, Email, Password, Role from \"user\" where UserID=?")
+
+	rows := sqlmock.NewRows([]string{"UserID", "Name", "Email", "Password", "Role"}).
+		AddRow("2", "Lakshmi", "lakshmi@example.com", "hashedpass", "guardian")
+	mock.ExpectQuery(query).WithArgs("2").WillReturnRows(rows)
+
+	user, err := repo.GetUserByID(context.Background(), "2")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if user == nil || user.Role != constants.Guardian {
+		t.Fatalf("expected guardian user, got %+v", user)
+	}
+
+	mock.ExpectQuery(query).WithArgs("missing").WillReturnError(sql.ErrNoRows)
+
+	user, err = repo.GetUserByID(context.Background(), "missing")
+	if err != nil || user != nil {
+		t.Errorf("expected nil user and nil error, got %+v, %v", user, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestUpdatePassword(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %s", err)
+	}
+	defer db.Close()
+
+	repo := userrepository.NewUserRepo(db)
+
+	mock.ExpectExec(regexp.QuoteMeta("update \"user\" set Password=? where UserID=?")).
+		WithArgs("newhash", "1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := repo.UpdatePassword(context.Background(), "1", "newhash"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetUserIDsByRole(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %s", err)
+	}
+	defer db.Close()
+
+	repo := userrepository.NewUserRepo(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`select UserID from "user" where Role=? order by UserID`)).
+		WithArgs(constants.Admin).
+		WillReturnRows(sqlmock.NewRows([]string{"UserID"}).AddRow("a1").AddRow("a2"))
+
+	ids, err := repo.GetUserIDsByRole(context.Background(), constants.Admin)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(ids) != 2 || ids[0] != "a1" || ids[1] != "a2" {
+		t.Errorf("unexpected admin IDs: %v", ids)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,23 @@
+package webhookRepository
+
+import (
+	"context"
+	"sms/constants"
+	"sms/models"
+	"time"
+)
+
+//go:generate mockgen -destination=../../mocks/webhook_repo_mock.go -package=mocks -source=interface.go
+type WebhookRepositoryI interface {
+	AddSubscription(ctx context.Context, sub models.WebhookSubscription) error
+	GetSubscription(ctx context.Context, subscriptionID string) (*models.WebhookSubscription, error)
+	GetSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error)
+	GetSubscriptionsForEvent(ctx context.Context, event constants.WebhookEvent) ([]models.WebhookSubscription, error)
+	SetSubscriptionActive(ctx context.Context, subscriptionID string, active bool) error
+	AddDeliveries(ctx context.Context, deliveries []models.WebhookDelivery) error
+	GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error)
+	GetDelivery(ctx context.Context, deliveryID string) (*models.WebhookDelivery, error)
+	GetDeliveries(ctx context.Context, subscriptionID string, limit int) ([]models.WebhookDelivery, error)
+	RecordAttempt(ctx context.Context, delivery models.WebhookDelivery, attempt models.WebhookAttempt) error
+	ResetDelivery(ctx context.Context, deliveryID string, nextAttemptAt time.Time) error
+}
@@ -0,0 +1,203 @@
+package webhookRepository
+
+import (
+	"context"
+	"database/sql"
+	"sms/constants"
+	"sms/models"
+	"sms/repository/transaction"
+	"time"
+)
+
+const (
+	subscriptionColumns = `s.SubscriptionID, s.URL, s.Secret, s.Active, s.CreatedAt`
+	deliveryColumns     = `DeliveryID, SubscriptionID, Event, Payload, Status, Attempts, NextAttemptAt, CreatedAt, DeliveredAt`
+)
+
+type WebhookRepo struct {
+	db transaction.Querier
+}
+
+func NewWebhookRepo(db transaction.Querier) *WebhookRepo {
+	return &WebhookRepo{db}
+}
+
+func (wr *WebhookRepo) AddSubscription(ctx context.Context, sub models.WebhookSubscription) error {
+	return transaction.RunInTx(ctx, wr.db, func(tx transaction.Querier) error {
+		_, err := tx.ExecContext(ctx, `insert into webhook_subscription values(?,?,?,?,?)`, sub.SubscriptionID, sub.URL, sub.Secret, sub.Active, sub.CreatedAt)
+		if err != nil {
+			return err
+		}
+		for _, event := range sub.Events {
+			if _, err := tx.ExecContext(ctx, `insert into webhook_subscription_event values(?,?)`, sub.SubscriptionID, event); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (wr *WebhookRepo) GetSubscription(ctx context.Context, subscriptionID string) (*models.WebhookSubscription, error) {
+	subs, err := wr.querySubscriptions(ctx, `select `+subscriptionColumns+` from webhook_subscription s where s.SubscriptionID=?`, subscriptionID)
+	if err != nil || len(subs) == 0 {
+		return nil, err
+	}
+	return &subs[0], nil
+}
+
+func (wr *WebhookRepo) GetSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error) {
+	return wr.querySubscriptions(ctx, `select `+subscriptionColumns+` from webhook_subscription s order by s.CreatedAt`)
+}
+
+// GetSubscriptionsForEvent returns the active subscriptions listening for the event.
+func (wr *WebhookRepo) GetSubscriptionsForEvent(ctx context.Context, event constants.WebhookEvent) ([]models.WebhookSubscription, error) {
+	stmt := `select ` + subscriptionColumns + ` from webhook_subscription s
+	join webhook_subscription_event e on e.SubscriptionID=s.SubscriptionID where e.Event=? and s.Active=? order by s.CreatedAt`
+	return wr.querySubscriptions(ctx, stmt, event, true)
+}
+
+func (wr *WebhookRepo) SetSubscriptionActive(ctx context.Context, subscriptionID string, active bool) error {
+	_, err := wr.db.ExecContext(ctx, `update webhook_subscription set Active=? where SubscriptionID=?`, active, subscriptionID)
+	return err
+}
+
+func (wr *WebhookRepo) AddDeliveries(ctx context.Context, deliveries []models.WebhookDelivery) error {
+	return transaction.RunInTx(ctx, wr.db, func(tx transaction.Querier) error {
+		for _, d := range deliveries {
+			_, err := tx.ExecContext(ctx, `insert into webhook_delivery (DeliveryID, SubscriptionID, Event, Payload, Status, Attempts, NextAttemptAt, CreatedAt) values(?,?,?,?,?,?,?,?)`,
+				d.DeliveryID, d.SubscriptionID, d.Event, d.Payload, d.Status, d.Attempts, d.NextAttemptAt, d.CreatedAt)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (wr *WebhookRepo) GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error) {
+	stmt := `select ` + deliveryColumns + ` from webhook_delivery where Status=? and NextAttemptAt<=? order by NextAttemptAt, CreatedAt limit ?`
+	return wr.queryDeliveries(ctx, stmt, constants.DeliveryPending, now, limit)
+}
+
+// GetDelivery returns a delivery with its attempt log, oldest attempt first.
+func (wr *WebhookRepo) GetDelivery(ctx context.Context, deliveryID string) (*models.WebhookDelivery, error) {
+	deliveries, err := wr.queryDeliveries(ctx, `select `+deliveryColumns+` from webhook_delivery where DeliveryID=?`, deliveryID)
+	if err != nil || len(deliveries) == 0 {
+		return nil, err
+	}
+	d := deliveries[0]
+
+	rows, err := wr.db.QueryContext(ctx, `select DeliveryID, AttemptedAt, StatusCode, Error, DurationMS from webhook_attempt where DeliveryID=? order by AttemptedAt`, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a models.WebhookAttempt
+		if err := rows.Scan(&a.DeliveryID, &a.AttemptedAt, &a.StatusCode, &a.Error, &a.DurationMS); err != nil {
+			return nil, err
+		}
+		d.Log = append(d.Log, a)
+	}
+	return &d, rows.Err()
+}
+
+// GetDeliveries returns the latest deliveries of a subscription, newest first.
+func (wr *WebhookRepo) GetDeliveries(ctx context.Context, subscriptionID string, limit int) ([]models.WebhookDelivery, error) {
+	stmt := `select ` + deliveryColumns + ` from webhook_delivery where SubscriptionID=? order by CreatedAt desc limit ?`
+	return wr.queryDeliveries(ctx, stmt, subscriptionID, limit)
+}
+
+// RecordAttempt logs an attempt and stores the delivery's new status in one transaction.
+func (wr *WebhookRepo) RecordAttempt(ctx context.Context, delivery models.WebhookDelivery, attempt models.WebhookAttempt) error {
+	return transaction.RunInTx(ctx, wr.db, func(tx transaction.Querier) error {
+		_, err := tx.ExecContext(ctx, `insert into webhook_attempt values(?,?,?,?,?)`,
+			attempt.DeliveryID, attempt.AttemptedAt, attempt.StatusCode, attempt.Error, attempt.DurationMS)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `update webhook_delivery set Status=?, Attempts=?, NextAttemptAt=?, DeliveredAt=? where DeliveryID=?`,
+			delivery.Status, delivery.Attempts, delivery.NextAttemptAt, delivery.DeliveredAt, delivery.DeliveryID)
+		if err != nil {
+			return err
+		}
+		return nil
+	})
+}
+
+// ResetDelivery queues a delivery again with a fresh set of attempts. Its log is kept.
+func (wr *WebhookRepo) ResetDelivery(ctx context.Context, deliveryID string, nextAttemptAt time.Time) error {
+	_, err := wr.db.ExecContext(ctx, `update webhook_delivery set Status=?, Attempts=0, NextAttemptAt=?, DeliveredAt=null where DeliveryID=?`,
+		constants.DeliveryPending, nextAttemptAt, deliveryID)
+	return err
+}
+
+func (wr *WebhookRepo) querySubscriptions(ctx context.Context, stmt string, args ...any) ([]models.WebhookSubscription, error) {
+	rows, err := wr.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subs []models.WebhookSubscription
+	for rows.Next() {
+		var s models.WebhookSubscription
+		if err := rows.Scan(&s.SubscriptionID, &s.URL, &s.Secret, &s.Active, &s.CreatedAt); err != nil {
+			return nil, err
+		}
+		subs = append(subs, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	for i := range subs {
+		if subs[i].Events, err = wr.getEvents(ctx, subs[i].SubscriptionID); err != nil {
+			return nil, err
+		}
+	}
+	return subs, nil
+}
+
+func (wr *WebhookRepo) getEvents(ctx context.Context, subscriptionID string) ([]constants.WebhookEvent, error) {
+	rows, err := wr.db.QueryContext(ctx, `select Event from webhook_subscription_event where SubscriptionID=? order by Event`, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []constants.WebhookEvent
+	for rows.Next() {
+		var event constants.WebhookEvent
+		if err := rows.Scan(&event); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+func (wr *WebhookRepo) queryDeliveries(ctx context.Context, stmt string, args ...any) ([]models.WebhookDelivery, error) {
+	rows, err := wr.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []models.WebhookDelivery
+	for rows.Next() {
+		var d models.WebhookDelivery
+		var deliveredAt sql.NullTime
+		err := rows.Scan(&d.DeliveryID, &d.SubscriptionID, &d.Event, &d.Payload, &d.Status, &d.Attempts, &d.NextAttemptAt, &d.CreatedAt, &deliveredAt)
+		if err != nil {
+			return nil, err
+		}
+		if deliveredAt.Valid {
+			d.DeliveredAt = &deliveredAt.Time
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, rows.Err()
+}
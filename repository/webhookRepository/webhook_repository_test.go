@@ -0,0 +1,176 @@
+package webhookRepository_test
+
+import (
+	"context"
+	"regexp"
+	"sms/constants"
+	"sms/models"
+	webhookRepository "sms/repository/webhookRepository"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+var deliveryColumns = []string{"DeliveryID", "SubscriptionID", "Event", "Payload", "Status", "Attempts", "NextAttemptAt", "CreatedAt", "DeliveredAt"}
+
+func TestAddSubscription(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := webhookRepository.NewWebhookRepo(db)
+	now := time.Date(2026, 9, 1, 10, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`insert into webhook_subscription values(?,?,?,?,?)`)).
+		WithArgs("w1", "https://lms.example.com/hook", "secret", true, now).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`insert into webhook_subscription_event values(?,?)`)).
+		WithArgs("w1", constants.WebhookGradePosted).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`insert into webhook_subscription_event values(?,?)`)).
+		WithArgs("w1", constants.WebhookGradeChanged).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err = repo.AddSubscription(context.Background(), models.WebhookSubscription{
+		SubscriptionID: "w1", URL: "https://lms.example.com/hook", Secret: "secret", Active: true, CreatedAt: now,
+		Events: []constants.WebhookEvent{constants.WebhookGradePosted, constants.WebhookGradeChanged},
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetSubscriptionsForEvent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := webhookRepository.NewWebhookRepo(db)
+	now := time.Date(2026, 9, 1, 10, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`select s.SubscriptionID, s.URL, s.Secret, s.Active, s.CreatedAt from webhook_subscription s
+	join webhook_subscription_event e on e.SubscriptionID=s.SubscriptionID where e.Event=? and s.Active=? order by s.CreatedAt`)).
+		WithArgs(constants.WebhookGradePosted, true).
+		WillReturnRows(sqlmock.NewRows([]string{"SubscriptionID", "URL", "Secret", "Active", "CreatedAt"}).AddRow("w1", "https://lms.example.com/hook", "secret", true, now))
+	mock.ExpectQuery(regexp.QuoteMeta(`select Event from webhook_subscription_event where SubscriptionID=? order by Event`)).
+		WithArgs("w1").WillReturnRows(sqlmock.NewRows([]string{"Event"}).AddRow("grade.changed").AddRow("grade.posted"))
+
+	subs, err := repo.GetSubscriptionsForEvent(context.Background(), constants.WebhookGradePosted)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(subs) != 1 || subs[0].Secret != "secret" || len(subs[0].Events) != 2 {
+		t.Errorf("unexpected subscriptions: %+v", subs)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetSubscription_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := webhookRepository.NewWebhookRepo(db)
+	mock.ExpectQuery(regexp.QuoteMeta(`select s.SubscriptionID, s.URL, s.Secret, s.Active, s.CreatedAt from webhook_subscription s where s.SubscriptionID=?`)).
+		WithArgs("missing").WillReturnRows(sqlmock.NewRows([]string{"SubscriptionID", "URL", "Secret", "Active", "CreatedAt"}))
+
+	sub, err := repo.GetSubscription(context.Background(), "missing")
+	if err != nil || sub != nil {
+		t.Errorf("expected nil subscription and nil error, got %+v, %v", sub, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetDelivery(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := webhookRepository.NewWebhookRepo(db)
+	now := time.Date(2026, 9, 1, 10, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`select DeliveryID, SubscriptionID, Event, Payload, Status, Attempts, NextAttemptAt, CreatedAt, DeliveredAt from webhook_delivery where DeliveryID=?`)).
+		WithArgs("d1").
+		WillReturnRows(sqlmock.NewRows(deliveryColumns).AddRow("d1", "w1", "grade.posted", `{"id":"d1"}`, "delivered", 2, now, now, now))
+	mock.ExpectQuery(regexp.QuoteMeta(`select DeliveryID, AttemptedAt, StatusCode, Error, DurationMS from webhook_attempt where DeliveryID=? order by AttemptedAt`)).
+		WithArgs("d1").
+		WillReturnRows(sqlmock.NewRows([]string{"DeliveryID", "AttemptedAt", "StatusCode", "Error", "DurationMS"}).
+			AddRow("d1", now.Add(-time.Minute), 503, "endpoint responded with status 503", 40).
+			AddRow("d1", now, 200, "", 35))
+
+	d, err := repo.GetDelivery(context.Background(), "d1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d == nil || d.Status != constants.DeliveryDelivered || d.DeliveredAt == nil || len(d.Log) != 2 || d.Log[0].StatusCode != 503 {
+		t.Errorf("unexpected delivery: %+v", d)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRecordAttempt(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := webhookRepository.NewWebhookRepo(db)
+	now := time.Date(2026, 9, 1, 10, 0, 0, 0, time.UTC)
+	next := now.Add(time.Minute)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`insert into webhook_attempt values(?,?,?,?,?)`)).
+		WithArgs("d1", now, 503, "endpoint responded with status 503", int64(40)).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`update webhook_delivery set Status=?, Attempts=?, NextAttemptAt=?, DeliveredAt=? where DeliveryID=?`)).
+		WithArgs(constants.DeliveryPending, 2, next, nil, "d1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = repo.RecordAttempt(context.Background(),
+		models.WebhookDelivery{DeliveryID: "d1", Status: constants.DeliveryPending, Attempts: 2, NextAttemptAt: next},
+		models.WebhookAttempt{DeliveryID: "d1", AttemptedAt: now, StatusCode: 503, Error: "endpoint responded with status 503", DurationMS: 40},
+	)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
+
+func TestResetDelivery(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := webhookRepository.NewWebhookRepo(db)
+	now := time.Date(2026, 9, 1, 10, 0, 0, 0, time.UTC)
+	mock.ExpectExec(regexp.QuoteMeta(`update webhook_delivery set Status=?, Attempts=0, NextAttemptAt=?, DeliveredAt=null where DeliveryID=?`)).
+		WithArgs(constants.DeliveryPending, now, "d1").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := repo.ResetDelivery(context.Background(), "d1", now); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,191 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sms/apperrors"
+	"sms/constants"
+	"sms/models"
+	alertRepository "sms/repository/alertRepository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AlertService struct {
+	ar       alertRepository.AlertRepositoryI
+	notifier AtRiskNotifierI
+}
+
+func NewAlertService(ar alertRepository.AlertRepositoryI, notifier AtRiskNotifierI) *AlertService {
+	return &AlertService{ar: ar, notifier: notifier}
+}
+
+// DefaultAtRiskCriteria are the criteria of a scan that doesn't set its own.
+func DefaultAtRiskCriteria() models.AtRiskCriteria {
+	return models.AtRiskCriteria{
+		MinAverage:        constants.DefaultAtRiskMinAverage,
+		MaxDrop:           constants.DefaultAtRiskMaxDrop,
+		PassMark:          constants.DefaultPassMark,
+		MaxFailedSubjects: constants.DefaultAtRiskMaxFailedSubjects,
+	}
+}
+
+type studentSemester struct {
+	classID string
+	grades  []int
+}
+
+// DetectAtRisk scans the grades of a semester and replaces the stored flags for
+// that semester. A student who was already flagged keeps the flag raised first;
+// only students flagged for the first time are sent to the notifier, so running
+// the scan again doesn't repeat the alerts. Faculty and admins start a scan
+// through the API and RunWorker repeats it on a schedule.
+func (as *AlertService) DetectAtRisk(ctx context.Context, semester int, criteria models.AtRiskCriteria) ([]models.AtRiskFlag, error) {
+	if semester <= 0 {
+		return nil, apperrors.Validation("semester must be positive")
+	}
+
+	semesters := []int{semester}
+	if semester > 1 {
+		semesters = append(semesters, semester-1)
+	}
+	grades, err := as.ar.GetStudentGrades(ctx, semesters...)
+	if err != nil {
+		return nil, err
+	}
+
+	current := map[string]*studentSemester{}
+	previous := map[string]*studentSemester{}
+	var order []string
+	for _, g := range grades {
+		byStudent := previous
+		if g.Semester == semester {
+			byStudent = current
+		}
+		s, ok := byStudent[g.StudentID]
+		if !ok {
+			s = &studentSemester{classID: g.ClassID}
+			byStudent[g.StudentID] = s
+			if g.Semester == semester {
+				order = append(order, g.StudentID)
+			}
+		}
+		s.grades = append(s.grades, g.Grade)
+	}
+
+	stored, err := as.ar.GetFlags(ctx, semester)
+	if err != nil {
+		return nil, err
+	}
+	flagged := map[string]models.AtRiskFlag{}
+	for _, f := range stored {
+		flagged[f.StudentID] = f
+	}
+
+	now := time.Now().UTC()
+	var flags, raised []models.AtRiskFlag
+	for _, studentID := range order {
+		s := current[studentID]
+		reasons := atRiskReasons(s.grades, previous[studentID], criteria)
+		if len(reasons) == 0 {
+			continue
+		}
+		flag := models.AtRiskFlag{
+			FlagID:    uuid.New().String(),
+			StudentID: studentID,
+			ClassID:   s.classID,
+			Semester:  semester,
+			Reasons:   reasons,
+			CreatedAt: now,
+		}
+		if f, ok := flagged[studentID]; ok {
+			flag.FlagID, flag.CreatedAt = f.FlagID, f.CreatedAt
+		} else {
+			raised = append(raised, flag)
+		}
+		flags = append(flags, flag)
+	}
+
+	if err := as.ar.ReplaceFlags(ctx, semester, flags); err != nil {
+		return nil, err
+	}
+	for _, f := range raised {
+		if err := as.notifier.NotifyAtRisk(ctx, f); err != nil {
+			log.Printf("failed to notify at-risk flag %s: %v", f.FlagID, err)
+		}
+	}
+	return flags, nil
+}
+
+// RunWorker scans the semesters students are in now every interval until ctx is
+// done.
+func (as *AlertService) RunWorker(ctx context.Context, interval time.Duration, criteria models.AtRiskCriteria) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := as.scanCurrentSemesters(ctx, criteria); err != nil {
+				log.Printf("failed to scan for at-risk students: %v", err)
+			}
+		}
+	}
+}
+
+func (as *AlertService) scanCurrentSemesters(ctx context.Context, criteria models.AtRiskCriteria) error {
+	semesters, err := as.ar.GetCurrentSemesters(ctx)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, semester := range semesters {
+		if _, err := as.DetectAtRisk(ctx, semester, criteria); err != nil {
+			errs = append(errs, fmt.Errorf("semester %d: %w", semester, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (as *AlertService) GetAtRiskFlags(ctx context.Context, semester int) ([]models.AtRiskFlag, error) {
+	if semester < 0 {
+		return nil, apperrors.Validation("semester can't be negative")
+	}
+	return as.ar.GetFlags(ctx, semester)
+}
+
+func atRiskReasons(grades []int, previous *studentSemester, criteria models.AtRiskCriteria) []string {
+	var reasons []string
+
+	avg := average(grades)
+	if avg < criteria.MinAverage {
+		reasons = append(reasons, fmt.Sprintf("average %.2f is below %.2f", avg, criteria.MinAverage))
+	}
+	if previous != nil {
+		if drop := average(previous.grades) - avg; drop >= criteria.MaxDrop {
+			reasons = append(reasons, fmt.Sprintf("average dropped by %.2f from the previous semester", drop))
+		}
+	}
+	failed := 0
+	for _, g := range grades {
+		if g < criteria.PassMark {
+			failed++
+		}
+	}
+	if failed > criteria.MaxFailedSubjects {
+		reasons = append(reasons, fmt.Sprintf("failed %d subjects, at most %d allowed", failed, criteria.MaxFailedSubjects))
+	}
+	return reasons
+}
+
+func average(grades []int) float64 {
+	sum := 0
+	for _, g := range grades {
+		sum += g
+	}
+	return float64(sum) / float64(len(grades))
+}
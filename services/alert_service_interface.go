@@ -0,0 +1,12 @@
+package services
+
+import (
+	"context"
+	"sms/models"
+)
+
+//go:generate mockgen -destination=../mocks/alert_service_mock.go -package=mocks -source=alert_service_interface.go
+type AlertServiceI interface {
+	DetectAtRisk(ctx context.Context, semester int, criteria models.AtRiskCriteria) ([]models.AtRiskFlag, error)
+	GetAtRiskFlags(ctx context.Context, semester int) ([]models.AtRiskFlag, error)
+}
@@ -0,0 +1,152 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	mockrepo "sms/mocks"
+	"sms/models"
+	alertRepository "sms/repository/alertRepository"
+	"sms/services"
+)
+
+type recordingNotifier struct {
+	flags []models.AtRiskFlag
+	err   error
+}
+
+func (rn *recordingNotifier) NotifyAtRisk(_ context.Context, flag models.AtRiskFlag) error {
+	rn.flags = append(rn.flags, flag)
+	return rn.err
+}
+
+func TestDetectAtRisk(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockAlertRepositoryI(ctrl)
+	notifier := &recordingNotifier{err: errors.New("smtp down")}
+	svc := services.NewAlertService(mockRepo, notifier)
+
+	grades := []alertRepository.StudentGrade{
+		// s1: healthy in both semesters
+		{StudentID: "s1", ClassID: "C1", SubjectID: "a", Grade: 80, Semester: 1},
+		{StudentID: "s1", ClassID: "C1", SubjectID: "b", Grade: 85, Semester: 2},
+		// s2: sharp drop from 90 to 60
+		{StudentID: "s2", ClassID: "C1", SubjectID: "a", Grade: 90, Semester: 1},
+		{StudentID: "s2", ClassID: "C1", SubjectID: "b", Grade: 60, Semester: 2},
+		// s3: low average and two failed subjects, no previous semester
+		{StudentID: "s3", ClassID: "C2", SubjectID: "b", Grade: 30, Semester: 2},
+		{StudentID: "s3", ClassID: "C2", SubjectID: "c", Grade: 20, Semester: 2},
+	}
+	// at most one failed subject is allowed, as in rollovers
+	criteria := models.AtRiskCriteria{MinAverage: 50, MaxDrop: 15, PassMark: 40, MaxFailedSubjects: 1}
+
+	// s2 was flagged by an earlier scan, s1 recovered since
+	raisedAt := time.Date(2026, 9, 1, 10, 0, 0, 0, time.UTC)
+	stored := []models.AtRiskFlag{
+		{FlagID: "f1", StudentID: "s1", ClassID: "C1", Semester: 2, CreatedAt: raisedAt},
+		{FlagID: "f2", StudentID: "s2", ClassID: "C1", Semester: 2, CreatedAt: raisedAt},
+	}
+
+	mockRepo.EXPECT().GetStudentGrades(gomock.Any(), 2, 1).Return(grades, nil)
+	mockRepo.EXPECT().GetFlags(gomock.Any(), 2).Return(stored, nil)
+	mockRepo.EXPECT().ReplaceFlags(gomock.Any(), 2, gomock.Len(2)).Return(nil)
+
+	flags, err := svc.DetectAtRisk(context.Background(), 2, criteria)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(flags) != 2 {
+		t.Fatalf("expected 2 flags, got %d", len(flags))
+	}
+	if flags[0].StudentID != "s2" || len(flags[0].Reasons) != 1 || flags[0].FlagID != "f2" || !flags[0].CreatedAt.Equal(raisedAt) {
+		t.Errorf("expected s2 to keep its flag for a drop, got %+v", flags[0])
+	}
+	if flags[1].StudentID != "s3" || flags[1].ClassID != "C2" || len(flags[1].Reasons) != 2 || flags[1].Reasons[1] != "failed 2 subjects, at most 1 allowed" {
+		t.Errorf("expected s3 flagged for average and failures, got %+v", flags[1])
+	}
+	if len(notifier.flags) != 1 || notifier.flags[0].StudentID != "s3" {
+		t.Errorf("expected only the new flag for s3 to be notified, got %+v", notifier.flags)
+	}
+}
+
+func TestAlertWorker(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockAlertRepositoryI(ctrl)
+	notifier := &recordingNotifier{}
+	svc := services.NewAlertService(mockRepo, notifier)
+
+	grades := []alertRepository.StudentGrade{{StudentID: "s1", ClassID: "C1", SubjectID: "a", Grade: 20, Semester: 1}}
+	criteria := models.AtRiskCriteria{MinAverage: 50, MaxDrop: 15, PassMark: 40, MaxFailedSubjects: 1}
+	scanned := make(chan struct{})
+	var once sync.Once
+
+	mockRepo.EXPECT().GetCurrentSemesters(gomock.Any()).Return([]int{1}, nil).MinTimes(1)
+	mockRepo.EXPECT().GetStudentGrades(gomock.Any(), 1).Return(grades, nil).MinTimes(1)
+	mockRepo.EXPECT().GetFlags(gomock.Any(), 1).Return(nil, nil).MinTimes(1)
+	mockRepo.EXPECT().ReplaceFlags(gomock.Any(), 1, gomock.Len(1)).DoAndReturn(func(context.Context, int, []models.AtRiskFlag) error {
+		once.Do(func() { close(scanned) })
+		return nil
+	}).MinTimes(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		svc.RunWorker(ctx, time.Millisecond, criteria)
+		close(stopped)
+	}()
+	select {
+	case <-scanned:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the worker did not scan")
+	}
+	cancel()
+	<-stopped
+
+	if len(notifier.flags) == 0 || notifier.flags[0].StudentID != "s1" {
+		t.Errorf("expected s1 to be notified, got %+v", notifier.flags)
+	}
+}
+
+func TestDetectAtRisk_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockAlertRepositoryI(ctrl)
+	svc := services.NewAlertService(mockRepo, services.NewLogNotifier())
+
+	if _, err := svc.DetectAtRisk(context.Background(), 0, models.AtRiskCriteria{}); err == nil {
+		t.Errorf("expected error for invalid semester")
+	}
+
+	mockRepo.EXPECT().GetStudentGrades(gomock.Any(), 1).Return(nil, errors.New("db error"))
+	if _, err := svc.DetectAtRisk(context.Background(), 1, models.AtRiskCriteria{}); err == nil || err.Error() != "db error" {
+		t.Errorf("expected db error, got %v", err)
+	}
+}
+
+func TestGetAtRiskFlags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockAlertRepositoryI(ctrl)
+	svc := services.NewAlertService(mockRepo, services.NewLogNotifier())
+
+	mockRepo.EXPECT().GetFlags(gomock.Any(), 0).Return([]models.AtRiskFlag{{FlagID: "f1"}}, nil)
+	flags, err := svc.GetAtRiskFlags(context.Background(), 0)
+	if err != nil || len(flags) != 1 {
+		t.Errorf("expected one flag, got %v, %v", flags, err)
+	}
+
+	if _, err := svc.GetAtRiskFlags(context.Background(), -1); err == nil {
+		t.Errorf("expected error for negative semester")
+	}
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"sms/apperrors"
+	"sms/constants"
+	"sms/models"
+	attendanceRepository "sms/repository/attendanceRepository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AttendanceService struct {
+	ar            attendanceRepository.AttendanceRepositoryI
+	minAttendance float64
+}
+
+func NewAttendanceService(ar attendanceRepository.AttendanceRepositoryI, minAttendance float64) *AttendanceService {
+	return &AttendanceService{ar: ar, minAttendance: minAttendance}
+}
+
+func (as *AttendanceService) CreateSession(ctx context.Context, classID, subjectID string, semester int, date time.Time, createdBy string) (*models.AttendanceSession, error) {
+	if classID == "" || subjectID == "" {
+		return nil, apperrors.Validation("classID and subjectID can't be empty")
+	}
+	if semester <= 0 {
+		return nil, apperrors.Validation("semester must be positive")
+	}
+	session := models.AttendanceSession{
+		SessionID: uuid.New().String(),
+		ClassID:   classID,
+		SubjectID: subjectID,
+		Semester:  semester,
+		Date:      date,
+		CreatedBy: createdBy,
+	}
+	if err := as.ar.AddSession(ctx, session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (as *AttendanceService) MarkAttendance(ctx context.Context, sessionID string, marks []models.AttendanceMark) error {
+	if len(marks) == 0 {
+		return apperrors.Validation("no attendance marks given")
+	}
+	session, err := as.ar.GetSession(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if session == nil {
+		return apperrors.NotFound("attendance session not found")
+	}
+	for i := range marks {
+		switch marks[i].Status {
+		case constants.Present, constants.Absent, constants.Late, constants.Excused:
+		default:
+			return apperrors.Validation("invalid attendance status %q", marks[i].Status)
+		}
+		if marks[i].StudentID == "" {
+			return apperrors.Validation("studentID can't be empty")
+		}
+		marks[i].SessionID = sessionID
+	}
+	return as.ar.MarkAttendance(ctx, marks)
+}
+
+func (as *AttendanceService) GetStudentAttendance(ctx context.Context, studentID, subjectID string, semester int) (*models.AttendanceSummary, error) {
+	counts, err := as.ar.GetStudentStatusCounts(ctx, studentID, subjectID, semester)
+	if err != nil {
+		return nil, err
+	}
+	summary := models.AttendanceSummary{StudentID: studentID, SubjectID: subjectID, Semester: semester}
+	for _, c := range counts {
+		addStatusCount(&summary, c)
+	}
+	summary.Percentage = attendancePercentage(summary)
+	return &summary, nil
+}
+
+func (as *AttendanceService) GetClassAttendance(ctx context.Context, classID, subjectID string, semester int) ([]models.AttendanceSummary, error) {
+	counts, err := as.ar.GetClassStatusCounts(ctx, classID, subjectID, semester)
+	if err != nil {
+		return nil, err
+	}
+	var summaries []models.AttendanceSummary
+	for _, c := range counts {
+		if n := len(summaries); n == 0 || summaries[n-1].StudentID != c.StudentID {
+			summaries = append(summaries, models.AttendanceSummary{StudentID: c.StudentID, SubjectID: subjectID, Semester: semester})
+		}
+		addStatusCount(&summaries[len(summaries)-1], c)
+	}
+	for i := range summaries {
+		summaries[i].Percentage = attendancePercentage(summaries[i])
+	}
+	return summaries, nil
+}
+
+// CanBeGraded refuses students below the minimum attendance.
+func (as *AttendanceService) CanBeGraded(ctx context.Context, studentID, subjectID string, semester int) error {
+	summary, err := as.GetStudentAttendance(ctx, studentID, subjectID, semester)
+	if err != nil {
+		return err
+	}
+	if summary.Percentage < as.minAttendance {
+		return apperrors.Forbidden("attendance %.2f%% is below the minimum of %.2f%%", summary.Percentage, as.minAttendance)
+	}
+	return nil
+}
+
+func addStatusCount(summary *models.AttendanceSummary, c attendanceRepository.StatusCount) {
+	switch c.Status {
+	case constants.Present:
+		summary.Present += c.Count
+	case constants.Absent:
+		summary.Absent += c.Count
+	case constants.Late:
+		summary.Late += c.Count
+	case constants.Excused:
+		summary.Excused += c.Count
+	}
+	summary.Total += c.Count
+}
+
+// attendancePercentage counts late arrivals as attended and leaves excused
+// absences out of the total. A student with no sessions held yet is at 100%.
+func attendancePercentage(summary models.AttendanceSummary) float64 {
+	counted := summary.Total - summary.Excused
+	if counted == 0 {
+		return 100
+	}
+	return float64(summary.Present+summary.Late) / float64(counted) * 100
+}
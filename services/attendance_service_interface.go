@@ -0,0 +1,16 @@
+package services
+
+import (
+	"context"
+	"sms/models"
+	"time"
+)
+
+//go:generate mockgen -destination=../mocks/attendance_service_mock.go -package=mocks -source=attendance_service_interface.go
+type AttendanceServiceI interface {
+	CreateSession(ctx context.Context, classID, subjectID string, semester int, date time.Time, createdBy string) (*models.AttendanceSession, error)
+	MarkAttendance(ctx context.Context, sessionID string, marks []models.AttendanceMark) error
+	GetStudentAttendance(ctx context.Context, studentID, subjectID string, semester int) (*models.AttendanceSummary, error)
+	GetClassAttendance(ctx context.Context, classID, subjectID string, semester int) ([]models.AttendanceSummary, error)
+	CanBeGraded(ctx context.Context, studentID, subjectID string, semester int) error
+}
@@ -0,0 +1,138 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"sms/constants"
+	mockrepo "sms/mocks"
+	"sms/models"
+	attendanceRepository "sms/repository/attendanceRepository"
+	"sms/services"
+)
+
+func TestCreateSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockAttendanceRepositoryI(ctrl)
+	svc := services.NewAttendanceService(mockRepo, 75)
+	date := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	mockRepo.EXPECT().AddSession(gomock.Any(), gomock.Any()).Return(nil)
+	session, err := svc.CreateSession(context.Background(), "C1", "sub1", 1, date, "fac1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if session.SessionID == "" || session.CreatedBy != "fac1" {
+		t.Errorf("unexpected session: %+v", session)
+	}
+
+	if _, err := svc.CreateSession(context.Background(), "", "sub1", 1, date, "fac1"); err == nil {
+		t.Errorf("expected error for empty classID")
+	}
+	if _, err := svc.CreateSession(context.Background(), "C1", "sub1", 0, date, "fac1"); err == nil {
+		t.Errorf("expected error for invalid semester")
+	}
+}
+
+func TestMarkAttendance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockAttendanceRepositoryI(ctrl)
+	svc := services.NewAttendanceService(mockRepo, 75)
+
+	mockRepo.EXPECT().GetSession(gomock.Any(), "sess1").Return(&models.AttendanceSession{SessionID: "sess1"}, nil).Times(2)
+	mockRepo.EXPECT().MarkAttendance(gomock.Any(), []models.AttendanceMark{{SessionID: "sess1", StudentID: "s1", Status: constants.Late}}).Return(nil)
+
+	if err := svc.MarkAttendance(context.Background(), "sess1", []models.AttendanceMark{{StudentID: "s1", Status: constants.Late}}); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if err := svc.MarkAttendance(context.Background(), "sess1", []models.AttendanceMark{{StudentID: "s1", Status: "sleeping"}}); err == nil {
+		t.Errorf("expected error for invalid status")
+	}
+
+	mockRepo.EXPECT().GetSession(gomock.Any(), "missing").Return(nil, nil)
+	if err := svc.MarkAttendance(context.Background(), "missing", []models.AttendanceMark{{StudentID: "s1", Status: constants.Present}}); err == nil {
+		t.Errorf("expected error for missing session")
+	}
+	if err := svc.MarkAttendance(context.Background(), "sess1", nil); err == nil {
+		t.Errorf("expected error for empty marks")
+	}
+}
+
+func TestGetStudentAttendance_And_Eligibility(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockAttendanceRepositoryI(ctrl)
+	svc := services.NewAttendanceService(mockRepo, 75)
+
+	counts := []attendanceRepository.StatusCount{
+		{StudentID: "s1", Status: constants.Present, Count: 5},
+		{StudentID: "s1", Status: constants.Late, Count: 1},
+		{StudentID: "s1", Status: constants.Absent, Count: 2},
+		{StudentID: "s1", Status: constants.Excused, Count: 2},
+	}
+	mockRepo.EXPECT().GetStudentStatusCounts(gomock.Any(), "s1", "sub1", 1).Return(counts, nil).Times(2)
+
+	summary, err := svc.GetStudentAttendance(context.Background(), "s1", "sub1", 1)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if summary.Total != 10 || summary.Percentage != 75 {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+
+	if err := svc.CanBeGraded(context.Background(), "s1", "sub1", 1); err != nil {
+		t.Errorf("expected student to be gradable, got %v", err)
+	}
+
+	mockRepo.EXPECT().GetStudentStatusCounts(gomock.Any(), "s2", "sub1", 1).Return([]attendanceRepository.StatusCount{
+		{StudentID: "s2", Status: constants.Absent, Count: 3},
+		{StudentID: "s2", Status: constants.Present, Count: 1},
+	}, nil)
+	if err := svc.CanBeGraded(context.Background(), "s2", "sub1", 1); err == nil {
+		t.Errorf("expected low attendance to block grading")
+	}
+
+	mockRepo.EXPECT().GetStudentStatusCounts(gomock.Any(), "s3", "sub1", 1).Return(nil, nil)
+	if err := svc.CanBeGraded(context.Background(), "s3", "sub1", 1); err != nil {
+		t.Errorf("expected student without sessions to be gradable, got %v", err)
+	}
+}
+
+func TestGetClassAttendance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockAttendanceRepositoryI(ctrl)
+	svc := services.NewAttendanceService(mockRepo, 75)
+
+	mockRepo.EXPECT().GetClassStatusCounts(gomock.Any(), "C1", "", 1).Return([]attendanceRepository.StatusCount{
+		{StudentID: "s1", Status: constants.Present, Count: 3},
+		{StudentID: "s1", Status: constants.Absent, Count: 1},
+		{StudentID: "s2", Status: constants.Present, Count: 4},
+	}, nil)
+
+	summaries, err := svc.GetClassAttendance(context.Background(), "C1", "", 1)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(summaries) != 2 {
+		t.Fatalf("expected 2 summaries, got %d", len(summaries))
+	}
+	if summaries[0].Percentage != 75 || summaries[1].Percentage != 100 {
+		t.Errorf("unexpected percentages: %+v", summaries)
+	}
+
+	mockRepo.EXPECT().GetClassStatusCounts(gomock.Any(), "C2", "", 1).Return(nil, errors.New("db error"))
+	if _, err := svc.GetClassAttendance(context.Background(), "C2", "", 1); err == nil {
+		t.Errorf("expected db error")
+	}
+}
@@ -5,6 +5,9 @@ import (
 	"errors"
 	"net/mail"
 	"regexp"
+	"sms/apperrors"
+	"sms/constants"
+	"sms/events"
 	"sms/models"
 	userrepository "sms/repository/userRepository"
 
@@ -12,33 +15,66 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+const weakPasswordMessage = "password must be at least 12 characters long, and include uppercase, lowercase, number, and symbol"
+
 type AuthService struct {
-	ur userrepository.UserRepositoryI
+	ur         userrepository.UserRepositoryI
+	events     events.Publisher
+	bcryptCost int
+	tokens     *JWT
+}
+
+type AuthServiceOption func(*AuthService)
+
+// WithAuthEvents publishes UserSignedUp for every account created through the service.
+func WithAuthEvents(publisher events.Publisher) AuthServiceOption {
+	return func(a *AuthService) {
+		a.events = publisher
+	}
+}
+
+// WithBcryptCost hashes new passwords at the given cost instead of
+// bcrypt.DefaultCost. Existing hashes keep the cost they were made with.
+func WithBcryptCost(cost int) AuthServiceOption {
+	return func(a *AuthService) {
+		a.bcryptCost = cost
+	}
+}
+
+// WithTokens signs the tokens IssueToken hands out.
+func WithTokens(tokens *JWT) AuthServiceOption {
+	return func(a *AuthService) {
+		a.tokens = tokens
+	}
 }
 
-func NewAuthService(ur userrepository.UserRepositoryI) *AuthService {
-	return &AuthService{ur: ur}
+func NewAuthService(ur userrepository.UserRepositoryI, opts ...AuthServiceOption) *AuthService {
+	a := &AuthService{ur: ur, bcryptCost: bcrypt.DefaultCost}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
 }
 
 func (a *AuthService) ValidateLogin(ctx context.Context, email, password string) (models.User, error) {
-	user, err := a.ur.GetUserByEmailID(email)
+	user, err := a.ur.GetUserByEmailID(ctx, email)
 
 	if user == nil && err == nil {
-		return models.User{}, errors.New("user not found")
+		return models.User{}, apperrors.Unauthorized("invalid email or password")
 	}
 	if err != nil {
 		return models.User{}, err
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
-		return models.User{}, errors.New("invalid email or password")
+		return models.User{}, apperrors.Unauthorized("invalid email or password")
 	}
 
 	return *user, nil
 }
 
 func (a *AuthService) HashPassword(password string) (string, error) {
-	hashedBytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	hashedBytes, err := bcrypt.GenerateFromPassword([]byte(password), a.bcryptCost)
 	return string(hashedBytes), err
 }
 
@@ -60,27 +96,81 @@ func (a *AuthService) IsValidPassword(password string) bool {
 }
 
 func (a *AuthService) Signup(ctx context.Context, name, email, password string) (models.User, error) {
-	if !a.IsValidEmail(email) {
-		return models.User{}, errors.New("invalid email format")
+	uuid, hashedPassword, err := a.prepareAccount(ctx, email, password)
+	if err != nil {
+		return models.User{}, err
+	}
+	err = a.ur.AddUser(ctx, uuid, name, email, hashedPassword)
+	if err != nil {
+		return models.User{}, err
 	}
 
-	if user, _ := a.ur.GetUserByEmailID(email); user != nil {
-		return models.User{}, errors.New("email already in use")
+	publishEvent(ctx, a.events, events.UserSignedUp{UserID: uuid, Name: name, Email: email, Role: constants.Faculty})
+	return models.User{Name: name, UserID: uuid, Role: "faculty"}, nil
+}
+
+// CreateAccount creates a user with the given role, for accounts that are set up
+// by an admin rather than through signup.
+func (a *AuthService) CreateAccount(ctx context.Context, name, email, password string, role constants.Role) (models.User, error) {
+	if role != constants.Admin && role != constants.Faculty && role != constants.Guardian {
+		return models.User{}, apperrors.Validation("invalid role")
+	}
+	uuid, hashedPassword, err := a.prepareAccount(ctx, email, password)
+	if err != nil {
+		return models.User{}, err
+	}
+	if err := a.ur.AddUserWithRole(ctx, uuid, name, email, hashedPassword, role); err != nil {
+		return models.User{}, err
 	}
+	publishEvent(ctx, a.events, events.UserSignedUp{UserID: uuid, Name: name, Email: email, Role: role})
+	return models.User{Name: name, UserID: uuid, Email: email, Role: role}, nil
+}
 
+// ResetPassword sets a new password for the account with the email. The new
+// password has to meet the same rules as at signup.
+func (a *AuthService) ResetPassword(ctx context.Context, email, password string) error {
+	user, err := a.ur.GetUserByEmailID(ctx, email)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return apperrors.NotFound("user not found")
+	}
 	if !a.IsValidPassword(password) {
-		return models.User{}, errors.New("password must be at least 12 characters long, and include uppercase, lowercase, number, and symbol")
+		return apperrors.Validation(weakPasswordMessage)
 	}
 	hashedPassword, err := a.HashPassword(password)
 	if err != nil {
-		return models.User{}, err
+		return err
+	}
+	return a.ur.UpdatePassword(ctx, user.UserID, hashedPassword)
+}
+
+// prepareAccount validates the credentials of a new account and returns its ID
+// and hashed password.
+func (a *AuthService) prepareAccount(ctx context.Context, email, password string) (string, string, error) {
+	if !a.IsValidEmail(email) {
+		return "", "", apperrors.Validation("invalid email format")
+	}
+
+	if user, _ := a.ur.GetUserByEmailID(ctx, email); user != nil {
+		return "", "", apperrors.Conflict("email already in use")
 	}
 
-	uuid := uuid.New().String()
-	err = a.ur.AddUser(uuid, name, email, hashedPassword)
+	if !a.IsValidPassword(password) {
+		return "", "", apperrors.Validation(weakPasswordMessage)
+	}
+	hashedPassword, err := a.HashPassword(password)
 	if err != nil {
-		return models.User{}, err
+		return "", "", err
 	}
+	return uuid.New().String(), hashedPassword, nil
+}
 
-	return models.User{Name: name, UserID: uuid, Role: "faculty"}, nil
+// IssueToken signs a token for a user who has logged in or signed up.
+func (a *AuthService) IssueToken(user models.User) (string, error) {
+	if a.tokens == nil {
+		return "", errors.New("no token signer configured")
+	}
+	return a.tokens.Generate(user.UserID, user.Email, user.Role)
 }
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"sms/constants"
 	"sms/models"
 )
 
@@ -9,4 +10,7 @@ import (
 type AuthServiceI interface {
 	ValidateLogin(ctx context.Context, email, password string) (models.User, error)
 	Signup(ctx context.Context, name, email, password string) (models.User, error)
+	CreateAccount(ctx context.Context, name, email, password string, role constants.Role) (models.User, error)
+	ResetPassword(ctx context.Context, email, password string) error
+	IssueToken(user models.User) (string, error)
 }
@@ -2,13 +2,20 @@ package services_test
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
+	"sms/apperrors"
+	"sms/constants"
+	"sms/events"
+	"sms/events/eventstest"
 	mockrepo "sms/mocks"
 	"sms/models"
 	"sms/services"
 
-	"github.com/golang/mock/gomock"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func TestValidateLogin_Success(t *testing.T) {
@@ -31,7 +38,7 @@ func TestValidateLogin_Success(t *testing.T) {
 		Role:     "faculty",
 	}
 
-	mockRepo.EXPECT().GetUserByEmailID(email).Return(user, nil)
+	mockRepo.EXPECT().GetUserByEmailID(gomock.Any(), email).Return(user, nil)
 
 	ctx := context.Background()
 	result, err := authSvc.ValidateLogin(ctx, email, password)
@@ -52,12 +59,14 @@ func TestValidateLogin_UserNotFound(t *testing.T) {
 
 	email := "notfound@example.com"
 
-	mockRepo.EXPECT().GetUserByEmailID(email).Return(nil, nil)
+	mockRepo.EXPECT().GetUserByEmailID(gomock.Any(), email).Return(nil, nil)
 
 	ctx := context.Background()
 	_, err := authSvc.ValidateLogin(ctx, email, "password")
-	if err == nil || err.Error() != "user not found" {
-		t.Fatalf("expected 'user not found' error, got %v", err)
+	// an unknown email is reported like a wrong password so logins don't reveal
+	// which accounts exist
+	if !errors.Is(err, apperrors.ErrUnauthorized) || err.Error() != "invalid email or password" {
+		t.Fatalf("expected 'invalid email or password' error, got %v", err)
 	}
 }
 
@@ -80,7 +89,7 @@ func TestValidateLogin_InvalidPassword(t *testing.T) {
 		Role:     "faculty",
 	}
 
-	mockRepo.EXPECT().GetUserByEmailID(email).Return(user, nil)
+	mockRepo.EXPECT().GetUserByEmailID(gomock.Any(), email).Return(user, nil)
 
 	ctx := context.Background()
 	_, err := authSvc.ValidateLogin(ctx, email, "WrongPassword")
@@ -100,8 +109,8 @@ func TestSignup_Success(t *testing.T) {
 	password := "StrongPass123!"
 	name := "New User"
 
-	mockRepo.EXPECT().GetUserByEmailID(email).Return(nil, nil)
-	mockRepo.EXPECT().AddUser(gomock.Any(), name, email, gomock.Any()).Return(nil)
+	mockRepo.EXPECT().GetUserByEmailID(gomock.Any(), email).Return(nil, nil)
+	mockRepo.EXPECT().AddUser(gomock.Any(), gomock.Any(), name, email, gomock.Any()).Return(nil)
 
 	ctx := context.Background()
 	user, err := authSvc.Signup(ctx, name, email, password)
@@ -123,7 +132,7 @@ func TestSignup_EmailAlreadyUsed(t *testing.T) {
 	email := "existing@example.com"
 	user := &models.User{Email: email}
 
-	mockRepo.EXPECT().GetUserByEmailID(email).Return(user, nil)
+	mockRepo.EXPECT().GetUserByEmailID(gomock.Any(), email).Return(user, nil)
 
 	ctx := context.Background()
 	_, err := authSvc.Signup(ctx, "Name", email, "StrongPass123!")
@@ -132,6 +141,67 @@ func TestSignup_EmailAlreadyUsed(t *testing.T) {
 	}
 }
 
+func TestCreateAccount_Guardian(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockUserRepositoryI(ctrl)
+	authSvc := services.NewAuthService(mockRepo)
+
+	email := "guardian@example.com"
+	name := "Guardian"
+
+	mockRepo.EXPECT().GetUserByEmailID(gomock.Any(), email).Return(nil, nil)
+	mockRepo.EXPECT().AddUserWithRole(gomock.Any(), gomock.Any(), name, email, gomock.Any(), constants.Guardian).Return(nil)
+
+	ctx := context.Background()
+	user, err := authSvc.CreateAccount(ctx, name, email, "StrongPass123!", constants.Guardian)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if user.Role != constants.Guardian || user.UserID == "" {
+		t.Errorf("expected guardian with an ID, got %+v", user)
+	}
+}
+
+func TestCreateAccount_PublishesUserSignedUp(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockUserRepositoryI(ctrl)
+	recorder := eventstest.NewRecorder()
+	authSvc := services.NewAuthService(mockRepo, services.WithAuthEvents(recorder))
+
+	email := "guardian@example.com"
+	mockRepo.EXPECT().GetUserByEmailID(gomock.Any(), email).Return(nil, nil)
+	mockRepo.EXPECT().AddUserWithRole(gomock.Any(), gomock.Any(), "Guardian", email, gomock.Any(), constants.Guardian).Return(nil)
+
+	user, err := authSvc.CreateAccount(context.Background(), "Guardian", email, "StrongPass123!", constants.Guardian)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	eventstest.AssertPublished(t, recorder, events.UserSignedUp{UserID: user.UserID, Name: "Guardian", Email: email, Role: constants.Guardian})
+
+	recorder.Reset()
+	mockRepo.EXPECT().GetUserByEmailID(gomock.Any(), email).Return(&models.User{Email: email}, nil)
+	if _, err := authSvc.Signup(context.Background(), "Guardian", email, "StrongPass123!"); err == nil {
+		t.Fatalf("expected error for a used email")
+	}
+	eventstest.AssertNotPublished[events.UserSignedUp](t, recorder)
+}
+
+func TestCreateAccount_InvalidRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	authSvc := services.NewAuthService(mockrepo.NewMockUserRepositoryI(ctrl))
+
+	_, err := authSvc.CreateAccount(context.Background(), "Name", "student@example.com", "StrongPass123!", "student")
+	if err == nil || err.Error() != "invalid role" {
+		t.Fatalf("expected 'invalid role', got %v", err)
+	}
+}
+
 func TestIsValidEmail(t *testing.T) {
 	authSvc := services.NewAuthService(nil)
 
@@ -159,3 +229,62 @@ func TestIsValidPassword(t *testing.T) {
 		t.Errorf("expected invalid password to fail")
 	}
 }
+
+func TestResetPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockUserRepositoryI(ctrl)
+	authSvc := services.NewAuthService(mockRepo)
+	ctx := context.Background()
+	email := "faculty@example.com"
+
+	var stored string
+	mockRepo.EXPECT().GetUserByEmailID(gomock.Any(), email).Return(&models.User{UserID: "1", Email: email}, nil)
+	mockRepo.EXPECT().UpdatePassword(gomock.Any(), "1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, hash string) error { stored = hash; return nil })
+	if err := authSvc.ResetPassword(ctx, email, "NewPassword123!"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(stored), []byte("NewPassword123!")); err != nil {
+		t.Errorf("expected the new password to be stored hashed: %v", err)
+	}
+
+	mockRepo.EXPECT().GetUserByEmailID(gomock.Any(), email).Return(&models.User{UserID: "1", Email: email}, nil)
+	if err := authSvc.ResetPassword(ctx, email, "weak"); !errors.Is(err, apperrors.ErrValidation) {
+		t.Errorf("expected a validation error for a weak password, got %v", err)
+	}
+
+	mockRepo.EXPECT().GetUserByEmailID(gomock.Any(), "missing@example.com").Return(nil, nil)
+	if err := authSvc.ResetPassword(ctx, "missing@example.com", "NewPassword123!"); !errors.Is(err, apperrors.ErrNotFound) {
+		t.Errorf("expected not found for an unknown email, got %v", err)
+	}
+}
+
+func TestWithBcryptCost(t *testing.T) {
+	authSvc := services.NewAuthService(nil, services.WithBcryptCost(bcrypt.MinCost))
+	hash, err := authSvc.HashPassword("Password123!")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cost, err := bcrypt.Cost([]byte(hash)); err != nil || cost != bcrypt.MinCost {
+		t.Errorf("expected cost %d, got %d, %v", bcrypt.MinCost, cost, err)
+	}
+}
+
+func TestIssueToken(t *testing.T) {
+	user := models.User{UserID: "u1", Email: "u1@example.com", Role: constants.Faculty}
+	if _, err := services.NewAuthService(nil).IssueToken(user); err == nil {
+		t.Error("expected error without a token signer")
+	}
+
+	tokens := newJWT(t, testJWTSecret, time.Hour)
+	token, err := services.NewAuthService(nil, services.WithTokens(tokens)).IssueToken(user)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	claims, err := tokens.Validate(token)
+	if err != nil || claims.UserID != "u1" || claims.Role != constants.Faculty {
+		t.Errorf("unexpected claims %+v, error %v", claims, err)
+	}
+}
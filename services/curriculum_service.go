@@ -0,0 +1,173 @@
+package services
+
+import (
+	"context"
+	"sms/apperrors"
+	"sms/constants"
+	"sms/models"
+	curriculumRepository "sms/repository/curriculumRepository"
+	gradeRepository "sms/repository/gradesRepository"
+	studentRepo "sms/repository/studentRepository"
+
+	"github.com/google/uuid"
+)
+
+type CurriculumService struct {
+	cr curriculumRepository.CurriculumRepositoryI
+	sr studentRepo.StudentRepositoryI
+	gr gradeRepository.GradeRepositoryI
+	es EnrollmentServiceI
+}
+
+func NewCurriculumService(cr curriculumRepository.CurriculumRepositoryI, sr studentRepo.StudentRepositoryI, gr gradeRepository.GradeRepositoryI, es EnrollmentServiceI) *CurriculumService {
+	return &CurriculumService{cr: cr, sr: sr, gr: gr, es: es}
+}
+
+func (cs *CurriculumService) CreateProgram(ctx context.Context, name string, semesters int) (*models.Program, error) {
+	if name == "" {
+		return nil, apperrors.Validation("name can't be empty")
+	}
+	if semesters <= 0 {
+		return nil, apperrors.Validation("semesters must be positive")
+	}
+	program := models.Program{ProgramID: uuid.New().String(), Name: name, Semesters: semesters}
+	if err := cs.cr.AddProgram(ctx, program); err != nil {
+		return nil, err
+	}
+	return &program, nil
+}
+
+func (cs *CurriculumService) AddProgramSubject(ctx context.Context, subject models.ProgramSubject) error {
+	program, err := cs.cr.GetProgram(ctx, subject.ProgramID)
+	if err != nil {
+		return err
+	}
+	if program == nil {
+		return apperrors.NotFound("program not found")
+	}
+	if subject.Semester <= 0 || subject.Semester > program.Semesters {
+		return apperrors.Validation("semester must be between 1 and %d", program.Semesters)
+	}
+	if subject.Kind != constants.CoreSubject && subject.Kind != constants.ElectiveSubject {
+		return apperrors.Validation("invalid subject kind %q", subject.Kind)
+	}
+	if subject.Credits <= 0 {
+		return apperrors.Validation("credits must be positive")
+	}
+	for _, prerequisite := range subject.Prerequisites {
+		if prerequisite == subject.SubjectID {
+			return apperrors.Validation("a subject can't be its own prerequisite")
+		}
+		ps, err := cs.cr.GetProgramSubject(ctx, subject.ProgramID, prerequisite)
+		if err != nil {
+			return err
+		}
+		if ps == nil || ps.Semester >= subject.Semester {
+			return apperrors.Validation("prerequisite %s must belong to an earlier semester of the program", prerequisite)
+		}
+	}
+	return cs.cr.AddProgramSubject(ctx, subject)
+}
+
+func (cs *CurriculumService) GetCurriculum(ctx context.Context, programID string, semester int) ([]models.ProgramSubject, error) {
+	if semester <= 0 {
+		return nil, apperrors.Validation("semester must be positive")
+	}
+	return cs.cr.GetProgramSubjects(ctx, programID, semester)
+}
+
+func (cs *CurriculumService) AssignClassProgram(ctx context.Context, classID, programID string) error {
+	program, err := cs.cr.GetProgram(ctx, programID)
+	if err != nil {
+		return err
+	}
+	if program == nil {
+		return apperrors.NotFound("program not found")
+	}
+	return cs.cr.SetClassProgram(ctx, classID, programID)
+}
+
+// AutoEnrollClass enrolls every student of the class into the core subjects of
+// its program for the semester.
+func (cs *CurriculumService) AutoEnrollClass(ctx context.Context, classID string, semester int) ([]models.Enrollment, error) {
+	programID, err := cs.classProgram(ctx, classID)
+	if err != nil {
+		return nil, err
+	}
+	subjects, err := cs.cr.GetProgramSubjects(ctx, programID, semester)
+	if err != nil {
+		return nil, err
+	}
+	var core []string
+	for _, s := range subjects {
+		if s.Kind == constants.CoreSubject {
+			core = append(core, s.SubjectID)
+		}
+	}
+	if len(core) == 0 {
+		return nil, apperrors.Validation("program has no core subjects for the semester")
+	}
+	return cs.es.EnrollClass(ctx, classID, core, semester)
+}
+
+// ValidateElective checks that the subject is an elective of the student's
+// program in that semester and that every prerequisite was passed.
+func (cs *CurriculumService) ValidateElective(ctx context.Context, studentID, subjectID string, semester int) error {
+	student, err := cs.sr.GetStudentByID(ctx, studentID)
+	if err != nil {
+		return err
+	}
+	if student == nil {
+		return apperrors.NotFound("student not found")
+	}
+	programID, err := cs.classProgram(ctx, student.ClassID)
+	if err != nil {
+		return err
+	}
+
+	subject, err := cs.cr.GetProgramSubject(ctx, programID, subjectID)
+	if err != nil {
+		return err
+	}
+	if subject == nil || subject.Kind != constants.ElectiveSubject || subject.Semester != semester {
+		return apperrors.Validation("subject is not an elective of the program for the semester")
+	}
+
+	if len(subject.Prerequisites) == 0 {
+		return nil
+	}
+	grades, err := cs.gr.GetStudentGrades(ctx, studentID)
+	if err != nil {
+		return err
+	}
+	passed := map[string]bool{}
+	for _, g := range grades {
+		if g.Grade >= constants.DefaultPassMark {
+			passed[g.SubjectID] = true
+		}
+	}
+	for _, prerequisite := range subject.Prerequisites {
+		if !passed[prerequisite] {
+			return apperrors.Forbidden("prerequisite %s has not been passed", prerequisite)
+		}
+	}
+	return nil
+}
+
+func (cs *CurriculumService) EnrollElective(ctx context.Context, studentID, subjectID string, semester int) (*models.Enrollment, error) {
+	if err := cs.ValidateElective(ctx, studentID, subjectID, semester); err != nil {
+		return nil, err
+	}
+	return cs.es.Enroll(ctx, studentID, subjectID, semester)
+}
+
+func (cs *CurriculumService) classProgram(ctx context.Context, classID string) (string, error) {
+	programID, err := cs.cr.GetClassProgram(ctx, classID)
+	if err != nil {
+		return "", err
+	}
+	if programID == "" {
+		return "", apperrors.Conflict("class is not assigned to a program")
+	}
+	return programID, nil
+}
@@ -0,0 +1,17 @@
+package services
+
+import (
+	"context"
+	"sms/models"
+)
+
+//go:generate mockgen -destination=../mocks/curriculum_service_mock.go -package=mocks -source=curriculum_service_interface.go
+type CurriculumServiceI interface {
+	CreateProgram(ctx context.Context, name string, semesters int) (*models.Program, error)
+	AddProgramSubject(ctx context.Context, subject models.ProgramSubject) error
+	GetCurriculum(ctx context.Context, programID string, semester int) ([]models.ProgramSubject, error)
+	AssignClassProgram(ctx context.Context, classID, programID string) error
+	AutoEnrollClass(ctx context.Context, classID string, semester int) ([]models.Enrollment, error)
+	ValidateElective(ctx context.Context, studentID, subjectID string, semester int) error
+	EnrollElective(ctx context.Context, studentID, subjectID string, semester int) (*models.Enrollment, error)
+}
@@ -0,0 +1,153 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	"sms/constants"
+	mockrepo "sms/mocks"
+	"sms/models"
+	"sms/services"
+)
+
+type curriculumMocks struct {
+	cr *mockrepo.MockCurriculumRepositoryI
+	sr *mockrepo.MockStudentRepositoryI
+	gr *mockrepo.MockGradeRepositoryI
+	es *mockrepo.MockEnrollmentServiceI
+}
+
+func newCurriculumService(ctrl *gomock.Controller) (*services.CurriculumService, curriculumMocks) {
+	m := curriculumMocks{
+		cr: mockrepo.NewMockCurriculumRepositoryI(ctrl),
+		sr: mockrepo.NewMockStudentRepositoryI(ctrl),
+		gr: mockrepo.NewMockGradeRepositoryI(ctrl),
+		es: mockrepo.NewMockEnrollmentServiceI(ctrl),
+	}
+	return services.NewCurriculumService(m.cr, m.sr, m.gr, m.es), m
+}
+
+func TestCreateProgram(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc, m := newCurriculumService(ctrl)
+
+	m.cr.EXPECT().AddProgram(gomock.Any(), gomock.Any()).Return(nil)
+	program, err := svc.CreateProgram(context.Background(), "B.Tech CSE", 8)
+	if err != nil || program.ProgramID == "" {
+		t.Errorf("expected program, got %+v, %v", program, err)
+	}
+	if _, err := svc.CreateProgram(context.Background(), "", 8); err == nil {
+		t.Errorf("expected error for empty name")
+	}
+	if _, err := svc.CreateProgram(context.Background(), "B.Tech CSE", 0); err == nil {
+		t.Errorf("expected error for zero semesters")
+	}
+}
+
+func TestAddProgramSubject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc, m := newCurriculumService(ctrl)
+
+	m.cr.EXPECT().GetProgram(gomock.Any(), "p1").Return(&models.Program{ProgramID: "p1", Semesters: 8}, nil).AnyTimes()
+	m.cr.EXPECT().GetProgramSubject(gomock.Any(), "p1", "maths").Return(&models.ProgramSubject{SubjectID: "maths", Semester: 1}, nil).AnyTimes()
+
+	valid := models.ProgramSubject{ProgramID: "p1", SubjectID: "ml", Semester: 5, Kind: constants.ElectiveSubject, Credits: 3, Prerequisites: []string{"maths"}}
+	m.cr.EXPECT().AddProgramSubject(gomock.Any(), valid).Return(nil)
+	if err := svc.AddProgramSubject(context.Background(), valid); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+
+	invalid := []models.ProgramSubject{
+		{ProgramID: "p1", SubjectID: "ml", Semester: 9, Kind: constants.CoreSubject, Credits: 3},
+		{ProgramID: "p1", SubjectID: "ml", Semester: 5, Kind: "optional", Credits: 3},
+		{ProgramID: "p1", SubjectID: "ml", Semester: 5, Kind: constants.CoreSubject, Credits: 0},
+		{ProgramID: "p1", SubjectID: "ml", Semester: 5, Kind: constants.CoreSubject, Credits: 3, Prerequisites: []string{"ml"}},
+		{ProgramID: "p1", SubjectID: "calculus", Semester: 1, Kind: constants.CoreSubject, Credits: 3, Prerequisites: []string{"maths"}},
+	}
+	for _, subject := range invalid {
+		if err := svc.AddProgramSubject(context.Background(), subject); err == nil {
+			t.Errorf("expected error for %+v", subject)
+		}
+	}
+}
+
+func TestAutoEnrollClass(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc, m := newCurriculumService(ctrl)
+
+	m.cr.EXPECT().GetClassProgram(gomock.Any(), "C1").Return("p1", nil)
+	m.cr.EXPECT().GetProgramSubjects(gomock.Any(), "p1", 1).Return([]models.ProgramSubject{
+		{SubjectID: "maths", Kind: constants.CoreSubject},
+		{SubjectID: "art", Kind: constants.ElectiveSubject},
+		{SubjectID: "physics", Kind: constants.CoreSubject},
+	}, nil)
+	m.es.EXPECT().EnrollClass(gomock.Any(), "C1", []string{"maths", "physics"}, 1).Return([]models.Enrollment{{}, {}}, nil)
+
+	enrollments, err := svc.AutoEnrollClass(context.Background(), "C1", 1)
+	if err != nil || len(enrollments) != 2 {
+		t.Errorf("expected 2 enrollments, got %v, %v", enrollments, err)
+	}
+
+	m.cr.EXPECT().GetClassProgram(gomock.Any(), "C2").Return("", nil)
+	if _, err := svc.AutoEnrollClass(context.Background(), "C2", 1); err == nil {
+		t.Errorf("expected error for class without program")
+	}
+}
+
+func TestValidateElective_And_EnrollElective(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc, m := newCurriculumService(ctrl)
+
+	m.sr.EXPECT().GetStudentByID(gomock.Any(), "s1").Return(&models.Students{StudentID: "s1", ClassID: "C1"}, nil).AnyTimes()
+	m.cr.EXPECT().GetClassProgram(gomock.Any(), "C1").Return("p1", nil).AnyTimes()
+	m.cr.EXPECT().GetProgramSubject(gomock.Any(), "p1", "ml").Return(&models.ProgramSubject{SubjectID: "ml", Semester: 5, Kind: constants.ElectiveSubject, Prerequisites: []string{"maths", "stats"}}, nil).AnyTimes()
+	m.cr.EXPECT().GetProgramSubject(gomock.Any(), "p1", "os").Return(&models.ProgramSubject{SubjectID: "os", Semester: 5, Kind: constants.CoreSubject}, nil).AnyTimes()
+
+	m.gr.EXPECT().GetStudentGrades(gomock.Any(), "s1").Return([]models.Grade{
+		{SubjectID: "maths", Grade: 70},
+		{SubjectID: "stats", Grade: 20},
+	}, nil)
+	if err := svc.ValidateElective(context.Background(), "s1", "ml", 5); err == nil {
+		t.Errorf("expected failed prerequisite to be rejected")
+	}
+
+	if err := svc.ValidateElective(context.Background(), "s1", "os", 5); err == nil {
+		t.Errorf("expected core subject to be rejected as elective")
+	}
+	if err := svc.ValidateElective(context.Background(), "s1", "ml", 4); err == nil {
+		t.Errorf("expected elective of another semester to be rejected")
+	}
+
+	m.gr.EXPECT().GetStudentGrades(gomock.Any(), "s1").Return([]models.Grade{
+		{SubjectID: "maths", Grade: 70},
+		{SubjectID: "stats", Grade: 60},
+	}, nil)
+	m.es.EXPECT().Enroll(gomock.Any(), "s1", "ml", 5).Return(&models.Enrollment{SubjectID: "ml"}, nil)
+	enrollment, err := svc.EnrollElective(context.Background(), "s1", "ml", 5)
+	if err != nil || enrollment.SubjectID != "ml" {
+		t.Errorf("expected elective enrollment, got %+v, %v", enrollment, err)
+	}
+}
+
+func TestAssignClassProgram(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc, m := newCurriculumService(ctrl)
+
+	m.cr.EXPECT().GetProgram(gomock.Any(), "p1").Return(&models.Program{ProgramID: "p1"}, nil)
+	m.cr.EXPECT().SetClassProgram(gomock.Any(), "C1", "p1").Return(nil)
+	if err := svc.AssignClassProgram(context.Background(), "C1", "p1"); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+
+	m.cr.EXPECT().GetProgram(gomock.Any(), "missing").Return(nil, nil)
+	if err := svc.AssignClassProgram(context.Background(), "C1", "missing"); err == nil {
+		t.Errorf("expected error for missing program")
+	}
+}
@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"sms/apperrors"
+	"sms/constants"
+	"sms/models"
+	enrollmentRepository "sms/repository/enrollmentRepository"
+	studentRepo "sms/repository/studentRepository"
+	"time"
+)
+
+type EnrollmentService struct {
+	er enrollmentRepository.EnrollmentRepositoryI
+	sr studentRepo.StudentRepositoryI
+}
+
+func NewEnrollmentService(er enrollmentRepository.EnrollmentRepositoryI, sr studentRepo.StudentRepositoryI) *EnrollmentService {
+	return &EnrollmentService{er: er, sr: sr}
+}
+
+func (es *EnrollmentService) Enroll(ctx context.Context, studentID, subjectID string, semester int) (*models.Enrollment, error) {
+	enrollments, err := es.BulkEnroll(ctx, []string{studentID}, []string{subjectID}, semester)
+	if err != nil {
+		return nil, err
+	}
+	if len(enrollments) == 0 {
+		return nil, apperrors.Conflict("student is already enrolled in the subject")
+	}
+	return &enrollments[0], nil
+}
+
+// BulkEnroll enrolls every student in every subject for the semester in one
+// transaction. Pairs that are already enrolled are skipped and left out of the
+// returned enrollments.
+func (es *EnrollmentService) BulkEnroll(ctx context.Context, studentIDs, subjectIDs []string, semester int) ([]models.Enrollment, error) {
+	if len(studentIDs) == 0 || len(subjectIDs) == 0 {
+		return nil, apperrors.Validation("at least one student and one subject are required")
+	}
+	if semester <= 0 {
+		return nil, apperrors.Validation("semester must be positive")
+	}
+
+	now := time.Now().UTC()
+	var enrollments []models.Enrollment
+	for _, studentID := range studentIDs {
+		student, err := es.sr.GetStudentByID(ctx, studentID)
+		if err != nil {
+			return nil, err
+		}
+		if student == nil {
+			return nil, apperrors.NotFound("student %s not found", studentID)
+		}
+		for _, subjectID := range subjectIDs {
+			existing, err := es.er.GetEnrollment(ctx, studentID, subjectID, semester)
+			if err != nil {
+				return nil, err
+			}
+			if existing != nil && existing.Status != constants.Dropped {
+				continue
+			}
+			enrollments = append(enrollments, models.Enrollment{
+				StudentID: studentID,
+				SubjectID: subjectID,
+				Semester:  semester,
+				Status:    constants.Enrolled,
+				AddedOn:   now,
+			})
+		}
+	}
+
+	if len(enrollments) == 0 {
+		return nil, nil
+	}
+	if err := es.er.AddEnrollments(ctx, enrollments); err != nil {
+		return nil, err
+	}
+	return enrollments, nil
+}
+
+// EnrollClass enrolls every student currently in the class.
+func (es *EnrollmentService) EnrollClass(ctx context.Context, classID string, subjectIDs []string, semester int) ([]models.Enrollment, error) {
+	students, err := es.sr.GetStudentsByClass(ctx, classID)
+	if err != nil {
+		return nil, err
+	}
+	if len(students) == 0 {
+		return nil, apperrors.Validation("class has no students")
+	}
+	studentIDs := make([]string, 0, len(students))
+	for _, s := range students {
+		studentIDs = append(studentIDs, s.StudentID)
+	}
+	return es.BulkEnroll(ctx, studentIDs, subjectIDs, semester)
+}
+
+func (es *EnrollmentService) Drop(ctx context.Context, studentID, subjectID string, semester int) error {
+	existing, err := es.er.GetEnrollment(ctx, studentID, subjectID, semester)
+	if err != nil {
+		return err
+	}
+	if existing == nil || existing.Status != constants.Enrolled {
+		return apperrors.NotFound("student is not enrolled in the subject")
+	}
+	return es.er.DropEnrollment(ctx, studentID, subjectID, semester, time.Now().UTC())
+}
+
+func (es *EnrollmentService) GetStudentEnrollments(ctx context.Context, studentID string, semester int) ([]models.Enrollment, error) {
+	if semester < 0 {
+		return nil, apperrors.Validation("semester can't be negative")
+	}
+	return es.er.GetStudentEnrollments(ctx, studentID, semester)
+}
+
+// CanBeGraded refuses subjects the student never took or has dropped.
+func (es *EnrollmentService) CanBeGraded(ctx context.Context, studentID, subjectID string, semester int) error {
+	enrollment, err := es.er.GetEnrollment(ctx, studentID, subjectID, semester)
+	if err != nil {
+		return err
+	}
+	if enrollment == nil || enrollment.Status == constants.Dropped {
+		return apperrors.Forbidden("student is not enrolled in the subject for the semester")
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package services
+
+import (
+	"context"
+	"sms/models"
+)
+
+//go:generate mockgen -destination=../mocks/enrollment_service_mock.go -package=mocks -source=enrollment_service_interface.go
+type EnrollmentServiceI interface {
+	Enroll(ctx context.Context, studentID, subjectID string, semester int) (*models.Enrollment, error)
+	BulkEnroll(ctx context.Context, studentIDs, subjectIDs []string, semester int) ([]models.Enrollment, error)
+	EnrollClass(ctx context.Context, classID string, subjectIDs []string, semester int) ([]models.Enrollment, error)
+	Drop(ctx context.Context, studentID, subjectID string, semester int) error
+	GetStudentEnrollments(ctx context.Context, studentID string, semester int) ([]models.Enrollment, error)
+	CanBeGraded(ctx context.Context, studentID, subjectID string, semester int) error
+}
@@ -0,0 +1,145 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	"sms/constants"
+	mockrepo "sms/mocks"
+	"sms/models"
+	"sms/services"
+)
+
+func TestEnroll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEnrollmentRepo := mockrepo.NewMockEnrollmentRepositoryI(ctrl)
+	mockStudentRepo := mockrepo.NewMockStudentRepositoryI(ctrl)
+	svc := services.NewEnrollmentService(mockEnrollmentRepo, mockStudentRepo)
+
+	mockStudentRepo.EXPECT().GetStudentByID(gomock.Any(), "s1").Return(&models.Students{StudentID: "s1"}, nil).Times(3)
+
+	mockEnrollmentRepo.EXPECT().GetEnrollment(gomock.Any(), "s1", "sub1", 1).Return(nil, nil)
+	mockEnrollmentRepo.EXPECT().AddEnrollments(gomock.Any(), gomock.Len(1)).Return(nil)
+	enrollment, err := svc.Enroll(context.Background(), "s1", "sub1", 1)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if enrollment.Status != constants.Enrolled {
+		t.Errorf("expected enrolled status, got %s", enrollment.Status)
+	}
+
+	mockEnrollmentRepo.EXPECT().GetEnrollment(gomock.Any(), "s1", "sub2", 1).Return(&models.Enrollment{Status: constants.Enrolled}, nil)
+	if _, err := svc.Enroll(context.Background(), "s1", "sub2", 1); err == nil {
+		t.Errorf("expected error for duplicate enrollment")
+	}
+
+	mockEnrollmentRepo.EXPECT().GetEnrollment(gomock.Any(), "s1", "sub3", 1).Return(&models.Enrollment{Status: constants.Dropped}, nil)
+	mockEnrollmentRepo.EXPECT().AddEnrollments(gomock.Any(), gomock.Len(1)).Return(nil)
+	if _, err := svc.Enroll(context.Background(), "s1", "sub3", 1); err != nil {
+		t.Errorf("expected dropped subject to be re-enrolled, got %v", err)
+	}
+
+	mockStudentRepo.EXPECT().GetStudentByID(gomock.Any(), "missing").Return(nil, nil)
+	if _, err := svc.Enroll(context.Background(), "missing", "sub1", 1); err == nil {
+		t.Errorf("expected error for missing student")
+	}
+}
+
+func TestBulkEnroll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEnrollmentRepo := mockrepo.NewMockEnrollmentRepositoryI(ctrl)
+	mockStudentRepo := mockrepo.NewMockStudentRepositoryI(ctrl)
+	svc := services.NewEnrollmentService(mockEnrollmentRepo, mockStudentRepo)
+
+	mockStudentRepo.EXPECT().GetStudentByID(gomock.Any(), gomock.Any()).Return(&models.Students{}, nil).Times(2)
+	mockEnrollmentRepo.EXPECT().GetEnrollment(gomock.Any(), gomock.Any(), gomock.Any(), 2).Return(nil, nil).Times(4)
+	mockEnrollmentRepo.EXPECT().AddEnrollments(gomock.Any(), gomock.Len(4)).Return(errors.New("db error"))
+
+	if _, err := svc.BulkEnroll(context.Background(), []string{"s1", "s2"}, []string{"sub1", "sub2"}, 2); err == nil || err.Error() != "db error" {
+		t.Errorf("expected db error, got %v", err)
+	}
+	if _, err := svc.BulkEnroll(context.Background(), nil, []string{"sub1"}, 2); err == nil {
+		t.Errorf("expected error for no students")
+	}
+	if _, err := svc.BulkEnroll(context.Background(), []string{"s1"}, []string{"sub1"}, 0); err == nil {
+		t.Errorf("expected error for invalid semester")
+	}
+}
+
+func TestEnrollClass(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEnrollmentRepo := mockrepo.NewMockEnrollmentRepositoryI(ctrl)
+	mockStudentRepo := mockrepo.NewMockStudentRepositoryI(ctrl)
+	svc := services.NewEnrollmentService(mockEnrollmentRepo, mockStudentRepo)
+
+	mockStudentRepo.EXPECT().GetStudentsByClass(gomock.Any(), "C1").Return([]models.Students{{StudentID: "s1"}, {StudentID: "s2"}}, nil)
+	mockStudentRepo.EXPECT().GetStudentByID(gomock.Any(), gomock.Any()).Return(&models.Students{}, nil).Times(2)
+	mockEnrollmentRepo.EXPECT().GetEnrollment(gomock.Any(), gomock.Any(), "sub1", 1).Return(nil, nil).Times(2)
+	mockEnrollmentRepo.EXPECT().AddEnrollments(gomock.Any(), gomock.Len(2)).Return(nil)
+
+	enrollments, err := svc.EnrollClass(context.Background(), "C1", []string{"sub1"}, 1)
+	if err != nil || len(enrollments) != 2 {
+		t.Errorf("expected 2 enrollments, got %v, %v", enrollments, err)
+	}
+
+	mockStudentRepo.EXPECT().GetStudentsByClass(gomock.Any(), "C2").Return(nil, nil)
+	if _, err := svc.EnrollClass(context.Background(), "C2", []string{"sub1"}, 1); err == nil {
+		t.Errorf("expected error for empty class")
+	}
+}
+
+func TestDrop_And_CanBeGraded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEnrollmentRepo := mockrepo.NewMockEnrollmentRepositoryI(ctrl)
+	mockStudentRepo := mockrepo.NewMockStudentRepositoryI(ctrl)
+	svc := services.NewEnrollmentService(mockEnrollmentRepo, mockStudentRepo)
+
+	mockEnrollmentRepo.EXPECT().GetEnrollment(gomock.Any(), "s1", "sub1", 1).Return(&models.Enrollment{Status: constants.Enrolled}, nil).Times(2)
+	mockEnrollmentRepo.EXPECT().DropEnrollment(gomock.Any(), "s1", "sub1", 1, gomock.Any()).Return(nil)
+	if err := svc.Drop(context.Background(), "s1", "sub1", 1); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if err := svc.CanBeGraded(context.Background(), "s1", "sub1", 1); err != nil {
+		t.Errorf("expected enrolled student to be gradable, got %v", err)
+	}
+
+	mockEnrollmentRepo.EXPECT().GetEnrollment(gomock.Any(), "s1", "sub2", 1).Return(&models.Enrollment{Status: constants.Dropped}, nil).Times(2)
+	if err := svc.Drop(context.Background(), "s1", "sub2", 1); err == nil {
+		t.Errorf("expected error dropping a dropped subject")
+	}
+	if err := svc.CanBeGraded(context.Background(), "s1", "sub2", 1); err == nil {
+		t.Errorf("expected dropped subject to block grading")
+	}
+
+	mockEnrollmentRepo.EXPECT().GetEnrollment(gomock.Any(), "s1", "sub3", 1).Return(nil, nil)
+	if err := svc.CanBeGraded(context.Background(), "s1", "sub3", 1); err == nil {
+		t.Errorf("expected unenrolled subject to block grading")
+	}
+}
+
+func TestGetStudentEnrollments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEnrollmentRepo := mockrepo.NewMockEnrollmentRepositoryI(ctrl)
+	svc := services.NewEnrollmentService(mockEnrollmentRepo, mockrepo.NewMockStudentRepositoryI(ctrl))
+
+	mockEnrollmentRepo.EXPECT().GetStudentEnrollments(gomock.Any(), "s1", 0).Return([]models.Enrollment{{StudentID: "s1"}}, nil)
+	if enrollments, err := svc.GetStudentEnrollments(context.Background(), "s1", 0); err != nil || len(enrollments) != 1 {
+		t.Errorf("expected one enrollment, got %v, %v", enrollments, err)
+	}
+	if _, err := svc.GetStudentEnrollments(context.Background(), "s1", -1); err == nil {
+		t.Errorf("expected error for negative semester")
+	}
+}
@@ -0,0 +1,20 @@
+package services
+
+import (
+	"context"
+	"log"
+	"sms/events"
+)
+
+// publishEvent publishes an event about a change that is already saved, so a
+// failing subscriber is logged rather than reported to the caller. Subscribers
+// get ctx without its cancellation: a client that disconnects after the save
+// must not stop them from reacting to it.
+func publishEvent(ctx context.Context, publisher events.Publisher, e events.Event) {
+	if publisher == nil {
+		return
+	}
+	if err := publisher.Publish(context.WithoutCancel(ctx), e); err != nil {
+		log.Printf("failed to handle %s: %v", e.EventName(), err)
+	}
+}
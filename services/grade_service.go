@@ -1,46 +1,619 @@
 package services
 
 import (
+	"context"
 	"errors"
+	"math"
+	"sms/apperrors"
+	"sms/constants"
+	"sms/events"
+	"sms/models"
+	assessmentRepository "sms/repository/assessmentRepository"
 	gradeRepository "sms/repository/gradesRepository"
+	"sms/repository/transaction"
+	"sort"
+
+	"github.com/google/uuid"
 )
 
 type GradeService struct {
-	gr gradeRepository.GradeRepositoryI
+	gr       gradeRepository.GradeRepositoryI
+	checkers []GradeEligibilityCheckerI
+	window   GradeEntryWindowI
+	ar       assessmentRepository.AssessmentRepositoryI
+	events   events.Publisher
+	tm       transaction.Manager
+}
+
+// GradeEligibilityCheckerI decides whether a student may receive a grade for a
+// subject in a semester. GradeService asks every checker given through
+// WithEligibilityChecker before AddGrades, ImportGrades and RecordScores write
+// anything; the first error rejects the write and is returned to the caller as
+// is, so a checker reports the reason as an apperrors.Forbidden.
+type GradeEligibilityCheckerI interface {
+	CanBeGraded(ctx context.Context, studentID, subjectID string, semester int) error
+}
+
+// GradeEntryWindowI decides whether grades of a semester may be written now.
+type GradeEntryWindowI interface {
+	CanEnterGrades(ctx context.Context, semester int) error
+}
+
+type GradeServiceOption func(*GradeService)
+
+// WithEligibilityChecker makes AddGrades refuse grades the checker rejects.
+func WithEligibilityChecker(checker GradeEligibilityCheckerI) GradeServiceOption {
+	return func(gs *GradeService) {
+		gs.checkers = append(gs.checkers, checker)
+	}
+}
+
+// WithGradeEntryWindow makes AddGrades and UpdateGrade refuse writes outside the
+// grade-entry window of the grade's semester.
+func WithGradeEntryWindow(window GradeEntryWindowI) GradeServiceOption {
+	return func(gs *GradeService) {
+		gs.window = window
+	}
+}
+
+// WithAssessments lets subjects be graded through weighted assessments. The
+// grade of a subject with assessments is computed and can't be written directly;
+// scores and the grades computed from them are saved in one transaction.
+func WithAssessments(tm transaction.Manager, ar assessmentRepository.AssessmentRepositoryI) GradeServiceOption {
+	return func(gs *GradeService) {
+		gs.tm = tm
+		gs.ar = ar
+	}
+}
+
+// WithGradeEvents publishes GradeAdded and GradeUpdated once a grade is saved.
+func WithGradeEvents(publisher events.Publisher) GradeServiceOption {
+	return func(gs *GradeService) {
+		gs.events = publisher
+	}
+}
+
+// WithGradeTransactions enables ImportGrades, which saves a batch of grades in
+// one transaction.
+func WithGradeTransactions(tm transaction.Manager) GradeServiceOption {
+	return func(gs *GradeService) {
+		gs.tm = tm
+	}
 }
 
-func NewGradeService(gr gradeRepository.GradeRepositoryI) *GradeService {
-	return &GradeService{gr}
+func NewGradeService(gr gradeRepository.GradeRepositoryI, opts ...GradeServiceOption) *GradeService {
+	gs := &GradeService{gr: gr}
+	for _, opt := range opts {
+		opt(gs)
+	}
+	return gs
 }
 
-func (gs *GradeService) GetAverageOfClass(classID string, semester int) (float64, error) {
-	averageOfClass, err := gs.gr.GetClassAverage(classID, semester)
+func (gs *GradeService) GetAverageOfClass(ctx context.Context, classID string, semester int) (float64, error) {
+	averageOfClass, err := gs.gr.GetClassAverage(ctx, classID, semester)
 	if err != nil {
 		return 0, err
 	}
 	return averageOfClass, nil
 }
 
-func (gs *GradeService) GetToppers(classID string, semester int, top int) ([]gradeRepository.StudentAverage, error) {
-	toppers, err := gs.gr.GetToppers(classID, semester, top)
+func (gs *GradeService) GetToppers(ctx context.Context, classID string, semester int, top int) ([]gradeRepository.StudentAverage, error) {
+	toppers, err := gs.gr.GetToppers(ctx, classID, semester, top)
 	if err != nil {
 		return nil, err
 	}
 	return toppers, nil
 }
 
-func (gs *GradeService) AddGrades(studentID string, subjectID string, grade int, semester int) error {
+func (gs *GradeService) AddGrades(ctx context.Context, studentID string, subjectID string, grade int, semester int) error {
 	if grade < 0 {
-		return errors.New("grade can't be negative")
+		return apperrors.Validation("grade can't be negative")
+	}
+	if err := gs.rejectComputed(ctx, subjectID, semester); err != nil {
+		return err
+	}
+	if gs.window != nil {
+		if err := gs.window.CanEnterGrades(ctx, semester); err != nil {
+			return err
+		}
 	}
-	err := gs.gr.AddGrades(studentID, subjectID, grade, semester)
-	return err
+	if err := gs.checkEligibility(ctx, studentID, subjectID, semester); err != nil {
+		return err
+	}
+	if err := gs.gr.AddGrades(ctx, studentID, subjectID, grade, semester); err != nil {
+		return err
+	}
+	publishEvent(ctx, gs.events, events.GradeAdded{StudentID: studentID, SubjectID: subjectID, Grade: grade, Semester: semester})
+	return nil
 }
 
-func (gs *GradeService) UpdateGrade(studentID string, subjectID string, newGrade int) error {
+// ImportGrades adds a batch of grades. Every grade is checked first and then all
+// of them are saved in one transaction, so either the whole batch is imported or
+// nothing is.
+func (gs *GradeService) ImportGrades(ctx context.Context, grades []models.Grade) error {
+	if gs.tm == nil {
+		return errors.New("grade import is not enabled")
+	}
+	if len(grades) == 0 {
+		return apperrors.Validation("no grades given")
+	}
+	for _, g := range grades {
+		if g.StudentID == "" || g.SubjectID == "" {
+			return apperrors.Validation("studentID and subjectID can't be empty")
+		}
+		if g.Grade < 0 {
+			return apperrors.Validation("grade of student %s in %s can't be negative", g.StudentID, g.SubjectID)
+		}
+		if err := gs.rejectComputed(ctx, g.SubjectID, g.Semester); err != nil {
+			return err
+		}
+		if gs.window != nil {
+			if err := gs.window.CanEnterGrades(ctx, g.Semester); err != nil {
+				return err
+			}
+		}
+		if err := gs.checkEligibility(ctx, g.StudentID, g.SubjectID, g.Semester); err != nil {
+			return err
+		}
+	}
+
+	err := gs.tm.WithinTx(ctx, func(tx transaction.Querier) error {
+		gr := gs.gr.WithTx(tx)
+		for _, g := range grades {
+			if err := gr.AddGrades(ctx, g.StudentID, g.SubjectID, g.Grade, g.Semester); err != nil {
+				return apperrors.Wrap(err, "grade of student %s in %s", g.StudentID, g.SubjectID)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, g := range grades {
+		publishEvent(ctx, gs.events, events.GradeAdded{StudentID: g.StudentID, SubjectID: g.SubjectID, Grade: g.Grade, Semester: g.Semester})
+	}
+	return nil
+}
+
+func (gs *GradeService) UpdateGrade(ctx context.Context, studentID string, subjectID string, newGrade int) error {
 	if newGrade < 0 {
-		return errors.New("grade can't be negative")
+		return apperrors.Validation("grade can't be negative")
+	}
+	var old *models.Grade
+	if gs.window != nil || gs.ar != nil || gs.events != nil {
+		grade, err := gs.gr.GetGrade(ctx, studentID, subjectID)
+		if err != nil {
+			return err
+		}
+		if grade == nil {
+			return apperrors.NotFound("grade not found")
+		}
+		if err := gs.rejectComputed(ctx, subjectID, grade.Semester); err != nil {
+			return err
+		}
+		if gs.window != nil {
+			if err := gs.window.CanEnterGrades(ctx, grade.Semester); err != nil {
+				return err
+			}
+		}
+		old = grade
+	}
+	if err := gs.gr.UpdateGrade(ctx, studentID, subjectID, newGrade); err != nil {
+		return err
+	}
+	if old != nil {
+		publishEvent(ctx, gs.events, events.GradeUpdated{StudentID: studentID, SubjectID: subjectID, OldGrade: old.Grade, Grade: newGrade, Semester: old.Semester})
+	}
+	return nil
+}
+
+func (gs *GradeService) checkEligibility(ctx context.Context, studentID, subjectID string, semester int) error {
+	for _, checker := range gs.checkers {
+		if err := checker.CanBeGraded(ctx, studentID, subjectID, semester); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (gs *GradeService) GetClassStatistics(ctx context.Context, classID string, semester int, subjectID string, bucketWidth int, passMark int) (*models.GradeStatistics, error) {
+	if bucketWidth <= 0 {
+		return nil, apperrors.Validation("bucket width must be positive")
+	}
+	if passMark < 0 {
+		return nil, apperrors.Validation("pass mark can't be negative")
+	}
+
+	var grades []int
+	var err error
+	if subjectID == "" {
+		grades, err = gs.gr.GetClassGrades(ctx, classID, semester)
+	} else {
+		grades, err = gs.gr.GetClassSubjectGrades(ctx, classID, subjectID, semester)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(grades) == 0 {
+		return nil, apperrors.NotFound("no grades found for the given class and semester")
+	}
+
+	stats := computeStatistics(grades, bucketWidth, passMark)
+	stats.ClassID = classID
+	stats.SubjectID = subjectID
+	stats.Semester = semester
+	return stats, nil
+}
+
+func (gs *GradeService) GetRankList(ctx context.Context, classID string, semester int, subjectID string, method constants.RankingMethod) ([]models.RankEntry, error) {
+	if method != constants.DenseRanking && method != constants.CompetitionRanking {
+		return nil, apperrors.Validation("unknown ranking method %q", method)
+	}
+	averages, err := gs.gr.GetStudentAverages(ctx, classID, semester, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	return rankAverages(averages, method), nil
+}
+
+func (gs *GradeService) GetStudentRank(ctx context.Context, classID string, semester int, subjectID string, studentID string, method constants.RankingMethod) (*models.RankEntry, error) {
+	ranks, err := gs.GetRankList(ctx, classID, semester, subjectID, method)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range ranks {
+		if r.StudentID == studentID {
+			return &r, nil
+		}
+	}
+	return nil, apperrors.NotFound("student has no grades for the given class and semester")
+}
+
+// rankAverages assigns ranks to averages that are already sorted in descending
+// order. Students with equal averages share a rank: dense ranking continues with
+// the next integer (1, 1, 2) while competition ranking skips the tied positions
+// (1, 1, 3). The percentile rank is the share of students scoring below the
+// student plus half of those tied with them.
+func rankAverages(averages []gradeRepository.StudentAverage, method constants.RankingMethod) []models.RankEntry {
+	n := len(averages)
+	ranks := make([]models.RankEntry, 0, n)
+
+	rank := 0
+	for i := 0; i < n; {
+		j := i
+		for j < n && averages[j].Average == averages[i].Average {
+			j++
+		}
+		if method == constants.DenseRanking {
+			rank++
+		} else {
+			rank = i + 1
+		}
+		below := n - j
+		tied := j - i
+		percentile := (float64(below) + 0.5*float64(tied)) / float64(n) * 100
+		for k := i; k < j; k++ {
+			ranks = append(ranks, models.RankEntry{
+				StudentID:   averages[k].StudentID,
+				StudentName: averages[k].StudentName,
+				Average:     averages[k].Average,
+				Rank:        rank,
+				Percentile:  percentile,
+			})
+		}
+		i = j
+	}
+	return ranks
+}
+
+func computeStatistics(grades []int, bucketWidth int, passMark int) *models.GradeStatistics {
+	sorted := make([]int, len(grades))
+	copy(sorted, grades)
+	sort.Ints(sorted)
+
+	n := len(sorted)
+	sum, passed := 0, 0
+	for _, g := range sorted {
+		sum += g
+		if g >= passMark {
+			passed++
+		}
+	}
+	mean := float64(sum) / float64(n)
+
+	variance := 0.0
+	for _, g := range sorted {
+		variance += (float64(g) - mean) * (float64(g) - mean)
+	}
+	variance /= float64(n)
+
+	return &models.GradeStatistics{
+		Count:     n,
+		Average:   mean,
+		Median:    quantile(sorted, 0.5),
+		StdDev:    math.Sqrt(variance),
+		Min:       sorted[0],
+		Max:       sorted[n-1],
+		Q1:        quantile(sorted, 0.25),
+		Q3:        quantile(sorted, 0.75),
+		PassMark:  passMark,
+		PassRate:  float64(passed) / float64(n) * 100,
+		Histogram: histogram(sorted, bucketWidth),
+	}
+}
+
+// quantile linearly interpolates between the closest ranks of an ascending slice.
+func quantile(sorted []int, q float64) float64 {
+	pos := q * float64(len(sorted)-1)
+	lower := int(math.Floor(pos))
+	upper := int(math.Ceil(pos))
+	frac := pos - float64(lower)
+	return float64(sorted[lower]) + frac*float64(sorted[upper]-sorted[lower])
+}
+
+// histogram groups an ascending slice into contiguous buckets of the given width,
+// including empty buckets between the lowest and highest grade.
+func histogram(sorted []int, width int) []models.HistogramBucket {
+	bucketOf := func(g int) int {
+		return int(math.Floor(float64(g)/float64(width))) * width
+	}
+	first := bucketOf(sorted[0])
+	last := bucketOf(sorted[len(sorted)-1])
+
+	buckets := make([]models.HistogramBucket, 0, (last-first)/width+1)
+	for lower := first; lower <= last; lower += width {
+		buckets = append(buckets, models.HistogramBucket{Lower: lower, Upper: lower + width - 1})
+	}
+	for _, g := range sorted {
+		buckets[(bucketOf(g)-first)/width].Count++
+	}
+	return buckets
+}
+
+func (gs *GradeService) CreateAssessment(ctx context.Context, assessment models.Assessment) (*models.Assessment, error) {
+	if gs.ar == nil {
+		return nil, errors.New("assessments are not enabled")
+	}
+	if assessment.SubjectID == "" || assessment.Name == "" {
+		return nil, apperrors.Validation("subjectID and name can't be empty")
+	}
+	if assessment.Semester <= 0 {
+		return nil, apperrors.Validation("semester must be positive")
+	}
+	switch assessment.Kind {
+	case constants.Midterm, constants.Assignment, constants.Lab, constants.Final:
+	default:
+		return nil, apperrors.Validation("invalid assessment kind %q", assessment.Kind)
+	}
+	if err := gs.checkWeight(ctx, assessment, assessment.Weight, assessment.MaxMarks); err != nil {
+		return nil, err
+	}
+
+	assessment.AssessmentID = uuid.New().String()
+	if err := gs.ar.AddAssessment(ctx, assessment); err != nil {
+		return nil, err
+	}
+	return &assessment, nil
+}
+
+func (gs *GradeService) GetAssessments(ctx context.Context, subjectID string, semester int) ([]models.Assessment, error) {
+	if gs.ar == nil {
+		return nil, errors.New("assessments are not enabled")
+	}
+	return gs.ar.GetAssessments(ctx, subjectID, semester)
+}
+
+// UpdateAssessment changes the weight and maximum marks of an assessment and
+// recomputes the grades of every student already scored in the subject.
+func (gs *GradeService) UpdateAssessment(ctx context.Context, assessmentID string, weight float64, maxMarks int) (*models.Assessment, error) {
+	assessment, err := gs.getAssessment(ctx, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := gs.checkWeight(ctx, *assessment, weight, maxMarks); err != nil {
+		return nil, err
+	}
+	if gs.window != nil {
+		if err := gs.window.CanEnterGrades(ctx, assessment.Semester); err != nil {
+			return nil, err
+		}
+	}
+
+	scores, err := gs.ar.GetScores(ctx, assessment.SubjectID, assessment.Semester)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range scores {
+		if s.AssessmentID == assessmentID && s.Marks > float64(maxMarks) {
+			return nil, apperrors.Conflict("student %s already scored %.2f, more than %d", s.StudentID, s.Marks, maxMarks)
+		}
+	}
+
+	var changes []events.Event
+	err = gs.tm.WithinTx(ctx, func(tx transaction.Querier) error {
+		ar := gs.ar.WithTx(tx)
+		if err := ar.UpdateAssessment(ctx, assessmentID, weight, maxMarks); err != nil {
+			return err
+		}
+		changes, err = gs.recomputeGrades(ctx, ar, gs.gr.WithTx(tx), assessment.SubjectID, assessment.Semester)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range changes {
+		publishEvent(ctx, gs.events, e)
+	}
+	assessment.Weight, assessment.MaxMarks = weight, maxMarks
+	return assessment, nil
+}
+
+// RecordScores stores the marks of students in an assessment and recomputes
+// their grades for the subject.
+func (gs *GradeService) RecordScores(ctx context.Context, assessmentID string, scores []models.AssessmentScore) error {
+	if len(scores) == 0 {
+		return apperrors.Validation("no scores given")
+	}
+	assessment, err := gs.getAssessment(ctx, assessmentID)
+	if err != nil {
+		return err
+	}
+	if gs.window != nil {
+		if err := gs.window.CanEnterGrades(ctx, assessment.Semester); err != nil {
+			return err
+		}
+	}
+
+	studentIDs := make([]string, len(scores))
+	for i := range scores {
+		if scores[i].StudentID == "" {
+			return apperrors.Validation("studentID can't be empty")
+		}
+		if scores[i].Marks < 0 || scores[i].Marks > float64(assessment.MaxMarks) {
+			return apperrors.Validation("marks of student %s must be between 0 and %d", scores[i].StudentID, assessment.MaxMarks)
+		}
+		if err := gs.checkEligibility(ctx, scores[i].StudentID, assessment.SubjectID, assessment.Semester); err != nil {
+			return err
+		}
+		scores[i].AssessmentID = assessmentID
+		studentIDs[i] = scores[i].StudentID
+	}
+
+	var changes []events.Event
+	err = gs.tm.WithinTx(ctx, func(tx transaction.Querier) error {
+		ar := gs.ar.WithTx(tx)
+		if err := ar.SaveScores(ctx, scores); err != nil {
+			return err
+		}
+		changes, err = gs.recomputeGrades(ctx, ar, gs.gr.WithTx(tx), assessment.SubjectID, assessment.Semester, studentIDs...)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	for _, e := range changes {
+		publishEvent(ctx, gs.events, e)
+	}
+	return nil
+}
+
+func (gs *GradeService) getAssessment(ctx context.Context, assessmentID string) (*models.Assessment, error) {
+	if gs.ar == nil {
+		return nil, errors.New("assessments are not enabled")
+	}
+	assessment, err := gs.ar.GetAssessment(ctx, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if assessment == nil {
+		return nil, apperrors.NotFound("assessment not found")
+	}
+	return assessment, nil
+}
+
+// checkWeight validates the weight and maximum marks of an assessment and makes
+// sure the weights of the subject's assessments don't add up to more than 100.
+func (gs *GradeService) checkWeight(ctx context.Context, assessment models.Assessment, weight float64, maxMarks int) error {
+	if weight <= 0 || weight > 100 {
+		return apperrors.Validation("weight must be greater than 0 and at most 100")
+	}
+	if maxMarks <= 0 {
+		return apperrors.Validation("maximum marks must be positive")
+	}
+	assessments, err := gs.ar.GetAssessments(ctx, assessment.SubjectID, assessment.Semester)
+	if err != nil {
+		return err
+	}
+	total := weight
+	for _, a := range assessments {
+		if a.AssessmentID != assessment.AssessmentID {
+			total += a.Weight
+		}
+	}
+	if total > 100 {
+		return apperrors.Conflict("weights of the subject's assessments would add up to %.2f, more than 100", total)
+	}
+	return nil
+}
+
+// rejectComputed refuses direct writes to grades computed from assessments.
+func (gs *GradeService) rejectComputed(ctx context.Context, subjectID string, semester int) error {
+	if gs.ar == nil {
+		return nil
+	}
+	assessments, err := gs.ar.GetAssessments(ctx, subjectID, semester)
+	if err != nil {
+		return err
+	}
+	if len(assessments) > 0 {
+		return apperrors.Conflict("grade of the subject is computed from its assessments")
+	}
+	return nil
+}
+
+// recomputeGrades writes the weighted grade of the subject for the given students,
+// or for every scored student when none are given, through ar and gr so it can
+// share the caller's transaction. It returns the events to publish once that
+// transaction commits.
+func (gs *GradeService) recomputeGrades(ctx context.Context, ar assessmentRepository.AssessmentRepositoryI, gr gradeRepository.GradeRepositoryI, subjectID string, semester int, studentIDs ...string) ([]events.Event, error) {
+	assessments, err := ar.GetAssessments(ctx, subjectID, semester)
+	if err != nil {
+		return nil, err
+	}
+	scores, err := ar.GetScores(ctx, subjectID, semester, studentIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	byStudent := map[string][]models.AssessmentScore{}
+	var order []string
+	for _, s := range scores {
+		if _, ok := byStudent[s.StudentID]; !ok {
+			order = append(order, s.StudentID)
+		}
+		byStudent[s.StudentID] = append(byStudent[s.StudentID], s)
+	}
+
+	var changes []events.Event
+	for _, studentID := range order {
+		grade := weightedGrade(assessments, byStudent[studentID])
+		existing, err := gr.GetGrade(ctx, studentID, subjectID)
+		if err != nil {
+			return nil, err
+		}
+		if existing == nil {
+			if err := gr.AddGrades(ctx, studentID, subjectID, grade, semester); err != nil {
+				return nil, err
+			}
+			changes = append(changes, events.GradeAdded{StudentID: studentID, SubjectID: subjectID, Grade: grade, Semester: semester})
+		} else if existing.Grade != grade {
+			if err := gr.UpdateGrade(ctx, studentID, subjectID, grade); err != nil {
+				return nil, err
+			}
+			changes = append(changes, events.GradeUpdated{StudentID: studentID, SubjectID: subjectID, OldGrade: existing.Grade, Grade: grade, Semester: semester})
+		}
+	}
+	return changes, nil
+}
+
+// weightedGrade is the weighted percentage over the assessments the student was
+// scored in, so components not yet held don't pull the grade down.
+func weightedGrade(assessments []models.Assessment, scores []models.AssessmentScore) int {
+	byID := make(map[string]models.Assessment, len(assessments))
+	for _, a := range assessments {
+		byID[a.AssessmentID] = a
+	}
+	var earned, weights float64
+	for _, s := range scores {
+		a, ok := byID[s.AssessmentID]
+		if !ok {
+			continue
+		}
+		earned += a.Weight * s.Marks / float64(a.MaxMarks)
+		weights += a.Weight
+	}
+	if weights == 0 {
+		return 0
 	}
-	err := gs.gr.UpdateGrade(studentID, subjectID, newGrade)
-	return err
+	return int(math.Round(earned / weights * 100))
 }
@@ -1,13 +1,24 @@
 package services
 
 import (
+	"context"
+	"sms/constants"
+	"sms/models"
 	gradeRepository "sms/repository/gradesRepository"
 )
 
 //go:generate mockgen -destination=../mocks/grade_service_mock.go -package=mocks -source=grade_service_interface.go
 type GradeServiceI interface {
-	GetAverageOfClass(classID string, semester int) (float64, error)
-	GetToppers(classID string, semester int, top int) ([]gradeRepository.StudentAverage, error)
-	AddGrades(studentID string, subjectID string, Grade int, semester int) error
-	UpdateGrade(studentID string, subjectID string, newGrade int) error
+	GetAverageOfClass(ctx context.Context, classID string, semester int) (float64, error)
+	GetToppers(ctx context.Context, classID string, semester int, top int) ([]gradeRepository.StudentAverage, error)
+	AddGrades(ctx context.Context, studentID string, subjectID string, Grade int, semester int) error
+	UpdateGrade(ctx context.Context, studentID string, subjectID string, newGrade int) error
+	ImportGrades(ctx context.Context, grades []models.Grade) error
+	GetRankList(ctx context.Context, classID string, semester int, subjectID string, method constants.RankingMethod) ([]models.RankEntry, error)
+	GetStudentRank(ctx context.Context, classID string, semester int, subjectID string, studentID string, method constants.RankingMethod) (*models.RankEntry, error)
+	GetClassStatistics(ctx context.Context, classID string, semester int, subjectID string, bucketWidth int, passMark int) (*models.GradeStatistics, error)
+	CreateAssessment(ctx context.Context, assessment models.Assessment) (*models.Assessment, error)
+	GetAssessments(ctx context.Context, subjectID string, semester int) ([]models.Assessment, error)
+	UpdateAssessment(ctx context.Context, assessmentID string, weight float64, maxMarks int) (*models.Assessment, error)
+	RecordScores(ctx context.Context, assessmentID string, scores []models.AssessmentScore) error
 }
@@ -1,13 +1,21 @@
 package services_test
 
 import (
+	"context"
 	"errors"
+	"math"
 	"reflect"
+	"strings"
 	"testing"
 
+	"sms/constants"
+	"sms/events"
+	"sms/events/eventstest"
 	"sms/mocks"
 	mockrepo "sms/mocks"
+	"sms/models"
 	gradeRepository "sms/repository/gradesRepository"
+	"sms/repository/transaction"
 	"sms/services"
 
 	"go.uber.org/mock/gomock"
@@ -40,7 +48,7 @@ func TestGetToppers(t *testing.T) {
 			semester: 1,
 			top:      3,
 			mockSetup: func() {
-				mockRepo.EXPECT().GetToppers("CS101", 1, 3).Return(expectedToppers, nil).Times(1)
+				mockRepo.EXPECT().GetToppers(gomock.Any(), "CS101", 1, 3).Return(expectedToppers, nil).Times(1)
 			},
 			expectedToppers: expectedToppers,
 			expectedError:   nil,
@@ -51,7 +59,7 @@ func TestGetToppers(t *testing.T) {
 			semester: 2,
 			top:      5,
 			mockSetup: func() {
-				mockRepo.EXPECT().GetToppers("CS102", 2, 5).Return(nil, errors.New("database error")).Times(1)
+				mockRepo.EXPECT().GetToppers(gomock.Any(), "CS102", 2, 5).Return(nil, errors.New("database error")).Times(1)
 			},
 			expectedToppers: nil,
 			expectedError:   errors.New("database error"),
@@ -62,7 +70,7 @@ func TestGetToppers(t *testing.T) {
 			semester: 3,
 			top:      10,
 			mockSetup: func() {
-				mockRepo.EXPECT().GetToppers("CS103", 3, 10).Return([]gradeRepository.StudentAverage{}, nil).Times(1)
+				mockRepo.EXPECT().GetToppers(gomock.Any(), "CS103", 3, 10).Return([]gradeRepository.StudentAverage{}, nil).Times(1)
 			},
 			expectedToppers: []gradeRepository.StudentAverage{},
 			expectedError:   nil,
@@ -72,7 +80,7 @@ func TestGetToppers(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
-			toppers, err := gradeService.GetToppers(tt.classID, tt.semester, tt.top)
+			toppers, err := gradeService.GetToppers(context.Background(), tt.classID, tt.semester, tt.top)
 
 			if tt.expectedError != nil {
 				if err == nil || err.Error() != tt.expectedError.Error() {
@@ -98,21 +106,21 @@ func TestAddGrades_And_UpdateGrade(t *testing.T) {
 
 	gs := services.NewGradeService(mockGradeRepo)
 
-	mockGradeRepo.EXPECT().AddGrades("s1", "sub1", 90, 1).Return(nil)
-	if err := gs.AddGrades("s1", "sub1", 90, 1); err != nil {
+	mockGradeRepo.EXPECT().AddGrades(gomock.Any(), "s1", "sub1", 90, 1).Return(nil)
+	if err := gs.AddGrades(context.Background(), "s1", "sub1", 90, 1); err != nil {
 		t.Errorf("expected no error, got %v", err)
 	}
 
-	if err := gs.AddGrades("s1", "sub1", -5, 1); err == nil {
+	if err := gs.AddGrades(context.Background(), "s1", "sub1", -5, 1); err == nil {
 		t.Errorf("expected error for negative grade")
 	}
 
-	mockGradeRepo.EXPECT().UpdateGrade("s1", "sub1", 95).Return(nil)
-	if err := gs.UpdateGrade("s1", "sub1", 95); err != nil {
+	mockGradeRepo.EXPECT().UpdateGrade(gomock.Any(), "s1", "sub1", 95).Return(nil)
+	if err := gs.UpdateGrade(context.Background(), "s1", "sub1", 95); err != nil {
 		t.Errorf("expected no error, got %v", err)
 	}
 
-	if err := gs.UpdateGrade("s1", "sub1", -10); err == nil {
+	if err := gs.UpdateGrade(context.Background(), "s1", "sub1", -10); err == nil {
 		t.Errorf("expected error for negative grade")
 	}
 }
@@ -131,7 +139,7 @@ func TestGetAverageOfClass(t *testing.T) {
 			classID:  "CS101",
 			semester: 1,
 			mockSetup: func(mockRepo *mocks.MockGradeRepositoryI) {
-				mockRepo.EXPECT().GetClassAverage("CS101", 1).Return(85.5, nil).Times(1)
+				mockRepo.EXPECT().GetClassAverage(gomock.Any(), "CS101", 1).Return(85.5, nil).Times(1)
 			},
 			expectedAvg:   85.5,
 			expectedError: nil,
@@ -141,7 +149,7 @@ func TestGetAverageOfClass(t *testing.T) {
 			classID:  "CS102",
 			semester: 2,
 			mockSetup: func(mockRepo *mocks.MockGradeRepositoryI) {
-				mockRepo.EXPECT().GetClassAverage("CS102", 2).Return(0.0, errors.New("database error")).Times(1)
+				mockRepo.EXPECT().GetClassAverage(gomock.Any(), "CS102", 2).Return(0.0, errors.New("database error")).Times(1)
 			},
 			expectedAvg:   0.0,
 			expectedError: errors.New("database error"),
@@ -151,7 +159,7 @@ func TestGetAverageOfClass(t *testing.T) {
 			classID:  "",
 			semester: 1,
 			mockSetup: func(mockRepo *mocks.MockGradeRepositoryI) {
-				mockRepo.EXPECT().GetClassAverage("", 1).Return(0.0, errors.New("invalid classID from repo")).Times(1)
+				mockRepo.EXPECT().GetClassAverage(gomock.Any(), "", 1).Return(0.0, errors.New("invalid classID from repo")).Times(1)
 			},
 			expectedAvg:   0.0,
 			expectedError: errors.New("invalid classID from repo"),
@@ -168,7 +176,7 @@ func TestGetAverageOfClass(t *testing.T) {
 
 			tt.mockSetup(mockRepo)
 
-			average, err := gradeService.GetAverageOfClass(tt.classID, tt.semester)
+			average, err := gradeService.GetAverageOfClass(context.Background(), tt.classID, tt.semester)
 
 			if tt.expectedError != nil {
 				if err == nil || err.Error() != tt.expectedError.Error() {
@@ -185,3 +193,449 @@ func TestGetAverageOfClass(t *testing.T) {
 		})
 	}
 }
+
+func TestGetClassStatistics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockGradeRepositoryI(ctrl)
+	gradeService := services.NewGradeService(mockRepo)
+
+	mockRepo.EXPECT().GetClassGrades(gomock.Any(), "CS101", 1).Return([]int{90, 35, 60, 75}, nil)
+	stats, err := gradeService.GetClassStatistics(context.Background(), "CS101", 1, "", 20, 40)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.Count != 4 || stats.Min != 35 || stats.Max != 90 {
+		t.Errorf("unexpected count/min/max: %+v", stats)
+	}
+	if stats.Average != 65 {
+		t.Errorf("expected average 65, got %f", stats.Average)
+	}
+	if stats.Median != 67.5 {
+		t.Errorf("expected median 67.5, got %f", stats.Median)
+	}
+	if stats.Q1 != 53.75 || stats.Q3 != 78.75 {
+		t.Errorf("expected quartiles 53.75/78.75, got %f/%f", stats.Q1, stats.Q3)
+	}
+	if math.Abs(stats.StdDev-20.3101) > 0.001 {
+		t.Errorf("expected stddev ~20.3101, got %f", stats.StdDev)
+	}
+	if stats.PassRate != 75 {
+		t.Errorf("expected pass rate 75, got %f", stats.PassRate)
+	}
+	expectedHistogram := []models.HistogramBucket{
+		{Lower: 20, Upper: 39, Count: 1},
+		{Lower: 40, Upper: 59, Count: 0},
+		{Lower: 60, Upper: 79, Count: 2},
+		{Lower: 80, Upper: 99, Count: 1},
+	}
+	if !reflect.DeepEqual(stats.Histogram, expectedHistogram) {
+		t.Errorf("expected histogram %v, got %v", expectedHistogram, stats.Histogram)
+	}
+
+	mockRepo.EXPECT().GetClassSubjectGrades(gomock.Any(), "CS101", "sub1", 1).Return([]int{80}, nil)
+	stats, err = gradeService.GetClassStatistics(context.Background(), "CS101", 1, "sub1", 10, 40)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.SubjectID != "sub1" || stats.Median != 80 || stats.StdDev != 0 {
+		t.Errorf("unexpected single grade statistics: %+v", stats)
+	}
+
+	mockRepo.EXPECT().GetClassGrades(gomock.Any(), "CS102", 1).Return(nil, nil)
+	if _, err := gradeService.GetClassStatistics(context.Background(), "CS102", 1, "", 10, 40); err == nil {
+		t.Errorf("expected error when no grades are found")
+	}
+
+	if _, err := gradeService.GetClassStatistics(context.Background(), "CS101", 1, "", 0, 40); err == nil {
+		t.Errorf("expected error for zero bucket width")
+	}
+}
+
+func TestGetRankList(t *testing.T) {
+	averages := []gradeRepository.StudentAverage{
+		{StudentID: "s1", StudentName: "A", Average: 95},
+		{StudentID: "s2", StudentName: "B", Average: 90},
+		{StudentID: "s3", StudentName: "C", Average: 90},
+		{StudentID: "s4", StudentName: "D", Average: 80},
+	}
+
+	tests := []struct {
+		name          string
+		method        constants.RankingMethod
+		expectedRanks []int
+	}{
+		{name: "dense ranking", method: constants.DenseRanking, expectedRanks: []int{1, 2, 2, 3}},
+		{name: "competition ranking", method: constants.CompetitionRanking, expectedRanks: []int{1, 2, 2, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockGradeRepositoryI(ctrl)
+			gradeService := services.NewGradeService(mockRepo)
+			mockRepo.EXPECT().GetStudentAverages(gomock.Any(), "CS101", 1, "").Return(averages, nil)
+
+			ranks, err := gradeService.GetRankList(context.Background(), "CS101", 1, "", tt.method)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for i, r := range ranks {
+				if r.Rank != tt.expectedRanks[i] {
+					t.Errorf("expected rank %d for %s, got %d", tt.expectedRanks[i], r.StudentID, r.Rank)
+				}
+			}
+			expectedPercentiles := []float64{87.5, 50, 50, 12.5}
+			for i, r := range ranks {
+				if r.Percentile != expectedPercentiles[i] {
+					t.Errorf("expected percentile %f for %s, got %f", expectedPercentiles[i], r.StudentID, r.Percentile)
+				}
+			}
+		})
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockGradeRepositoryI(ctrl)
+	gradeService := services.NewGradeService(mockRepo)
+	if _, err := gradeService.GetRankList(context.Background(), "CS101", 1, "", "alphabetical"); err == nil {
+		t.Errorf("expected error for unknown ranking method")
+	}
+}
+
+func TestGetStudentRank(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockGradeRepositoryI(ctrl)
+	gradeService := services.NewGradeService(mockRepo)
+	averages := []gradeRepository.StudentAverage{
+		{StudentID: "s1", Average: 95},
+		{StudentID: "s2", Average: 90},
+	}
+
+	mockRepo.EXPECT().GetStudentAverages(gomock.Any(), "CS101", 1, "sub1").Return(averages, nil).Times(2)
+
+	rank, err := gradeService.GetStudentRank(context.Background(), "CS101", 1, "sub1", "s2", constants.DenseRanking)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rank.Rank != 2 || rank.Average != 90 {
+		t.Errorf("unexpected rank entry: %+v", rank)
+	}
+
+	if _, err := gradeService.GetStudentRank(context.Background(), "CS101", 1, "sub1", "missing", constants.DenseRanking); err == nil {
+		t.Errorf("expected error for student without grades")
+	}
+}
+
+type stubEligibilityChecker struct {
+	err error
+}
+
+func (s stubEligibilityChecker) CanBeGraded(_ context.Context, studentID, subjectID string, semester int) error {
+	return s.err
+}
+
+func TestAddGrades_EligibilityCheckers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGradeRepo := mockrepo.NewMockGradeRepositoryI(ctrl)
+
+	gs := services.NewGradeService(mockGradeRepo,
+		services.WithEligibilityChecker(stubEligibilityChecker{}),
+		services.WithEligibilityChecker(stubEligibilityChecker{err: errors.New("attendance too low")}),
+	)
+	if err := gs.AddGrades(context.Background(), "s1", "sub1", 90, 1); err == nil || err.Error() != "attendance too low" {
+		t.Errorf("expected attendance error, got %v", err)
+	}
+
+	gs = services.NewGradeService(mockGradeRepo, services.WithEligibilityChecker(stubEligibilityChecker{}))
+	mockGradeRepo.EXPECT().AddGrades(gomock.Any(), "s1", "sub1", 90, 1).Return(nil)
+	if err := gs.AddGrades(context.Background(), "s1", "sub1", 90, 1); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+type stubGradeEntryWindow struct {
+	closed map[int]bool
+}
+
+func (s stubGradeEntryWindow) CanEnterGrades(_ context.Context, semester int) error {
+	if s.closed[semester] {
+		return errors.New("grade entry is closed")
+	}
+	return nil
+}
+
+func TestGradeEntryWindow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGradeRepo := mockrepo.NewMockGradeRepositoryI(ctrl)
+	gs := services.NewGradeService(mockGradeRepo, services.WithGradeEntryWindow(stubGradeEntryWindow{closed: map[int]bool{1: true}}))
+
+	if err := gs.AddGrades(context.Background(), "s1", "sub1", 90, 1); err == nil {
+		t.Errorf("expected error for closed semester")
+	}
+	mockGradeRepo.EXPECT().AddGrades(gomock.Any(), "s1", "sub2", 90, 2).Return(nil)
+	if err := gs.AddGrades(context.Background(), "s1", "sub2", 90, 2); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+
+	mockGradeRepo.EXPECT().GetGrade(gomock.Any(), "s1", "sub1").Return(&models.Grade{SubjectID: "sub1", StudentID: "s1", Grade: 80, Semester: 1}, nil)
+	if err := gs.UpdateGrade(context.Background(), "s1", "sub1", 95); err == nil {
+		t.Errorf("expected error updating a grade of a closed semester")
+	}
+	mockGradeRepo.EXPECT().GetGrade(gomock.Any(), "s1", "sub2").Return(&models.Grade{SubjectID: "sub2", StudentID: "s1", Grade: 80, Semester: 2}, nil)
+	mockGradeRepo.EXPECT().UpdateGrade(gomock.Any(), "s1", "sub2", 95).Return(nil)
+	if err := gs.UpdateGrade(context.Background(), "s1", "sub2", 95); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	mockGradeRepo.EXPECT().GetGrade(gomock.Any(), "s1", "missing").Return(nil, nil)
+	if err := gs.UpdateGrade(context.Background(), "s1", "missing", 95); err == nil {
+		t.Errorf("expected error for missing grade")
+	}
+}
+
+func TestGradeEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGradeRepo := mockrepo.NewMockGradeRepositoryI(ctrl)
+	recorder := eventstest.NewRecorder()
+	gs := services.NewGradeService(mockGradeRepo, services.WithGradeEvents(recorder))
+
+	mockGradeRepo.EXPECT().AddGrades(gomock.Any(), "s1", "sub1", 90, 1).Return(nil)
+	if err := gs.AddGrades(context.Background(), "s1", "sub1", 90, 1); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+
+	mockGradeRepo.EXPECT().GetGrade(gomock.Any(), "s1", "sub1").Return(&models.Grade{StudentID: "s1", SubjectID: "sub1", Grade: 90, Semester: 1}, nil)
+	mockGradeRepo.EXPECT().UpdateGrade(gomock.Any(), "s1", "sub1", 95).Return(nil)
+	if err := gs.UpdateGrade(context.Background(), "s1", "sub1", 95); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+
+	mockGradeRepo.EXPECT().AddGrades(gomock.Any(), "s1", "sub2", 90, 1).Return(errors.New("db error"))
+	if err := gs.AddGrades(context.Background(), "s1", "sub2", 90, 1); err == nil {
+		t.Errorf("expected repo error")
+	}
+
+	eventstest.AssertPublished(t, recorder,
+		events.GradeAdded{StudentID: "s1", SubjectID: "sub1", Grade: 90, Semester: 1},
+		events.GradeUpdated{StudentID: "s1", SubjectID: "sub1", OldGrade: 90, Grade: 95, Semester: 1},
+	)
+}
+
+var subjectAssessments = []models.Assessment{
+	{AssessmentID: "mid", SubjectID: "sub1", Semester: 1, Kind: constants.Midterm, Weight: 30, MaxMarks: 50},
+	{AssessmentID: "final", SubjectID: "sub1", Semester: 1, Kind: constants.Final, Weight: 70, MaxMarks: 100},
+}
+
+func TestCreateAssessment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGradeRepo := mockrepo.NewMockGradeRepositoryI(ctrl)
+	mockAssessmentRepo := mockrepo.NewMockAssessmentRepositoryI(ctrl)
+	gs := services.NewGradeService(mockGradeRepo, services.WithAssessments(mockrepo.NewMockManager(ctrl), mockAssessmentRepo))
+
+	mockAssessmentRepo.EXPECT().GetAssessments(gomock.Any(), "sub1", 1).Return(subjectAssessments[:1], nil).Times(2)
+	mockAssessmentRepo.EXPECT().AddAssessment(gomock.Any(), gomock.Any()).Return(nil)
+
+	assessment, err := gs.CreateAssessment(context.Background(), models.Assessment{SubjectID: "sub1", Semester: 1, Name: "Final", Kind: constants.Final, Weight: 70, MaxMarks: 100})
+	if err != nil || assessment.AssessmentID == "" {
+		t.Errorf("expected assessment, got %+v, %v", assessment, err)
+	}
+	if _, err := gs.CreateAssessment(context.Background(), models.Assessment{SubjectID: "sub1", Semester: 1, Name: "Lab", Kind: constants.Lab, Weight: 80, MaxMarks: 20}); err == nil {
+		t.Errorf("expected error for weights above 100")
+	}
+	if _, err := gs.CreateAssessment(context.Background(), models.Assessment{SubjectID: "sub1", Semester: 1, Name: "Quiz", Kind: "quiz", Weight: 10, MaxMarks: 20}); err == nil {
+		t.Errorf("expected error for unknown kind")
+	}
+
+	if _, err := services.NewGradeService(mockGradeRepo).CreateAssessment(context.Background(), models.Assessment{}); err == nil {
+		t.Errorf("expected error when assessments are not enabled")
+	}
+}
+
+func TestRecordScores_RecomputesGrades(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGradeRepo := mockrepo.NewMockGradeRepositoryI(ctrl)
+	mockAssessmentRepo := mockrepo.NewMockAssessmentRepositoryI(ctrl)
+	mockTx := mockrepo.NewMockManager(ctrl)
+	recorder := eventstest.NewRecorder()
+	gs := services.NewGradeService(mockGradeRepo, services.WithAssessments(mockTx, mockAssessmentRepo), services.WithGradeEvents(recorder))
+
+	mockAssessmentRepo.EXPECT().GetAssessment(gomock.Any(), "final").Return(&subjectAssessments[1], nil).AnyTimes()
+	mockAssessmentRepo.EXPECT().GetAssessments(gomock.Any(), "sub1", 1).Return(subjectAssessments, nil).AnyTimes()
+
+	if err := gs.RecordScores(context.Background(), "final", []models.AssessmentScore{{StudentID: "s1", Marks: 120}}); err == nil {
+		t.Errorf("expected error for marks above maximum")
+	}
+
+	// the scores and the grades computed from them share one transaction
+	scores := []models.AssessmentScore{{StudentID: "s1", Marks: 80}, {StudentID: "s2", Marks: 50}}
+	mockTx.EXPECT().WithinTx(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, fn func(transaction.Querier) error) error { return fn(nil) }).Times(2)
+	mockAssessmentRepo.EXPECT().WithTx(nil).Return(mockAssessmentRepo).Times(2)
+	mockGradeRepo.EXPECT().WithTx(nil).Return(mockGradeRepo).Times(2)
+	mockAssessmentRepo.EXPECT().SaveScores(gomock.Any(), []models.AssessmentScore{
+		{AssessmentID: "final", StudentID: "s1", Marks: 80},
+		{AssessmentID: "final", StudentID: "s2", Marks: 50},
+	}).Return(nil)
+	mockAssessmentRepo.EXPECT().GetScores(gomock.Any(), "sub1", 1, "s1", "s2").Return([]models.AssessmentScore{
+		{AssessmentID: "final", StudentID: "s1", Marks: 80},
+		{AssessmentID: "mid", StudentID: "s1", Marks: 40},
+		{AssessmentID: "final", StudentID: "s2", Marks: 50},
+	}, nil)
+	// s1: 30*40/50 + 70*80/100 = 80; s2 has only the final: 50
+	mockGradeRepo.EXPECT().GetGrade(gomock.Any(), "s1", "sub1").Return(&models.Grade{Grade: 72, Semester: 1}, nil)
+	mockGradeRepo.EXPECT().UpdateGrade(gomock.Any(), "s1", "sub1", 80).Return(nil)
+	mockGradeRepo.EXPECT().GetGrade(gomock.Any(), "s2", "sub1").Return(nil, nil)
+	mockGradeRepo.EXPECT().AddGrades(gomock.Any(), "s2", "sub1", 50, 1).Return(nil)
+
+	if err := gs.RecordScores(context.Background(), "final", scores); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	eventstest.AssertPublished(t, recorder,
+		events.GradeUpdated{StudentID: "s1", SubjectID: "sub1", OldGrade: 72, Grade: 80, Semester: 1},
+		events.GradeAdded{StudentID: "s2", SubjectID: "sub1", Grade: 50, Semester: 1},
+	)
+
+	// a grade that can't be saved fails the scores with it, and nothing is announced
+	recorder.Reset()
+	mockAssessmentRepo.EXPECT().SaveScores(gomock.Any(), gomock.Any()).Return(nil)
+	mockAssessmentRepo.EXPECT().GetScores(gomock.Any(), "sub1", 1, "s1").Return([]models.AssessmentScore{{AssessmentID: "final", StudentID: "s1", Marks: 90}}, nil)
+	mockGradeRepo.EXPECT().GetGrade(gomock.Any(), "s1", "sub1").Return(&models.Grade{Grade: 80, Semester: 1}, nil)
+	mockGradeRepo.EXPECT().UpdateGrade(gomock.Any(), "s1", "sub1", 90).Return(errors.New("database is locked"))
+	if err := gs.RecordScores(context.Background(), "final", []models.AssessmentScore{{StudentID: "s1", Marks: 90}}); err == nil {
+		t.Errorf("expected the failing grade to fail the scores")
+	}
+	eventstest.AssertNotPublished[events.GradeUpdated](t, recorder)
+
+	if err := gs.AddGrades(context.Background(), "s3", "sub1", 90, 1); err == nil {
+		t.Errorf("expected direct grade to be refused for a subject with assessments")
+	}
+}
+
+func TestUpdateAssessment_RecomputesAllScoredStudents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGradeRepo := mockrepo.NewMockGradeRepositoryI(ctrl)
+	mockAssessmentRepo := mockrepo.NewMockAssessmentRepositoryI(ctrl)
+	mockTx := mockrepo.NewMockManager(ctrl)
+	gs := services.NewGradeService(mockGradeRepo, services.WithAssessments(mockTx, mockAssessmentRepo))
+
+	mid := subjectAssessments[0]
+	updated := []models.Assessment{subjectAssessments[1], mid}
+	updated[1].MaxMarks = 40
+	scores := []models.AssessmentScore{
+		{AssessmentID: "mid", StudentID: "s1", Marks: 40},
+		{AssessmentID: "final", StudentID: "s1", Marks: 80},
+	}
+	mockAssessmentRepo.EXPECT().GetAssessment(gomock.Any(), "mid").Return(&mid, nil).Times(2)
+	gomock.InOrder(
+		mockAssessmentRepo.EXPECT().GetAssessments(gomock.Any(), "sub1", 1).Return(subjectAssessments, nil),
+		mockAssessmentRepo.EXPECT().GetAssessments(gomock.Any(), "sub1", 1).Return(subjectAssessments, nil),
+		mockAssessmentRepo.EXPECT().GetAssessments(gomock.Any(), "sub1", 1).Return(updated, nil),
+	)
+	mockAssessmentRepo.EXPECT().GetScores(gomock.Any(), "sub1", 1).Return(scores, nil).Times(3)
+
+	if _, err := gs.UpdateAssessment(context.Background(), "mid", 30, 30); err == nil {
+		t.Errorf("expected error when existing marks exceed the new maximum")
+	}
+
+	mockTx.EXPECT().WithinTx(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, fn func(transaction.Querier) error) error { return fn(nil) })
+	mockAssessmentRepo.EXPECT().WithTx(nil).Return(mockAssessmentRepo)
+	mockGradeRepo.EXPECT().WithTx(nil).Return(mockGradeRepo)
+	mockAssessmentRepo.EXPECT().UpdateAssessment(gomock.Any(), "mid", 30.0, 40).Return(nil)
+	// s1: 30*40/40 + 70*80/100 = 86
+	mockGradeRepo.EXPECT().GetGrade(gomock.Any(), "s1", "sub1").Return(&models.Grade{Grade: 80, Semester: 1}, nil)
+	mockGradeRepo.EXPECT().UpdateGrade(gomock.Any(), "s1", "sub1", 86).Return(nil)
+
+	assessment, err := gs.UpdateAssessment(context.Background(), "mid", 30, 40)
+	if err != nil || assessment.MaxMarks != 40 {
+		t.Errorf("expected updated assessment, got %+v, %v", assessment, err)
+	}
+}
+
+func TestImportGrades(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGradeRepo := mockrepo.NewMockGradeRepositoryI(ctrl)
+	mockTxRepo := mockrepo.NewMockGradeRepositoryI(ctrl)
+	mockTx := mockrepo.NewMockManager(ctrl)
+	recorder := eventstest.NewRecorder()
+	gs := services.NewGradeService(mockGradeRepo, services.WithGradeTransactions(mockTx), services.WithGradeEvents(recorder))
+
+	grades := []models.Grade{
+		{StudentID: "s1", SubjectID: "maths", Grade: 80, Semester: 1},
+		{StudentID: "s2", SubjectID: "maths", Grade: 70, Semester: 1},
+	}
+	runTx := func(_ context.Context, fn func(transaction.Querier) error) error { return fn(nil) }
+
+	mockTx.EXPECT().WithinTx(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+	mockGradeRepo.EXPECT().WithTx(nil).Return(mockTxRepo)
+	mockTxRepo.EXPECT().AddGrades(gomock.Any(), "s1", "maths", 80, 1).Return(nil)
+	mockTxRepo.EXPECT().AddGrades(gomock.Any(), "s2", "maths", 70, 1).Return(nil)
+	if err := gs.ImportGrades(context.Background(), grades); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	eventstest.AssertPublished(t, recorder,
+		events.GradeAdded{StudentID: "s1", SubjectID: "maths", Grade: 80, Semester: 1},
+		events.GradeAdded{StudentID: "s2", SubjectID: "maths", Grade: 70, Semester: 1},
+	)
+
+	recorder.Reset()
+	mockTx.EXPECT().WithinTx(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+	mockGradeRepo.EXPECT().WithTx(nil).Return(mockTxRepo)
+	mockTxRepo.EXPECT().AddGrades(gomock.Any(), "s1", "maths", 80, 1).Return(nil)
+	mockTxRepo.EXPECT().AddGrades(gomock.Any(), "s2", "maths", 70, 1).Return(errors.New("UNIQUE constraint failed"))
+	err := gs.ImportGrades(context.Background(), grades)
+	if err == nil || !strings.Contains(err.Error(), "grade of student s2 in maths") {
+		t.Errorf("expected the failing grade to be reported, got %v", err)
+	}
+	eventstest.AssertNotPublished[events.GradeAdded](t, recorder)
+}
+
+func TestImportGrades_Validation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGradeRepo := mockrepo.NewMockGradeRepositoryI(ctrl)
+
+	if err := services.NewGradeService(mockGradeRepo).ImportGrades(context.Background(), []models.Grade{{StudentID: "s1", SubjectID: "maths"}}); err == nil {
+		t.Errorf("expected error without a transaction manager")
+	}
+
+	// No transaction is expected: a batch with an invalid grade never reaches the database.
+	gs := services.NewGradeService(mockGradeRepo,
+		services.WithGradeTransactions(mockrepo.NewMockManager(ctrl)),
+		services.WithEligibilityChecker(stubEligibilityChecker{err: errors.New("student is not enrolled in the subject")}),
+	)
+	tests := []struct {
+		name   string
+		grades []models.Grade
+	}{
+		{name: "empty batch"},
+		{name: "missing subject", grades: []models.Grade{{StudentID: "s1", Grade: 80, Semester: 1}}},
+		{name: "negative grade", grades: []models.Grade{{StudentID: "s1", SubjectID: "maths", Grade: -1, Semester: 1}}},
+		{name: "ineligible student", grades: []models.Grade{{StudentID: "s1", SubjectID: "maths", Grade: 80, Semester: 1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := gs.ImportGrades(context.Background(), tt.grades); err == nil {
+				t.Errorf("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+	"sms/apperrors"
+	"sms/constants"
+	"sms/models"
+	guardianRepository "sms/repository/guardianRepository"
+	studentRepo "sms/repository/studentRepository"
+	userrepository "sms/repository/userRepository"
+)
+
+type GuardianService struct {
+	gr guardianRepository.GuardianRepositoryI
+	ur userrepository.UserRepositoryI
+	sr studentRepo.StudentRepositoryI
+}
+
+func NewGuardianService(gr guardianRepository.GuardianRepositoryI, ur userrepository.UserRepositoryI, sr studentRepo.StudentRepositoryI) *GuardianService {
+	return &GuardianService{gr: gr, ur: ur, sr: sr}
+}
+
+func (gs *GuardianService) LinkStudent(ctx context.Context, link models.GuardianLink) error {
+	guardian, err := gs.ur.GetUserByID(ctx, link.GuardianID)
+	if err != nil {
+		return err
+	}
+	if guardian == nil || guardian.Role != constants.Guardian {
+		return apperrors.NotFound("guardian not found")
+	}
+	student, err := gs.sr.GetStudentByID(ctx, link.StudentID)
+	if err != nil {
+		return err
+	}
+	if student == nil {
+		return apperrors.NotFound("student not found")
+	}
+	return gs.gr.LinkStudent(ctx, link)
+}
+
+func (gs *GuardianService) UnlinkStudent(ctx context.Context, guardianID, studentID string) error {
+	linked, err := gs.gr.IsLinked(ctx, guardianID, studentID)
+	if err != nil {
+		return err
+	}
+	if !linked {
+		return apperrors.NotFound("student is not linked to the guardian")
+	}
+	return gs.gr.UnlinkStudent(ctx, guardianID, studentID)
+}
+
+func (gs *GuardianService) GetLinkedStudents(ctx context.Context, guardianID string) ([]models.Students, error) {
+	return gs.gr.GetLinkedStudents(ctx, guardianID)
+}
+
+func (gs *GuardianService) CanViewStudent(ctx context.Context, guardianID, studentID string) (bool, error) {
+	return gs.gr.IsLinked(ctx, guardianID, studentID)
+}
@@ -0,0 +1,14 @@
+package services
+
+import (
+	"context"
+	"sms/models"
+)
+
+//go:generate mockgen -destination=../mocks/guardian_service_mock.go -package=mocks -source=guardian_service_interface.go
+type GuardianServiceI interface {
+	LinkStudent(ctx context.Context, link models.GuardianLink) error
+	UnlinkStudent(ctx context.Context, guardianID, studentID string) error
+	GetLinkedStudents(ctx context.Context, guardianID string) ([]models.Students, error)
+	CanViewStudent(ctx context.Context, guardianID, studentID string) (bool, error)
+}
@@ -0,0 +1,85 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	"sms/constants"
+	mockrepo "sms/mocks"
+	"sms/models"
+	"sms/services"
+)
+
+func TestLinkGuardianStudent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGuardianRepo := mockrepo.NewMockGuardianRepositoryI(ctrl)
+	mockUserRepo := mockrepo.NewMockUserRepositoryI(ctrl)
+	mockStudentRepo := mockrepo.NewMockStudentRepositoryI(ctrl)
+	svc := services.NewGuardianService(mockGuardianRepo, mockUserRepo, mockStudentRepo)
+
+	link := models.GuardianLink{GuardianID: "g1", StudentID: "s1", Relationship: "father"}
+	mockUserRepo.EXPECT().GetUserByID(gomock.Any(), "g1").Return(&models.User{UserID: "g1", Role: constants.Guardian}, nil)
+	mockStudentRepo.EXPECT().GetStudentByID(gomock.Any(), "s1").Return(&models.Students{StudentID: "s1"}, nil)
+	mockGuardianRepo.EXPECT().LinkStudent(gomock.Any(), link).Return(nil)
+	if err := svc.LinkStudent(context.Background(), link); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	mockUserRepo.EXPECT().GetUserByID(gomock.Any(), "f1").Return(&models.User{UserID: "f1", Role: constants.Faculty}, nil)
+	if err := svc.LinkStudent(context.Background(), models.GuardianLink{GuardianID: "f1", StudentID: "s1"}); err == nil {
+		t.Errorf("expected error when linking a non-guardian user")
+	}
+
+	mockUserRepo.EXPECT().GetUserByID(gomock.Any(), "g1").Return(&models.User{UserID: "g1", Role: constants.Guardian}, nil)
+	mockStudentRepo.EXPECT().GetStudentByID(gomock.Any(), "missing").Return(nil, nil)
+	if err := svc.LinkStudent(context.Background(), models.GuardianLink{GuardianID: "g1", StudentID: "missing"}); err == nil {
+		t.Errorf("expected error for missing student")
+	}
+}
+
+func TestUnlinkGuardianStudent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGuardianRepo := mockrepo.NewMockGuardianRepositoryI(ctrl)
+	svc := services.NewGuardianService(mockGuardianRepo, mockrepo.NewMockUserRepositoryI(ctrl), mockrepo.NewMockStudentRepositoryI(ctrl))
+
+	mockGuardianRepo.EXPECT().IsLinked(gomock.Any(), "g1", "s1").Return(true, nil)
+	mockGuardianRepo.EXPECT().UnlinkStudent(gomock.Any(), "g1", "s1").Return(nil)
+	if err := svc.UnlinkStudent(context.Background(), "g1", "s1"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	mockGuardianRepo.EXPECT().IsLinked(gomock.Any(), "g1", "s2").Return(false, nil)
+	if err := svc.UnlinkStudent(context.Background(), "g1", "s2"); err == nil {
+		t.Errorf("expected error for a student that is not linked")
+	}
+
+	mockGuardianRepo.EXPECT().IsLinked(gomock.Any(), "g1", "s3").Return(false, errors.New("db error"))
+	if err := svc.UnlinkStudent(context.Background(), "g1", "s3"); err == nil {
+		t.Errorf("expected repo error to be returned")
+	}
+}
+
+func TestCanViewStudent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGuardianRepo := mockrepo.NewMockGuardianRepositoryI(ctrl)
+	svc := services.NewGuardianService(mockGuardianRepo, mockrepo.NewMockUserRepositoryI(ctrl), mockrepo.NewMockStudentRepositoryI(ctrl))
+
+	mockGuardianRepo.EXPECT().IsLinked(gomock.Any(), "g1", "s1").Return(true, nil)
+	mockGuardianRepo.EXPECT().IsLinked(gomock.Any(), "g1", "s2").Return(false, nil)
+
+	if ok, err := svc.CanViewStudent(context.Background(), "g1", "s1"); err != nil || !ok {
+		t.Errorf("expected guardian to view linked student, got %v, %v", ok, err)
+	}
+	if ok, err := svc.CanViewStudent(context.Background(), "g1", "s2"); err != nil || ok {
+		t.Errorf("expected guardian not to view other student, got %v, %v", ok, err)
+	}
+}
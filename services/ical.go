@@ -0,0 +1,71 @@
+package services
+
+import (
+	"fmt"
+	"sms/models"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// icalEscaper escapes TEXT values as required by RFC 5545.
+var icalEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+
+// icalLineLength is the most octets RFC 5545 allows on a line, without the CRLF.
+const icalLineLength = 75
+
+// buildCalendar renders the slots as weekly recurring events between from and
+// until. Times are floating local times, as the slots carry no time zone.
+func buildCalendar(name string, slots []models.TimetableSlot, from, until time.Time) []byte {
+	var b strings.Builder
+	line := func(format string, args ...any) {
+		b.WriteString(foldICalLine(fmt.Sprintf(format, args...)))
+		b.WriteString("\r\n")
+	}
+
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//sms//timetable//EN")
+	line("CALSCALE:GREGORIAN")
+	line("X-WR-CALNAME:%s", icalEscaper.Replace(name))
+	for _, s := range slots {
+		first := from.AddDate(0, 0, (int(s.Weekday)-int(from.Weekday())+7)%7)
+		if first.After(until) {
+			continue
+		}
+		day := first.Format("20060102")
+		line("BEGIN:VEVENT")
+		line("UID:%s@sms", s.SlotID)
+		line("DTSTAMP:%s", stamp)
+		line("DTSTART:%sT%s00", day, strings.ReplaceAll(s.StartTime, ":", ""))
+		line("DTEND:%sT%s00", day, strings.ReplaceAll(s.EndTime, ":", ""))
+		line("RRULE:FREQ=WEEKLY;UNTIL=%sT235959", until.Format("20060102"))
+		line("SUMMARY:%s", icalEscaper.Replace(s.SubjectName))
+		line("DESCRIPTION:%s", icalEscaper.Replace("Class "+s.ClassID))
+		line("LOCATION:%s", icalEscaper.Replace(s.RoomName))
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+	return []byte(b.String())
+}
+
+// foldICalLine breaks a content line longer than icalLineLength octets into
+// lines joined by CRLF and a space, without splitting a UTF-8 sequence.
+func foldICalLine(line string) string {
+	var b strings.Builder
+	limit := icalLineLength
+	for len(line) > limit {
+		cut := limit
+		for !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// the space starting the next line counts towards its length
+		limit = icalLineLength - 1
+	}
+	b.WriteString(line)
+	return b.String()
+}
@@ -2,13 +2,30 @@ package services
 
 import (
 	"errors"
+	"fmt"
 	"sms/constants"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
-var jwtSecret = []byte("your-secret-key")
+// JWT signs the tokens users authenticate with and checks the ones they present.
+type JWT struct {
+	secret []byte
+	expiry time.Duration
+}
+
+// NewJWT signs tokens with secret, which must be at least
+// constants.MinJWTSecretLength bytes, and keeps them valid for expiry.
+func NewJWT(secret []byte, expiry time.Duration) (*JWT, error) {
+	if len(secret) < constants.MinJWTSecretLength {
+		return nil, fmt.Errorf("the JWT secret must be at least %d bytes", constants.MinJWTSecretLength)
+	}
+	if expiry <= 0 {
+		return nil, errors.New("the JWT expiry must be positive")
+	}
+	return &JWT{secret: secret, expiry: expiry}, nil
+}
 
 type Claims struct {
 	UserID string         `json:"user_id"`
@@ -17,21 +34,21 @@ type Claims struct {
 	jwt.RegisteredClaims
 }
 
-func GenerateJWT(userID, email string, role constants.Role) (string, error) {
+func (j *JWT) Generate(userID, email string, role constants.Role) (string, error) {
 	claims := Claims{
 		UserID: userID,
 		Email:  email,
 		Role:   role,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(j.expiry)),
 		},
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(jwtSecret)
+	return token.SignedString(j.secret)
 }
 
-func ValidateJWT(tokenString string) (*Claims, error) {
+func (j *JWT) Validate(tokenString string) (*Claims, error) {
 	claims := &Claims{}
 
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (any, error) {
@@ -39,7 +56,7 @@ func ValidateJWT(tokenString string) (*Claims, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, errors.New("unexpected signing method")
 		}
-		return jwtSecret, nil
+		return j.secret, nil
 	})
 	if err != nil {
 		return nil, err
@@ -3,17 +3,30 @@ package services_test
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"sms/constants"
 	"sms/services"
 )
 
+const testJWTSecret = "0123456789abcdef0123456789abcdef"
+
+func newJWT(t *testing.T, secret string, expiry time.Duration) *services.JWT {
+	t.Helper()
+	tokens, err := services.NewJWT([]byte(secret), expiry)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	return tokens
+}
+
 func TestGenerateAndValidateJWT_Success(t *testing.T) {
 	userID := "123"
 	email := "test@example.com"
 	role := constants.Role("admin")
+	tokens := newJWT(t, testJWTSecret, time.Hour)
 
-	token, err := services.GenerateJWT(userID, email, role)
+	token, err := tokens.Generate(userID, email, role)
 	if err != nil {
 		t.Fatalf("expected no error generating token, got %v", err)
 	}
@@ -21,7 +34,7 @@ func TestGenerateAndValidateJWT_Success(t *testing.T) {
 		t.Fatal("expected token string, got empty")
 	}
 
-	claims, err := services.ValidateJWT(token)
+	claims, err := tokens.Validate(token)
 	if err != nil {
 		t.Fatalf("expected no error validating token, got %v", err)
 	}
@@ -35,12 +48,15 @@ func TestGenerateAndValidateJWT_Success(t *testing.T) {
 	if claims.Role != role {
 		t.Errorf("expected Role %v, got %v", role, claims.Role)
 	}
+	if left := time.Until(claims.ExpiresAt.Time); left > time.Hour || left < 59*time.Minute {
+		t.Errorf("expected the token to expire in an hour, got %v", left)
+	}
 }
 
 func TestValidateJWT_InvalidToken(t *testing.T) {
 	invalidToken := "this.is.not.a.valid.token"
 
-	claims, err := services.ValidateJWT(invalidToken)
+	claims, err := newJWT(t, testJWTSecret, time.Hour).Validate(invalidToken)
 	if err == nil {
 		t.Fatal("expected error for invalid token, got nil")
 	}
@@ -56,7 +72,7 @@ func TestValidateJWT_WrongSigningMethod(t *testing.T) {
 		"invalidsignature",
 	}, ".")
 
-	claims, err := services.ValidateJWT(token)
+	claims, err := newJWT(t, testJWTSecret, time.Hour).Validate(token)
 	if err == nil {
 		t.Fatal("expected error for wrong signing method, got nil")
 	}
@@ -64,3 +80,25 @@ func TestValidateJWT_WrongSigningMethod(t *testing.T) {
 		t.Fatal("expected claims to be nil for wrong signing method")
 	}
 }
+
+func TestValidateJWT_OtherSecret(t *testing.T) {
+	token, err := newJWT(t, "another-secret-of-at-least-32-bytes", time.Hour).Generate("123", "test@example.com", constants.Admin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newJWT(t, testJWTSecret, time.Hour).Validate(token); err == nil {
+		t.Error("expected a token signed with another secret to be rejected")
+	}
+}
+
+func TestNewJWT_Errors(t *testing.T) {
+	if _, err := services.NewJWT(nil, time.Hour); err == nil {
+		t.Error("expected error without a secret")
+	}
+	if _, err := services.NewJWT([]byte("your-secret-key"), time.Hour); err == nil {
+		t.Error("expected error for a short secret")
+	}
+	if _, err := services.NewJWT([]byte(testJWTSecret), 0); err == nil {
+		t.Error("expected error for a zero expiry")
+	}
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"net/smtp"
+	"sms/models"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// NotificationSenderI delivers a rendered notification over one channel.
+type NotificationSenderI interface {
+	Send(ctx context.Context, n models.Notification) error
+}
+
+// InAppSender delivers to the in-app inbox. The outbox row is the inbox entry,
+// so marking it sent is all that's needed.
+type InAppSender struct{}
+
+func NewInAppSender() *InAppSender {
+	return &InAppSender{}
+}
+
+func (s *InAppSender) Send(_ context.Context, n models.Notification) error {
+	return nil
+}
+
+// SMTPSender sends notifications as plain-text email.
+type SMTPSender struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+func NewSMTPSender(addr, from string, auth smtp.Auth) *SMTPSender {
+	return &SMTPSender{addr: addr, from: from, auth: auth}
+}
+
+// Send gives up before dialling if ctx is already done; net/smtp can't abort a
+// conversation that has started.
+func (s *SMTPSender) Send(ctx context.Context, n models.Notification) error {
+	if n.Target == "" {
+		return fmt.Errorf("notification %s has no email address", n.NotificationID)
+	}
+	if strings.ContainsAny(s.from+n.Target, "\r\n") {
+		return fmt.Errorf("notification %s has a line break in an address", n.NotificationID)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", s.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", n.Target)
+	// encoding keeps line breaks in a subject from starting new header lines
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("UTF-8", n.Subject))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(n.Body, "\n", "\r\n"))
+	msg.WriteString("\r\n")
+	return smtp.SendMail(s.addr, s.auth, s.from, []string{n.Target}, []byte(msg.String()))
+}
+
+type webhookPayload struct {
+	NotificationID string    `json:"notificationID"`
+	UserID         string    `json:"userID"`
+	Event          string    `json:"event"`
+	Subject        string    `json:"subject"`
+	Body           string    `json:"body"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+// WebhookSender posts notifications as JSON to the URL a user registered.
+type WebhookSender struct {
+	client *http.Client
+}
+
+func NewWebhookSender(client *http.Client) *WebhookSender {
+	return &WebhookSender{client: client}
+}
+
+func (s *WebhookSender) Send(ctx context.Context, n models.Notification) error {
+	if n.Target == "" {
+		return fmt.Errorf("notification %s has no webhook URL", n.NotificationID)
+	}
+	payload, err := json.Marshal(webhookPayload{
+		NotificationID: n.NotificationID,
+		UserID:         n.UserID,
+		Event:          string(n.Event),
+		Subject:        n.Subject,
+		Body:           n.Body,
+		CreatedAt:      n.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.Target, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+var errNonPublicAddress = errors.New("not a public address")
+
+// isPublicIP reports whether ip is a unicast address outside the loopback,
+// private and link-local ranges.
+func isPublicIP(ip net.IP) bool {
+	return ip != nil && !ip.IsUnspecified() && !ip.IsLoopback() && !ip.IsPrivate() &&
+		!ip.IsLinkLocalUnicast() && !ip.IsMulticast()
+}
+
+// checkPublicHost resolves host and fails unless every address it has is public.
+func checkPublicHost(ctx context.Context, host string) error {
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return err
+	}
+	for _, addr := range addrs {
+		if !isPublicIP(addr.IP) {
+			return fmt.Errorf("%s resolves to %s, %w", host, addr.IP, errNonPublicAddress)
+		}
+	}
+	return nil
+}
+
+// NewPublicHTTPClient returns a client for URLs that users choose. It won't
+// connect to loopback, private or link-local addresses. The check runs on the
+// address being dialled, so a host that resolves differently after it was
+// validated is still refused. Proxy settings are ignored for the same reason.
+func NewPublicHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if !isPublicIP(net.ParseIP(host)) {
+				return fmt.Errorf("%s is %w", host, errNonPublicAddress)
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
@@ -0,0 +1,147 @@
+package services_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"sms/constants"
+	"sms/models"
+	"sms/services"
+)
+
+func TestWebhookSender(t *testing.T) {
+	var got map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected content type %s", r.Header.Get("Content-Type"))
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+		if got["notificationID"] == "n2" {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	sender := services.NewWebhookSender(server.Client())
+	n := models.Notification{NotificationID: "n1", UserID: "u1", Event: constants.EventAtRisk, Target: server.URL, Subject: "subject", Body: "body"}
+	if err := sender.Send(context.Background(), n); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got["event"] != "at_risk" || got["body"] != "body" {
+		t.Errorf("unexpected payload: %v", got)
+	}
+
+	n.NotificationID = "n2"
+	if err := sender.Send(context.Background(), n); err == nil {
+		t.Errorf("expected error for a non-2xx response")
+	}
+	if err := sender.Send(context.Background(), models.Notification{NotificationID: "n3"}); err == nil {
+		t.Errorf("expected error without a webhook URL")
+	}
+}
+
+func TestPublicHTTPClientRefusesPrivateAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request reached a loopback server")
+	}))
+	defer server.Close()
+
+	sender := services.NewWebhookSender(services.NewPublicHTTPClient(time.Second))
+	err := sender.Send(context.Background(), models.Notification{NotificationID: "n1", Target: server.URL})
+	if err == nil || !strings.Contains(err.Error(), "not a public address") {
+		t.Errorf("expected the loopback server to be refused, got %v", err)
+	}
+}
+
+// fakeSMTPServer accepts one mail and returns what was sent in DATA.
+func fakeSMTPServer(t *testing.T) (string, <-chan string) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	data := make(chan string, 1)
+	go func() {
+		defer l.Close()
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		reply := func(s string) { conn.Write([]byte(s + "\r\n")) }
+		reply("220 localhost ready")
+		var body strings.Builder
+		inData := false
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			if inData {
+				if line == ".\r\n" {
+					inData = false
+					data <- body.String()
+					reply("250 queued")
+					continue
+				}
+				body.WriteString(line)
+				continue
+			}
+			switch cmd := strings.ToUpper(strings.TrimSpace(line)); {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				reply("250 localhost")
+			case cmd == "DATA":
+				inData = true
+				reply("354 go ahead")
+			case cmd == "QUIT":
+				reply("221 bye")
+				return
+			default:
+				reply("250 ok")
+			}
+		}
+	}()
+	return l.Addr().String(), data
+}
+
+func TestSMTPSender(t *testing.T) {
+	addr, data := fakeSMTPServer(t)
+
+	sender := services.NewSMTPSender(addr, "school@example.com", nil)
+	err := sender.Send(context.Background(), models.Notification{NotificationID: "n1", Target: "parent@example.com", Subject: "Grade posted for maths", Body: "s1 received 91 in maths."})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	msg := <-data
+	for _, want := range []string{"To: parent@example.com\r\n", "Subject: Grade posted for maths\r\n", "\r\n\r\ns1 received 91 in maths.\r\n"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("expected message to contain %q, got %q", want, msg)
+		}
+	}
+}
+
+func TestSMTPSenderKeepsHeadersIntact(t *testing.T) {
+	addr, data := fakeSMTPServer(t)
+
+	sender := services.NewSMTPSender(addr, "school@example.com", nil)
+	err := sender.Send(context.Background(), models.Notification{NotificationID: "n1", Target: "parent@example.com", Subject: "Grade posted for X\r\nBcc: attacker@example.com", Body: "body"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if msg := <-data; strings.Contains(msg, "\r\nBcc:") {
+		t.Errorf("subject injected a header: %q", msg)
+	}
+
+	err = sender.Send(context.Background(), models.Notification{NotificationID: "n2", Target: "parent@example.com\r\nBcc: attacker@example.com", Subject: "subject"})
+	if err == nil {
+		t.Errorf("expected error for a line break in the address")
+	}
+}
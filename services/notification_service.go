@@ -0,0 +1,325 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/mail"
+	"net/url"
+	"sms/apperrors"
+	"sms/constants"
+	"sms/events"
+	"sms/models"
+	guardianRepository "sms/repository/guardianRepository"
+	notificationRepository "sms/repository/notificationRepository"
+	studentRepo "sms/repository/studentRepository"
+	subjectRepository "sms/repository/subjectRepository"
+	timetableRepository "sms/repository/timetableRepository"
+	userrepository "sms/repository/userRepository"
+	"sms/utils"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// notificationChannels is the order channels are offered in, with whether they
+// are on for users who haven't set a preference.
+var notificationChannels = []struct {
+	channel constants.NotificationChannel
+	enabled bool
+}{
+	{constants.ChannelInApp, true},
+	{constants.ChannelEmail, true},
+	{constants.ChannelWebhook, false},
+}
+
+type NotificationService struct {
+	nr          notificationRepository.NotificationRepositoryI
+	ur          userrepository.UserRepositoryI
+	gr          guardianRepository.GuardianRepositoryI
+	tr          timetableRepository.TimetableRepositoryI
+	sr          studentRepo.StudentRepositoryI
+	subr        subjectRepository.SubjectRepositoryI
+	senders     map[constants.NotificationChannel]NotificationSenderI
+	maxAttempts int
+	backoff     time.Duration
+}
+
+// NewNotificationService delivers over the channels that have a sender. A failed
+// delivery is retried after backoff, doubling each time up to
+// constants.MaxNotificationBackoff, and gives up after maxAttempts tries.
+// Students and subjects are looked up to name them in the messages, and the
+// timetable to find the faculty who advise a class.
+func NewNotificationService(nr notificationRepository.NotificationRepositoryI, ur userrepository.UserRepositoryI, gr guardianRepository.GuardianRepositoryI,
+	tr timetableRepository.TimetableRepositoryI, sr studentRepo.StudentRepositoryI, subr subjectRepository.SubjectRepositoryI,
+	senders map[constants.NotificationChannel]NotificationSenderI, maxAttempts int, backoff time.Duration) *NotificationService {
+	return &NotificationService{nr: nr, ur: ur, gr: gr, tr: tr, sr: sr, subr: subr, senders: senders, maxAttempts: maxAttempts, backoff: backoff}
+}
+
+// Notify renders the event and queues one notification per channel the user has on.
+func (ns *NotificationService) Notify(ctx context.Context, userID string, event constants.NotificationEvent, data map[string]any) error {
+	subject, body, err := renderNotification(event, data)
+	if err != nil {
+		return err
+	}
+	user, err := ns.ur.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return apperrors.NotFound("user not found")
+	}
+	prefs, err := ns.GetPreferences(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	var notifications []models.Notification
+	for _, p := range prefs {
+		if !p.Enabled {
+			continue
+		}
+		if _, ok := ns.senders[p.Channel]; !ok {
+			continue
+		}
+		target := p.Target
+		if p.Channel == constants.ChannelEmail && target == "" {
+			target = user.Email
+		}
+		notifications = append(notifications, models.Notification{
+			NotificationID: uuid.New().String(),
+			UserID:         userID,
+			Event:          event,
+			Channel:        p.Channel,
+			Target:         target,
+			Subject:        subject,
+			Body:           body,
+			Status:         constants.NotificationPending,
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+		})
+	}
+	if len(notifications) == 0 {
+		return nil
+	}
+	return ns.nr.AddNotifications(ctx, notifications)
+}
+
+// NotifyGuardians notifies every guardian linked to the student.
+func (ns *NotificationService) NotifyGuardians(ctx context.Context, studentID string, event constants.NotificationEvent, data map[string]any) error {
+	guardianIDs, err := ns.gr.GetGuardianIDs(ctx, studentID)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, guardianID := range guardianIDs {
+		if err := ns.Notify(ctx, guardianID, event, data); err != nil {
+			errs = append(errs, fmt.Errorf("guardian %s: %w", guardianID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Subscribe queues notifications for the domain events users are told about.
+// The subscribers are synchronous so the outbox rows exist before the request
+// that caused them returns.
+func (ns *NotificationService) Subscribe(bus *events.Bus) {
+	events.Subscribe(bus, func(ctx context.Context, e events.GradeAdded) error {
+		return ns.notifyGrade(ctx, constants.EventGradePosted, e.StudentID, e.SubjectID, e.Grade, e.Semester)
+	})
+	events.Subscribe(bus, func(ctx context.Context, e events.GradeUpdated) error {
+		return ns.notifyGrade(ctx, constants.EventGradeChanged, e.StudentID, e.SubjectID, e.Grade, e.Semester)
+	})
+	events.Subscribe(bus, func(ctx context.Context, e events.UserSignedUp) error {
+		return ns.Notify(ctx, e.UserID, constants.EventAccountCreated, map[string]any{"Name": e.Name, "Email": e.Email, "Role": e.Role})
+	})
+}
+
+func (ns *NotificationService) notifyGrade(ctx context.Context, event constants.NotificationEvent, studentID, subjectID string, grade, semester int) error {
+	student, err := ns.studentName(ctx, studentID)
+	if err != nil {
+		return err
+	}
+	subject, err := ns.subjectName(ctx, subjectID)
+	if err != nil {
+		return err
+	}
+	return ns.NotifyGuardians(ctx, studentID, event, map[string]any{
+		"Student": student, "Subject": subject, "Grade": grade, "Semester": semester,
+	})
+}
+
+// NotifyAtRisk lets NotificationService stand in as the AlertService notifier.
+// The student's advisors are told along with the guardians: the faculty who
+// teach the class, or the admins while nobody is assigned to it.
+func (ns *NotificationService) NotifyAtRisk(ctx context.Context, flag models.AtRiskFlag) error {
+	student, err := ns.studentName(ctx, flag.StudentID)
+	if err != nil {
+		return err
+	}
+	data := map[string]any{
+		"Student":  student,
+		"ClassID":  flag.ClassID,
+		"Semester": flag.Semester,
+		"Reasons":  strings.Join(flag.Reasons, "; "),
+	}
+	advisorIDs, err := ns.tr.GetClassFacultyIDs(ctx, flag.ClassID)
+	if err != nil {
+		return err
+	}
+	if len(advisorIDs) == 0 {
+		if advisorIDs, err = ns.ur.GetUserIDsByRole(ctx, constants.Admin); err != nil {
+			return err
+		}
+	}
+
+	var errs []error
+	for _, advisorID := range advisorIDs {
+		if err := ns.Notify(ctx, advisorID, constants.EventAtRisk, data); err != nil {
+			errs = append(errs, fmt.Errorf("advisor %s: %w", advisorID, err))
+		}
+	}
+	if err := ns.NotifyGuardians(ctx, flag.StudentID, constants.EventAtRisk, data); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// studentName falls back to the ID for a student that no longer exists.
+func (ns *NotificationService) studentName(ctx context.Context, studentID string) (string, error) {
+	student, err := ns.sr.GetStudentByID(ctx, studentID)
+	if err != nil || student == nil {
+		return studentID, err
+	}
+	return student.Name, nil
+}
+
+// subjectName falls back to the ID for a subject that isn't in the catalogue.
+func (ns *NotificationService) subjectName(ctx context.Context, subjectID string) (string, error) {
+	subject, err := ns.subr.GetSubject(ctx, subjectID)
+	if err != nil || subject == nil {
+		return subjectID, err
+	}
+	return subject.SubjectName, nil
+}
+
+// ProcessOutbox delivers the notifications that are due and returns how many were sent.
+func (ns *NotificationService) ProcessOutbox(ctx context.Context, now time.Time) (int, error) {
+	due, err := ns.nr.GetDueNotifications(ctx, now, constants.NotificationBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, n := range due {
+		sender, ok := ns.senders[n.Channel]
+		if !ok {
+			err = fmt.Errorf("channel %s is not configured", n.Channel)
+		} else {
+			err = sender.Send(ctx, n)
+		}
+		if err == nil {
+			if err := ns.nr.MarkSent(ctx, n.NotificationID, now); err != nil {
+				return sent, err
+			}
+			sent++
+			continue
+		}
+
+		attempts := n.Attempts + 1
+		status, next := constants.NotificationPending, now.Add(utils.Backoff(ns.backoff, constants.MaxNotificationBackoff, attempts-1))
+		if !ok || attempts >= ns.maxAttempts {
+			status, next = constants.NotificationFailed, n.NextAttemptAt
+		}
+		if err := ns.nr.RecordFailure(ctx, n.NotificationID, status, attempts, next, err.Error()); err != nil {
+			return sent, err
+		}
+	}
+	return sent, nil
+}
+
+// RunWorker processes the outbox every interval until ctx is done.
+func (ns *NotificationService) RunWorker(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := ns.ProcessOutbox(ctx, time.Now().UTC()); err != nil {
+				log.Printf("failed to process notification outbox: %v", err)
+			}
+		}
+	}
+}
+
+func (ns *NotificationService) GetInbox(ctx context.Context, userID string) ([]models.Notification, error) {
+	return ns.nr.GetInbox(ctx, userID)
+}
+
+func (ns *NotificationService) MarkRead(ctx context.Context, userID, notificationID string) error {
+	found, err := ns.nr.MarkRead(ctx, userID, notificationID, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	if !found {
+		return apperrors.NotFound("notification not found")
+	}
+	return nil
+}
+
+// GetPreferences returns the user's preference for every channel, falling back
+// to the channel default where the user hasn't set one.
+func (ns *NotificationService) GetPreferences(ctx context.Context, userID string) ([]models.NotificationPreference, error) {
+	stored, err := ns.nr.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	byChannel := make(map[constants.NotificationChannel]models.NotificationPreference, len(stored))
+	for _, p := range stored {
+		byChannel[p.Channel] = p
+	}
+
+	prefs := make([]models.NotificationPreference, 0, len(notificationChannels))
+	for _, c := range notificationChannels {
+		p, ok := byChannel[c.channel]
+		if !ok {
+			p = models.NotificationPreference{UserID: userID, Channel: c.channel, Enabled: c.enabled}
+		}
+		prefs = append(prefs, p)
+	}
+	return prefs, nil
+}
+
+func (ns *NotificationService) SetPreference(ctx context.Context, pref models.NotificationPreference) error {
+	switch pref.Channel {
+	case constants.ChannelInApp:
+		pref.Target = ""
+	case constants.ChannelEmail:
+		if pref.Target != "" {
+			// the bare address is stored, as it is used as the SMTP recipient
+			addr, err := mail.ParseAddress(pref.Target)
+			if err != nil {
+				return apperrors.Validation("invalid email address")
+			}
+			pref.Target = addr.Address
+		}
+	case constants.ChannelWebhook:
+		if pref.Enabled || pref.Target != "" {
+			u, err := url.Parse(pref.Target)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return apperrors.Validation("webhook needs an http or https URL")
+			}
+			if err := checkPublicHost(ctx, u.Hostname()); err != nil {
+				return apperrors.Validation("webhook must point at a public host: %v", err)
+			}
+		}
+	default:
+		return apperrors.Validation("unknown channel %s", pref.Channel)
+	}
+	return ns.nr.SetPreference(ctx, pref)
+}
@@ -0,0 +1,19 @@
+package services
+
+import (
+	"context"
+	"sms/constants"
+	"sms/models"
+	"time"
+)
+
+//go:generate mockgen -destination=../mocks/notification_service_mock.go -package=mocks -source=notification_service_interface.go
+type NotificationServiceI interface {
+	Notify(ctx context.Context, userID string, event constants.NotificationEvent, data map[string]any) error
+	NotifyGuardians(ctx context.Context, studentID string, event constants.NotificationEvent, data map[string]any) error
+	ProcessOutbox(ctx context.Context, now time.Time) (int, error)
+	GetInbox(ctx context.Context, userID string) ([]models.Notification, error)
+	MarkRead(ctx context.Context, userID, notificationID string) error
+	GetPreferences(ctx context.Context, userID string) ([]models.NotificationPreference, error)
+	SetPreference(ctx context.Context, pref models.NotificationPreference) error
+}
@@ -0,0 +1,341 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"sms/constants"
+	"sms/events"
+	mockrepo "sms/mocks"
+	"sms/models"
+	"sms/services"
+)
+
+type stubSender struct {
+	err  error
+	sent []models.Notification
+}
+
+func (s *stubSender) Send(_ context.Context, n models.Notification) error {
+	s.sent = append(s.sent, n)
+	return s.err
+}
+
+type notificationMocks struct {
+	nr   *mockrepo.MockNotificationRepositoryI
+	ur   *mockrepo.MockUserRepositoryI
+	gr   *mockrepo.MockGuardianRepositoryI
+	tr   *mockrepo.MockTimetableRepositoryI
+	sr   *mockrepo.MockStudentRepositoryI
+	subr *mockrepo.MockSubjectRepositoryI
+}
+
+func newNotificationService(ctrl *gomock.Controller, senders map[constants.NotificationChannel]services.NotificationSenderI) (*services.NotificationService, notificationMocks) {
+	m := notificationMocks{
+		nr:   mockrepo.NewMockNotificationRepositoryI(ctrl),
+		ur:   mockrepo.NewMockUserRepositoryI(ctrl),
+		gr:   mockrepo.NewMockGuardianRepositoryI(ctrl),
+		tr:   mockrepo.NewMockTimetableRepositoryI(ctrl),
+		sr:   mockrepo.NewMockStudentRepositoryI(ctrl),
+		subr: mockrepo.NewMockSubjectRepositoryI(ctrl),
+	}
+	return services.NewNotificationService(m.nr, m.ur, m.gr, m.tr, m.sr, m.subr, senders, 3, time.Minute), m
+}
+
+func TestNotify(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	senders := map[constants.NotificationChannel]services.NotificationSenderI{
+		constants.ChannelInApp:   &stubSender{},
+		constants.ChannelEmail:   &stubSender{},
+		constants.ChannelWebhook: &stubSender{},
+	}
+	svc, m := newNotificationService(ctrl, senders)
+
+	m.ur.EXPECT().GetUserByID(gomock.Any(), "g1").Return(&models.User{UserID: "g1", Email: "g1@example.com", Role: constants.Guardian}, nil)
+	m.nr.EXPECT().GetPreferences(gomock.Any(), "g1").Return([]models.NotificationPreference{
+		{UserID: "g1", Channel: constants.ChannelWebhook, Enabled: true, Target: "https://example.com/hook"},
+	}, nil)
+	m.nr.EXPECT().AddNotifications(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, notifications []models.Notification) error {
+		if len(notifications) != 3 {
+			t.Fatalf("expected a notification per enabled channel, got %d", len(notifications))
+		}
+		targets := map[constants.NotificationChannel]string{}
+		for _, n := range notifications {
+			targets[n.Channel] = n.Target
+			if n.Status != constants.NotificationPending || n.Subject != "Grade posted for Mathematics" {
+				t.Errorf("unexpected notification: %+v", n)
+			}
+			if n.Body != "Asha received 91 in Mathematics for semester 2." {
+				t.Errorf("unexpected body: %q", n.Body)
+			}
+		}
+		if targets[constants.ChannelEmail] != "g1@example.com" || targets[constants.ChannelWebhook] != "https://example.com/hook" {
+			t.Errorf("unexpected targets: %v", targets)
+		}
+		return nil
+	})
+
+	err := svc.Notify(context.Background(), "g1", constants.EventGradePosted, map[string]any{"Student": "Asha", "Subject": "Mathematics", "Grade": 91, "Semester": 2})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestNotify_SkipsUnconfiguredAndDisabledChannels(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc, m := newNotificationService(ctrl, map[constants.NotificationChannel]services.NotificationSenderI{
+		constants.ChannelInApp: &stubSender{},
+	})
+
+	m.ur.EXPECT().GetUserByID(gomock.Any(), "u1").Return(&models.User{UserID: "u1", Email: "u1@example.com"}, nil)
+	m.nr.EXPECT().GetPreferences(gomock.Any(), "u1").Return([]models.NotificationPreference{
+		{UserID: "u1", Channel: constants.ChannelInApp, Enabled: false},
+	}, nil)
+
+	err := svc.Notify(context.Background(), "u1", constants.EventAccountCreated, map[string]any{"Name": "Anu", "Email": "u1@example.com", "Role": constants.Faculty})
+	if err != nil {
+		t.Fatalf("expected nothing to be queued without error, got %v", err)
+	}
+}
+
+func TestNotify_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc, m := newNotificationService(ctrl, nil)
+
+	if err := svc.Notify(context.Background(), "u1", "unknown", nil); err == nil {
+		t.Errorf("expected error for an event without template")
+	}
+	if err := svc.Notify(context.Background(), "u1", constants.EventGradePosted, map[string]any{"Student": "Asha"}); err == nil {
+		t.Errorf("expected error for missing template data")
+	}
+
+	m.ur.EXPECT().GetUserByID(gomock.Any(), "missing").Return(nil, nil)
+	if err := svc.Notify(context.Background(), "missing", constants.EventAccountCreated, map[string]any{"Name": "", "Email": "", "Role": ""}); err == nil {
+		t.Errorf("expected error for missing user")
+	}
+}
+
+func TestNotifyAtRisk(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc, m := newNotificationService(ctrl, map[constants.NotificationChannel]services.NotificationSenderI{
+		constants.ChannelInApp: &stubSender{},
+	})
+
+	notified := map[string]bool{}
+	expectNotify := func(userID string) {
+		m.ur.EXPECT().GetUserByID(gomock.Any(), userID).Return(&models.User{UserID: userID}, nil)
+		m.nr.EXPECT().GetPreferences(gomock.Any(), userID).Return(nil, nil)
+		m.nr.EXPECT().AddNotifications(gomock.Any(), gomock.Len(1)).DoAndReturn(func(_ context.Context, notifications []models.Notification) error {
+			if want := "Asha (class C1) is at risk in semester 2: failed 2 subjects; average 35.00 is below 40.00."; notifications[0].Body != want {
+				t.Errorf("expected body %q, got %q", want, notifications[0].Body)
+			}
+			notified[notifications[0].UserID] = true
+			return nil
+		})
+	}
+	flag := models.AtRiskFlag{StudentID: "s1", ClassID: "C1", Semester: 2, Reasons: []string{"failed 2 subjects", "average 35.00 is below 40.00"}}
+
+	// the faculty of the class hear about it along with the guardians
+	m.sr.EXPECT().GetStudentByID(gomock.Any(), "s1").Return(&models.Students{StudentID: "s1", Name: "Asha"}, nil)
+	m.tr.EXPECT().GetClassFacultyIDs(gomock.Any(), "C1").Return([]string{"f1"}, nil)
+	expectNotify("f1")
+	m.gr.EXPECT().GetGuardianIDs(gomock.Any(), "s1").Return([]string{"g1", "g2"}, nil)
+	expectNotify("g1")
+	m.ur.EXPECT().GetUserByID(gomock.Any(), "g2").Return(nil, errors.New("db error"))
+
+	err := svc.NotifyAtRisk(context.Background(), flag)
+	if err == nil || !strings.Contains(err.Error(), "guardian g2") {
+		t.Errorf("expected the failing guardian to be reported, got %v", err)
+	}
+	if !notified["f1"] || !notified["g1"] {
+		t.Errorf("expected f1 and g1 to be notified, got %v", notified)
+	}
+
+	// a class nobody teaches yet falls back to the admins
+	m.sr.EXPECT().GetStudentByID(gomock.Any(), "s1").Return(&models.Students{StudentID: "s1", Name: "Asha"}, nil)
+	m.tr.EXPECT().GetClassFacultyIDs(gomock.Any(), "C1").Return(nil, nil)
+	m.ur.EXPECT().GetUserIDsByRole(gomock.Any(), constants.Admin).Return([]string{"a1"}, nil)
+	expectNotify("a1")
+	m.gr.EXPECT().GetGuardianIDs(gomock.Any(), "s1").Return(nil, nil)
+
+	if err := svc.NotifyAtRisk(context.Background(), flag); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if !notified["a1"] {
+		t.Errorf("expected the admin to be notified, got %v", notified)
+	}
+}
+
+func TestNotificationSubscribers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc, m := newNotificationService(ctrl, map[constants.NotificationChannel]services.NotificationSenderI{
+		constants.ChannelInApp: &stubSender{},
+	})
+	bus := events.NewBus()
+	svc.Subscribe(bus)
+
+	m.sr.EXPECT().GetStudentByID(gomock.Any(), "s1").Return(&models.Students{StudentID: "s1", Name: "Asha"}, nil).Times(2)
+	m.subr.EXPECT().GetSubject(gomock.Any(), "maths").Return(nil, nil).Times(2)
+	m.gr.EXPECT().GetGuardianIDs(gomock.Any(), "s1").Return([]string{"g1"}, nil).Times(2)
+	m.ur.EXPECT().GetUserByID(gomock.Any(), "g1").Return(&models.User{UserID: "g1"}, nil).Times(2)
+	m.nr.EXPECT().GetPreferences(gomock.Any(), "g1").Return(nil, nil).Times(2)
+	gomock.InOrder(
+		m.nr.EXPECT().AddNotifications(gomock.Any(), gomock.Len(1)).DoAndReturn(func(_ context.Context, notifications []models.Notification) error {
+			if notifications[0].Event != constants.EventGradePosted || notifications[0].Body != "Asha received 90 in maths for semester 1." {
+				t.Errorf("expected grade_posted, got %+v", notifications[0])
+			}
+			return nil
+		}),
+		m.nr.EXPECT().AddNotifications(gomock.Any(), gomock.Len(1)).DoAndReturn(func(_ context.Context, notifications []models.Notification) error {
+			if notifications[0].Event != constants.EventGradeChanged || !strings.Contains(notifications[0].Body, "95") {
+				t.Errorf("expected grade_changed with the new grade, got %+v", notifications[0])
+			}
+			return nil
+		}),
+	)
+	if err := bus.Publish(context.Background(), events.GradeAdded{StudentID: "s1", SubjectID: "maths", Grade: 90, Semester: 1}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := bus.Publish(context.Background(), events.GradeUpdated{StudentID: "s1", SubjectID: "maths", OldGrade: 90, Grade: 95, Semester: 1}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	m.ur.EXPECT().GetUserByID(gomock.Any(), "u1").Return(nil, errors.New("db error"))
+	if err := bus.Publish(context.Background(), events.UserSignedUp{UserID: "u1", Name: "Anu", Email: "u1@example.com", Role: constants.Faculty}); err == nil {
+		t.Errorf("expected the subscriber error to reach the publisher")
+	}
+}
+
+func TestProcessOutbox(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	inApp := &stubSender{}
+	email := &stubSender{err: errors.New("connection refused")}
+	svc, m := newNotificationService(ctrl, map[constants.NotificationChannel]services.NotificationSenderI{
+		constants.ChannelInApp: inApp,
+		constants.ChannelEmail: email,
+	})
+
+	now := time.Date(2026, 9, 1, 10, 0, 0, 0, time.UTC)
+	m.nr.EXPECT().GetDueNotifications(gomock.Any(), now, constants.NotificationBatchSize).Return([]models.Notification{
+		{NotificationID: "n1", Channel: constants.ChannelInApp},
+		{NotificationID: "n2", Channel: constants.ChannelEmail, Attempts: 1, NextAttemptAt: now},
+		{NotificationID: "n3", Channel: constants.ChannelEmail, Attempts: 2, NextAttemptAt: now},
+		{NotificationID: "n4", Channel: constants.ChannelWebhook, NextAttemptAt: now},
+	}, nil)
+	m.nr.EXPECT().MarkSent(gomock.Any(), "n1", now).Return(nil)
+	m.nr.EXPECT().RecordFailure(gomock.Any(), "n2", constants.NotificationPending, 2, now.Add(2*time.Minute), "connection refused").Return(nil)
+	m.nr.EXPECT().RecordFailure(gomock.Any(), "n3", constants.NotificationFailed, 3, now, "connection refused").Return(nil)
+	m.nr.EXPECT().RecordFailure(gomock.Any(), "n4", constants.NotificationFailed, 1, now, "channel webhook is not configured").Return(nil)
+
+	sent, err := svc.ProcessOutbox(context.Background(), now)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if sent != 1 {
+		t.Errorf("expected 1 sent, got %d", sent)
+	}
+	if len(inApp.sent) != 1 || len(email.sent) != 2 {
+		t.Errorf("unexpected deliveries: in-app %d, email %d", len(inApp.sent), len(email.sent))
+	}
+}
+
+func TestNotificationBackoffIsCapped(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := notificationMocks{nr: mockrepo.NewMockNotificationRepositoryI(ctrl)}
+	email := &stubSender{err: errors.New("connection refused")}
+	svc := services.NewNotificationService(m.nr, nil, nil, nil, nil, nil,
+		map[constants.NotificationChannel]services.NotificationSenderI{constants.ChannelEmail: email}, 100, time.Hour)
+
+	now := time.Date(2026, 9, 1, 10, 0, 0, 0, time.UTC)
+	m.nr.EXPECT().GetDueNotifications(gomock.Any(), now, constants.NotificationBatchSize).Return([]models.Notification{
+		{NotificationID: "n1", Channel: constants.ChannelEmail, Attempts: 70, NextAttemptAt: now},
+	}, nil)
+	m.nr.EXPECT().RecordFailure(gomock.Any(), "n1", constants.NotificationPending, 71, now.Add(constants.MaxNotificationBackoff), "connection refused").Return(nil)
+
+	if _, err := svc.ProcessOutbox(context.Background(), now); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestNotificationPreferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc, m := newNotificationService(ctrl, nil)
+
+	m.nr.EXPECT().GetPreferences(gomock.Any(), "u1").Return([]models.NotificationPreference{
+		{UserID: "u1", Channel: constants.ChannelEmail, Enabled: false},
+	}, nil)
+	prefs, err := svc.GetPreferences(context.Background(), "u1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	want := map[constants.NotificationChannel]bool{constants.ChannelInApp: true, constants.ChannelEmail: false, constants.ChannelWebhook: false}
+	if len(prefs) != len(want) {
+		t.Fatalf("expected a preference per channel, got %+v", prefs)
+	}
+	for _, p := range prefs {
+		if p.Enabled != want[p.Channel] {
+			t.Errorf("expected %s enabled=%v, got %v", p.Channel, want[p.Channel], p.Enabled)
+		}
+	}
+
+	m.nr.EXPECT().SetPreference(gomock.Any(), models.NotificationPreference{UserID: "u1", Channel: constants.ChannelWebhook, Enabled: true, Target: "https://203.0.113.10/hook"}).Return(nil)
+	if err := svc.SetPreference(context.Background(), models.NotificationPreference{UserID: "u1", Channel: constants.ChannelWebhook, Enabled: true, Target: "https://203.0.113.10/hook"}); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	for _, target := range []string{"http://127.0.0.1:8080/hook", "http://localhost/hook", "http://169.254.169.254/latest/meta-data", "http://10.0.0.5/hook", "http://[::1]/hook"} {
+		if err := svc.SetPreference(context.Background(), models.NotificationPreference{UserID: "u1", Channel: constants.ChannelWebhook, Enabled: true, Target: target}); err == nil {
+			t.Errorf("expected error for webhook to %s", target)
+		}
+	}
+	if err := svc.SetPreference(context.Background(), models.NotificationPreference{UserID: "u1", Channel: constants.ChannelWebhook, Enabled: true}); err == nil {
+		t.Errorf("expected error for webhook without URL")
+	}
+	m.nr.EXPECT().SetPreference(gomock.Any(), models.NotificationPreference{UserID: "u1", Channel: constants.ChannelEmail, Enabled: true, Target: "bob@example.org"}).Return(nil)
+	if err := svc.SetPreference(context.Background(), models.NotificationPreference{UserID: "u1", Channel: constants.ChannelEmail, Enabled: true, Target: "Bob <bob@example.org>"}); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if err := svc.SetPreference(context.Background(), models.NotificationPreference{UserID: "u1", Channel: constants.ChannelEmail, Enabled: true, Target: "not-an-email"}); err == nil {
+		t.Errorf("expected error for invalid email")
+	}
+	if err := svc.SetPreference(context.Background(), models.NotificationPreference{UserID: "u1", Channel: "sms", Enabled: true}); err == nil {
+		t.Errorf("expected error for unknown channel")
+	}
+}
+
+func TestMarkNotificationRead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc, m := newNotificationService(ctrl, nil)
+
+	m.nr.EXPECT().MarkRead(gomock.Any(), "u1", "n1", gomock.Any()).Return(true, nil)
+	m.nr.EXPECT().MarkRead(gomock.Any(), "u1", "n2", gomock.Any()).Return(false, nil)
+
+	if err := svc.MarkRead(context.Background(), "u1", "n1"); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if err := svc.MarkRead(context.Background(), "u1", "n2"); err == nil {
+		t.Errorf("expected error for unknown notification")
+	}
+}
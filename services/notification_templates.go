@@ -0,0 +1,51 @@
+package services
+
+import (
+	"fmt"
+	"sms/constants"
+	"strings"
+	"text/template"
+)
+
+type notificationTemplate struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+func newNotificationTemplate(event constants.NotificationEvent, subject, body string) notificationTemplate {
+	return notificationTemplate{
+		subject: template.Must(template.New(string(event) + "_subject").Option("missingkey=error").Parse(subject)),
+		body:    template.Must(template.New(string(event) + "_body").Option("missingkey=error").Parse(body)),
+	}
+}
+
+var notificationTemplates = map[constants.NotificationEvent]notificationTemplate{
+	constants.EventGradePosted: newNotificationTemplate(constants.EventGradePosted,
+		`Grade posted for {{.Subject}}`,
+		`{{.Student}} received {{.Grade}} in {{.Subject}} for semester {{.Semester}}.`),
+	constants.EventGradeChanged: newNotificationTemplate(constants.EventGradeChanged,
+		`Grade updated for {{.Subject}}`,
+		`The grade of {{.Student}} in {{.Subject}} was changed to {{.Grade}}.`),
+	constants.EventAccountCreated: newNotificationTemplate(constants.EventAccountCreated,
+		`Your account has been created`,
+		`Hello {{.Name}}, your {{.Role}} account has been created. Sign in with {{.Email}}.`),
+	constants.EventAtRisk: newNotificationTemplate(constants.EventAtRisk,
+		`{{.Student}} needs attention`,
+		`{{.Student}} (class {{.ClassID}}) is at risk in semester {{.Semester}}: {{.Reasons}}.`),
+}
+
+// renderNotification fills the subject and body templates of an event.
+func renderNotification(event constants.NotificationEvent, data map[string]any) (string, string, error) {
+	tmpl, ok := notificationTemplates[event]
+	if !ok {
+		return "", "", fmt.Errorf("no template for event %s", event)
+	}
+	var subject, body strings.Builder
+	if err := tmpl.subject.Execute(&subject, data); err != nil {
+		return "", "", err
+	}
+	if err := tmpl.body.Execute(&body, data); err != nil {
+		return "", "", err
+	}
+	return subject.String(), body.String(), nil
+}
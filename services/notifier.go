@@ -0,0 +1,26 @@
+package services
+
+import (
+	"context"
+	"log"
+	"sms/models"
+	"strings"
+)
+
+// AtRiskNotifierI delivers at-risk flags to advisors. Implementations can be
+// swapped in app.SetupServer without touching AlertService.
+type AtRiskNotifierI interface {
+	NotifyAtRisk(ctx context.Context, flag models.AtRiskFlag) error
+}
+
+// LogNotifier writes at-risk flags to the standard logger.
+type LogNotifier struct{}
+
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+func (ln *LogNotifier) NotifyAtRisk(_ context.Context, flag models.AtRiskFlag) error {
+	log.Printf("student %s (class %s) at risk in semester %d: %s", flag.StudentID, flag.ClassID, flag.Semester, strings.Join(flag.Reasons, "; "))
+	return nil
+}
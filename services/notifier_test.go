@@ -0,0 +1,27 @@
+package services_test
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"sms/models"
+	"sms/services"
+)
+
+func TestLogNotifier(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	err := services.NewLogNotifier().NotifyAtRisk(context.Background(), models.AtRiskFlag{StudentID: "s1", ClassID: "C1", Semester: 2, Reasons: []string{"failed 2 subjects"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "student s1 (class C1) at risk in semester 2: failed 2 subjects") {
+		t.Errorf("unexpected log output: %s", buf.String())
+	}
+}
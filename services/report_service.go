@@ -0,0 +1,126 @@
+package services
+
+import (
+	"context"
+	"sms/apperrors"
+	"sms/constants"
+	"sms/models"
+	gradeRepository "sms/repository/gradesRepository"
+	studentRepo "sms/repository/studentRepository"
+)
+
+// trendThreshold is the change in average per semester below which a student's
+// progress is considered steady.
+const trendThreshold = 1.0
+
+type ReportService struct {
+	gr gradeRepository.GradeRepositoryI
+	sr studentRepo.StudentRepositoryI
+}
+
+func NewReportService(gr gradeRepository.GradeRepositoryI, sr studentRepo.StudentRepositoryI) *ReportService {
+	return &ReportService{gr: gr, sr: sr}
+}
+
+func (rs *ReportService) GetProgressReport(ctx context.Context, studentID string) (*models.ProgressReport, error) {
+	student, err := rs.sr.GetStudentByID(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	if student == nil {
+		return nil, apperrors.NotFound("student not found")
+	}
+
+	grades, err := rs.gr.GetStudentGrades(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.ProgressReport{
+		StudentID:  student.StudentID,
+		Name:       student.Name,
+		RollNumber: student.RollNumber,
+		ClassID:    student.ClassID,
+		Trend:      constants.TrendSteady,
+	}
+
+	// grades are ordered by semester, so consecutive runs form one semester
+	for i := 0; i < len(grades); {
+		j := i
+		sum := 0
+		for j < len(grades) && grades[j].Semester == grades[i].Semester {
+			sum += grades[j].Grade
+			j++
+		}
+		semester := models.SemesterProgress{
+			Semester: grades[i].Semester,
+			Average:  float64(sum) / float64(j-i),
+			Grades:   grades[i:j],
+		}
+
+		classAverage, err := rs.gr.GetClassAverage(ctx, student.ClassID, semester.Semester)
+		if err != nil {
+			return nil, err
+		}
+		semester.ClassAverage = classAverage
+
+		if n := len(report.Semesters); n > 0 {
+			previous := report.Semesters[n-1].Average
+			semester.ChangeFromPrevious = semester.Average - previous
+			for _, g := range semester.Grades {
+				if float64(g.Grade) < previous {
+					semester.BelowPreviousAverage = append(semester.BelowPreviousAverage, g.SubjectID)
+				}
+			}
+		}
+
+		report.Semesters = append(report.Semesters, semester)
+		i = j
+	}
+
+	report.Trend = trendOf(report.Semesters)
+	return report, nil
+}
+
+// trendOf fits a least-squares line through the semester averages and classifies
+// its slope.
+func trendOf(semesters []models.SemesterProgress) constants.Trend {
+	n := float64(len(semesters))
+	if n < 2 {
+		return constants.TrendSteady
+	}
+
+	var sumX, sumY, sumXY, sumXX float64
+	for _, s := range semesters {
+		x := float64(s.Semester)
+		sumX += x
+		sumY += s.Average
+		sumXY += x * s.Average
+		sumXX += x * x
+	}
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return constants.TrendSteady
+	}
+	slope := (n*sumXY - sumX*sumY) / denominator
+
+	switch {
+	case slope >= trendThreshold:
+		return constants.TrendImproving
+	case slope <= -trendThreshold:
+		return constants.TrendDeclining
+	default:
+		return constants.TrendSteady
+	}
+}
+
+func (rs *ReportService) GetStudentGrades(ctx context.Context, studentID string) ([]models.Grade, error) {
+	student, err := rs.sr.GetStudentByID(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	if student == nil {
+		return nil, apperrors.NotFound("student not found")
+	}
+	return rs.gr.GetStudentGrades(ctx, studentID)
+}
@@ -0,0 +1,12 @@
+package services
+
+import (
+	"context"
+	"sms/models"
+)
+
+//go:generate mockgen -destination=../mocks/report_service_mock.go -package=mocks -source=report_service_interface.go
+type ReportServiceI interface {
+	GetProgressReport(ctx context.Context, studentID string) (*models.ProgressReport, error)
+	GetStudentGrades(ctx context.Context, studentID string) ([]models.Grade, error)
+}
@@ -0,0 +1,128 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	"sms/constants"
+	mockrepo "sms/mocks"
+	"sms/models"
+	"sms/services"
+)
+
+func TestGetProgressReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGradeRepo := mockrepo.NewMockGradeRepositoryI(ctrl)
+	mockStudentRepo := mockrepo.NewMockStudentRepositoryI(ctrl)
+	svc := services.NewReportService(mockGradeRepo, mockStudentRepo)
+
+	student := &models.Students{StudentID: "s1", Name: "Rohith", RollNumber: "101", ClassID: "CSE", Semester: 3}
+	grades := []models.Grade{
+		{SubjectID: "maths", StudentID: "s1", Grade: 90, Semester: 1},
+		{SubjectID: "physics", StudentID: "s1", Grade: 80, Semester: 1},
+		{SubjectID: "chemistry", StudentID: "s1", Grade: 70, Semester: 2},
+		{SubjectID: "electronics", StudentID: "s1", Grade: 90, Semester: 2},
+		{SubjectID: "networks", StudentID: "s1", Grade: 60, Semester: 3},
+	}
+
+	mockStudentRepo.EXPECT().GetStudentByID(gomock.Any(), "s1").Return(student, nil)
+	mockGradeRepo.EXPECT().GetStudentGrades(gomock.Any(), "s1").Return(grades, nil)
+	mockGradeRepo.EXPECT().GetClassAverage(gomock.Any(), "CSE", 1).Return(75.0, nil)
+	mockGradeRepo.EXPECT().GetClassAverage(gomock.Any(), "CSE", 2).Return(78.0, nil)
+	mockGradeRepo.EXPECT().GetClassAverage(gomock.Any(), "CSE", 3).Return(72.0, nil)
+
+	report, err := svc.GetProgressReport(context.Background(), "s1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(report.Semesters) != 3 {
+		t.Fatalf("expected 3 semesters, got %d", len(report.Semesters))
+	}
+	if report.Semesters[0].Average != 85 || report.Semesters[0].ClassAverage != 75 {
+		t.Errorf("unexpected first semester: %+v", report.Semesters[0])
+	}
+	if report.Semesters[1].ChangeFromPrevious != -5 {
+		t.Errorf("expected change of -5, got %f", report.Semesters[1].ChangeFromPrevious)
+	}
+	if !reflect.DeepEqual(report.Semesters[1].BelowPreviousAverage, []string{"chemistry"}) {
+		t.Errorf("expected chemistry below the previous average, got %v", report.Semesters[1].BelowPreviousAverage)
+	}
+	if !reflect.DeepEqual(report.Semesters[2].BelowPreviousAverage, []string{"networks"}) {
+		t.Errorf("expected networks below the previous average, got %v", report.Semesters[2].BelowPreviousAverage)
+	}
+	if report.Trend != constants.TrendDeclining {
+		t.Errorf("expected declining trend, got %s", report.Trend)
+	}
+}
+
+func TestGetProgressReport_NoGrades(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGradeRepo := mockrepo.NewMockGradeRepositoryI(ctrl)
+	mockStudentRepo := mockrepo.NewMockStudentRepositoryI(ctrl)
+	svc := services.NewReportService(mockGradeRepo, mockStudentRepo)
+
+	mockStudentRepo.EXPECT().GetStudentByID(gomock.Any(), "s1").Return(&models.Students{StudentID: "s1"}, nil)
+	mockGradeRepo.EXPECT().GetStudentGrades(gomock.Any(), "s1").Return(nil, nil)
+
+	report, err := svc.GetProgressReport(context.Background(), "s1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(report.Semesters) != 0 || report.Trend != constants.TrendSteady {
+		t.Errorf("expected empty steady report, got %+v", report)
+	}
+}
+
+func TestGetProgressReport_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGradeRepo := mockrepo.NewMockGradeRepositoryI(ctrl)
+	mockStudentRepo := mockrepo.NewMockStudentRepositoryI(ctrl)
+	svc := services.NewReportService(mockGradeRepo, mockStudentRepo)
+
+	mockStudentRepo.EXPECT().GetStudentByID(gomock.Any(), "missing").Return(nil, nil)
+	if _, err := svc.GetProgressReport(context.Background(), "missing"); err == nil || err.Error() != "student not found" {
+		t.Errorf("expected student not found error, got %v", err)
+	}
+
+	mockStudentRepo.EXPECT().GetStudentByID(gomock.Any(), "s1").Return(&models.Students{StudentID: "s1"}, nil)
+	mockGradeRepo.EXPECT().GetStudentGrades(gomock.Any(), "s1").Return(nil, errors.New("db error"))
+	if _, err := svc.GetProgressReport(context.Background(), "s1"); err == nil || err.Error() != "db error" {
+		t.Errorf("expected db error, got %v", err)
+	}
+}
+
+func TestGetStudentGrades(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGradeRepo := mockrepo.NewMockGradeRepositoryI(ctrl)
+	mockStudentRepo := mockrepo.NewMockStudentRepositoryI(ctrl)
+	svc := services.NewReportService(mockGradeRepo, mockStudentRepo)
+
+	grades := []models.Grade{{SubjectID: "maths", StudentID: "s1", Grade: 90, Semester: 1}}
+	mockStudentRepo.EXPECT().GetStudentByID(gomock.Any(), "s1").Return(&models.Students{StudentID: "s1"}, nil)
+	mockGradeRepo.EXPECT().GetStudentGrades(gomock.Any(), "s1").Return(grades, nil)
+
+	got, err := svc.GetStudentGrades(context.Background(), "s1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(got, grades) {
+		t.Errorf("expected %v, got %v", grades, got)
+	}
+
+	mockStudentRepo.EXPECT().GetStudentByID(gomock.Any(), "missing").Return(nil, nil)
+	if _, err := svc.GetStudentGrades(context.Background(), "missing"); err == nil {
+		t.Errorf("expected error for missing student")
+	}
+}
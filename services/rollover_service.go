@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sms/apperrors"
+	"sms/constants"
+	"sms/models"
+	gradeRepository "sms/repository/gradesRepository"
+	rolloverRepository "sms/repository/rolloverRepository"
+	studentRepo "sms/repository/studentRepository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RolloverService struct {
+	rr         rolloverRepository.RolloverRepositoryI
+	sr         studentRepo.StudentRepositoryI
+	gr         gradeRepository.GradeRepositoryI
+	undoWindow time.Duration
+}
+
+func NewRolloverService(rr rolloverRepository.RolloverRepositoryI, sr studentRepo.StudentRepositoryI, gr gradeRepository.GradeRepositoryI, undoWindow time.Duration) *RolloverService {
+	return &RolloverService{rr: rr, sr: sr, gr: gr, undoWindow: undoWindow}
+}
+
+// PreviewRollover decides the outcome of every student of the class without moving anyone.
+func (rs *RolloverService) PreviewRollover(ctx context.Context, classID string, semester int, targetClassID string, criteria models.RolloverCriteria) (*models.Rollover, error) {
+	if semester <= 0 {
+		return nil, apperrors.Validation("semester must be positive")
+	}
+	if targetClassID == "" {
+		targetClassID = classID
+	}
+
+	students, err := rs.sr.GetStudentsByClass(ctx, classID)
+	if err != nil {
+		return nil, err
+	}
+
+	rollover := models.Rollover{ClassID: classID, Semester: semester, TargetClassID: targetClassID, DryRun: true}
+	promoted := 0
+	for _, s := range students {
+		if s.Semester != semester {
+			continue
+		}
+		grades, err := rs.gr.GetSemesterGrades(ctx, s.StudentID, semester)
+		if err != nil {
+			return nil, err
+		}
+		outcome, reasons := rolloverOutcome(grades, criteria)
+		student := models.RolloverStudent{
+			StudentID:    s.StudentID,
+			FromClassID:  s.ClassID,
+			ToClassID:    s.ClassID,
+			FromSemester: semester,
+			ToSemester:   semester,
+			Outcome:      outcome,
+			Reasons:      reasons,
+		}
+		if outcome != constants.Detained {
+			student.ToClassID = targetClassID
+			student.ToSemester = semester + 1
+			promoted++
+		}
+		rollover.Students = append(rollover.Students, student)
+	}
+	if len(rollover.Students) == 0 {
+		return nil, apperrors.Validation("no students in the class for the semester")
+	}
+
+	if targetClassID != classID {
+		if err := rs.checkCapacity(ctx, targetClassID, promoted); err != nil {
+			return nil, err
+		}
+	}
+	return &rollover, nil
+}
+
+// Rollover promotes the class and keeps the previous placement of every student so
+// the rollover can be undone within the undo window.
+func (rs *RolloverService) Rollover(ctx context.Context, classID string, semester int, targetClassID string, criteria models.RolloverCriteria, createdBy string) (*models.Rollover, error) {
+	rollover, err := rs.PreviewRollover(ctx, classID, semester, targetClassID, criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	rollover.RolloverID = uuid.New().String()
+	rollover.CreatedBy = createdBy
+	rollover.CreatedAt = now
+	rollover.UndoUntil = now.Add(rs.undoWindow)
+	rollover.DryRun = false
+	if err := rs.rr.SaveRollover(ctx, *rollover); err != nil {
+		return nil, err
+	}
+	return rollover, nil
+}
+
+func (rs *RolloverService) GetRollover(ctx context.Context, rolloverID string) (*models.Rollover, error) {
+	rollover, err := rs.rr.GetRollover(ctx, rolloverID)
+	if err != nil {
+		return nil, err
+	}
+	if rollover == nil {
+		return nil, apperrors.NotFound("rollover not found")
+	}
+	return rollover, nil
+}
+
+func (rs *RolloverService) UndoRollover(ctx context.Context, rolloverID string) (*models.Rollover, error) {
+	rollover, err := rs.GetRollover(ctx, rolloverID)
+	if err != nil {
+		return nil, err
+	}
+	if rollover.UndoneAt != nil {
+		return nil, apperrors.Conflict("rollover was already undone")
+	}
+	now := time.Now().UTC()
+	if now.After(rollover.UndoUntil) {
+		return nil, apperrors.Conflict("undo window has expired")
+	}
+	if err := rs.rr.UndoRollover(ctx, *rollover, now); err != nil {
+		return nil, err
+	}
+	rollover.UndoneAt = &now
+	return rollover, nil
+}
+
+func (rs *RolloverService) checkCapacity(ctx context.Context, classID string, incoming int) error {
+	class, err := rs.rr.GetClass(ctx, classID)
+	if err != nil {
+		return err
+	}
+	if class == nil {
+		return apperrors.NotFound("target class not found")
+	}
+	if class.Capacity <= 0 {
+		return nil
+	}
+	occupied, err := rs.rr.CountClassStudents(ctx, classID)
+	if err != nil {
+		return err
+	}
+	if occupied+incoming > class.Capacity {
+		return apperrors.Conflict("target class has room for %d students, %d would be promoted", class.Capacity-occupied, incoming)
+	}
+	return nil
+}
+
+// rolloverOutcome detains students without grades, below the minimum average or
+// with too many failed subjects, and flags promoted students who carry failures.
+func rolloverOutcome(grades []int, criteria models.RolloverCriteria) (constants.RolloverOutcome, []string) {
+	if len(grades) == 0 {
+		return constants.Detained, []string{"no grades recorded for the semester"}
+	}
+
+	var reasons []string
+	failed := 0
+	for _, g := range grades {
+		if g < criteria.PassMark {
+			failed++
+		}
+	}
+	avg := average(grades)
+	detained := false
+	if avg < criteria.MinAverage {
+		reasons = append(reasons, fmt.Sprintf("average %.2f is below %.2f", avg, criteria.MinAverage))
+		detained = true
+	}
+	if failed > criteria.MaxFailedSubjects {
+		reasons = append(reasons, fmt.Sprintf("failed %d subjects, at most %d allowed", failed, criteria.MaxFailedSubjects))
+		detained = true
+	}
+	if detained {
+		return constants.Detained, reasons
+	}
+	if failed > 0 {
+		return constants.Flagged, []string{fmt.Sprintf("promoted with %d failed subjects", failed)}
+	}
+	return constants.Promoted, nil
+}
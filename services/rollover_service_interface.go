@@ -0,0 +1,14 @@
+package services
+
+import (
+	"context"
+	"sms/models"
+)
+
+//go:generate mockgen -destination=../mocks/rollover_service_mock.go -package=mocks -source=rollover_service_interface.go
+type RolloverServiceI interface {
+	PreviewRollover(ctx context.Context, classID string, semester int, targetClassID string, criteria models.RolloverCriteria) (*models.Rollover, error)
+	Rollover(ctx context.Context, classID string, semester int, targetClassID string, criteria models.RolloverCriteria, createdBy string) (*models.Rollover, error)
+	GetRollover(ctx context.Context, rolloverID string) (*models.Rollover, error)
+	UndoRollover(ctx context.Context, rolloverID string) (*models.Rollover, error)
+}
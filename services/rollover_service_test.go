@@ -0,0 +1,142 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"sms/constants"
+	mockrepo "sms/mocks"
+	"sms/models"
+	"sms/services"
+)
+
+var rolloverCriteria = models.RolloverCriteria{PassMark: 40, MinAverage: 40, MaxFailedSubjects: 1}
+
+func TestPreviewRollover(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rr := mockrepo.NewMockRolloverRepositoryI(ctrl)
+	sr := mockrepo.NewMockStudentRepositoryI(ctrl)
+	gr := mockrepo.NewMockGradeRepositoryI(ctrl)
+	svc := services.NewRolloverService(rr, sr, gr, time.Hour)
+
+	sr.EXPECT().GetStudentsByClass(gomock.Any(), "C1").Return([]models.Students{
+		{StudentID: "s1", ClassID: "C1", Semester: 1},
+		{StudentID: "s2", ClassID: "C1", Semester: 1},
+		{StudentID: "s3", ClassID: "C1", Semester: 1},
+		{StudentID: "s4", ClassID: "C1", Semester: 1},
+		{StudentID: "old", ClassID: "C1", Semester: 3},
+	}, nil)
+	gr.EXPECT().GetSemesterGrades(gomock.Any(), "s1", 1).Return([]int{80, 70}, nil)
+	gr.EXPECT().GetSemesterGrades(gomock.Any(), "s2", 1).Return([]int{30, 90}, nil)
+	gr.EXPECT().GetSemesterGrades(gomock.Any(), "s3", 1).Return([]int{30, 20}, nil)
+	gr.EXPECT().GetSemesterGrades(gomock.Any(), "s4", 1).Return(nil, nil)
+	rr.EXPECT().GetClass(gomock.Any(), "C2").Return(&models.Class{ClassID: "C2", Capacity: 60}, nil)
+	rr.EXPECT().CountClassStudents(gomock.Any(), "C2").Return(10, nil)
+
+	preview, err := svc.PreviewRollover(context.Background(), "C1", 1, "C2", rolloverCriteria)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !preview.DryRun || len(preview.Students) != 4 {
+		t.Fatalf("unexpected preview: %+v", preview)
+	}
+	expected := []struct {
+		outcome    constants.RolloverOutcome
+		classID    string
+		toSemester int
+	}{
+		{constants.Promoted, "C2", 2},
+		{constants.Flagged, "C2", 2},
+		{constants.Detained, "C1", 1},
+		{constants.Detained, "C1", 1},
+	}
+	for i, e := range expected {
+		s := preview.Students[i]
+		if s.Outcome != e.outcome || s.ToClassID != e.classID || s.ToSemester != e.toSemester {
+			t.Errorf("student %s: expected %v, got %+v", s.StudentID, e, s)
+		}
+	}
+}
+
+func TestPreviewRollover_TargetClassFull(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rr := mockrepo.NewMockRolloverRepositoryI(ctrl)
+	sr := mockrepo.NewMockStudentRepositoryI(ctrl)
+	gr := mockrepo.NewMockGradeRepositoryI(ctrl)
+	svc := services.NewRolloverService(rr, sr, gr, time.Hour)
+
+	sr.EXPECT().GetStudentsByClass(gomock.Any(), "C1").Return([]models.Students{{StudentID: "s1", ClassID: "C1", Semester: 1}}, nil)
+	gr.EXPECT().GetSemesterGrades(gomock.Any(), "s1", 1).Return([]int{80}, nil)
+	rr.EXPECT().GetClass(gomock.Any(), "C2").Return(&models.Class{ClassID: "C2", Capacity: 10}, nil)
+	rr.EXPECT().CountClassStudents(gomock.Any(), "C2").Return(10, nil)
+
+	if _, err := svc.PreviewRollover(context.Background(), "C1", 1, "C2", rolloverCriteria); err == nil {
+		t.Errorf("expected error for full target class")
+	}
+}
+
+func TestRollover(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rr := mockrepo.NewMockRolloverRepositoryI(ctrl)
+	sr := mockrepo.NewMockStudentRepositoryI(ctrl)
+	gr := mockrepo.NewMockGradeRepositoryI(ctrl)
+	svc := services.NewRolloverService(rr, sr, gr, time.Hour)
+
+	sr.EXPECT().GetStudentsByClass(gomock.Any(), "C1").Return([]models.Students{{StudentID: "s1", ClassID: "C1", Semester: 1}}, nil)
+	gr.EXPECT().GetSemesterGrades(gomock.Any(), "s1", 1).Return([]int{80}, nil)
+	rr.EXPECT().SaveRollover(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, r models.Rollover) error {
+		if r.RolloverID == "" || r.DryRun || r.CreatedBy != "admin1" || r.UndoUntil.Sub(r.CreatedAt) != time.Hour {
+			t.Errorf("unexpected rollover saved: %+v", r)
+		}
+		return nil
+	})
+
+	rollover, err := svc.Rollover(context.Background(), "C1", 1, "", rolloverCriteria, "admin1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rollover.TargetClassID != "C1" || rollover.Students[0].ToSemester != 2 {
+		t.Errorf("unexpected rollover: %+v", rollover)
+	}
+}
+
+func TestUndoRollover(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rr := mockrepo.NewMockRolloverRepositoryI(ctrl)
+	svc := services.NewRolloverService(rr, mockrepo.NewMockStudentRepositoryI(ctrl), mockrepo.NewMockGradeRepositoryI(ctrl), time.Hour)
+
+	open := &models.Rollover{RolloverID: "r1", UndoUntil: time.Now().Add(time.Hour)}
+	rr.EXPECT().GetRollover(gomock.Any(), "r1").Return(open, nil)
+	rr.EXPECT().UndoRollover(gomock.Any(), *open, gomock.Any()).Return(nil)
+	undone, err := svc.UndoRollover(context.Background(), "r1")
+	if err != nil || undone.UndoneAt == nil {
+		t.Errorf("expected undone rollover, got %+v, %v", undone, err)
+	}
+
+	rr.EXPECT().GetRollover(gomock.Any(), "expired").Return(&models.Rollover{RolloverID: "expired", UndoUntil: time.Now().Add(-time.Minute)}, nil)
+	if _, err := svc.UndoRollover(context.Background(), "expired"); err == nil {
+		t.Errorf("expected error for expired undo window")
+	}
+
+	undoneAt := time.Now()
+	rr.EXPECT().GetRollover(gomock.Any(), "twice").Return(&models.Rollover{RolloverID: "twice", UndoUntil: time.Now().Add(time.Hour), UndoneAt: &undoneAt}, nil)
+	if _, err := svc.UndoRollover(context.Background(), "twice"); err == nil {
+		t.Errorf("expected error for rollover undone twice")
+	}
+
+	rr.EXPECT().GetRollover(gomock.Any(), "missing").Return(nil, nil)
+	if _, err := svc.UndoRollover(context.Background(), "missing"); err == nil {
+		t.Errorf("expected error for missing rollover")
+	}
+}
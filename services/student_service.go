@@ -1,50 +1,136 @@
 package services
 
 import (
+	"context"
 	"errors"
-	"fmt"
+	"sms/apperrors"
+	"sms/constants"
+	"sms/events"
 	"sms/models"
+	enrollmentRepository "sms/repository/enrollmentRepository"
 	studentRepo "sms/repository/studentRepository"
+	"sms/repository/transaction"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type StudentService struct {
-	sr studentRepo.StudentRepositoryI
+	sr     studentRepo.StudentRepositoryI
+	events events.Publisher
+	tm     transaction.Manager
+	er     enrollmentRepository.EnrollmentRepositoryI
 }
 
-func NewStudentService(sr studentRepo.StudentRepositoryI) StudentService {
-	return StudentService{sr}
+type StudentServiceOption func(*StudentService)
+
+// WithStudentEvents publishes StudentCreated and StudentUpdated once a student is saved.
+func WithStudentEvents(publisher events.Publisher) StudentServiceOption {
+	return func(ss *StudentService) {
+		ss.events = publisher
+	}
+}
+
+// WithStudentEnrollments enables CreateStudentWithEnrollments, which saves a new
+// student and their enrollments in one transaction.
+func WithStudentEnrollments(tm transaction.Manager, er enrollmentRepository.EnrollmentRepositoryI) StudentServiceOption {
+	return func(ss *StudentService) {
+		ss.tm = tm
+		ss.er = er
+	}
+}
+
+func NewStudentService(sr studentRepo.StudentRepositoryI, opts ...StudentServiceOption) StudentService {
+	ss := StudentService{sr: sr}
+	for _, opt := range opts {
+		opt(&ss)
+	}
+	return ss
+}
+
+func (ss *StudentService) CreateStudent(ctx context.Context, rollNumber, name, classID string, semester int) (*models.Students, error) {
+	newStudent, err := ss.newStudent(ctx, rollNumber, name, classID, semester)
+	if err != nil {
+		return nil, err
+	}
+	err = ss.sr.AddStudent(ctx, newStudent.StudentID, rollNumber, name, classID, semester)
+	if err != nil {
+		return nil, err
+	}
+	publishEvent(ctx, ss.events, events.StudentCreated{Student: *newStudent})
+	return newStudent, nil
+}
+
+// CreateStudentWithEnrollments creates a student already enrolled in the given
+// subjects for their semester. The student is saved only if every enrollment is.
+func (ss *StudentService) CreateStudentWithEnrollments(ctx context.Context, rollNumber, name, classID string, semester int, subjectIDs []string) (*models.Students, []models.Enrollment, error) {
+	if ss.tm == nil || ss.er == nil {
+		return nil, nil, errors.New("enrolling new students is not enabled")
+	}
+	if len(subjectIDs) == 0 {
+		return nil, nil, apperrors.Validation("at least one subject is required")
+	}
+	if semester <= 0 {
+		return nil, nil, apperrors.Validation("semester must be positive")
+	}
+	newStudent, err := ss.newStudent(ctx, rollNumber, name, classID, semester)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now().UTC()
+	enrollments := make([]models.Enrollment, len(subjectIDs))
+	for i, subjectID := range subjectIDs {
+		enrollments[i] = models.Enrollment{
+			StudentID: newStudent.StudentID,
+			SubjectID: subjectID,
+			Semester:  semester,
+			Status:    constants.Enrolled,
+			AddedOn:   now,
+		}
+	}
+
+	err = ss.tm.WithinTx(ctx, func(tx transaction.Querier) error {
+		if err := ss.sr.WithTx(tx).AddStudent(ctx, newStudent.StudentID, rollNumber, name, classID, semester); err != nil {
+			return err
+		}
+		return ss.er.WithTx(tx).AddEnrollments(ctx, enrollments)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	publishEvent(ctx, ss.events, events.StudentCreated{Student: *newStudent})
+	return newStudent, enrollments, nil
 }
 
-func (ss *StudentService) CreateStudent(rollNumber, name, classID string, semester int) (*models.Students, error) {
+// newStudent validates a new student and gives them an ID.
+func (ss *StudentService) newStudent(ctx context.Context, rollNumber, name, classID string, semester int) (*models.Students, error) {
 	//rollNumber check
-	student, _ := ss.sr.GetStudentByRollNumber(rollNumber)
+	student, _ := ss.sr.GetStudentByRollNumber(ctx, rollNumber)
 	if student != nil {
-		return nil, fmt.Errorf("student %v already exists with given rollnumber", student)
+		return nil, apperrors.Conflict("student with roll number %s already exists", rollNumber)
 	}
 	//name not empty
 	if name == "" {
-		return nil, errors.New("name can't be empty")
+		return nil, apperrors.Validation("name can't be empty")
 	}
-	uuid := uuid.New().String()
-	err := ss.sr.AddStudent(uuid, rollNumber, name, classID, semester)
-	if err != nil {
-		return nil, err
-	}
-	newStudent := models.Students{
-		StudentID:  uuid,
+	return &models.Students{
+		StudentID:  uuid.New().String(),
 		RollNumber: rollNumber,
 		ClassID:    classID,
 		Semester:   semester,
 		Name:       name,
-	}
-	return &newStudent, nil
+	}, nil
 }
 
-func (ss *StudentService) UpdateStudent(studentID, name, rollnumber, classID string, semester int) error {
-	student, _ := ss.sr.GetStudentByID(studentID)
-
+func (ss *StudentService) UpdateStudent(ctx context.Context, studentID, name, rollnumber, classID string, semester int) error {
+	student, err := ss.sr.GetStudentByID(ctx, studentID)
+	if err != nil {
+		return err
+	}
+	if student == nil {
+		return apperrors.NotFound("student not found")
+	}
 	if name != "" {
 		student.Name = name
 	}
@@ -58,6 +144,10 @@ func (ss *StudentService) UpdateStudent(studentID, name, rollnumber, classID str
 		student.Semester = semester
 	}
 
-	err := ss.sr.UpdateStudent(studentID, student.Name, student.RollNumber, student.ClassID, student.Semester)
-	return err
+	err = ss.sr.UpdateStudent(ctx, studentID, student.Name, student.RollNumber, student.ClassID, student.Semester)
+	if err != nil {
+		return err
+	}
+	publishEvent(ctx, ss.events, events.StudentUpdated{Student: *student})
+	return nil
 }
@@ -1,9 +1,13 @@
 package services
 
-import "sms/models"
+import (
+	"context"
+	"sms/models"
+)
 
 //go:generate mockgen -destination=../mocks/student_service_mock.go -package=mocks -source=student_service_interface.go
 type StudentServiceI interface {
-	CreateStudent(rollNumber, name, classID string, semester int) (*models.Students, error)
-	UpdateStudent(studentID, name, rollnumber, classID string, semester int) error
+	CreateStudent(ctx context.Context, rollNumber, name, classID string, semester int) (*models.Students, error)
+	CreateStudentWithEnrollments(ctx context.Context, rollNumber, name, classID string, semester int, subjectIDs []string) (*models.Students, []models.Enrollment, error)
+	UpdateStudent(ctx context.Context, studentID, name, rollnumber, classID string, semester int) error
 }
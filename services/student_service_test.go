@@ -1,14 +1,19 @@
 package services_test
 
 import (
+	"context"
 	"errors"
 	"strings"
 	"testing"
 
 	"go.uber.org/mock/gomock"
 
+	"sms/apperrors"
+	"sms/events"
+	"sms/events/eventstest"
 	mockrepo "sms/mocks"
 	"sms/models"
+	"sms/repository/transaction"
 	"sms/services"
 )
 
@@ -19,10 +24,10 @@ func TestCreateStudent_Success(t *testing.T) {
 	mockRepo := mockrepo.NewMockStudentRepositoryI(ctrl)
 	svc := services.NewStudentService(mockRepo)
 
-	mockRepo.EXPECT().GetStudentByRollNumber("101").Return(nil, nil)
-	mockRepo.EXPECT().AddStudent(gomock.Any(), "101", "Rohith", "CSE", 5).Return(nil)
+	mockRepo.EXPECT().GetStudentByRollNumber(gomock.Any(), "101").Return(nil, nil)
+	mockRepo.EXPECT().AddStudent(gomock.Any(), gomock.Any(), "101", "Rohith", "CSE", 5).Return(nil)
 
-	student, err := svc.CreateStudent("101", "Rohith", "CSE", 5)
+	student, err := svc.CreateStudent(context.Background(), "101", "Rohith", "CSE", 5)
 
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
@@ -47,9 +52,9 @@ func TestCreateStudent_AlreadyExists(t *testing.T) {
 
 	existing := &models.Students{StudentID: "123", RollNumber: "101", Name: "Old"}
 
-	mockRepo.EXPECT().GetStudentByRollNumber("101").Return(existing, nil)
+	mockRepo.EXPECT().GetStudentByRollNumber(gomock.Any(), "101").Return(existing, nil)
 
-	student, err := svc.CreateStudent("101", "Rohith", "CSE", 5)
+	student, err := svc.CreateStudent(context.Background(), "101", "Rohith", "CSE", 5)
 
 	if student != nil {
 		t.Fatal("expected nil student, got value")
@@ -66,9 +71,9 @@ func TestCreateStudent_NameEmpty(t *testing.T) {
 	mockRepo := mockrepo.NewMockStudentRepositoryI(ctrl)
 	svc := services.NewStudentService(mockRepo)
 
-	mockRepo.EXPECT().GetStudentByRollNumber("102").Return(nil, nil)
+	mockRepo.EXPECT().GetStudentByRollNumber(gomock.Any(), "102").Return(nil, nil)
 
-	student, err := svc.CreateStudent("102", "", "CSE", 5)
+	student, err := svc.CreateStudent(context.Background(), "102", "", "CSE", 5)
 
 	if student != nil {
 		t.Fatal("expected nil student, got value")
@@ -85,10 +90,10 @@ func TestCreateStudent_AddStudentError(t *testing.T) {
 	mockRepo := mockrepo.NewMockStudentRepositoryI(ctrl)
 	svc := services.NewStudentService(mockRepo)
 
-	mockRepo.EXPECT().GetStudentByRollNumber("103").Return(nil, nil)
-	mockRepo.EXPECT().AddStudent(gomock.Any(), "103", "New", "CSE", 5).Return(errors.New("db error"))
+	mockRepo.EXPECT().GetStudentByRollNumber(gomock.Any(), "103").Return(nil, nil)
+	mockRepo.EXPECT().AddStudent(gomock.Any(), gomock.Any(), "103", "New", "CSE", 5).Return(errors.New("db error"))
 
-	student, err := svc.CreateStudent("103", "New", "CSE", 5)
+	student, err := svc.CreateStudent(context.Background(), "103", "New", "CSE", 5)
 
 	if student != nil {
 		t.Fatal("expected nil student, got value")
@@ -107,10 +112,10 @@ func TestUpdateStudent_Success(t *testing.T) {
 
 	existing := &models.Students{StudentID: "123", RollNumber: "101", Name: "Old", ClassID: "CSE", Semester: 5}
 
-	mockRepo.EXPECT().GetStudentByID("123").Return(existing, nil)
-	mockRepo.EXPECT().UpdateStudent("123", "NewName", "101", "CSE", 5).Return(nil)
+	mockRepo.EXPECT().GetStudentByID(gomock.Any(), "123").Return(existing, nil)
+	mockRepo.EXPECT().UpdateStudent(gomock.Any(), "123", "NewName", "101", "CSE", 5).Return(nil)
 
-	err := svc.UpdateStudent("123", "NewName", "", "", 0)
+	err := svc.UpdateStudent(context.Background(), "123", "NewName", "", "", 0)
 
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
@@ -126,12 +131,132 @@ func TestUpdateStudent_UpdateError(t *testing.T) {
 
 	existing := &models.Students{StudentID: "123", RollNumber: "101", Name: "Old", ClassID: "CSE", Semester: 5}
 
-	mockRepo.EXPECT().GetStudentByID("123").Return(existing, nil)
-	mockRepo.EXPECT().UpdateStudent("123", "FailName", "101", "CSE", 5).Return(errors.New("update failed"))
+	mockRepo.EXPECT().GetStudentByID(gomock.Any(), "123").Return(existing, nil)
+	mockRepo.EXPECT().UpdateStudent(gomock.Any(), "123", "FailName", "101", "CSE", 5).Return(errors.New("update failed"))
 
-	err := svc.UpdateStudent("123", "FailName", "", "", 0)
+	err := svc.UpdateStudent(context.Background(), "123", "FailName", "", "", 0)
 
 	if err == nil || err.Error() != "update failed" {
 		t.Fatalf("expected update failed error, got %v", err)
 	}
 }
+
+func TestUpdateStudent_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockStudentRepositoryI(ctrl)
+	svc := services.NewStudentService(mockRepo)
+
+	mockRepo.EXPECT().GetStudentByID(gomock.Any(), "missing").Return(nil, nil)
+	if err := svc.UpdateStudent(context.Background(), "missing", "Name", "", "", 0); !errors.Is(err, apperrors.ErrNotFound) {
+		t.Errorf("expected not found, got %v", err)
+	}
+
+	mockRepo.EXPECT().GetStudentByID(gomock.Any(), "123").Return(nil, errors.New("db error"))
+	if err := svc.UpdateStudent(context.Background(), "123", "Name", "", "", 0); err == nil || err.Error() != "db error" {
+		t.Errorf("expected db error, got %v", err)
+	}
+}
+
+func TestStudentEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockStudentRepositoryI(ctrl)
+	recorder := eventstest.NewRecorder()
+	svc := services.NewStudentService(mockRepo, services.WithStudentEvents(recorder))
+
+	mockRepo.EXPECT().GetStudentByRollNumber(gomock.Any(), "101").Return(nil, nil)
+	mockRepo.EXPECT().AddStudent(gomock.Any(), gomock.Any(), "101", "Rohith", "CSE", 5).Return(nil)
+	created, err := svc.CreateStudent(context.Background(), "101", "Rohith", "CSE", 5)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	mockRepo.EXPECT().GetStudentByID(gomock.Any(), created.StudentID).Return(created, nil)
+	mockRepo.EXPECT().UpdateStudent(gomock.Any(), created.StudentID, "Rohith", "101", "CSE", 6).Return(nil)
+	if err := svc.UpdateStudent(context.Background(), created.StudentID, "", "", "", 6); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	mockRepo.EXPECT().GetStudentByID(gomock.Any(), "s2").Return(&models.Students{StudentID: "s2"}, nil)
+	mockRepo.EXPECT().UpdateStudent(gomock.Any(), "s2", "", "", "", 2).Return(errors.New("db error"))
+	if err := svc.UpdateStudent(context.Background(), "s2", "", "", "", 2); err == nil {
+		t.Fatalf("expected repo error")
+	}
+
+	eventstest.AssertPublished(t, recorder,
+		events.StudentCreated{Student: models.Students{StudentID: created.StudentID, Name: "Rohith", RollNumber: "101", ClassID: "CSE", Semester: 5}},
+		events.StudentUpdated{Student: models.Students{StudentID: created.StudentID, Name: "Rohith", RollNumber: "101", ClassID: "CSE", Semester: 6}},
+	)
+}
+
+func TestCreateStudentWithEnrollments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockStudentRepositoryI(ctrl)
+	mockTxRepo := mockrepo.NewMockStudentRepositoryI(ctrl)
+	mockEnrollmentRepo := mockrepo.NewMockEnrollmentRepositoryI(ctrl)
+	mockTxEnrollmentRepo := mockrepo.NewMockEnrollmentRepositoryI(ctrl)
+	mockTx := mockrepo.NewMockManager(ctrl)
+	recorder := eventstest.NewRecorder()
+	svc := services.NewStudentService(mockRepo,
+		services.WithStudentEvents(recorder),
+		services.WithStudentEnrollments(mockTx, mockEnrollmentRepo),
+	)
+	runTx := func(_ context.Context, fn func(transaction.Querier) error) error { return fn(nil) }
+
+	mockRepo.EXPECT().GetStudentByRollNumber(gomock.Any(), "101").Return(nil, nil)
+	mockTx.EXPECT().WithinTx(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+	mockRepo.EXPECT().WithTx(nil).Return(mockTxRepo)
+	mockTxRepo.EXPECT().AddStudent(gomock.Any(), gomock.Any(), "101", "Rohith", "CSE", 5).Return(nil)
+	mockEnrollmentRepo.EXPECT().WithTx(nil).Return(mockTxEnrollmentRepo)
+	mockTxEnrollmentRepo.EXPECT().AddEnrollments(gomock.Any(), gomock.Len(2)).Return(nil)
+
+	student, enrollments, err := svc.CreateStudentWithEnrollments(context.Background(), "101", "Rohith", "CSE", 5, []string{"maths", "physics"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(enrollments) != 2 || enrollments[1].StudentID != student.StudentID || enrollments[1].SubjectID != "physics" || enrollments[1].Semester != 5 {
+		t.Errorf("unexpected enrollments %+v", enrollments)
+	}
+	eventstest.AssertPublished(t, recorder, events.StudentCreated{Student: *student})
+
+	recorder.Reset()
+	mockRepo.EXPECT().GetStudentByRollNumber(gomock.Any(), "102").Return(nil, nil)
+	mockTx.EXPECT().WithinTx(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+	mockRepo.EXPECT().WithTx(nil).Return(mockTxRepo)
+	mockTxRepo.EXPECT().AddStudent(gomock.Any(), gomock.Any(), "102", "Anu", "CSE", 5).Return(nil)
+	mockEnrollmentRepo.EXPECT().WithTx(nil).Return(mockTxEnrollmentRepo)
+	mockTxEnrollmentRepo.EXPECT().AddEnrollments(gomock.Any(), gomock.Any()).Return(errors.New("no such subject"))
+
+	if _, _, err := svc.CreateStudentWithEnrollments(context.Background(), "102", "Anu", "CSE", 5, []string{"history"}); err == nil {
+		t.Fatalf("expected enrollment error")
+	}
+	eventstest.AssertNotPublished[events.StudentCreated](t, recorder)
+}
+
+func TestCreateStudentWithEnrollments_Validation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockrepo.NewMockStudentRepositoryI(ctrl)
+	plain := services.NewStudentService(mockRepo)
+	if _, _, err := plain.CreateStudentWithEnrollments(context.Background(), "101", "Rohith", "CSE", 5, []string{"maths"}); err == nil {
+		t.Errorf("expected error without enrollments configured")
+	}
+
+	svc := services.NewStudentService(mockRepo, services.WithStudentEnrollments(mockrepo.NewMockManager(ctrl), mockrepo.NewMockEnrollmentRepositoryI(ctrl)))
+	if _, _, err := svc.CreateStudentWithEnrollments(context.Background(), "101", "Rohith", "CSE", 5, nil); err == nil {
+		t.Errorf("expected error without subjects")
+	}
+	if _, _, err := svc.CreateStudentWithEnrollments(context.Background(), "101", "Rohith", "CSE", 0, []string{"maths"}); err == nil {
+		t.Errorf("expected error for a non-positive semester")
+	}
+	mockRepo.EXPECT().GetStudentByRollNumber(gomock.Any(), "101").Return(&models.Students{StudentID: "s1"}, nil)
+	if _, _, err := svc.CreateStudentWithEnrollments(context.Background(), "101", "Rohith", "CSE", 5, []string{"maths"}); err == nil {
+		t.Errorf("expected error for a used roll number")
+	}
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"sms/apperrors"
+	"sms/constants"
+	"sms/models"
+	termRepository "sms/repository/termRepository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TermService struct {
+	tr termRepository.TermRepositoryI
+}
+
+func NewTermService(tr termRepository.TermRepositoryI) *TermService {
+	return &TermService{tr: tr}
+}
+
+func (ts *TermService) CreateTerm(ctx context.Context, term models.AcademicTerm) (*models.AcademicTerm, error) {
+	if term.Year <= 0 || term.TermNumber <= 0 {
+		return nil, apperrors.Validation("year and term number must be positive")
+	}
+	if len(term.Semesters) == 0 {
+		return nil, apperrors.Validation("a term must cover at least one semester")
+	}
+	seen := map[int]bool{}
+	for _, semester := range term.Semesters {
+		if semester <= 0 {
+			return nil, apperrors.Validation("semester must be positive")
+		}
+		if seen[semester] {
+			return nil, apperrors.Validation("semester %d is listed twice", semester)
+		}
+		seen[semester] = true
+	}
+	if !term.StartDate.Before(term.EndDate) {
+		return nil, apperrors.Validation("start date must be before end date")
+	}
+	if err := validateGradeEntryWindow(term, term.GradeEntryStart, term.GradeEntryEnd); err != nil {
+		return nil, err
+	}
+	if term.Status == "" {
+		term.Status = constants.TermOpen
+	}
+	if term.Status != constants.TermOpen && term.Status != constants.TermClosed {
+		return nil, apperrors.Validation("invalid term status %q", term.Status)
+	}
+
+	term.TermID = uuid.New().String()
+	if err := ts.tr.AddTerm(ctx, term); err != nil {
+		return nil, err
+	}
+	return &term, nil
+}
+
+func (ts *TermService) GetTerm(ctx context.Context, termID string) (*models.AcademicTerm, error) {
+	term, err := ts.tr.GetTerm(ctx, termID)
+	if err != nil {
+		return nil, err
+	}
+	if term == nil {
+		return nil, apperrors.NotFound("term not found")
+	}
+	return term, nil
+}
+
+func (ts *TermService) GetTerms(ctx context.Context, year int) ([]models.AcademicTerm, error) {
+	if year < 0 {
+		return nil, apperrors.Validation("year can't be negative")
+	}
+	return ts.tr.GetTerms(ctx, year)
+}
+
+func (ts *TermService) SetTermStatus(ctx context.Context, termID string, status constants.TermStatus) (*models.AcademicTerm, error) {
+	if status != constants.TermOpen && status != constants.TermClosed {
+		return nil, apperrors.Validation("invalid term status %q", status)
+	}
+	term, err := ts.GetTerm(ctx, termID)
+	if err != nil {
+		return nil, err
+	}
+	if err := ts.tr.SetTermStatus(ctx, termID, status); err != nil {
+		return nil, err
+	}
+	term.Status = status
+	return term, nil
+}
+
+func (ts *TermService) SetGradeEntryWindow(ctx context.Context, termID string, start, end time.Time) (*models.AcademicTerm, error) {
+	term, err := ts.GetTerm(ctx, termID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateGradeEntryWindow(*term, start, end); err != nil {
+		return nil, err
+	}
+	if err := ts.tr.SetGradeEntryWindow(ctx, termID, start, end); err != nil {
+		return nil, err
+	}
+	term.GradeEntryStart, term.GradeEntryEnd = start, end
+	return term, nil
+}
+
+// CanEnterGrades allows grade writes for a semester while an open term covering
+// that semester is inside its grade-entry window. A semester no term covers has
+// no window and is always open, so grades can be written before terms are set
+// up. Terms aren't tied to a class or program, so any term covering the semester
+// opens or closes it for every class.
+func (ts *TermService) CanEnterGrades(ctx context.Context, semester int) error {
+	terms, err := ts.tr.GetTermsForSemester(ctx, semester)
+	if err != nil {
+		return err
+	}
+	if len(terms) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	for _, t := range terms {
+		if t.Status != constants.TermOpen {
+			continue
+		}
+		// the end date is inclusive, so the window closes at the start of the next day
+		if !now.Before(t.GradeEntryStart) && now.Before(t.GradeEntryEnd.AddDate(0, 0, 1)) {
+			return nil
+		}
+	}
+	return apperrors.Forbidden("grade entry for semester %d is closed", semester)
+}
+
+func validateGradeEntryWindow(term models.AcademicTerm, start, end time.Time) error {
+	if end.Before(start) {
+		return apperrors.Validation("grade entry start must not be after grade entry end")
+	}
+	if start.Before(term.StartDate) {
+		return apperrors.Validation("grade entry can't start before the term starts")
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package services
+
+import (
+	"context"
+	"sms/constants"
+	"sms/models"
+	"time"
+)
+
+//go:generate mockgen -destination=../mocks/term_service_mock.go -package=mocks -source=term_service_interface.go
+type TermServiceI interface {
+	CreateTerm(ctx context.Context, term models.AcademicTerm) (*models.AcademicTerm, error)
+	GetTerm(ctx context.Context, termID string) (*models.AcademicTerm, error)
+	GetTerms(ctx context.Context, year int) ([]models.AcademicTerm, error)
+	SetTermStatus(ctx context.Context, termID string, status constants.TermStatus) (*models.AcademicTerm, error)
+	SetGradeEntryWindow(ctx context.Context, termID string, start, end time.Time) (*models.AcademicTerm, error)
+	CanEnterGrades(ctx context.Context, semester int) error
+}
@@ -0,0 +1,153 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"sms/constants"
+	mockrepo "sms/mocks"
+	"sms/models"
+	"sms/services"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestCreateTerm(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTermRepo := mockrepo.NewMockTermRepositoryI(ctrl)
+	svc := services.NewTermService(mockTermRepo)
+
+	valid := models.AcademicTerm{
+		Year:            2026,
+		TermNumber:      1,
+		Semesters:       []int{1, 3},
+		StartDate:       date(2026, 7, 1),
+		EndDate:         date(2026, 11, 30),
+		GradeEntryStart: date(2026, 11, 15),
+		GradeEntryEnd:   date(2026, 12, 15),
+	}
+	mockTermRepo.EXPECT().AddTerm(gomock.Any(), gomock.Any()).Return(nil)
+	term, err := svc.CreateTerm(context.Background(), valid)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if term.TermID == "" || term.Status != constants.TermOpen {
+		t.Errorf("expected an open term with an ID, got %+v", term)
+	}
+
+	invalid := map[string]func(*models.AcademicTerm){
+		"no semesters":         func(t *models.AcademicTerm) { t.Semesters = nil },
+		"duplicate semester":   func(t *models.AcademicTerm) { t.Semesters = []int{1, 1} },
+		"end before start":     func(t *models.AcademicTerm) { t.EndDate = date(2026, 6, 1) },
+		"window reversed":      func(t *models.AcademicTerm) { t.GradeEntryEnd = date(2026, 11, 1) },
+		"window before term":   func(t *models.AcademicTerm) { t.GradeEntryStart = date(2026, 6, 1) },
+		"unknown status":       func(t *models.AcademicTerm) { t.Status = "archived" },
+		"non-positive numbers": func(t *models.AcademicTerm) { t.TermNumber = 0 },
+	}
+	for name, mutate := range invalid {
+		term := valid
+		mutate(&term)
+		if _, err := svc.CreateTerm(context.Background(), term); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestCanEnterGrades(t *testing.T) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	tests := []struct {
+		name    string
+		terms   []models.AcademicTerm
+		wantErr bool
+	}{
+		{
+			name: "no term covers the semester",
+		},
+		{
+			name: "inside window, end date inclusive",
+			terms: []models.AcademicTerm{
+				{Status: constants.TermOpen, GradeEntryStart: today.AddDate(0, 0, -7), GradeEntryEnd: today},
+			},
+		},
+		{
+			name: "window not open yet",
+			terms: []models.AcademicTerm{
+				{Status: constants.TermOpen, GradeEntryStart: today.AddDate(0, 0, 1), GradeEntryEnd: today.AddDate(0, 0, 10)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "term closed",
+			terms: []models.AcademicTerm{
+				{Status: constants.TermClosed, GradeEntryStart: today.AddDate(0, 0, -1), GradeEntryEnd: today.AddDate(0, 0, 1)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "one of several terms open",
+			terms: []models.AcademicTerm{
+				{Status: constants.TermOpen, GradeEntryStart: today.AddDate(-1, 0, 0), GradeEntryEnd: today.AddDate(-1, 0, 10)},
+				{Status: constants.TermOpen, GradeEntryStart: today.AddDate(0, 0, -1), GradeEntryEnd: today.AddDate(0, 0, 1)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTermRepo := mockrepo.NewMockTermRepositoryI(ctrl)
+			mockTermRepo.EXPECT().GetTermsForSemester(gomock.Any(), 3).Return(tt.terms, nil)
+
+			err := services.NewTermService(mockTermRepo).CanEnterGrades(context.Background(), 3)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestSetTermStatus_And_SetGradeEntryWindow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTermRepo := mockrepo.NewMockTermRepositoryI(ctrl)
+	svc := services.NewTermService(mockTermRepo)
+	stored := models.AcademicTerm{TermID: "t1", StartDate: date(2026, 7, 1), EndDate: date(2026, 11, 30), Status: constants.TermOpen}
+
+	if _, err := svc.SetTermStatus(context.Background(), "t1", "archived"); err == nil {
+		t.Errorf("expected error for unknown status")
+	}
+
+	mockTermRepo.EXPECT().GetTerm(gomock.Any(), "t1").DoAndReturn(func(context.Context, string) (*models.AcademicTerm, error) {
+		term := stored
+		return &term, nil
+	}).Times(3)
+	mockTermRepo.EXPECT().SetTermStatus(gomock.Any(), "t1", constants.TermClosed).Return(nil)
+	term, err := svc.SetTermStatus(context.Background(), "t1", constants.TermClosed)
+	if err != nil || term.Status != constants.TermClosed {
+		t.Errorf("expected closed term, got %+v, %v", term, err)
+	}
+
+	mockTermRepo.EXPECT().SetGradeEntryWindow(gomock.Any(), "t1", date(2026, 11, 20), date(2026, 12, 20)).Return(nil)
+	if _, err := svc.SetGradeEntryWindow(context.Background(), "t1", date(2026, 11, 20), date(2026, 12, 20)); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if _, err := svc.SetGradeEntryWindow(context.Background(), "t1", date(2026, 6, 20), date(2026, 12, 20)); err == nil {
+		t.Errorf("expected error for window before term start")
+	}
+
+	mockTermRepo.EXPECT().GetTerm(gomock.Any(), "missing").Return(nil, nil)
+	if _, err := svc.SetTermStatus(context.Background(), "missing", constants.TermClosed); err == nil {
+		t.Errorf("expected error for missing term")
+	}
+}
@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sms/apperrors"
+	"sms/models"
+	timetableRepository "sms/repository/timetableRepository"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrTimetableConflict is returned when a slot would double-book a class, a
+// faculty member or a room.
+var ErrTimetableConflict = errors.New("timetable conflict")
+
+// slotTimeLayout is the layout of TimetableSlot.StartTime and EndTime.
+const slotTimeLayout = "15:04"
+
+type TimetableService struct {
+	tr timetableRepository.TimetableRepositoryI
+}
+
+func NewTimetableService(tr timetableRepository.TimetableRepositoryI) *TimetableService {
+	return &TimetableService{tr: tr}
+}
+
+func (ts *TimetableService) CreateRoom(ctx context.Context, name string, capacity int) (*models.Room, error) {
+	if name == "" {
+		return nil, apperrors.Validation("name can't be empty")
+	}
+	if capacity < 0 {
+		return nil, apperrors.Validation("capacity can't be negative")
+	}
+	room := models.Room{RoomID: uuid.New().String(), Name: name, Capacity: capacity}
+	if err := ts.tr.AddRoom(ctx, room); err != nil {
+		return nil, err
+	}
+	return &room, nil
+}
+
+func (ts *TimetableService) SetClassHomeRoom(ctx context.Context, classID, roomID string) error {
+	if _, err := ts.getRoom(ctx, roomID); err != nil {
+		return err
+	}
+	return ts.tr.SetClassHomeRoom(ctx, classID, roomID)
+}
+
+func (ts *TimetableService) AssignFaculty(ctx context.Context, assignment models.FacultyAssignment) error {
+	if assignment.FacultyID == "" || assignment.ClassID == "" || assignment.SubjectID == "" {
+		return apperrors.Validation("facultyID, classID and subjectID can't be empty")
+	}
+	return ts.tr.AddFacultyAssignment(ctx, assignment)
+}
+
+// AddSlot schedules a weekly period, falling back to the class's home room when
+// no room is given, and refuses it if it overlaps another period of the same
+// class, faculty member or room. It returns the slot as stored, with the names
+// of its subject and room.
+func (ts *TimetableService) AddSlot(ctx context.Context, slot models.TimetableSlot) (*models.TimetableSlot, error) {
+	if slot.ClassID == "" || slot.SubjectID == "" || slot.FacultyID == "" {
+		return nil, apperrors.Validation("classID, subjectID and facultyID can't be empty")
+	}
+	if slot.Weekday < time.Sunday || slot.Weekday > time.Saturday {
+		return nil, apperrors.Validation("invalid weekday")
+	}
+	start, err := time.Parse(slotTimeLayout, slot.StartTime)
+	if err != nil {
+		return nil, apperrors.Validation("start time must be in HH:MM format")
+	}
+	end, err := time.Parse(slotTimeLayout, slot.EndTime)
+	if err != nil {
+		return nil, apperrors.Validation("end time must be in HH:MM format")
+	}
+	if !start.Before(end) {
+		return nil, apperrors.Validation("start time must be before end time")
+	}
+	// normalise so string comparisons in the repository order times correctly
+	slot.StartTime, slot.EndTime = start.Format(slotTimeLayout), end.Format(slotTimeLayout)
+
+	assignment, err := ts.tr.GetFacultyAssignment(ctx, slot.FacultyID, slot.ClassID, slot.SubjectID)
+	if err != nil {
+		return nil, err
+	}
+	if assignment == nil {
+		return nil, apperrors.Validation("faculty is not assigned to the subject of the class")
+	}
+
+	if slot.RoomID == "" {
+		if slot.RoomID, err = ts.tr.GetClassHomeRoom(ctx, slot.ClassID); err != nil {
+			return nil, err
+		}
+		if slot.RoomID == "" {
+			return nil, apperrors.Validation("roomID is required as the class has no home room")
+		}
+	}
+	room, err := ts.getRoom(ctx, slot.RoomID)
+	if err != nil {
+		return nil, err
+	}
+	slot.RoomName = room.Name
+
+	overlapping, err := ts.tr.GetOverlappingSlots(ctx, slot)
+	if err != nil {
+		return nil, err
+	}
+	if len(overlapping) > 0 {
+		return nil, apperrors.Conflict("%s: %s", ErrTimetableConflict, strings.Join(conflicts(slot, overlapping), "; ")).Because(ErrTimetableConflict)
+	}
+
+	slot.SlotID = uuid.New().String()
+	if err := ts.tr.AddSlot(ctx, slot); err != nil {
+		return nil, err
+	}
+	return ts.tr.GetSlot(ctx, slot.SlotID)
+}
+
+func (ts *TimetableService) RemoveSlot(ctx context.Context, slotID string) error {
+	slot, err := ts.tr.GetSlot(ctx, slotID)
+	if err != nil {
+		return err
+	}
+	if slot == nil {
+		return apperrors.NotFound("slot not found")
+	}
+	return ts.tr.DeleteSlot(ctx, slotID)
+}
+
+func (ts *TimetableService) GetClassTimetable(ctx context.Context, classID string) ([]models.TimetableSlot, error) {
+	return ts.tr.GetClassSlots(ctx, classID)
+}
+
+func (ts *TimetableService) GetFacultyTimetable(ctx context.Context, facultyID string) ([]models.TimetableSlot, error) {
+	return ts.tr.GetFacultySlots(ctx, facultyID)
+}
+
+func (ts *TimetableService) ClassCalendar(ctx context.Context, classID string, from, until time.Time) ([]byte, error) {
+	if until.Before(from) {
+		return nil, apperrors.Validation("from must not be after until")
+	}
+	slots, err := ts.tr.GetClassSlots(ctx, classID)
+	if err != nil {
+		return nil, err
+	}
+	return buildCalendar("Timetable of class "+classID, slots, from, until), nil
+}
+
+func (ts *TimetableService) FacultyCalendar(ctx context.Context, facultyID string, from, until time.Time) ([]byte, error) {
+	if until.Before(from) {
+		return nil, apperrors.Validation("from must not be after until")
+	}
+	slots, err := ts.tr.GetFacultySlots(ctx, facultyID)
+	if err != nil {
+		return nil, err
+	}
+	return buildCalendar("Teaching timetable", slots, from, until), nil
+}
+
+func (ts *TimetableService) getRoom(ctx context.Context, roomID string) (*models.Room, error) {
+	room, err := ts.tr.GetRoom(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	if room == nil {
+		return nil, apperrors.NotFound("room not found")
+	}
+	return room, nil
+}
+
+func conflicts(slot models.TimetableSlot, overlapping []models.TimetableSlot) []string {
+	var reasons []string
+	for _, o := range overlapping {
+		period := fmt.Sprintf("%s %s-%s", o.Weekday, o.StartTime, o.EndTime)
+		if o.RoomID == slot.RoomID {
+			reasons = append(reasons, fmt.Sprintf("room %s is booked on %s", o.RoomName, period))
+		}
+		if o.FacultyID == slot.FacultyID {
+			reasons = append(reasons, fmt.Sprintf("faculty %s teaches class %s on %s", o.FacultyID, o.ClassID, period))
+		}
+		if o.ClassID == slot.ClassID {
+			reasons = append(reasons, fmt.Sprintf("class %s has subject %s on %s", o.ClassID, o.SubjectID, period))
+		}
+	}
+	return reasons
+}
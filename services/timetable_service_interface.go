@@ -0,0 +1,20 @@
+package services
+
+import (
+	"context"
+	"sms/models"
+	"time"
+)
+
+//go:generate mockgen -destination=../mocks/timetable_service_mock.go -package=mocks -source=timetable_service_interface.go
+type TimetableServiceI interface {
+	CreateRoom(ctx context.Context, name string, capacity int) (*models.Room, error)
+	SetClassHomeRoom(ctx context.Context, classID, roomID string) error
+	AssignFaculty(ctx context.Context, assignment models.FacultyAssignment) error
+	AddSlot(ctx context.Context, slot models.TimetableSlot) (*models.TimetableSlot, error)
+	RemoveSlot(ctx context.Context, slotID string) error
+	GetClassTimetable(ctx context.Context, classID string) ([]models.TimetableSlot, error)
+	GetFacultyTimetable(ctx context.Context, facultyID string) ([]models.TimetableSlot, error)
+	ClassCalendar(ctx context.Context, classID string, from, until time.Time) ([]byte, error)
+	FacultyCalendar(ctx context.Context, facultyID string, from, until time.Time) ([]byte, error)
+}
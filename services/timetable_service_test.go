@@ -0,0 +1,166 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"go.uber.org/mock/gomock"
+
+	mockrepo "sms/mocks"
+	"sms/models"
+	"sms/services"
+)
+
+func TestAddSlot(t *testing.T) {
+	base := models.TimetableSlot{ClassID: "C1", SubjectID: "sub1", FacultyID: "f1", Weekday: time.Monday, StartTime: "9:00", EndTime: "10:00"}
+
+	tests := []struct {
+		name         string
+		slot         func(s models.TimetableSlot) models.TimetableSlot
+		mockRepo     func(tr *mockrepo.MockTimetableRepositoryI)
+		wantErr      bool
+		wantConflict bool
+	}{
+		{
+			name: "uses the home room of the class",
+			slot: func(s models.TimetableSlot) models.TimetableSlot { return s },
+			mockRepo: func(tr *mockrepo.MockTimetableRepositoryI) {
+				tr.EXPECT().GetFacultyAssignment(gomock.Any(), "f1", "C1", "sub1").Return(&models.FacultyAssignment{}, nil)
+				tr.EXPECT().GetClassHomeRoom(gomock.Any(), "C1").Return("r1", nil)
+				tr.EXPECT().GetRoom(gomock.Any(), "r1").Return(&models.Room{RoomID: "r1", Name: "Room 101"}, nil)
+				tr.EXPECT().GetOverlappingSlots(gomock.Any(), gomock.Any()).Return(nil, nil)
+				var stored models.TimetableSlot
+				tr.EXPECT().AddSlot(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, s models.TimetableSlot) error {
+					if s.RoomID != "r1" || s.StartTime != "09:00" || s.SlotID == "" {
+						t.Errorf("unexpected slot stored: %+v", s)
+					}
+					stored = s
+					return nil
+				})
+				tr.EXPECT().GetSlot(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, slotID string) (*models.TimetableSlot, error) {
+					if slotID != stored.SlotID {
+						t.Errorf("expected the stored slot to be read back, got %s", slotID)
+					}
+					stored.SubjectName = "Mathematics"
+					return &stored, nil
+				})
+			},
+		},
+		{
+			name:     "end before start",
+			slot:     func(s models.TimetableSlot) models.TimetableSlot { s.EndTime = "08:00"; return s },
+			mockRepo: func(tr *mockrepo.MockTimetableRepositoryI) {},
+			wantErr:  true,
+		},
+		{
+			name: "faculty not assigned",
+			slot: func(s models.TimetableSlot) models.TimetableSlot { return s },
+			mockRepo: func(tr *mockrepo.MockTimetableRepositoryI) {
+				tr.EXPECT().GetFacultyAssignment(gomock.Any(), "f1", "C1", "sub1").Return(nil, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "room double-booked",
+			slot: func(s models.TimetableSlot) models.TimetableSlot { s.RoomID = "r1"; return s },
+			mockRepo: func(tr *mockrepo.MockTimetableRepositoryI) {
+				tr.EXPECT().GetFacultyAssignment(gomock.Any(), "f1", "C1", "sub1").Return(&models.FacultyAssignment{}, nil)
+				tr.EXPECT().GetRoom(gomock.Any(), "r1").Return(&models.Room{RoomID: "r1", Name: "Room 101"}, nil)
+				tr.EXPECT().GetOverlappingSlots(gomock.Any(), gomock.Any()).Return([]models.TimetableSlot{
+					{ClassID: "C2", FacultyID: "f2", RoomID: "r1", RoomName: "Room 101", Weekday: time.Monday, StartTime: "09:30", EndTime: "10:30"},
+				}, nil)
+			},
+			wantErr:      true,
+			wantConflict: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tr := mockrepo.NewMockTimetableRepositoryI(ctrl)
+			tt.mockRepo(tr)
+
+			_, err := services.NewTimetableService(tr).AddSlot(context.Background(), tt.slot(base))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if errors.Is(err, services.ErrTimetableConflict) != tt.wantConflict {
+				t.Errorf("expected conflict %v, got %v", tt.wantConflict, err)
+			}
+		})
+	}
+}
+
+func TestClassCalendar(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tr := mockrepo.NewMockTimetableRepositoryI(ctrl)
+	svc := services.NewTimetableService(tr)
+
+	longName := "Introduction to Thermodynamics, Statistical Mechanics and Kinetic Theory – Ünit 2"
+	tr.EXPECT().GetClassSlots(gomock.Any(), "C1").Return([]models.TimetableSlot{
+		{SlotID: "sl1", ClassID: "C1", SubjectID: "sub1", SubjectName: "Engineering Mathematics", RoomName: "Room 101, North", Weekday: time.Wednesday, StartTime: "09:00", EndTime: "10:00"},
+		{SlotID: "sl2", ClassID: "C1", SubjectID: "sub2", SubjectName: longName, RoomName: "Lab 1", Weekday: time.Thursday, StartTime: "09:00", EndTime: "10:00"},
+	}, nil)
+
+	// 2026-07-06 is a Monday, so the first Wednesday is 2026-07-08
+	calendar, err := svc.ClassCalendar(context.Background(), "C1", date(2026, 7, 6), date(2026, 11, 30))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ics := string(calendar)
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"UID:sl1@sms\r\n",
+		"DTSTART:20260708T090000\r\n",
+		"DTEND:20260708T100000\r\n",
+		"RRULE:FREQ=WEEKLY;UNTIL=20261130T235959\r\n",
+		"SUMMARY:Engineering Mathematics\r\n",
+		"DESCRIPTION:Class C1\r\n",
+		"LOCATION:Room 101\\, North\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(ics, want) {
+			t.Errorf("calendar is missing %q:\n%s", want, ics)
+		}
+	}
+	// long lines are folded at 75 octets and unfold to the original
+	for _, l := range strings.Split(ics, "\r\n") {
+		if len(l) > 75 || !utf8.ValidString(l) {
+			t.Errorf("line %q is not folded", l)
+		}
+	}
+	if want := "SUMMARY:" + strings.ReplaceAll(longName, ",", "\\,") + "\r\n"; !strings.Contains(strings.ReplaceAll(ics, "\r\n ", ""), want) {
+		t.Errorf("expected the folded summary to unfold to %q:\n%s", want, ics)
+	}
+
+	if _, err := svc.ClassCalendar(context.Background(), "C1", date(2026, 11, 30), date(2026, 7, 6)); err == nil {
+		t.Errorf("expected error for reversed range")
+	}
+}
+
+func TestRemoveSlot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tr := mockrepo.NewMockTimetableRepositoryI(ctrl)
+	svc := services.NewTimetableService(tr)
+
+	tr.EXPECT().GetSlot(gomock.Any(), "missing").Return(nil, nil)
+	if err := svc.RemoveSlot(context.Background(), "missing"); err == nil {
+		t.Errorf("expected error for missing slot")
+	}
+	tr.EXPECT().GetSlot(gomock.Any(), "sl1").Return(&models.TimetableSlot{SlotID: "sl1"}, nil)
+	tr.EXPECT().DeleteSlot(gomock.Any(), "sl1").Return(nil)
+	if err := svc.RemoveSlot(context.Background(), "sl1"); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
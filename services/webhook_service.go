@@ -0,0 +1,297 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"sms/apperrors"
+	"sms/constants"
+	"sms/events"
+	"sms/models"
+	webhookRepository "sms/repository/webhookRepository"
+	"sms/utils"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var webhookEvents = map[constants.WebhookEvent]bool{
+	constants.WebhookGradePosted:    true,
+	constants.WebhookGradeChanged:   true,
+	constants.WebhookStudentCreated: true,
+	constants.WebhookStudentUpdated: true,
+}
+
+// webhookDeliveryLimit caps how many deliveries of a subscription are listed.
+const webhookDeliveryLimit = 100
+
+type webhookEnvelope struct {
+	ID         string                 `json:"id"`
+	Event      constants.WebhookEvent `json:"event"`
+	OccurredAt time.Time              `json:"occurredAt"`
+	Data       any                    `json:"data"`
+}
+
+type WebhookService struct {
+	wr          webhookRepository.WebhookRepositoryI
+	client      *http.Client
+	maxAttempts int
+	backoff     time.Duration
+}
+
+// NewWebhookService retries a failed delivery after backoff, doubling each time up
+// to constants.MaxWebhookBackoff, and gives up after maxAttempts tries.
+func NewWebhookService(wr webhookRepository.WebhookRepositoryI, client *http.Client, maxAttempts int, backoff time.Duration) *WebhookService {
+	return &WebhookService{wr: wr, client: client, maxAttempts: maxAttempts, backoff: backoff}
+}
+
+// SignWebhookPayload returns the value of the signature header for a body sent at
+// timestamp. Receivers recompute it with their copy of the secret.
+func SignWebhookPayload(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// CreateSubscription registers a subscription, generating a secret when none is
+// given. The secret is only ever returned here.
+func (ws *WebhookService) CreateSubscription(ctx context.Context, sub models.WebhookSubscription) (*models.WebhookSubscription, error) {
+	u, err := url.Parse(sub.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, apperrors.Validation("url must be an http or https URL")
+	}
+	if len(sub.Events) == 0 {
+		return nil, apperrors.Validation("at least one event is required")
+	}
+	seen := map[constants.WebhookEvent]bool{}
+	events := make([]constants.WebhookEvent, 0, len(sub.Events))
+	for _, event := range sub.Events {
+		if !webhookEvents[event] {
+			return nil, apperrors.Validation("unknown event %s", event)
+		}
+		if !seen[event] {
+			seen[event] = true
+			events = append(events, event)
+		}
+	}
+	if sub.Secret == "" {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, err
+		}
+		sub.Secret = hex.EncodeToString(secret)
+	}
+
+	sub.SubscriptionID = uuid.New().String()
+	sub.Events = events
+	sub.Active = true
+	sub.CreatedAt = time.Now().UTC()
+	if err := ws.wr.AddSubscription(ctx, sub); err != nil {
+		return nil, err
+	}
+	return &sub, nil
+}
+
+func (ws *WebhookService) GetSubscription(ctx context.Context, subscriptionID string) (*models.WebhookSubscription, error) {
+	sub, err := ws.wr.GetSubscription(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if sub == nil {
+		return nil, apperrors.NotFound("subscription not found")
+	}
+	sub.Secret = ""
+	return sub, nil
+}
+
+func (ws *WebhookService) GetSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error) {
+	subs, err := ws.wr.GetSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range subs {
+		subs[i].Secret = ""
+	}
+	return subs, nil
+}
+
+func (ws *WebhookService) SetSubscriptionActive(ctx context.Context, subscriptionID string, active bool) error {
+	if _, err := ws.GetSubscription(ctx, subscriptionID); err != nil {
+		return err
+	}
+	return ws.wr.SetSubscriptionActive(ctx, subscriptionID, active)
+}
+
+// Subscribe queues webhook deliveries for the domain events subscriptions can
+// listen for. The event itself is sent as the payload data.
+func (ws *WebhookService) Subscribe(bus *events.Bus) {
+	events.Subscribe(bus, func(ctx context.Context, e events.GradeAdded) error {
+		return ws.Publish(ctx, constants.WebhookGradePosted, e)
+	})
+	events.Subscribe(bus, func(ctx context.Context, e events.GradeUpdated) error {
+		return ws.Publish(ctx, constants.WebhookGradeChanged, e)
+	})
+	events.Subscribe(bus, func(ctx context.Context, e events.StudentCreated) error {
+		return ws.Publish(ctx, constants.WebhookStudentCreated, e.Student)
+	})
+	events.Subscribe(bus, func(ctx context.Context, e events.StudentUpdated) error {
+		return ws.Publish(ctx, constants.WebhookStudentUpdated, e.Student)
+	})
+}
+
+// Publish queues a delivery of the event for every active subscription listening for it.
+func (ws *WebhookService) Publish(ctx context.Context, event constants.WebhookEvent, data any) error {
+	subs, err := ws.wr.GetSubscriptionsForEvent(ctx, event)
+	if err != nil || len(subs) == 0 {
+		return err
+	}
+
+	now := time.Now().UTC()
+	deliveries := make([]models.WebhookDelivery, 0, len(subs))
+	for _, sub := range subs {
+		id := uuid.New().String()
+		payload, err := json.Marshal(webhookEnvelope{ID: id, Event: event, OccurredAt: now, Data: data})
+		if err != nil {
+			return err
+		}
+		deliveries = append(deliveries, models.WebhookDelivery{
+			DeliveryID:     id,
+			SubscriptionID: sub.SubscriptionID,
+			Event:          event,
+			Payload:        string(payload),
+			Status:         constants.DeliveryPending,
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+		})
+	}
+	return ws.wr.AddDeliveries(ctx, deliveries)
+}
+
+// ProcessDeliveries posts the deliveries that are due and returns how many succeeded.
+func (ws *WebhookService) ProcessDeliveries(ctx context.Context, now time.Time) (int, error) {
+	due, err := ws.wr.GetDueDeliveries(ctx, now, constants.WebhookBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	subs := map[string]*models.WebhookSubscription{}
+	delivered := 0
+	for _, d := range due {
+		sub, ok := subs[d.SubscriptionID]
+		if !ok {
+			if sub, err = ws.wr.GetSubscription(ctx, d.SubscriptionID); err != nil {
+				return delivered, err
+			}
+			subs[d.SubscriptionID] = sub
+		}
+
+		attempt := models.WebhookAttempt{DeliveryID: d.DeliveryID, AttemptedAt: now}
+		d.Attempts++
+		if sub == nil || !sub.Active {
+			attempt.Error = "subscription is not active"
+			d.Status = constants.DeliveryFailed
+		} else {
+			start := time.Now()
+			attempt.StatusCode, err = ws.post(ctx, *sub, d, now)
+			attempt.DurationMS = time.Since(start).Milliseconds()
+			switch {
+			case err == nil:
+				d.Status = constants.DeliveryDelivered
+				d.DeliveredAt = &now
+				delivered++
+			case d.Attempts >= ws.maxAttempts:
+				attempt.Error = err.Error()
+				d.Status = constants.DeliveryFailed
+			default:
+				attempt.Error = err.Error()
+				d.Status = constants.DeliveryPending
+				d.NextAttemptAt = now.Add(utils.Backoff(ws.backoff, constants.MaxWebhookBackoff, d.Attempts-1))
+			}
+		}
+		if err := ws.wr.RecordAttempt(ctx, d, attempt); err != nil {
+			return delivered, err
+		}
+	}
+	return delivered, nil
+}
+
+// post sends a signed delivery and returns the response status. Any status
+// outside 2xx is an error.
+func (ws *WebhookService) post(ctx context.Context, sub models.WebhookSubscription, d models.WebhookDelivery, now time.Time) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader([]byte(d.Payload)))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := now.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(constants.WebhookEventHeader, string(d.Event))
+	req.Header.Set(constants.WebhookDeliveryHeader, d.DeliveryID)
+	req.Header.Set(constants.WebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(constants.WebhookSignatureHeader, SignWebhookPayload(sub.Secret, timestamp, []byte(d.Payload)))
+
+	resp, err := ws.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("endpoint responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// RunWorker processes due deliveries every interval until ctx is done.
+func (ws *WebhookService) RunWorker(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := ws.ProcessDeliveries(ctx, time.Now().UTC()); err != nil {
+				log.Printf("failed to process webhook deliveries: %v", err)
+			}
+		}
+	}
+}
+
+func (ws *WebhookService) GetDeliveries(ctx context.Context, subscriptionID string) ([]models.WebhookDelivery, error) {
+	if _, err := ws.GetSubscription(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+	return ws.wr.GetDeliveries(ctx, subscriptionID, webhookDeliveryLimit)
+}
+
+func (ws *WebhookService) GetDelivery(ctx context.Context, deliveryID string) (*models.WebhookDelivery, error) {
+	d, err := ws.wr.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, apperrors.NotFound("delivery not found")
+	}
+	return d, nil
+}
+
+// Redeliver queues a delivery to be sent again on the next worker run, whatever
+// its current status.
+func (ws *WebhookService) Redeliver(ctx context.Context, deliveryID string) error {
+	if _, err := ws.GetDelivery(ctx, deliveryID); err != nil {
+		return err
+	}
+	return ws.wr.ResetDelivery(ctx, deliveryID, time.Now().UTC())
+}
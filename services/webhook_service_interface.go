@@ -0,0 +1,21 @@
+package services
+
+import (
+	"context"
+	"sms/constants"
+	"sms/models"
+	"time"
+)
+
+//go:generate mockgen -destination=../mocks/webhook_service_mock.go -package=mocks -source=webhook_service_interface.go
+type WebhookServiceI interface {
+	CreateSubscription(ctx context.Context, sub models.WebhookSubscription) (*models.WebhookSubscription, error)
+	GetSubscription(ctx context.Context, subscriptionID string) (*models.WebhookSubscription, error)
+	GetSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error)
+	SetSubscriptionActive(ctx context.Context, subscriptionID string, active bool) error
+	Publish(ctx context.Context, event constants.WebhookEvent, data any) error
+	ProcessDeliveries(ctx context.Context, now time.Time) (int, error)
+	GetDeliveries(ctx context.Context, subscriptionID string) ([]models.WebhookDelivery, error)
+	GetDelivery(ctx context.Context, deliveryID string) (*models.WebhookDelivery, error)
+	Redeliver(ctx context.Context, deliveryID string) error
+}
@@ -0,0 +1,251 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"sms/constants"
+	"sms/events"
+	mockrepo "sms/mocks"
+	"sms/models"
+	"sms/services"
+)
+
+func TestCreateSubscription(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebhookRepo := mockrepo.NewMockWebhookRepositoryI(ctrl)
+	svc := services.NewWebhookService(mockWebhookRepo, http.DefaultClient, 3, time.Minute)
+
+	mockWebhookRepo.EXPECT().AddSubscription(gomock.Any(), gomock.Any()).Return(nil)
+	sub, err := svc.CreateSubscription(context.Background(), models.WebhookSubscription{
+		URL:    "https://lms.example.com/hook",
+		Events: []constants.WebhookEvent{constants.WebhookGradePosted, constants.WebhookGradePosted, constants.WebhookStudentCreated},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(sub.Secret) != 64 || !sub.Active || len(sub.Events) != 2 {
+		t.Errorf("expected an active subscription with a generated secret and unique events, got %+v", sub)
+	}
+
+	invalid := []models.WebhookSubscription{
+		{URL: "ftp://lms.example.com", Events: []constants.WebhookEvent{constants.WebhookGradePosted}},
+		{URL: "https://lms.example.com/hook"},
+		{URL: "https://lms.example.com/hook", Events: []constants.WebhookEvent{"grade.deleted"}},
+	}
+	for _, s := range invalid {
+		if _, err := svc.CreateSubscription(context.Background(), s); err == nil {
+			t.Errorf("expected error for %+v", s)
+		}
+	}
+}
+
+func TestGetSubscriptions_HidesSecrets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebhookRepo := mockrepo.NewMockWebhookRepositoryI(ctrl)
+	svc := services.NewWebhookService(mockWebhookRepo, http.DefaultClient, 3, time.Minute)
+
+	mockWebhookRepo.EXPECT().GetSubscriptions(gomock.Any()).Return([]models.WebhookSubscription{{SubscriptionID: "w1", Secret: "secret"}}, nil)
+	subs, err := svc.GetSubscriptions(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if subs[0].Secret != "" {
+		t.Errorf("expected secret to be hidden")
+	}
+
+	mockWebhookRepo.EXPECT().GetSubscription(gomock.Any(), "missing").Return(nil, nil)
+	if err := svc.SetSubscriptionActive(context.Background(), "missing", false); err == nil {
+		t.Errorf("expected error for missing subscription")
+	}
+}
+
+func TestPublishWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebhookRepo := mockrepo.NewMockWebhookRepositoryI(ctrl)
+	svc := services.NewWebhookService(mockWebhookRepo, http.DefaultClient, 3, time.Minute)
+
+	mockWebhookRepo.EXPECT().GetSubscriptionsForEvent(gomock.Any(), constants.WebhookGradePosted).Return([]models.WebhookSubscription{
+		{SubscriptionID: "w1"}, {SubscriptionID: "w2"},
+	}, nil)
+	mockWebhookRepo.EXPECT().AddDeliveries(gomock.Any(), gomock.Len(2)).DoAndReturn(func(_ context.Context, deliveries []models.WebhookDelivery) error {
+		for _, d := range deliveries {
+			var envelope struct {
+				ID    string
+				Event string
+				Data  models.Grade
+			}
+			if err := json.Unmarshal([]byte(d.Payload), &envelope); err != nil {
+				t.Fatalf("invalid payload: %v", err)
+			}
+			if envelope.ID != d.DeliveryID || envelope.Event != "grade.posted" || envelope.Data.Grade != 88 || d.Status != constants.DeliveryPending {
+				t.Errorf("unexpected delivery %+v with payload %s", d, d.Payload)
+			}
+		}
+		return nil
+	})
+	if err := svc.Publish(context.Background(), constants.WebhookGradePosted, models.Grade{StudentID: "s1", SubjectID: "maths", Grade: 88, Semester: 1}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	mockWebhookRepo.EXPECT().GetSubscriptionsForEvent(gomock.Any(), constants.WebhookStudentCreated).Return(nil, nil)
+	if err := svc.Publish(context.Background(), constants.WebhookStudentCreated, models.Students{StudentID: "s1"}); err != nil {
+		t.Errorf("expected nothing to be queued without subscribers, got %v", err)
+	}
+}
+
+func TestWebhookSubscribers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebhookRepo := mockrepo.NewMockWebhookRepositoryI(ctrl)
+	svc := services.NewWebhookService(mockWebhookRepo, http.DefaultClient, 3, time.Minute)
+	bus := events.NewBus()
+	svc.Subscribe(bus)
+
+	published := []events.Event{
+		events.GradeAdded{StudentID: "s1", SubjectID: "maths", Grade: 90, Semester: 1},
+		events.GradeUpdated{StudentID: "s1", SubjectID: "maths", OldGrade: 90, Grade: 95, Semester: 1},
+		events.StudentCreated{Student: models.Students{StudentID: "s1"}},
+		events.StudentUpdated{Student: models.Students{StudentID: "s1"}},
+		events.UserSignedUp{UserID: "u1"},
+	}
+	gomock.InOrder(
+		mockWebhookRepo.EXPECT().GetSubscriptionsForEvent(gomock.Any(), constants.WebhookGradePosted).Return(nil, nil),
+		mockWebhookRepo.EXPECT().GetSubscriptionsForEvent(gomock.Any(), constants.WebhookGradeChanged).Return(nil, nil),
+		mockWebhookRepo.EXPECT().GetSubscriptionsForEvent(gomock.Any(), constants.WebhookStudentCreated).Return(nil, nil),
+		mockWebhookRepo.EXPECT().GetSubscriptionsForEvent(gomock.Any(), constants.WebhookStudentUpdated).Return(nil, nil),
+	)
+	for _, e := range published {
+		if err := bus.Publish(context.Background(), e); err != nil {
+			t.Fatalf("expected no error publishing %s, got %v", e.EventName(), err)
+		}
+	}
+}
+
+func TestProcessDeliveries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2026, 9, 1, 10, 0, 0, 0, time.UTC)
+	payload := `{"id":"d1","event":"grade.posted"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp, _ := strconv.ParseInt(r.Header.Get(constants.WebhookTimestampHeader), 10, 64)
+		if r.Header.Get(constants.WebhookSignatureHeader) != services.SignWebhookPayload("secret", timestamp, body) {
+			t.Errorf("signature does not match the body")
+		}
+		if timestamp != now.Unix() || r.Header.Get(constants.WebhookEventHeader) != "grade.posted" {
+			t.Errorf("unexpected headers: %v", r.Header)
+		}
+		if r.Header.Get(constants.WebhookDeliveryHeader) != "d1" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	mockWebhookRepo := mockrepo.NewMockWebhookRepositoryI(ctrl)
+	svc := services.NewWebhookService(mockWebhookRepo, server.Client(), 3, time.Minute)
+
+	mockWebhookRepo.EXPECT().GetDueDeliveries(gomock.Any(), now, constants.WebhookBatchSize).Return([]models.WebhookDelivery{
+		{DeliveryID: "d1", SubscriptionID: "w1", Event: constants.WebhookGradePosted, Payload: payload},
+		{DeliveryID: "d2", SubscriptionID: "w1", Event: constants.WebhookGradePosted, Payload: payload, Attempts: 1},
+		{DeliveryID: "d3", SubscriptionID: "w1", Event: constants.WebhookGradePosted, Payload: payload, Attempts: 2},
+		{DeliveryID: "d4", SubscriptionID: "w2", Event: constants.WebhookGradePosted, Payload: payload},
+	}, nil)
+	mockWebhookRepo.EXPECT().GetSubscription(gomock.Any(), "w1").Return(&models.WebhookSubscription{SubscriptionID: "w1", URL: server.URL, Secret: "secret", Active: true}, nil)
+	mockWebhookRepo.EXPECT().GetSubscription(gomock.Any(), "w2").Return(&models.WebhookSubscription{SubscriptionID: "w2", Active: false}, nil)
+
+	results := map[string]models.WebhookDelivery{}
+	attempts := map[string]models.WebhookAttempt{}
+	mockWebhookRepo.EXPECT().RecordAttempt(gomock.Any(), gomock.Any(), gomock.Any()).Times(4).DoAndReturn(func(_ context.Context, d models.WebhookDelivery, a models.WebhookAttempt) error {
+		results[d.DeliveryID] = d
+		attempts[d.DeliveryID] = a
+		return nil
+	})
+
+	delivered, err := svc.ProcessDeliveries(context.Background(), now)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if delivered != 1 {
+		t.Errorf("expected 1 delivered, got %d", delivered)
+	}
+	if d := results["d1"]; d.Status != constants.DeliveryDelivered || d.DeliveredAt == nil || attempts["d1"].StatusCode != 200 {
+		t.Errorf("expected d1 delivered, got %+v %+v", d, attempts["d1"])
+	}
+	if d := results["d2"]; d.Status != constants.DeliveryPending || d.Attempts != 2 || !d.NextAttemptAt.Equal(now.Add(2*time.Minute)) {
+		t.Errorf("expected d2 retried after 2 minutes, got %+v", d)
+	}
+	if d := results["d3"]; d.Status != constants.DeliveryFailed || attempts["d3"].StatusCode != 503 {
+		t.Errorf("expected d3 to fail after its last attempt, got %+v %+v", d, attempts["d3"])
+	}
+	if d := results["d4"]; d.Status != constants.DeliveryFailed || attempts["d4"].Error == "" {
+		t.Errorf("expected d4 to fail for an inactive subscription, got %+v", d)
+	}
+}
+
+func TestWebhookBackoffIsCapped(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2026, 9, 1, 10, 0, 0, 0, time.UTC)
+	mockWebhookRepo := mockrepo.NewMockWebhookRepositoryI(ctrl)
+	client := &http.Client{Transport: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	})}
+	svc := services.NewWebhookService(mockWebhookRepo, client, 50, time.Hour)
+
+	mockWebhookRepo.EXPECT().GetDueDeliveries(gomock.Any(), now, constants.WebhookBatchSize).Return([]models.WebhookDelivery{{DeliveryID: "d1", SubscriptionID: "w1", Attempts: 10}}, nil)
+	mockWebhookRepo.EXPECT().GetSubscription(gomock.Any(), "w1").Return(&models.WebhookSubscription{SubscriptionID: "w1", URL: "https://lms.example.com/hook", Active: true}, nil)
+	mockWebhookRepo.EXPECT().RecordAttempt(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, d models.WebhookDelivery, a models.WebhookAttempt) error {
+		if !d.NextAttemptAt.Equal(now.Add(constants.MaxWebhookBackoff)) || a.StatusCode != 0 {
+			t.Errorf("expected retry after the maximum backoff, got %+v %+v", d, a)
+		}
+		return nil
+	})
+
+	if _, err := svc.ProcessDeliveries(context.Background(), now); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestRedeliver(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebhookRepo := mockrepo.NewMockWebhookRepositoryI(ctrl)
+	svc := services.NewWebhookService(mockWebhookRepo, http.DefaultClient, 3, time.Minute)
+
+	mockWebhookRepo.EXPECT().GetDelivery(gomock.Any(), "d1").Return(&models.WebhookDelivery{DeliveryID: "d1", Status: constants.DeliveryFailed}, nil)
+	mockWebhookRepo.EXPECT().ResetDelivery(gomock.Any(), "d1", gomock.Any()).Return(nil)
+	if err := svc.Redeliver(context.Background(), "d1"); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+
+	mockWebhookRepo.EXPECT().GetDelivery(gomock.Any(), "missing").Return(nil, nil)
+	if err := svc.Redeliver(context.Background(), "missing"); err == nil {
+		t.Errorf("expected error for missing delivery")
+	}
+}
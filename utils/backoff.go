@@ -0,0 +1,13 @@
+package utils
+
+import "time"
+
+// Backoff is the wait before retry n, counting from 0: base doubled n times, but
+// never more than max.
+func Backoff(base, max time.Duration, n int) time.Duration {
+	wait := base
+	for i := 0; i < n && wait < max; i++ {
+		wait *= 2
+	}
+	return min(wait, max)
+}
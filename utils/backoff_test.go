@@ -0,0 +1,26 @@
+package utils_test
+
+import (
+	"sms/utils"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		n    int
+		want time.Duration
+	}{
+		{0, time.Minute},
+		{1, 2 * time.Minute},
+		{3, 8 * time.Minute},
+		{6, time.Hour},
+		// large counts stay at the cap instead of overflowing
+		{100, time.Hour},
+	}
+	for _, tt := range tests {
+		if got := utils.Backoff(time.Minute, time.Hour, tt.n); got != tt.want {
+			t.Errorf("retry %d: expected %v, got %v", tt.n, tt.want, got)
+		}
+	}
+}
@@ -3,12 +3,17 @@ package utils
 import (
 	"encoding/json"
 	"net/http"
+	"sms/apperrors"
 )
 
 type CustomResponse struct {
 	Message    string `json:"message"`
 	StatusCode int    `json:"status_code"`
-	Data       any    `json:"data,omitempty"`
+	// Code names the kind of failure for clients that branch on it. It is set
+	// on every error response.
+	Code   string                 `json:"code,omitempty"`
+	Errors []apperrors.FieldError `json:"errors,omitempty"`
+	Data   any                    `json:"data,omitempty"`
 }
 
 func CustomResponseSender(w http.ResponseWriter, statusCode int, message string, data ...any) {
@@ -25,8 +30,13 @@ func CustomResponseSender(w http.ResponseWriter, statusCode int, message string,
 			StatusCode: statusCode,
 		}
 	}
+	resp.Code = ErrorCode(statusCode)
+	writeResponse(w, resp)
+}
+
+func writeResponse(w http.ResponseWriter, resp CustomResponse) {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
+	w.WriteHeader(resp.StatusCode)
 	if err := json.NewEncoder(w).Encode(resp); err != nil {
 		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
 	}
@@ -36,6 +36,7 @@ func TestCustomResponseSender(t *testing.T) {
 			expectedBody: utils.CustomResponse{
 				Message:    "bad request",
 				StatusCode: http.StatusBadRequest,
+				Code:       "bad_request",
 			},
 		},
 	}
@@ -0,0 +1,102 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"sms/apperrors"
+)
+
+var errorCodes = map[int]string{
+	http.StatusBadRequest:            "bad_request",
+	http.StatusUnauthorized:          "unauthorized",
+	http.StatusForbidden:             "forbidden",
+	http.StatusNotFound:              "not_found",
+	http.StatusMethodNotAllowed:      "method_not_allowed",
+	http.StatusConflict:              "conflict",
+	http.StatusRequestEntityTooLarge: "request_too_large",
+	http.StatusUnprocessableEntity:   "validation_failed",
+	http.StatusInternalServerError:   "internal",
+	http.StatusServiceUnavailable:    "unavailable",
+	http.StatusGatewayTimeout:        "timeout",
+}
+
+// ErrorCode is the machine-readable code of an error status, and empty for
+// statuses that aren't errors.
+func ErrorCode(statusCode int) string {
+	if statusCode < http.StatusBadRequest {
+		return ""
+	}
+	if code, ok := errorCodes[statusCode]; ok {
+		return code
+	}
+	if statusCode >= http.StatusInternalServerError {
+		return "internal"
+	}
+	return "bad_request"
+}
+
+var kindStatuses = []struct {
+	kind   error
+	status int
+}{
+	{apperrors.ErrValidation, http.StatusBadRequest},
+	{apperrors.ErrNotFound, http.StatusNotFound},
+	{apperrors.ErrConflict, http.StatusConflict},
+	{apperrors.ErrUnauthorized, http.StatusUnauthorized},
+	{apperrors.ErrForbidden, http.StatusForbidden},
+}
+
+// ErrorStatus is the status err is reported with. Validation errors that name
+// the fields at fault are 422, other validation errors 400.
+func ErrorStatus(err error) int {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return http.StatusRequestEntityTooLarge
+	}
+	var e *apperrors.Error
+	if errors.As(err, &e) {
+		if errors.Is(e.Kind, apperrors.ErrValidation) && len(e.Fields) > 0 {
+			return http.StatusUnprocessableEntity
+		}
+		for _, ks := range kindStatuses {
+			if errors.Is(e.Kind, ks.kind) {
+				return ks.status
+			}
+		}
+		return http.StatusInternalServerError
+	}
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case errors.Is(err, context.Canceled):
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
+
+// ErrorResponseSender writes err with the status and code of its kind. Only the
+// messages of typed client errors are sent; anything else is logged and
+// reported with a generic message.
+func ErrorResponseSender(w http.ResponseWriter, err error) {
+	status := ErrorStatus(err)
+	resp := CustomResponse{StatusCode: status, Code: ErrorCode(status)}
+	switch status {
+	case http.StatusInternalServerError:
+		log.Printf("internal error: %v", err)
+		resp.Message = "internal server error"
+	case http.StatusGatewayTimeout:
+		resp.Message = "request timed out"
+	case http.StatusServiceUnavailable:
+		resp.Message = "request was cancelled"
+	case http.StatusRequestEntityTooLarge:
+		resp.Message = "request body is too large"
+	default:
+		var e *apperrors.Error
+		errors.As(err, &e)
+		resp.Message = e.Error()
+		resp.Errors = e.Fields
+	}
+	writeResponse(w, resp)
+}
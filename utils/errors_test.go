@@ -0,0 +1,93 @@
+package utils_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sms/apperrors"
+	"sms/utils"
+	"testing"
+)
+
+func TestErrorResponseSender(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectedBody utils.CustomResponse
+	}{
+		{
+			name:         "validation",
+			err:          apperrors.Validation("semester must be positive"),
+			expectedBody: utils.CustomResponse{Message: "semester must be positive", StatusCode: http.StatusBadRequest, Code: "bad_request"},
+		},
+		{
+			name:         "not found",
+			err:          apperrors.NotFound("student not found"),
+			expectedBody: utils.CustomResponse{Message: "student not found", StatusCode: http.StatusNotFound, Code: "not_found"},
+		},
+		{
+			name:         "conflict wrapped by a caller",
+			err:          fmt.Errorf("creating student: %w", apperrors.Conflict("email already in use")),
+			expectedBody: utils.CustomResponse{Message: "email already in use", StatusCode: http.StatusConflict, Code: "conflict"},
+		},
+		{
+			name:         "unauthorized",
+			err:          apperrors.Unauthorized("invalid email or password"),
+			expectedBody: utils.CustomResponse{Message: "invalid email or password", StatusCode: http.StatusUnauthorized, Code: "unauthorized"},
+		},
+		{
+			name:         "forbidden",
+			err:          apperrors.Forbidden("grade entry for semester 2 is closed"),
+			expectedBody: utils.CustomResponse{Message: "grade entry for semester 2 is closed", StatusCode: http.StatusForbidden, Code: "forbidden"},
+		},
+		{
+			name: "field details",
+			err: &apperrors.Error{Kind: apperrors.ErrValidation, Message: "invalid grade",
+				Fields: []apperrors.FieldError{{Field: "grade", Message: "must be at most 100"}}},
+			expectedBody: utils.CustomResponse{Message: "invalid grade", StatusCode: http.StatusUnprocessableEntity, Code: "validation_failed",
+				Errors: []apperrors.FieldError{{Field: "grade", Message: "must be at most 100"}}},
+		},
+		{
+			name:         "untyped errors don't leak",
+			err:          errors.New("dial tcp 10.0.0.5:5432: connection refused"),
+			expectedBody: utils.CustomResponse{Message: "internal server error", StatusCode: http.StatusInternalServerError, Code: "internal"},
+		},
+		{
+			name:         "internal errors don't leak",
+			err:          apperrors.Internal(errors.New("token signing key missing")),
+			expectedBody: utils.CustomResponse{Message: "internal server error", StatusCode: http.StatusInternalServerError, Code: "internal"},
+		},
+		{
+			name:         "body too large",
+			err:          &http.MaxBytesError{Limit: 10},
+			expectedBody: utils.CustomResponse{Message: "request body is too large", StatusCode: http.StatusRequestEntityTooLarge, Code: "request_too_large"},
+		},
+		{
+			name:         "deadline exceeded",
+			err:          fmt.Errorf("querying grades: %w", context.DeadlineExceeded),
+			expectedBody: utils.CustomResponse{Message: "request timed out", StatusCode: http.StatusGatewayTimeout, Code: "timeout"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			utils.ErrorResponseSender(rr, tt.err)
+
+			if rr.Code != tt.expectedBody.StatusCode {
+				t.Errorf("wrong status code: got %v want %v", rr.Code, tt.expectedBody.StatusCode)
+			}
+			var actualBody utils.CustomResponse
+			if err := json.NewDecoder(rr.Body).Decode(&actualBody); err != nil {
+				t.Fatalf("could not decode response body: %v", err)
+			}
+			if !reflect.DeepEqual(actualBody, tt.expectedBody) {
+				t.Errorf("unexpected body:\nGot:  %v\nWant: %v", actualBody, tt.expectedBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sms/apperrors"
+	"sms/constants"
+	"sms/validation"
+	"strings"
+)
+
+// DecodeJSON reads the JSON body of r into dst and validates it. Bodies over
+// constants.MaxRequestBodyBytes, fields dst doesn't have and trailing data are
+// rejected. The error is ready for ErrorResponseSender.
+func DecodeJSON(w http.ResponseWriter, r *http.Request, dst any) error {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, constants.MaxRequestBodyBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(dst); err != nil {
+		return decodeError(err)
+	}
+	if err := dec.Decode(&struct{}{}); err != io.EOF {
+		return apperrors.Validation("invalid request body")
+	}
+	return validation.Validate(dst)
+}
+
+func decodeError(err error) error {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return err
+	}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return apperrors.Invalid(apperrors.FieldError{Field: typeErr.Field, Message: "must be a " + jsonType(typeErr.Type.Kind().String())})
+	}
+	// encoding/json has no typed error for unknown fields
+	if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		return apperrors.Invalid(apperrors.FieldError{Field: strings.Trim(field, `"`), Message: "is not a known field"})
+	}
+	return apperrors.Validation("invalid request body").Because(err)
+}
+
+func jsonType(kind string) string {
+	switch {
+	case strings.HasPrefix(kind, "int"), strings.HasPrefix(kind, "uint"), strings.HasPrefix(kind, "float"):
+		return "number"
+	case kind == "slice" || kind == "array":
+		return "list"
+	case kind == "struct" || kind == "map":
+		return "object"
+	case kind == "bool":
+		return "boolean"
+	}
+	return kind
+}
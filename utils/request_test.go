@@ -0,0 +1,92 @@
+package utils_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sms/apperrors"
+	"sms/utils"
+	"strings"
+	"testing"
+)
+
+type gradeRequest struct {
+	StudentID string `json:"studentID" validate:"required"`
+	Semester  int    `json:"semester" validate:"required,min=1"`
+	Grade     int    `json:"grade" validate:"min=0,max=100"`
+}
+
+func TestDecodeJSON(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedFields []apperrors.FieldError
+	}{
+		{
+			name:           "valid",
+			body:           `{"studentID": "1", "semester": 2, "grade": 0}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "all field errors at once",
+			body:           `{"studentID": "", "semester": 0, "grade": 10000}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedFields: []apperrors.FieldError{
+				{Field: "studentID", Message: "is required"},
+				{Field: "semester", Message: "is required"},
+				{Field: "grade", Message: "must be at most 100"},
+			},
+		},
+		{
+			name:           "unknown field",
+			body:           `{"studentID": "1", "semester": 2, "grde": 90}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedFields: []apperrors.FieldError{{Field: "grde", Message: "is not a known field"}},
+		},
+		{
+			name:           "wrong type",
+			body:           `{"studentID": "1", "semester": "two"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedFields: []apperrors.FieldError{{Field: "semester", Message: "must be a number"}},
+		},
+		{
+			name:           "malformed",
+			body:           `{"studentID": `,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "trailing data",
+			body:           `{"studentID": "1", "semester": 2} {}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "too large",
+			body:           `{"studentID": "` + strings.Repeat("x", 2<<20) + `"}`,
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/grades", strings.NewReader(tt.body))
+			var dst gradeRequest
+			err := utils.DecodeJSON(rr, req, &dst)
+			if err == nil {
+				if tt.expectedStatus != http.StatusOK {
+					t.Fatalf("expected status %d, got no error", tt.expectedStatus)
+				}
+				return
+			}
+			if status := utils.ErrorStatus(err); status != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d (%v)", tt.expectedStatus, status, err)
+			}
+			if tt.expectedFields != nil {
+				e, _ := err.(*apperrors.Error)
+				if e == nil || !reflect.DeepEqual(e.Fields, tt.expectedFields) {
+					t.Errorf("unexpected fields:\nGot:  %v\nWant: %v", e, tt.expectedFields)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+// Package validation checks request structs against the rules in their
+// `validate` tags:
+//
+//	required   the field can't be empty, zero or blank
+//	min=N      numbers at least N, strings at least N characters, lists at least N items
+//	max=N      numbers at most N, strings at most N characters, lists at most N items
+//	oneof=a b  the value is one of the space separated words
+//
+// Rules other than required are skipped for zero values, so optional fields
+// are only checked when they are given. Nested structs and lists of structs are
+// checked too, and fields are reported by their JSON names.
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"sms/apperrors"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Validate returns a validation error listing every field of v that breaks its
+// rules, or nil.
+func Validate(v any) error {
+	var fields []apperrors.FieldError
+	check(reflect.ValueOf(v), "", &fields)
+	if len(fields) == 0 {
+		return nil
+	}
+	return apperrors.Invalid(fields...)
+}
+
+func check(v reflect.Value, path string, fields *[]apperrors.FieldError) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			name := jsonName(f)
+			if name == "-" {
+				continue
+			}
+			if path != "" {
+				name = path + "." + name
+			}
+			fv := v.Field(i)
+			if msg := checkRules(fv, f.Tag.Get("validate")); msg != "" {
+				*fields = append(*fields, apperrors.FieldError{Field: name, Message: msg})
+				continue
+			}
+			check(fv, name, fields)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			check(v.Index(i), fmt.Sprintf("%s[%d]", path, i), fields)
+		}
+	}
+}
+
+func jsonName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "" {
+		return f.Name
+	}
+	return name
+}
+
+// checkRules returns what is wrong with v, or "" when it follows every rule.
+func checkRules(v reflect.Value, tag string) string {
+	if tag == "" {
+		return ""
+	}
+	rules := strings.Split(tag, ",")
+	for _, rule := range rules {
+		if rule == "required" && isEmpty(v) {
+			return "is required"
+		}
+	}
+	if v.IsZero() {
+		return ""
+	}
+	for _, rule := range rules {
+		name, arg, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required":
+		case "min", "max":
+			if msg := checkBound(v, name, arg); msg != "" {
+				return msg
+			}
+		case "oneof":
+			words := strings.Fields(arg)
+			if v.Kind() == reflect.String && !contains(words, v.String()) {
+				return "must be one of " + strings.Join(words, ", ")
+			}
+		default:
+			panic(fmt.Sprintf("validation: unknown rule %q", rule))
+		}
+	}
+	return ""
+}
+
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.String:
+		return strings.TrimSpace(v.String()) == ""
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
+
+func checkBound(v reflect.Value, name, arg string) string {
+	limit, err := strconv.ParseFloat(arg, 64)
+	if err != nil {
+		panic(fmt.Sprintf("validation: bad %s %q", name, arg))
+	}
+	var n float64
+	var unit string
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n = float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n = float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		n = v.Float()
+	case reflect.String:
+		n, unit = float64(utf8.RuneCountInString(v.String())), " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		n, unit = float64(v.Len()), " items"
+	default:
+		return ""
+	}
+	if name == "min" && n < limit {
+		return "must be at least " + arg + unit
+	}
+	if name == "max" && n > limit {
+		return "must be at most " + arg + unit
+	}
+	return ""
+}
+
+func contains(words []string, s string) bool {
+	for _, w := range words {
+		if w == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package validation_test
+
+import (
+	"errors"
+	"reflect"
+	"sms/apperrors"
+	"sms/validation"
+	"testing"
+)
+
+type line struct {
+	SubjectID string `json:"subjectID" validate:"required"`
+	Grade     int    `json:"grade" validate:"min=0,max=100"`
+}
+
+type request struct {
+	Name     string   `json:"name" validate:"required,max=5"`
+	Semester int      `json:"semester" validate:"required,min=1"`
+	Kind     string   `json:"kind" validate:"oneof=exam quiz"`
+	Tags     []string `json:"tags" validate:"max=2"`
+	Active   *bool    `json:"active" validate:"required"`
+	Lines    []line   `json:"lines"`
+}
+
+func TestValidate(t *testing.T) {
+	active := true
+	tests := []struct {
+		name string
+		req  request
+		want []apperrors.FieldError
+	}{
+		{
+			name: "valid",
+			req:  request{Name: "Asha", Semester: 2, Kind: "quiz", Active: &active, Lines: []line{{SubjectID: "s1", Grade: 90}}},
+		},
+		{
+			name: "optional fields are skipped when empty",
+			req:  request{Name: "Asha", Semester: 1, Active: &active},
+		},
+		{
+			name: "every broken field is reported",
+			req: request{
+				Name:   " ",
+				Kind:   "test",
+				Tags:   []string{"a", "b", "c"},
+				Active: nil,
+				Lines:  []line{{SubjectID: "s1", Grade: 100}, {Grade: 101}},
+			},
+			want: []apperrors.FieldError{
+				{Field: "name", Message: "is required"},
+				{Field: "semester", Message: "is required"},
+				{Field: "kind", Message: "must be one of exam, quiz"},
+				{Field: "tags", Message: "must be at most 2 items"},
+				{Field: "active", Message: "is required"},
+				{Field: "lines[1].subjectID", Message: "is required"},
+				{Field: "lines[1].grade", Message: "must be at most 100"},
+			},
+		},
+		{
+			name: "string length counts characters",
+			req:  request{Name: "Zoë Ñú", Semester: -1, Active: &active},
+			want: []apperrors.FieldError{
+				{Field: "name", Message: "must be at most 5 characters"},
+				{Field: "semester", Message: "must be at least 1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validation.Validate(&tt.req)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+			var e *apperrors.Error
+			if !errors.As(err, &e) || !errors.Is(err, apperrors.ErrValidation) {
+				t.Fatalf("expected a validation error, got %v", err)
+			}
+			if !reflect.DeepEqual(e.Fields, tt.want) {
+				t.Errorf("unexpected fields:\nGot:  %v\nWant: %v", e.Fields, tt.want)
+			}
+		})
+	}
+}